@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/gin-gonic/gin"
+)
+
+// contactBlocklistGet will return the global blocklist of the contact invitations
+// Get global contact blocklist godoc
+// @Summary		Get global contact blocklist
+// @Description	Get the paymails and domains whose contact invitations are rejected for all the users
+// @Tags		Admin
+// @Produce		json
+// @Success		200	{object} []response.ContactBlock "List of blocked paymails and domains"
+// @Failure 	500	"Internal Server Error - Error while getting the blocklist"
+// @Router		/v1/admin/contact-blocklist [get]
+// @Security	x-auth-xpub
+func (a *Action) contactBlocklistGet(c *gin.Context) {
+	blocks, err := a.Services.SpvWalletEngine.GetContactBlocks(c.Request.Context(), "")
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactBlockContracts(blocks))
+}
+
+// contactBlocklistAdd will add the paymail or domain into the global blocklist of the contact invitations
+// Block paymail or domain godoc
+// @Summary		Block paymail or domain globally
+// @Description	Reject the contact invitations of the paymail or the whole domain (including its subdomains) for all the users
+// @Tags		Admin
+// @Produce		json
+// @Param		subject path string true "Paymail address or domain to block"
+// @Success		200	{object} response.ContactBlock "Blocked paymail or domain"
+// @Failure		400	"Bad request - Invalid paymail or domain"
+// @Failure 	500	"Internal Server Error - Error while blocking the paymail"
+// @Router		/v1/admin/contact-blocklist/{subject} [put]
+// @Security	x-auth-xpub
+func (a *Action) contactBlocklistAdd(c *gin.Context) {
+	block, err := a.Services.SpvWalletEngine.BlockContact(
+		c.Request.Context(), "", c.Param("subject"), engine.ContactBlockActionBlock,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactBlockContract(block))
+}
+
+// contactBlocklistRemove will remove the paymail or domain from the global blocklist of the contact invitations
+// Unblock paymail or domain godoc
+// @Summary		Unblock paymail or domain globally
+// @Description	Remove the paymail or domain from the global blocklist (the blocklists of the users are not changed)
+// @Tags		Admin
+// @Produce		json
+// @Param		subject path string true "Blocked paymail address or domain"
+// @Success		200
+// @Failure		400	"Bad request - Invalid paymail or domain"
+// @Failure		404	"Not found - Paymail or domain is not blocked"
+// @Failure 	500	"Internal Server Error - Error while unblocking the paymail"
+// @Router		/v1/admin/contact-blocklist/{subject} [delete]
+// @Security	x-auth-xpub
+func (a *Action) contactBlocklistRemove(c *gin.Context) {
+	if err := a.Services.SpvWalletEngine.UnblockContact(c.Request.Context(), "", c.Param("subject")); err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -26,7 +26,7 @@ type PaymailAddress struct {
 
 // RecordTransaction is the model for recording a transaction
 type RecordTransaction struct {
-	// The transaction hex
+	// The transaction hex (raw transaction, or BEEF or EF which is verified and broadcast)
 	Hex string `json:"hex" example:"0100000002..."`
 }
 
@@ -46,3 +46,40 @@ type UpdateContact struct {
 	FullName string `json:"fullName" example:"John Doe"`
 }
 
+// CreatePaymailDomain is the model for adding a paymail domain (served without restart)
+type CreatePaymailDomain struct {
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+	// The paymail domain
+	Domain string `json:"domain" example:"spv-wallet.com"`
+	PaymailDomainSettings
+}
+
+// UpdatePaymailDomain is the model for updating the settings of a paymail domain
+type UpdatePaymailDomain struct {
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+	PaymailDomainSettings
+}
+
+// PaymailDomainSettings are the per-domain settings of a paymail domain
+type PaymailDomainSettings struct {
+	// Requires signed sender requests for the paymails of the domain
+	SenderValidationEnabled bool `json:"senderValidationEnabled" example:"true"`
+	// Accepts BEEF transactions for the paymails of the domain
+	BeefEnabled bool `json:"beefEnabled" example:"true"`
+	// Accepts PIKE requests for the paymails of the domain
+	PikeEnabled bool `json:"pikeEnabled" example:"false"`
+	// Sender paymail used for the xpubs without paymail sending from the domain
+	DefaultFromPaymail string `json:"defaultFromPaymail" example:"from@spv-wallet.com"`
+}
+
+// toEngine will convert the settings to the engine paymail domain settings
+func (s *PaymailDomainSettings) toEngine() *engine.PaymailDomainSettings {
+	return &engine.PaymailDomainSettings{
+		SenderValidationEnabled: s.SenderValidationEnabled,
+		BeefEnabled:             s.BeefEnabled,
+		PikeEnabled:             s.PikeEnabled,
+		DefaultFromPaymail:      s.DefaultFromPaymail,
+	}
+}
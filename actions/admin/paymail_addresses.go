@@ -201,3 +201,44 @@ func (a *Action) paymailDeleteAddress(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// paymailRotatePubKey will rotate the PKI public key of a paymail address
+// Rotate paymail PKI key godoc
+// @Summary		Rotate paymail PKI key
+// @Description	Rotate the PKI public key of a paymail address (previous key stays verifiable for a grace period) and notify its confirmed contacts
+// @Tags		Admin
+// @Produce		json
+// @Param		PaymailAddress body PaymailAddress false "PaymailAddress model containing paymail address to rotate the key of"
+// @Success		200	{object} response.PaymailPubKey "Rotated PKI public key of the paymail address"
+// @Failure		400	"Bad request - Error while parsing PaymailAddress from request body"
+// @Failure		404	"Not found - Paymail address not found"
+// @Failure 	500	"Internal Server Error - Error while rotating the PKI key"
+// @Router		/v1/admin/paymail/rotate-key [post]
+// @Security	x-auth-xpub
+func (a *Action) paymailRotatePubKey(c *gin.Context) {
+	var requestBody PaymailAddress
+
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	if requestBody.Address == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingAddress, a.Services.Logger)
+		return
+	}
+
+	paymailAddress, err := a.Services.SpvWalletEngine.RotatePaymailPubKey(c.Request.Context(), requestBody.Address)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	pubKey, err := paymailAddress.GetPubKey()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailPubKeyContract(paymailAddress, pubKey))
+}
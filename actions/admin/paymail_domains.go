@@ -0,0 +1,196 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/gin-gonic/gin"
+)
+
+// paymailDomainsCreate will add a paymail domain served by the paymail server (without restart)
+// Create paymail domain godoc
+// @Summary		Create paymail domain
+// @Description	Add a paymail domain with its settings, it is served by all the servers of the cluster without restart
+// @Tags		Admin
+// @Produce		json
+// @Param		CreatePaymailDomain body CreatePaymailDomain true "Paymail domain with its settings"
+// @Success		201	{object} response.PaymailDomain "Created paymail domain"
+// @Failure		400	"Bad request - Error while parsing CreatePaymailDomain from request body or invalid domain"
+// @Failure		409	"Conflict - Paymail domain already exists"
+// @Failure 	500	"Internal Server Error - Error while creating the paymail domain"
+// @Router		/v1/admin/paymail-domains [post]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainsCreate(c *gin.Context) {
+	var requestBody CreatePaymailDomain
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	if requestBody.Domain == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingPaymailDomain, a.Services.Logger)
+		return
+	}
+
+	paymailDomain, err := a.Services.SpvWalletEngine.NewPaymailDomain(
+		c.Request.Context(),
+		requestBody.Domain,
+		requestBody.toEngine(),
+		engine.WithMetadatas(requestBody.Metadata),
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappings.MapToPaymailDomainContract(paymailDomain))
+}
+
+// paymailDomainsList will return all the paymail domains managed at runtime
+// Get paymail domains godoc
+// @Summary		Get paymail domains
+// @Description	Get all the paymail domains managed at runtime (domains from config are not listed)
+// @Tags		Admin
+// @Produce		json
+// @Success		200	{object} []response.PaymailDomain "List of paymail domains"
+// @Failure 	500	"Internal Server Error - Error while getting the paymail domains"
+// @Router		/v1/admin/paymail-domains [get]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainsList(c *gin.Context) {
+	paymailDomains, err := a.Services.SpvWalletEngine.GetPaymailDomains(
+		c.Request.Context(),
+		nil,
+		map[string]interface{}{"deleted_at": nil},
+		nil,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailDomainContracts(paymailDomains))
+}
+
+// paymailDomainsGet will return the paymail domain
+// Get paymail domain godoc
+// @Summary		Get paymail domain
+// @Description	Get the paymail domain with its settings
+// @Tags		Admin
+// @Produce		json
+// @Param		domain path string true "Paymail domain"
+// @Success		200	{object} response.PaymailDomain "Paymail domain"
+// @Failure		404	"Not found - Paymail domain not found"
+// @Failure 	500	"Internal Server Error - Error while getting the paymail domain"
+// @Router		/v1/admin/paymail-domains/{domain} [get]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainsGet(c *gin.Context) {
+	paymailDomain, err := a.Services.SpvWalletEngine.GetPaymailDomain(c.Request.Context(), c.Param("domain"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailDomainContract(paymailDomain))
+}
+
+// paymailDomainsUpdate will update the settings of the paymail domain
+// Update paymail domain godoc
+// @Summary		Update paymail domain
+// @Description	Update the settings of the paymail domain, all the servers of the cluster are reloaded
+// @Tags		Admin
+// @Produce		json
+// @Param		domain path string true "Paymail domain"
+// @Param		UpdatePaymailDomain body UpdatePaymailDomain true "Settings of the paymail domain"
+// @Success		200	{object} response.PaymailDomain "Updated paymail domain"
+// @Failure		400	"Bad request - Error while parsing UpdatePaymailDomain from request body"
+// @Failure		404	"Not found - Paymail domain not found"
+// @Failure 	500	"Internal Server Error - Error while updating the paymail domain"
+// @Router		/v1/admin/paymail-domains/{domain} [patch]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainsUpdate(c *gin.Context) {
+	var requestBody UpdatePaymailDomain
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	paymailDomain, err := a.Services.SpvWalletEngine.UpdatePaymailDomain(
+		c.Request.Context(),
+		c.Param("domain"),
+		requestBody.toEngine(),
+		engine.WithMetadatas(requestBody.Metadata),
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailDomainContract(paymailDomain))
+}
+
+// paymailDomainsDelete will delete the paymail domain
+// Delete paymail domain godoc
+// @Summary		Delete paymail domain
+// @Description	Delete the paymail domain, it is not served anymore by the servers of the cluster
+// @Tags		Admin
+// @Produce		json
+// @Param		domain path string true "Paymail domain"
+// @Success		200
+// @Failure		404	"Not found - Paymail domain not found"
+// @Failure 	500	"Internal Server Error - Error while deleting the paymail domain"
+// @Router		/v1/admin/paymail-domains/{domain} [delete]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainsDelete(c *gin.Context) {
+	err := a.Services.SpvWalletEngine.DeletePaymailDomain(c.Request.Context(), c.Param("domain"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// paymailDomainCacheGet will return the cached capabilities and PKI of the counterpart paymail domain
+// Get paymail domain cache godoc
+// @Summary		Get paymail domain cache
+// @Description	Get the cached capabilities and PKI of the counterpart paymail domain (including the cached failed lookups)
+// @Tags		Admin
+// @Produce		json
+// @Param		domain path string true "Counterpart paymail domain"
+// @Success		200	{object} response.PaymailDomainCache "Cached data of the paymail domain"
+// @Failure		400	"Bad request - Invalid domain"
+// @Failure 	500	"Internal Server Error - Error while getting the cached data from cachestore"
+// @Router		/v1/admin/paymail-cache/{domain} [get]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainCacheGet(c *gin.Context) {
+	domainCache, err := a.Services.SpvWalletEngine.GetPaymailDomainCache(c.Request.Context(), c.Param("domain"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailDomainCacheContract(domainCache))
+}
+
+// paymailDomainCacheFlush will remove the cached capabilities and PKI of the counterpart paymail domain
+// Flush paymail domain cache godoc
+// @Summary		Flush paymail domain cache
+// @Description	Remove the cached capabilities and PKI of the counterpart paymail domain, they are requested again on the next use
+// @Tags		Admin
+// @Produce		json
+// @Param		domain path string true "Counterpart paymail domain"
+// @Success		200
+// @Failure		400	"Bad request - Invalid domain"
+// @Failure 	500	"Internal Server Error - Error while removing the cached data from cachestore"
+// @Router		/v1/admin/paymail-cache/{domain} [delete]
+// @Security	x-auth-xpub
+func (a *Action) paymailDomainCacheFlush(c *gin.Context) {
+	if err := a.Services.SpvWalletEngine.FlushPaymailDomainCache(c.Request.Context(), c.Param("domain")); err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
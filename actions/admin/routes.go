@@ -27,6 +27,9 @@ func NewHandler(appConfig *config.AppConfig, services *config.AppServices) route
 		adminGroup.DELETE("/contact/:id", action.contactsDelete)
 		adminGroup.PATCH("/contact/accepted/:id", action.contactsAccept)
 		adminGroup.PATCH("/contact/rejected/:id", action.contactsReject)
+		adminGroup.GET("/contact-blocklist", action.contactBlocklistGet)
+		adminGroup.PUT("/contact-blocklist/:subject", action.contactBlocklistAdd)
+		adminGroup.DELETE("/contact-blocklist/:subject", action.contactBlocklistRemove)
 		adminGroup.POST("/destinations/search", action.destinationsSearch)
 		adminGroup.POST("/destinations/count", action.destinationsCount)
 		adminGroup.POST("/paymail/get", action.paymailGetAddress)
@@ -34,9 +37,18 @@ func NewHandler(appConfig *config.AppConfig, services *config.AppServices) route
 		adminGroup.POST("/paymails/count", action.paymailAddressesCount)
 		adminGroup.POST("/paymail/create", action.paymailCreateAddress)
 		adminGroup.DELETE("/paymail/delete", action.paymailDeleteAddress)
+		adminGroup.POST("/paymail/rotate-key", action.paymailRotatePubKey)
+		adminGroup.POST("/paymail-domains", action.paymailDomainsCreate)
+		adminGroup.GET("/paymail-domains", action.paymailDomainsList)
+		adminGroup.GET("/paymail-domains/:domain", action.paymailDomainsGet)
+		adminGroup.PATCH("/paymail-domains/:domain", action.paymailDomainsUpdate)
+		adminGroup.DELETE("/paymail-domains/:domain", action.paymailDomainsDelete)
+		adminGroup.GET("/paymail-cache/:domain", action.paymailDomainCacheGet)
+		adminGroup.DELETE("/paymail-cache/:domain", action.paymailDomainCacheFlush)
 		adminGroup.POST("/transactions/search", action.transactionsSearch)
 		adminGroup.POST("/transactions/count", action.transactionsCount)
 		adminGroup.POST("/transactions/record", action.transactionRecord)
+		adminGroup.GET("/transactions/:id/export", action.transactionExport)
 		adminGroup.POST("/utxos/search", action.utxosSearch)
 		adminGroup.POST("/utxos/count", action.utxosCount)
 		adminGroup.POST("/xpub", action.xpubsCreate)
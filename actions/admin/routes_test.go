@@ -26,9 +26,21 @@ func (ts *TestSuite) TestXPubRegisterRoutes() {
 			{"POST", "/" + config.APIVersion + "/admin/paymails/count"},
 			{"POST", "/" + config.APIVersion + "/admin/paymail/create"},
 			{"DELETE", "/" + config.APIVersion + "/admin/paymail/delete"},
+			{"POST", "/" + config.APIVersion + "/admin/paymail/rotate-key"},
+			{"POST", "/" + config.APIVersion + "/admin/paymail-domains"},
+			{"GET", "/" + config.APIVersion + "/admin/paymail-domains"},
+			{"GET", "/" + config.APIVersion + "/admin/paymail-domains/:domain"},
+			{"PATCH", "/" + config.APIVersion + "/admin/paymail-domains/:domain"},
+			{"DELETE", "/" + config.APIVersion + "/admin/paymail-domains/:domain"},
+			{"GET", "/" + config.APIVersion + "/admin/paymail-cache/:domain"},
+			{"DELETE", "/" + config.APIVersion + "/admin/paymail-cache/:domain"},
+			{"GET", "/" + config.APIVersion + "/admin/contact-blocklist"},
+			{"PUT", "/" + config.APIVersion + "/admin/contact-blocklist/:subject"},
+			{"DELETE", "/" + config.APIVersion + "/admin/contact-blocklist/:subject"},
 			{"POST", "/" + config.APIVersion + "/admin/transactions/search"},
 			{"POST", "/" + config.APIVersion + "/admin/transactions/count"},
 			{"POST", "/" + config.APIVersion + "/admin/transactions/record"},
+			{"GET", "/" + config.APIVersion + "/admin/transactions/:id/export"},
 			{"POST", "/" + config.APIVersion + "/admin/utxos/search"},
 			{"POST", "/" + config.APIVersion + "/admin/utxos/count"},
 			{"POST", "/" + config.APIVersion + "/admin/xpub"},
@@ -0,0 +1,90 @@
+package contacts
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// getContactBlocks will fetch the blocked paymails and domains of the user
+// @Summary		Get blocked paymails
+// @Description	Get the paymails and domains whose contact invitations are rejected (blocked) or dropped silently (muted)
+// @Tags		Contacts
+// @Produce		json
+// @Success		200 {object} []response.ContactBlock "List of blocked paymails and domains"
+// @Failure 	500	"Internal server error - Error while fetching the blocked paymails"
+// @Router		/api/v1/contacts/blocklist [get]
+// @Security	x-auth-xpub
+func (a *Action) getContactBlocks(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	blocks, err := a.Services.SpvWalletEngine.GetContactBlocks(c.Request.Context(), reqXPubID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactBlockContracts(blocks))
+}
+
+// blockContact will block (or mute) the contact invitations of the paymail or domain
+// @Summary		Block paymail or domain
+// @Description	Block (or mute) the contact invitations of the paymail or the whole domain (including its subdomains). The invitations of the blocked paymails are rejected with an error, the invitations of the muted ones are dropped silently. The pending invitations are rejected
+// @Tags		Contacts
+// @Produce		json
+// @Param		subject path string true "Paymail address or domain to block"
+// @Param		BlockContact body contacts.BlockContact false "Action applied to the invitations"
+// @Success		200 {object} response.ContactBlock "Blocked paymail or domain"
+// @Failure		400	"Bad request - Invalid paymail, domain or action"
+// @Failure 	500	"Internal server error - Error while blocking the paymail"
+// @Router		/api/v1/contacts/blocklist/{subject} [put]
+// @Security	x-auth-xpub
+func (a *Action) blockContact(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	var req BlockContact
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+			return
+		}
+	}
+
+	block, err := a.Services.SpvWalletEngine.BlockContact(
+		c.Request.Context(), reqXPubID, c.Param("subject"), req.action(),
+		engine.WithMetadatas(req.Metadata),
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactBlockContract(block))
+}
+
+// unblockContact will remove the block of the paymail or domain
+// @Summary		Unblock paymail or domain
+// @Description	Remove the block of the paymail or domain, its contact invitations are accepted again
+// @Tags		Contacts
+// @Produce		json
+// @Param		subject path string true "Blocked paymail address or domain"
+// @Success		200
+// @Failure		400	"Bad request - Invalid paymail or domain"
+// @Failure		404	"Not found - Paymail or domain is not blocked"
+// @Failure 	500	"Internal server error - Error while unblocking the paymail"
+// @Router		/api/v1/contacts/blocklist/{subject} [delete]
+// @Security	x-auth-xpub
+func (a *Action) unblockContact(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	if err := a.Services.SpvWalletEngine.UnblockContact(c.Request.Context(), reqXPubID, c.Param("subject")); err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
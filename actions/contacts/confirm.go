@@ -1,6 +1,7 @@
 package contacts
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
@@ -8,32 +9,47 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// oldConfirm will confirm contact request
+// oldConfirm will confirm contact request without the verification code
 // Confirm contact godoc
 // @Summary		Confirm contact - Use (POST) /api/v1/contacts/{paymail}/confirmation instead
-// @Description	This endpoint has been deprecated. Use (POST) /api/v1/contacts/{paymail}/confirmation instead.
+// @Description	This endpoint has been deprecated. Confirm contact without the verification code, which is possible only if SPV Wallet has no paymail key provider. Use (POST) /api/v1/contacts/{paymail}/confirmation instead.
 // @Tags		Contact
 // @Produce		json
 // @Param		paymail path string true "Paymail address of the contact that the user would like to confirm"
 // @Success		200
 // @Failure		404	"Contact not found"
+// @Failure		410	"Gone - Use (POST) /api/v1/contacts/{paymail}/confirmation with the verification code"
 // @Failure		422	"Contact status not unconfirmed"
 // @Failure		500	"Internal server error"
 // @DeprecatedRouter  /v1/contact/confirmed/{paymail} [patch]
 // @Security	x-auth-xpub
 func (a *Action) oldConfirm(c *gin.Context) {
-	a.confirmContact(c)
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	paymail := c.Param("paymail")
+
+	err := a.Services.SpvWalletEngine.ConfirmContact(c, reqXPubID, paymail, "", "")
+	if errors.Is(err, spverrors.ErrMissingContactPasscode) {
+		err = spverrors.ErrContactConfirmationDeprecated
+	}
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+	c.Status(http.StatusOK)
 }
 
 // confirmContact will confirm contact request
 // @Summary		Confirm contact
-// @Description	Confirm contact. For contact with status "unconfirmed" change status to "confirmed"
+// @Description	Confirm contact. For contact with status "unconfirmed" change status to "confirmed" if the verification code generated by the contact (GET /api/v1/contacts/{paymail}/totp on the contact's side) is valid. The verification is locked after too many failed codes. Without the paymail key provider the codes are not available and the contact is confirmed without the code
 // @Tags		Contacts
 // @Produce		json
 // @Param		paymail path string true "Paymail address of the contact that the user would like to confirm"
+// @Param		ConfirmContact body contacts.ConfirmContact true "Verification code generated by the contact"
 // @Success		200
+// @Failure		400	"Bad request - Missing or invalid verification code"
 // @Failure		404	"Contact not found"
 // @Failure		422	"Contact status not unconfirmed"
+// @Failure		429	"Too many failed verification attempts"
 // @Failure		500	"Internal server error"
 // @Router		/api/v1/contacts/{paymail}/confirmation [post]
 // @Security	x-auth-xpub
@@ -41,8 +57,13 @@ func (a *Action) confirmContact(c *gin.Context) {
 	reqXPubID := c.GetString(auth.ParamXPubHashKey)
 	paymail := c.Param("paymail")
 
-	err := a.Services.SpvWalletEngine.ConfirmContact(c, reqXPubID, paymail)
+	var req ConfirmContact
+	if err := c.ShouldBindJSON(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
 
+	err := a.Services.SpvWalletEngine.ConfirmContact(c, reqXPubID, paymail, req.RequesterPaymail, req.Passcode)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, a.Services.Logger)
 		return
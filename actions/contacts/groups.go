@@ -0,0 +1,41 @@
+package contacts
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// setContactGroups will replace the groups (tags) of the contact
+// @Summary		Set contact groups
+// @Description	Replace the groups (tags) of the contact, the empty list removes the contact from all the groups. The contacts can be filtered by the group
+// @Tags		Contacts
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the contact"
+// @Param		ContactGroups body contacts.ContactGroups true "Groups of the contact"
+// @Success		200 {object} response.Contact "Contact with the groups"
+// @Failure		400	"Bad request - Invalid group"
+// @Failure		404	"Not found - Contact not found"
+// @Failure 	500	"Internal server error - Error while saving the contact"
+// @Router		/api/v1/contacts/{paymail}/groups [put]
+// @Security	x-auth-xpub
+func (a *Action) setContactGroups(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	var req ContactGroups
+	if err := c.ShouldBindJSON(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	contact, err := a.Services.SpvWalletEngine.SetContactGroups(c.Request.Context(), reqXPubID, c.Param("paymail"), req.Groups)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactContract(contact))
+}
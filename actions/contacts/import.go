@@ -0,0 +1,88 @@
+package contacts
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// contactsContentTypes are the content types of the exported contacts files
+var contactsContentTypes = map[engine.ContactsFormat]string{
+	engine.ContactsFormatCSV:   "text/csv",
+	engine.ContactsFormatVCard: "text/vcard",
+}
+
+// contactsFileExtensions are the extensions of the exported contacts files
+var contactsFileExtensions = map[engine.ContactsFormat]string{
+	engine.ContactsFormatCSV:   "csv",
+	engine.ContactsFormatVCard: "vcf",
+}
+
+// importContacts will add (or update) the contacts from the CSV or vCard file
+// @Summary		Import contacts
+// @Description	Add (or update) the contacts from the CSV or vCard file. The paymails are validated and the contacts are optionally invited via PIKE. The entries which failed are returned with the reason
+// @Tags		Contacts
+// @Produce		json
+// @Param		ImportContacts body contacts.ImportContacts true "File with the contacts"
+// @Success		200 {object} response.ContactsImport "Imported contacts and the entries which failed"
+// @Failure		400	"Bad request - Unsupported format or invalid file"
+// @Failure 	500	"Internal server error - Error while importing the contacts"
+// @Router		/api/v1/contacts/import [post]
+// @Security	x-auth-xpub
+func (a *Action) importContacts(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	var req ImportContacts
+	if err := c.ShouldBindJSON(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	result, err := a.Services.SpvWalletEngine.ImportContacts(
+		c.Request.Context(), reqXPubID, req.RequesterPaymail,
+		engine.ContactsFormat(req.Format), []byte(req.Data), req.Invite,
+		engine.WithMetadatas(req.Metadata),
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactsImportContract(result))
+}
+
+// exportContacts will export the contacts to the CSV or vCard file
+// @Summary		Export contacts
+// @Description	Export the contacts (optionally only the contacts of the group) to the CSV or vCard file
+// @Tags		Contacts
+// @Produce		text/csv,text/vcard
+// @Param		format query string false "Format of the file: csv (default) or vcard"
+// @Param		group query string false "Export only the contacts of the group"
+// @Success		200 {file} file "File with the contacts"
+// @Failure		400	"Bad request - Unsupported format"
+// @Failure 	500	"Internal server error - Error while exporting the contacts"
+// @Router		/api/v1/contacts/export [get]
+// @Security	x-auth-xpub
+func (a *Action) exportContacts(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	format := engine.ContactsFormat(c.DefaultQuery("format", string(engine.ContactsFormatCSV)))
+	conditions := map[string]interface{}{}
+	if group := c.Query("group"); group != "" {
+		conditions["groups"] = group
+	}
+
+	data, err := a.Services.SpvWalletEngine.ExportContacts(c.Request.Context(), reqXPubID, format, nil, conditions)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="contacts.%s"`, contactsFileExtensions[format]))
+	c.Data(http.StatusOK, contactsContentTypes[format], data)
+}
@@ -22,3 +22,46 @@ func (p *UpsertContact) validate() error {
 
 	return nil
 }
+
+// ConfirmContact is the model for confirming a contact
+type ConfirmContact struct {
+	// The verification code generated (and shared) by the contact, required if SPV Wallet has the paymail key provider.
+	Passcode string `json:"passcode" example:"123456"`
+	// Optional paymail address owned by the user to bind the contact to. It is required in case if user has multiple paymail addresses
+	RequesterPaymail string `json:"requesterPaymail"`
+}
+
+// BlockContact is the model for blocking a paymail or domain
+type BlockContact struct {
+	// The action applied to the invitations: block (rejected with an error, default) or mute (dropped silently).
+	Action string `json:"action" example:"block"`
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+}
+
+func (p *BlockContact) action() engine.ContactBlockAction {
+	if p.Action == "" {
+		return engine.ContactBlockActionBlock
+	}
+	return engine.ContactBlockAction(p.Action)
+}
+
+// ContactGroups is the model for setting the groups of a contact
+type ContactGroups struct {
+	// The groups (tags) of the contact, the empty list removes the contact from all the groups.
+	Groups []string `json:"groups" example:"family,friends"`
+}
+
+// ImportContacts is the model for importing contacts
+type ImportContacts struct {
+	// The format of the imported file: csv (with the header: full_name, paymail, groups separated by semicolons) or vcard.
+	Format string `json:"format" example:"csv"`
+	// The content of the imported file.
+	Data string `json:"data" example:"full_name,paymail,groups\nAlice,alice@example.com,family;friends"`
+	// Send the PIKE invitations to the imported contacts.
+	Invite bool `json:"invite" example:"false"`
+	// Optional paymail address owned by the user to send the invitations from. It is required in case if user has multiple paymail addresses
+	RequesterPaymail string `json:"requesterPaymail"`
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+}
@@ -0,0 +1,35 @@
+package contacts
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// getContactProfile will fetch the public profile of the contact
+// @Summary		Get contact profile
+// @Description	Get the public profile (name and avatar) of the contact served by its paymail provider. The profiles are cached
+// @Tags		Contacts
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the contact"
+// @Success		200 {object} response.ContactProfile "Contact profile"
+// @Failure		400	"Bad request - Paymail provider of the contact doesn't support public profile"
+// @Failure		404	"Not found - Contact not found"
+// @Failure 	500	"Internal server error - Error while fetching the contact profile"
+// @Router		/api/v1/contacts/{paymail}/profile [get]
+// @Security	x-auth-xpub
+func (a *Action) getContactProfile(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	paymail := c.Param("paymail")
+
+	profile, err := a.Services.SpvWalletEngine.GetContactProfile(c.Request.Context(), reqXPubID, paymail)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactProfileContract(paymail, profile))
+}
@@ -45,6 +45,17 @@ func NewHandler(appConfig *config.AppConfig, services *config.AppServices) (rout
 
 		group.GET("", action.getContacts)
 		group.GET(":paymail", action.getContactByPaymail)
+		group.GET("/:paymail/profile", action.getContactProfile)
+		group.GET("/:paymail/totp", action.generateContactTotp)
+		group.PUT("/:paymail/groups", action.setContactGroups)
+		group.GET("/:paymail/transactions", action.getContactTransactions)
+
+		group.POST("/import", action.importContacts)
+		group.GET("/export", action.exportContacts)
+
+		group.GET("/blocklist", action.getContactBlocks)
+		group.PUT("/blocklist/:subject", action.blockContact)
+		group.DELETE("/blocklist/:subject", action.unblockContact)
 	})
 
 	invitationsAPIEndpoints := routes.APIEndpointsFunc(func(router *gin.RouterGroup) {
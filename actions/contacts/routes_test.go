@@ -27,6 +27,15 @@ func (ts *TestSuite) TestContactsRegisterRoutes() {
 			{"DELETE", "/api/" + config.APIVersion + "/contacts/:paymail/confirmation"},
 			{"GET", "/api/" + config.APIVersion + "/contacts"},
 			{"GET", "/api/" + config.APIVersion + "/contacts/:paymail"},
+			{"GET", "/api/" + config.APIVersion + "/contacts/:paymail/profile"},
+			{"GET", "/api/" + config.APIVersion + "/contacts/:paymail/totp"},
+			{"PUT", "/api/" + config.APIVersion + "/contacts/:paymail/groups"},
+			{"GET", "/api/" + config.APIVersion + "/contacts/:paymail/transactions"},
+			{"POST", "/api/" + config.APIVersion + "/contacts/import"},
+			{"GET", "/api/" + config.APIVersion + "/contacts/export"},
+			{"GET", "/api/" + config.APIVersion + "/contacts/blocklist"},
+			{"PUT", "/api/" + config.APIVersion + "/contacts/blocklist/:subject"},
+			{"DELETE", "/api/" + config.APIVersion + "/contacts/blocklist/:subject"},
 
 			{"POST", "/api/" + config.APIVersion + "/invitations/:paymail/contacts"},
 			{"DELETE", "/api/" + config.APIVersion + "/invitations/:paymail"},
@@ -0,0 +1,37 @@
+package contacts
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// generateContactTotp will generate the verification code for the contact
+// @Summary		Generate contact verification code
+// @Description	Generate the time-based verification code which the user shares with the contact (e.g. by phone), so the contact can confirm the user. The code is derived from the PKI keys of both paymails
+// @Tags		Contacts
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the contact"
+// @Param		requesterPaymail query string false "Paymail address of the user, required in case if user has multiple paymail addresses"
+// @Success		200 {object} response.ContactTotp "Verification code"
+// @Failure		400	"Bad request - Contact status not unconfirmed or confirmed"
+// @Failure		404	"Not found - Contact not found"
+// @Failure 	500	"Internal server error - Error while generating the verification code"
+// @Failure 	501	"Not implemented - Contact verification requires the paymail key provider"
+// @Router		/api/v1/contacts/{paymail}/totp [get]
+// @Security	x-auth-xpub
+func (a *Action) generateContactTotp(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	paymail := c.Param("paymail")
+
+	totp, err := a.Services.SpvWalletEngine.GenerateContactTotp(c.Request.Context(), reqXPubID, paymail, c.Query("requesterPaymail"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToContactTotpContract(totp))
+}
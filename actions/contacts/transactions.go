@@ -0,0 +1,52 @@
+package contacts
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/actions/common"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/internal/query"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// getContactTransactions will fetch the transactions exchanged with the contact
+// @Summary		Get contact transactions
+// @Description	Get the transactions exchanged with the contact (sent to and received from the contact) with the totals sent and received
+// @Tags		Contacts
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the contact"
+// @Param		SwaggerCommonParams query swagger.CommonFilteringQueryParams false "Supports options for pagination and sorting to streamline data exploration and analysis"
+// @Success		200 {object} response.ContactTransactions "Page of the transactions exchanged with the contact"
+// @Failure		400	"Bad request - Error while parsing query params"
+// @Failure		404	"Not found - Contact not found"
+// @Failure 	500	"Internal server error - Error while fetching the transactions"
+// @Router		/api/v1/contacts/{paymail}/transactions [get]
+// @Security	x-auth-xpub
+func (a *Action) getContactTransactions(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	searchParams, err := query.ParseSearchParams[struct{}](c)
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotParseQueryParams, a.Services.Logger)
+		return
+	}
+	pageOptions := mappings.MapToDbQueryParams(&searchParams.Page)
+
+	transactions, err := a.Services.SpvWalletEngine.GetContactTransactions(
+		c.Request.Context(),
+		reqXPubID,
+		c.Param("paymail"),
+		pageOptions,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToContactTransactionsContract(transactions)
+	contract.Page = common.GetPageDescriptionFromSearchParams(pageOptions, transactions.Count)
+
+	c.JSON(http.StatusOK, contract)
+}
@@ -0,0 +1,148 @@
+package multisig
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// createAccount will make a new multisig account
+// Create multisig account godoc
+// @Summary		Create multisig account
+// @Description	Create an m-of-n multisig account composed of registered xpubs. The requesting xpub has to be one of the members
+// @Tags		Multisig
+// @Produce		json
+// @Param		CreateMultisigAccount body CreateMultisigAccount true "CreateMultisigAccount model containing the xpubs of the members, the threshold and metadata"
+// @Success		201 {object} response.MultisigAccount "Created multisig account"
+// @Failure		400	"Bad request - Error while parsing CreateMultisigAccount from request body, invalid threshold or number of keys"
+// @Failure		403	"Forbidden - The requesting xpub is not a member"
+// @Failure		409	"Conflict - Multisig account already exists"
+// @Failure 	500	"Internal Server Error - Error while creating multisig account"
+// @Router		/api/v1/multisig/accounts [post]
+// @Security	x-auth-xpub
+func (a *Action) createAccount(c *gin.Context) {
+	reqXPub := c.GetString(auth.ParamXPubKey)
+
+	xPub, err := a.Services.SpvWalletEngine.GetXpub(c.Request.Context(), reqXPub)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if xPub == nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindXpub, a.Services.Logger)
+		return
+	}
+
+	var requestBody CreateMultisigAccount
+	if err = c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	opts := a.Services.SpvWalletEngine.DefaultModelOptions()
+	if requestBody.Metadata != nil {
+		opts = append(opts, engine.WithMetadatas(requestBody.Metadata))
+	}
+
+	account, err := a.Services.SpvWalletEngine.NewMultisigAccount(
+		c.Request.Context(),
+		xPub.RawXpub(),
+		requestBody.XpubKeys,
+		requestBody.Threshold,
+		opts...,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToMultisigAccountContract(account)
+	c.JSON(http.StatusCreated, contract)
+}
+
+// getAccount will fetch a multisig account by id
+// Get multisig account godoc
+// @Summary		Get multisig account
+// @Description	Get multisig account (the requesting xpub has to be one of the members)
+// @Tags		Multisig
+// @Produce		json
+// @Param		id path string true "id of the multisig account"
+// @Success		200 {object} response.MultisigAccount "Multisig account"
+// @Failure		400	"Bad request - Missing required field: id"
+// @Failure		403	"Forbidden - The requesting xpub is not a member"
+// @Failure		404	"Not found - Multisig account not found"
+// @Failure 	500	"Internal server error - Error while fetching multisig account"
+// @Router		/api/v1/multisig/accounts/{id} [get]
+// @Security	x-auth-xpub
+func (a *Action) getAccount(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	account, err := a.Services.SpvWalletEngine.GetMultisigAccount(
+		c.Request.Context(), reqXPubID, id,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToMultisigAccountContract(account)
+	c.JSON(http.StatusOK, contract)
+}
+
+// newDestination will create a new destination of a multisig account
+// New multisig destination godoc
+// @Summary		New multisig destination
+// @Description	Create a new (bare-multisig) destination of the multisig account
+// @Tags		Multisig
+// @Produce		json
+// @Param		id path string true "id of the multisig account"
+// @Param		NewMultisigDestination body NewMultisigDestination false "NewMultisigDestination model containing metadata"
+// @Success		201 {object} response.Destination "Created destination"
+// @Failure		400	"Bad request - Missing required field: id or error while parsing NewMultisigDestination from request body"
+// @Failure		403	"Forbidden - The requesting xpub is not a member"
+// @Failure		404	"Not found - Multisig account not found"
+// @Failure 	500	"Internal server error - Error while creating destination"
+// @Router		/api/v1/multisig/accounts/{id}/destinations [post]
+// @Security	x-auth-xpub
+func (a *Action) newDestination(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	var requestBody NewMultisigDestination
+	if c.Request.ContentLength > 0 {
+		if err := c.Bind(&requestBody); err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+			return
+		}
+	}
+
+	opts := a.Services.SpvWalletEngine.DefaultModelOptions()
+	if requestBody.Metadata != nil {
+		opts = append(opts, engine.WithMetadatas(requestBody.Metadata))
+	}
+
+	destination, err := a.Services.SpvWalletEngine.NewMultisigDestination(
+		c.Request.Context(), reqXPubID, id, opts...,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToDestinationContract(destination)
+	c.JSON(http.StatusCreated, contract)
+}
@@ -0,0 +1,157 @@
+package multisig
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// newDraft will create a new draft transaction spending the funds of a multisig account
+// New multisig draft transaction godoc
+// @Summary		New multisig draft transaction
+// @Description	Create a draft transaction spending the funds of the multisig account. It has to be signed by at least "threshold" members
+// @Tags		Multisig
+// @Produce		json
+// @Param		id path string true "id of the multisig account"
+// @Param		NewMultisigDraftTransaction body NewMultisigDraftTransaction true "NewMultisigDraftTransaction model containing the transaction config and metadata"
+// @Success		201 {object} response.MultisigDraftTransaction "Created draft transaction"
+// @Failure		400	"Bad request - Missing required field: id or error while parsing NewMultisigDraftTransaction from request body"
+// @Failure		403	"Forbidden - The requesting xpub is not a member"
+// @Failure		404	"Not found - Multisig account not found"
+// @Failure 	500	"Internal server error - Error while creating draft transaction"
+// @Router		/api/v1/multisig/accounts/{id}/drafts [post]
+// @Security	x-auth-xpub
+func (a *Action) newDraft(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	var requestBody NewMultisigDraftTransaction
+	if err := c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	opts := a.Services.SpvWalletEngine.DefaultModelOptions()
+	if requestBody.Metadata != nil {
+		opts = append(opts, engine.WithMetadatas(requestBody.Metadata))
+	}
+
+	txConfig := mappings.MapTransactionConfigEngineToModel(&requestBody.Config)
+
+	draft, err := a.Services.SpvWalletEngine.NewMultisigTransaction(
+		c.Request.Context(), reqXPubID, id, txConfig, opts...,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	account, err := a.Services.SpvWalletEngine.GetMultisigAccount(c.Request.Context(), reqXPubID, id)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToMultisigDraftTransactionContract(draft, account, "")
+	c.JSON(http.StatusCreated, contract)
+}
+
+// getDraft will fetch a multisig draft transaction with the collected signatures
+// Get multisig draft transaction godoc
+// @Summary		Get multisig draft transaction
+// @Description	Get multisig draft transaction with the collected signatures. When enough signatures are collected, the signed transaction hex is returned and can be recorded
+// @Tags		Multisig
+// @Produce		json
+// @Param		id path string true "id of the draft transaction"
+// @Success		200 {object} response.MultisigDraftTransaction "Multisig draft transaction"
+// @Failure		400	"Bad request - Missing required field: id or draft transaction is not a multisig draft"
+// @Failure		403	"Forbidden - The requesting xpub is not a member"
+// @Failure		404	"Not found - Draft transaction not found"
+// @Failure 	500	"Internal server error - Error while fetching draft transaction"
+// @Router		/api/v1/multisig/drafts/{id} [get]
+// @Security	x-auth-xpub
+func (a *Action) getDraft(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	draft, account, err := a.Services.SpvWalletEngine.GetMultisigDraftTransaction(
+		c.Request.Context(), reqXPubID, id,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	a.multisigDraftResponse(c, http.StatusOK, draft, account)
+}
+
+// addSignatures will add the partial signatures of the requesting member to a multisig draft transaction
+// Add multisig signatures godoc
+// @Summary		Add multisig signatures
+// @Description	Add (verified) partial signatures of the requesting member. When enough signatures are collected, the signed transaction hex is returned and can be recorded
+// @Tags		Multisig
+// @Produce		json
+// @Param		id path string true "id of the draft transaction"
+// @Param		AddMultisigSignatures body AddMultisigSignatures true "AddMultisigSignatures model containing the signatures of the inputs"
+// @Success		200 {object} response.MultisigDraftTransaction "Multisig draft transaction"
+// @Failure		400	"Bad request - Missing required field: id, invalid signature or draft transaction is not a multisig draft"
+// @Failure		403	"Forbidden - The requesting xpub is not a member"
+// @Failure		404	"Not found - Draft transaction not found"
+// @Failure 	500	"Internal server error - Error while adding signatures"
+// @Router		/api/v1/multisig/drafts/{id}/signatures [post]
+// @Security	x-auth-xpub
+func (a *Action) addSignatures(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	var requestBody AddMultisigSignatures
+	if err := c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	draft, account, err := a.Services.SpvWalletEngine.AddMultisigSignatures(
+		c.Request.Context(), reqXPubID, id, requestBody.toEngine(),
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	a.multisigDraftResponse(c, http.StatusOK, draft, account)
+}
+
+func (a *Action) multisigDraftResponse(c *gin.Context, status int, draft *engine.DraftTransaction,
+	account *engine.MultisigAccount,
+) {
+	var signedHex string
+	if draft.IsMultisigComplete(account) {
+		var err error
+		if signedHex, err = draft.CombineMultisigSignatures(account); err != nil {
+			spverrors.ErrorResponse(c, err, a.Services.Logger)
+			return
+		}
+	}
+
+	contract := mappings.MapToMultisigDraftTransactionContract(draft, account, signedHex)
+	c.JSON(status, contract)
+}
@@ -0,0 +1,55 @@
+package multisig
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// CreateMultisigAccount is the model for creating a multisig account
+type CreateMultisigAccount struct {
+	// Raw xpubs of all the members (the requesting xpub has to be one of them), all of them have to be registered
+	XpubKeys []string `json:"xpubKeys" example:"[\"xpub661MyMwAqRbcGpZVrSHU...\",\"xpub661MyMwAqRbcFtXgS5sY...\"]"`
+	// Number of signatures required to spend the funds of the account
+	Threshold uint16 `json:"threshold" example:"2"`
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+}
+
+// NewMultisigDestination is the model for creating a destination of a multisig account
+type NewMultisigDestination struct {
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+}
+
+// NewMultisigDraftTransaction is the model for creating a draft transaction spending the funds of a multisig account
+type NewMultisigDraftTransaction struct {
+	// Configuration of the transaction
+	Config response.TransactionConfig `json:"config"`
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+}
+
+// AddMultisigSignatures is the model for adding partial signatures to a multisig draft transaction
+type AddMultisigSignatures struct {
+	// Signatures of the inputs made by the requesting member
+	Signatures []*MultisigSignature `json:"signatures"`
+}
+
+// MultisigSignature is a partial signature of a multisig input
+type MultisigSignature struct {
+	// Index of the signed input
+	InputIndex uint32 `json:"inputIndex" example:"0"`
+	// DER signature with the sighash flag (SIGHASH_ALL|FORKID) appended, in hex
+	Signature string `json:"signature" example:"3044022017...41"`
+}
+
+func (s *AddMultisigSignatures) toEngine() []*engine.MultisigSignature {
+	signatures := make([]*engine.MultisigSignature, 0, len(s.Signatures))
+	for _, signature := range s.Signatures {
+		signatures = append(signatures, &engine.MultisigSignature{
+			InputIndex: signature.InputIndex,
+			Signature:  signature.Signature,
+		})
+	}
+	return signatures
+}
@@ -0,0 +1,30 @@
+package multisig
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/actions"
+	"github.com/bitcoin-sv/spv-wallet/config"
+	"github.com/bitcoin-sv/spv-wallet/server/routes"
+	"github.com/gin-gonic/gin"
+)
+
+// Action is an extension of actions.Action for this package
+type Action struct {
+	actions.Action
+}
+
+// NewHandler creates the specific package routes
+func NewHandler(appConfig *config.AppConfig, services *config.AppServices) routes.APIEndpointsFunc {
+	action := &Action{actions.Action{AppConfig: appConfig, Services: services}}
+
+	apiEndpoints := routes.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		group := router.Group("/multisig")
+		group.POST("/accounts", action.createAccount)
+		group.GET("/accounts/:id", action.getAccount)
+		group.POST("/accounts/:id/destinations", action.newDestination)
+		group.POST("/accounts/:id/drafts", action.newDraft)
+		group.GET("/drafts/:id", action.getDraft)
+		group.POST("/drafts/:id/signatures", action.addSignatures)
+	})
+
+	return apiEndpoints
+}
@@ -0,0 +1,37 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMultisigRegisterRoutes will test routes
+func (ts *TestSuite) TestMultisigRegisterRoutes() {
+	ts.T().Run("test routes", func(t *testing.T) {
+		testCases := []struct {
+			method string
+			url    string
+		}{
+			{"POST", "/api/" + config.APIVersion + "/multisig/accounts"},
+			{"GET", "/api/" + config.APIVersion + "/multisig/accounts/:id"},
+			{"POST", "/api/" + config.APIVersion + "/multisig/accounts/:id/destinations"},
+			{"POST", "/api/" + config.APIVersion + "/multisig/accounts/:id/drafts"},
+			{"GET", "/api/" + config.APIVersion + "/multisig/drafts/:id"},
+			{"POST", "/api/" + config.APIVersion + "/multisig/drafts/:id/signatures"},
+		}
+
+		for _, testCase := range testCases {
+			found := false
+			for _, routeInfo := range ts.Router.Routes() {
+				if testCase.url == routeInfo.Path && testCase.method == routeInfo.Method {
+					assert.NotNil(t, routeInfo.HandlerFunc)
+					found = true
+					break
+				}
+			}
+			assert.True(t, found)
+		}
+	})
+}
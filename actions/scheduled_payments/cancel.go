@@ -0,0 +1,44 @@
+package scheduledpayments
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// cancel will stop the scheduled payment, drafts created before are not affected
+// Cancel scheduled payment godoc
+// @Summary		Cancel scheduled payment
+// @Description	Cancel scheduled payment, no more drafts will be created for it
+// @Tags		Scheduled-payments
+// @Produce		json
+// @Param		id path string true "id of the scheduled payment"
+// @Success		200 {object} response.ScheduledPayment "Canceled scheduled payment"
+// @Failure		400	"Bad request - Missing required field: id or scheduled payment is not active"
+// @Failure		404	"Not found - Scheduled payment not found"
+// @Failure 	500	"Internal server error - Error while canceling scheduled payment"
+// @Router		/api/v1/scheduled-payments/{id} [delete]
+// @Security	x-auth-xpub
+func (a *Action) cancel(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	scheduledPayment, err := a.Services.SpvWalletEngine.CancelScheduledPayment(
+		c.Request.Context(), reqXPubID, id,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToScheduledPaymentContract(scheduledPayment)
+	c.JSON(http.StatusOK, contract)
+}
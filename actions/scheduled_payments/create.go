@@ -0,0 +1,67 @@
+package scheduledpayments
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// create will make a new scheduled payment
+// Create scheduled payment godoc
+// @Summary		Create scheduled payment
+// @Description	Create a recurring payment. When it is due, a draft transaction is created and the owner is notified to sign it
+// @Tags		Scheduled-payments
+// @Produce		json
+// @Param		CreateScheduledPayment body CreateScheduledPayment true "CreateScheduledPayment model containing the schedule and metadata"
+// @Success		201 {object} response.ScheduledPayment "Created scheduled payment"
+// @Failure		400	"Bad request - Error while parsing CreateScheduledPayment from request body or invalid schedule"
+// @Failure 	500	"Internal Server Error - Error while creating scheduled payment"
+// @Router		/api/v1/scheduled-payments [post]
+// @Security	x-auth-xpub
+func (a *Action) create(c *gin.Context) {
+	reqXPub := c.GetString(auth.ParamXPubKey)
+
+	xPub, err := a.Services.SpvWalletEngine.GetXpub(c.Request.Context(), reqXPub)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if xPub == nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindXpub, a.Services.Logger)
+		return
+	}
+
+	var requestBody CreateScheduledPayment
+	if err = c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	config, err := requestBody.toConfig()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	opts := a.Services.SpvWalletEngine.DefaultModelOptions()
+	if requestBody.Metadata != nil {
+		opts = append(opts, engine.WithMetadatas(requestBody.Metadata))
+	}
+
+	scheduledPayment, err := a.Services.SpvWalletEngine.NewScheduledPayment(
+		c.Request.Context(),
+		xPub.RawXpub(),
+		config,
+		opts...,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToScheduledPaymentContract(scheduledPayment)
+	c.JSON(http.StatusCreated, contract)
+}
@@ -0,0 +1,44 @@
+package scheduledpayments
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// get will fetch a scheduled payment by id
+// Get scheduled payment godoc
+// @Summary		Get scheduled payment
+// @Description	Get scheduled payment with its latest runs
+// @Tags		Scheduled-payments
+// @Produce		json
+// @Param		id path string true "id of the scheduled payment"
+// @Success		200 {object} response.ScheduledPayment "Scheduled payment"
+// @Failure		400	"Bad request - Missing required field: id"
+// @Failure		404	"Not found - Scheduled payment not found"
+// @Failure 	500	"Internal server error - Error while fetching scheduled payment"
+// @Router		/api/v1/scheduled-payments/{id} [get]
+// @Security	x-auth-xpub
+func (a *Action) get(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	scheduledPayment, err := a.Services.SpvWalletEngine.GetScheduledPayment(
+		c.Request.Context(), reqXPubID, id,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToScheduledPaymentContract(scheduledPayment)
+	c.JSON(http.StatusOK, contract)
+}
@@ -0,0 +1,48 @@
+package scheduledpayments
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// CreateScheduledPayment is the model for creating a scheduled payment
+type CreateScheduledPayment struct {
+	// Paymail, address or handle of the recipient
+	Recipient string `json:"recipient" example:"alice@example.com"`
+	// Amount of satoshis sent on every run
+	Satoshis uint64 `json:"satoshis" example:"1000"`
+	// Standard (5 fields) cron expression of the schedule, takes precedence over interval
+	CronExpression string `json:"cronExpression" example:"0 12 1 * *"`
+	// Interval between runs in Go duration format, used when no cron expression is set
+	Interval string `json:"interval" example:"24h"`
+	// Time of the first run, defaults to now
+	StartAt time.Time `json:"startAt" example:"2024-03-01T12:00:00Z"`
+	// Time after which no more runs are made
+	EndAt time.Time `json:"endAt" example:"2025-03-01T12:00:00Z"`
+	// Maximum number of runs, 0 means unlimited
+	MaxRuns uint32 `json:"maxRuns" example:"12"`
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+}
+
+func (p *CreateScheduledPayment) toConfig() (*engine.ScheduledPaymentConfig, error) {
+	var interval time.Duration
+	if p.Interval != "" {
+		var err error
+		if interval, err = time.ParseDuration(p.Interval); err != nil {
+			return nil, spverrors.ErrScheduledPaymentInvalidInterval
+		}
+	}
+
+	return &engine.ScheduledPaymentConfig{
+		Recipient:      p.Recipient,
+		Satoshis:       p.Satoshis,
+		CronExpression: p.CronExpression,
+		Interval:       interval,
+		StartAt:        p.StartAt,
+		EndAt:          p.EndAt,
+		MaxRuns:        p.MaxRuns,
+	}, nil
+}
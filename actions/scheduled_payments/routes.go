@@ -0,0 +1,28 @@
+package scheduledpayments
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/actions"
+	"github.com/bitcoin-sv/spv-wallet/config"
+	"github.com/bitcoin-sv/spv-wallet/server/routes"
+	"github.com/gin-gonic/gin"
+)
+
+// Action is an extension of actions.Action for this package
+type Action struct {
+	actions.Action
+}
+
+// NewHandler creates the specific package routes
+func NewHandler(appConfig *config.AppConfig, services *config.AppServices) routes.APIEndpointsFunc {
+	action := &Action{actions.Action{AppConfig: appConfig, Services: services}}
+
+	apiEndpoints := routes.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		group := router.Group("/scheduled-payments")
+		group.POST("", action.create)
+		group.GET("", action.search)
+		group.GET("/:id", action.get)
+		group.DELETE("/:id", action.cancel)
+	})
+
+	return apiEndpoints
+}
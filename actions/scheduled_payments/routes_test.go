@@ -0,0 +1,35 @@
+package scheduledpayments
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestScheduledPaymentsRegisterRoutes will test routes
+func (ts *TestSuite) TestScheduledPaymentsRegisterRoutes() {
+	ts.T().Run("test routes", func(t *testing.T) {
+		testCases := []struct {
+			method string
+			url    string
+		}{
+			{"POST", "/api/" + config.APIVersion + "/scheduled-payments"},
+			{"GET", "/api/" + config.APIVersion + "/scheduled-payments"},
+			{"GET", "/api/" + config.APIVersion + "/scheduled-payments/:id"},
+			{"DELETE", "/api/" + config.APIVersion + "/scheduled-payments/:id"},
+		}
+
+		for _, testCase := range testCases {
+			found := false
+			for _, routeInfo := range ts.Router.Routes() {
+				if testCase.url == routeInfo.Path && testCase.method == routeInfo.Method {
+					assert.NotNil(t, routeInfo.HandlerFunc)
+					found = true
+					break
+				}
+			}
+			assert.True(t, found)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package scheduledpayments
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/actions/common"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/internal/query"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/models/filter"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// search will fetch a list of scheduled payments filtered by metadata
+// Search scheduled payments godoc
+// @Summary		Search scheduled payments
+// @Description	Search scheduled payments
+// @Tags		Scheduled-payments
+// @Produce		json
+// @Param		SwaggerCommonParams query swagger.CommonFilteringQueryParams false "Supports options for pagination and sorting to streamline data exploration and analysis"
+// @Param		ScheduledPaymentParams query filter.ScheduledPaymentFilter false "Supports targeted resource searches with filters"
+// @Success		200 {object} response.PageModel[response.ScheduledPayment] "Page of scheduled payments"
+// @Failure		400	"Bad request - Error while parsing SearchScheduledPayments from request query"
+// @Failure 	500	"Internal server error - Error while searching for scheduled payments"
+// @Router		/api/v1/scheduled-payments [get]
+// @Security	x-auth-xpub
+func (a *Action) search(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	searchParams, err := query.ParseSearchParams[filter.ScheduledPaymentFilter](c)
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotParseQueryParams, a.Services.Logger)
+		return
+	}
+
+	conditions, err := searchParams.Conditions.ToDbConditions()
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrInvalidConditions, a.Services.Logger)
+		return
+	}
+	metadata := mappings.MapToMetadata(searchParams.Metadata)
+	pageOptions := mappings.MapToDbQueryParams(&searchParams.Page)
+
+	scheduledPayments, err := a.Services.SpvWalletEngine.GetScheduledPaymentsByXpubID(
+		c.Request.Context(),
+		reqXPubID,
+		metadata,
+		conditions,
+		pageOptions,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	count, err := a.Services.SpvWalletEngine.GetScheduledPaymentsByXpubIDCount(
+		c.Request.Context(),
+		reqXPubID,
+		metadata,
+		conditions,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	response := response.PageModel[response.ScheduledPayment]{
+		Content: mappings.MapToScheduledPaymentContracts(scheduledPayments),
+		Page:    common.GetPageDescriptionFromSearchParams(pageOptions, count),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -21,7 +21,7 @@ import (
 func (a *Action) oldGet(c *gin.Context) {
 	makeConfig := sync.OnceValue(func() models.SharedConfig {
 		return models.SharedConfig{
-			PaymailDomains: a.AppConfig.Paymail.Domains,
+			PaymailDomains: a.paymailDomains(),
 			ExperimentalFeatures: map[string]bool{
 				"pike_contacts_enabled": a.AppConfig.ExperimentalFeatures.PikeContactsEnabled,
 				"pike_payment_enabled":  a.AppConfig.ExperimentalFeatures.PikePaymentEnabled,
@@ -44,7 +44,7 @@ func (a *Action) oldGet(c *gin.Context) {
 func (a *Action) get(c *gin.Context) {
 	makeConfig := sync.OnceValue(func() response.SharedConfig {
 		return response.SharedConfig{
-			PaymailDomains: a.AppConfig.Paymail.Domains,
+			PaymailDomains: a.paymailDomains(),
 			ExperimentalFeatures: map[string]bool{
 				"pikeContactsEnabled": a.AppConfig.ExperimentalFeatures.PikeContactsEnabled,
 				"pikePaymentEnabled":  a.AppConfig.ExperimentalFeatures.PikePaymentEnabled,
@@ -54,3 +54,13 @@ func (a *Action) get(c *gin.Context) {
 
 	c.JSON(http.StatusOK, makeConfig())
 }
+
+// paymailDomains will return the served paymail domains (from config and managed at runtime)
+func (a *Action) paymailDomains() []string {
+	if a.Services.SpvWalletEngine != nil {
+		if domains := a.Services.SpvWalletEngine.GetPaymailConfig().DomainNames(); domains != nil {
+			return domains
+		}
+	}
+	return a.AppConfig.Paymail.Domains
+}
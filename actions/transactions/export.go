@@ -0,0 +1,51 @@
+package transactions
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// export will export a transaction as BEEF, EF or raw hex
+// Export transaction godoc
+// @Summary		Export transaction
+// @Description	Export the transaction as BEEF (with the merkle proofs of its ancestors, optionally as Atomic BEEF), Extended Format or raw hex, which can be handed to third parties
+// @Tags		Transactions
+// @Produce		json
+// @Param		id path string true "id"
+// @Param		format query string false "Format of the exported transaction: beef (default), ef or raw"
+// @Param		atomic query bool false "Export the transaction as Atomic BEEF (only with the beef format)"
+// @Success		200 {object} response.TransactionExport "Exported transaction"
+// @Failure		400	"Bad request - Unsupported format"
+// @Failure		401	"Unauthorized - Transaction associated with another xpub"
+// @Failure		404	"Not found - Transaction not found"
+// @Failure 	500	"Internal Server Error - Error while exporting transaction (e.g. its ancestors are missing)"
+// @Router		/api/v1/transactions/{id}/export [get]
+// @Security	x-auth-xpub
+func (a *Action) export(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Param("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	export, err := a.Services.SpvWalletEngine.ExportTransaction(
+		c.Request.Context(),
+		reqXPubID,
+		id,
+		engine.TransactionExportFormat(c.DefaultQuery("format", string(engine.TransactionExportBeef))),
+		c.Query("atomic") == "true",
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToTransactionExportContract(export))
+}
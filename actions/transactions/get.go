@@ -1,10 +1,13 @@
 package transactions
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/bitcoin-sv/spv-wallet/engine"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
 	"github.com/bitcoin-sv/spv-wallet/server/auth"
 	"github.com/gin-gonic/gin"
 )
@@ -76,6 +79,22 @@ func (a *Action) getByID(c *gin.Context) {
 		return
 	}
 
-	contract := mappings.MapToTransactionContract(transaction)
+	contract := a.mapToTransactionContractWithP2PDelivery(c.Request.Context(), transaction)
 	c.JSON(http.StatusOK, contract)
 }
+
+// mapToTransactionContractWithP2PDelivery will map the transaction with the delivery state to its P2P receivers
+func (a *Action) mapToTransactionContractWithP2PDelivery(ctx context.Context, transaction *engine.Transaction) *response.Transaction {
+	contract := mappings.MapToTransactionContract(transaction)
+
+	delivery, err := a.Services.SpvWalletEngine.GetTransactionP2PDelivery(ctx, transaction.ID)
+	if err != nil {
+		a.Services.Logger.Warn().
+			Str("txID", transaction.ID).
+			Msgf("failed to get the P2P delivery state of the transaction: %s", err.Error())
+		return contract
+	}
+
+	contract.P2PDelivery = mappings.MapToP2PDeliveryContract(delivery)
+	return contract
+}
@@ -23,12 +23,20 @@ type UpdateTransactionRequest struct {
 type RecordTransaction struct {
 	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
 	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
-	// Hex of the transaction
+	// Hex of the transaction (raw transaction or BEEF version 1, 2 or Atomic BEEF)
 	Hex string `json:"hex" example:"0100000002..."`
-	// ReferenceID which is a ID of the draft transaction
+	// ReferenceID which is a ID of the draft transaction (empty for the incoming transaction given as BEEF or EF)
 	ReferenceID string `json:"referenceId" example:"b356f7fa00cd3f20cce6c21d704cd13e871d28d714a5ebd0532f5a0e0cde63f7"`
 }
 
+// RecordSignedTransaction is the model for recording a transaction signed offline
+type RecordSignedTransaction struct {
+	// Accepts a JSON object for embedding custom metadata, enabling arbitrary additional information to be associated with the resource
+	Metadata engine.Metadata `json:"metadata" swaggertype:"object,string" example:"key:value,key2:value2"`
+	// Hex of the signed transaction
+	Hex string `json:"hex" example:"0100000002..."`
+}
+
 // NewDraftTransaction is the model for creating a new transaction
 type NewDraftTransaction struct {
 	// Configuration of the transaction
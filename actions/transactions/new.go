@@ -113,3 +113,55 @@ func (a *Action) newTransactionDraft(c *gin.Context) {
 	contract := mappings.MapToDraftTransactionContract(transaction)
 	c.JSON(http.StatusCreated, contract)
 }
+
+// simulateTransactionDraft will preview a transaction draft without reserving or persisting anything
+// Simulate transaction draft godoc
+// @Summary		Simulate transaction draft
+// @Description	Simulate transaction draft - returns the fee, inputs and outputs of the draft without reserving utxos, persisting the draft or allocating change destinations
+// @Tags		Transactions
+// @Produce		json
+// @Param		NewDraftTransaction body NewDraftTransaction true "NewDraftTransaction model containing the transaction config and metadata"
+// @Success		200 {object} response.DraftTransaction "Preview of the transaction draft"
+// @Failure		400	"Bad request - Error while parsing NewDraftTransaction from request body or xpub not found"
+// @Failure 	500	"Internal Server Error - Error while simulating transaction"
+// @Router		/api/v1/transactions/drafts/simulate [post]
+// @Security	x-auth-xpub
+func (a *Action) simulateTransactionDraft(c *gin.Context) {
+	reqXPub := c.GetString(auth.ParamXPubKey)
+
+	xPub, err := a.Services.SpvWalletEngine.GetXpub(c.Request.Context(), reqXPub)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if xPub == nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindXpub, a.Services.Logger)
+		return
+	}
+
+	var requestBody NewDraftTransaction
+	if err = c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	opts := a.Services.SpvWalletEngine.DefaultModelOptions()
+	if requestBody.Metadata != nil {
+		opts = append(opts, engine.WithMetadatas(requestBody.Metadata))
+	}
+
+	txConfig := mappings.MapTransactionConfigEngineToModel(&requestBody.Config)
+
+	var transaction *engine.DraftTransaction
+	if transaction, err = a.Services.SpvWalletEngine.SimulateTransaction(
+		c.Request.Context(),
+		xPub.RawXpub(),
+		txConfig,
+		opts...,
+	); err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := mappings.MapToDraftTransactionContract(transaction)
+	c.JSON(http.StatusOK, contract)
+}
@@ -0,0 +1,91 @@
+package transactions
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// signingRequest will export a draft transaction as a request which can be signed offline
+// Signing request godoc
+// @Summary		Get signing request of a draft transaction
+// @Description	Export the draft transaction as a portable signing request (unsigned transaction in Extended Format with the derivation of every input), which can be signed offline with the signer package
+// @Tags		Transactions
+// @Produce		json
+// @Param		id path string true "id of the draft transaction"
+// @Success		200 {object} signer.Request "Signing request"
+// @Failure		400	"Bad request - Missing required field: id or draft transaction is not awaiting signatures"
+// @Failure		404	"Not found - Draft transaction not found"
+// @Failure 	500	"Internal server error - Error while exporting the signing request"
+// @Router		/api/v1/transactions/drafts/{id}/signing-request [get]
+// @Security	x-auth-xpub
+func (a *Action) signingRequest(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	request, err := a.Services.SpvWalletEngine.GetSigningRequest(c.Request.Context(), reqXPubID, id)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// recordSignedTransaction will validate a transaction signed offline against its draft and record it
+// Record signed transaction godoc
+// @Summary		Record transaction signed offline
+// @Description	Validate the transaction signed offline (it has to be the transaction of the draft with all the inputs correctly signed) and record it
+// @Tags		Transactions
+// @Produce		json
+// @Param		id path string true "id of the draft transaction"
+// @Param		RecordSignedTransaction body RecordSignedTransaction true "Signed transaction to be recorded"
+// @Success		201 {object} response.Transaction "Created transaction"
+// @Failure		400	"Bad request - Error while parsing RecordSignedTransaction from request body, transaction does not match the draft or has an invalid signature"
+// @Failure		404	"Not found - Draft transaction not found"
+// @Failure 	500	"Internal Server Error - Error while recording transaction"
+// @Router		/api/v1/transactions/drafts/{id}/signed [post]
+// @Security	x-auth-xpub
+func (a *Action) recordSignedTransaction(c *gin.Context) {
+	reqXPub := c.GetString(auth.ParamXPubKey)
+	id := c.Params.ByName("id")
+
+	if id == "" {
+		spverrors.ErrorResponse(c, spverrors.ErrMissingFieldID, a.Services.Logger)
+		return
+	}
+
+	var requestBody RecordSignedTransaction
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	opts := make([]engine.ModelOps, 0)
+	if requestBody.Metadata != nil {
+		opts = append(opts, engine.WithMetadatas(requestBody.Metadata))
+	}
+
+	transaction, err := a.Services.SpvWalletEngine.RecordSignedTransaction(
+		c.Request.Context(),
+		reqXPub,
+		id,
+		requestBody.Hex,
+		opts...,
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	contract := a.mapToTransactionContractWithP2PDelivery(c.Request.Context(), transaction)
+	c.JSON(http.StatusCreated, contract)
+}
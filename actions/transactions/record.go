@@ -108,6 +108,6 @@ func (a *Action) recordTransaction(c *gin.Context) {
 		return
 	}
 
-	contract := mappings.MapToTransactionContract(transaction)
+	contract := a.mapToTransactionContractWithP2PDelivery(c.Request.Context(), transaction)
 	c.JSON(http.StatusCreated, contract)
 }
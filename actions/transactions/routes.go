@@ -20,12 +20,16 @@ func NewHandler(appConfig *config.AppConfig, services *config.AppServices) *rout
 		BasicEndpoints: routes.BasicEndpointsFunc(func(router *gin.RouterGroup) {
 			basicTransactionGroup := router.Group("/transactions")
 			basicTransactionGroup.GET(":id", action.getByID)
+			basicTransactionGroup.GET(":id/export", action.export)
 			basicTransactionGroup.PATCH(":id", action.updateTransactionMetadata)
 			basicTransactionGroup.GET("", action.transactions)
 		}),
 		APIEndpoints: routes.APIEndpointsFunc(func(router *gin.RouterGroup) {
 			apiTransactionGroup := router.Group("/transactions")
 			apiTransactionGroup.POST("/drafts", action.newTransactionDraft)
+			apiTransactionGroup.POST("/drafts/simulate", action.simulateTransactionDraft)
+			apiTransactionGroup.GET("/drafts/:id/signing-request", action.signingRequest)
+			apiTransactionGroup.POST("/drafts/:id/signed", action.recordSignedTransaction)
 			apiTransactionGroup.POST("", action.recordTransaction)
 		}),
 		CallbackEndpoints: routes.CallbackEndpointsFunc(func(router *gin.RouterGroup) {
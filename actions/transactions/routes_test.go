@@ -24,9 +24,13 @@ func (ts *TestSuite) TestTransactionRegisterRoutes() {
 
 			// New routes
 			{"GET", "/api/" + config.APIVersion + "/transactions/:id"},
+			{"GET", "/api/" + config.APIVersion + "/transactions/:id/export"},
 			{"PATCH", "/api/" + config.APIVersion + "/transactions/:id"},
 			{"GET", "/api/" + config.APIVersion + "/transactions"},
 			{"POST", "/api/" + config.APIVersion + "/transactions/drafts"},
+			{"POST", "/api/" + config.APIVersion + "/transactions/drafts/simulate"},
+			{"GET", "/api/" + config.APIVersion + "/transactions/drafts/:id/signing-request"},
+			{"POST", "/api/" + config.APIVersion + "/transactions/drafts/:id/signed"},
 			{"POST", "/api/" + config.APIVersion + "/transactions"},
 		}
 
@@ -0,0 +1,39 @@
+package users
+
+// UpdatePaymailProfile is the model for updating the public profile of a paymail address
+type UpdatePaymailProfile struct {
+	// Name served by the public-profile capability
+	PublicName string `json:"publicName" example:"Test User"`
+	// Avatar URL served by the public-profile capability
+	Avatar string `json:"avatar" example:"https://spvwallet.com/avatar.png"`
+	// Hides the name and avatar from the public-profile capability
+	PublicProfileHidden bool `json:"publicProfileHidden" example:"false"`
+	// Disables the answers to the verify-pubkey capability requests
+	VerifyPubKeyDisabled bool `json:"verifyPubKeyDisabled" example:"false"`
+}
+
+// UpdatePaymailReceivePolicy is the model for updating the policy of a paymail address for receiving the P2P payments
+type UpdatePaymailReceivePolicy struct {
+	// Minimum amount of a payment (0 = no limit)
+	MinSatoshis uint64 `json:"minSatoshis" example:"1000"`
+	// Maximum amount of a payment (0 = no limit)
+	MaxSatoshis uint64 `json:"maxSatoshis" example:"0"`
+	// Maximum number of payment destination requests per day (0 = no limit)
+	DailyDestinationQuota int `json:"dailyDestinationQuota" example:"100"`
+	// Paymails or domains of the only accepted senders (empty = all senders)
+	AllowedSenders []string `json:"allowedSenders" example:"friend@example.com,trusted.com"`
+	// Paymails or domains of the rejected senders
+	DeniedSenders []string `json:"deniedSenders" example:"spammer@example.com"`
+	// Accept the payments only from the contacts
+	ContactsOnly bool `json:"contactsOnly" example:"false"`
+}
+
+// UpdatePaymailOutputTemplate is the model for updating the template of the PIKE outputs of a paymail address
+type UpdatePaymailOutputTemplate struct {
+	// Name of the template: p2pkh (single output, default), p2pkh_split or p2pkh_memo
+	Name string `json:"name" example:"p2pkh_split"`
+	// Amounts of the outputs of the p2pkh_split template (the rest of the payment is the last output)
+	Denominations []uint64 `json:"denominations" example:"10000,1000,100"`
+	// Memo appended after OP_RETURN to the output of the p2pkh_memo template
+	Memo string `json:"memo" example:"thank you"`
+}
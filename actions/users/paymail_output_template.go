@@ -0,0 +1,79 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// getPaymailOutputTemplate will fetch the PIKE output template of the paymail address of the current user
+// Get paymail output template godoc
+// @Summary		Get paymail output template
+// @Description	Get the template of the outputs created for the PIKE payments to the paymail address of the current user
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Success		200 {object} response.PaymailOutputTemplate "Paymail output template"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while fetching the paymail address"
+// @Router		/api/v1/users/current/paymails/{paymail}/output-template [get]
+// @Security	x-auth-xpub
+func (a *Action) getPaymailOutputTemplate(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	paymailAddress, err := a.Services.SpvWalletEngine.GetPaymailAddress(c.Request.Context(), c.Param("paymail"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if paymailAddress == nil || paymailAddress.XpubID != reqXPubID {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindPaymail, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailOutputTemplateContract(paymailAddress))
+}
+
+// updatePaymailOutputTemplate will update the PIKE output template of the paymail address of the current user
+// Update paymail output template godoc
+// @Summary		Update paymail output template
+// @Description	Update the template of the outputs created for the PIKE payments (single P2PKH output, P2PKH outputs split into denominations or P2PKH output with OP_RETURN memo) to the paymail address of the current user
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Param		UpdatePaymailOutputTemplate body UpdatePaymailOutputTemplate true "Output template of the paymail address"
+// @Success		200 {object} response.PaymailOutputTemplate "Updated paymail output template"
+// @Failure		400	"Bad request - Error while parsing UpdatePaymailOutputTemplate from request body or invalid template"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while updating the paymail address"
+// @Router		/api/v1/users/current/paymails/{paymail}/output-template [put]
+// @Security	x-auth-xpub
+func (a *Action) updatePaymailOutputTemplate(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	var requestBody UpdatePaymailOutputTemplate
+	if err := c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	paymailAddress, err := a.Services.SpvWalletEngine.UpdatePaymailOutputTemplate(
+		c.Request.Context(),
+		reqXPubID,
+		c.Param("paymail"),
+		&engine.PaymailOutputTemplate{
+			Name:          requestBody.Name,
+			Denominations: requestBody.Denominations,
+			Memo:          requestBody.Memo,
+		},
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailOutputTemplateContract(paymailAddress))
+}
@@ -0,0 +1,80 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// getPaymailProfile will fetch the public profile of the paymail address of the current user
+// Get paymail profile godoc
+// @Summary		Get paymail profile
+// @Description	Get the public profile (and its privacy settings) of the paymail address of the current user
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Success		200 {object} response.PaymailProfile "Paymail profile"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while fetching the paymail address"
+// @Router		/api/v1/users/current/paymails/{paymail}/profile [get]
+// @Security	x-auth-xpub
+func (a *Action) getPaymailProfile(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	paymailAddress, err := a.Services.SpvWalletEngine.GetPaymailAddress(c.Request.Context(), c.Param("paymail"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if paymailAddress == nil || paymailAddress.XpubID != reqXPubID {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindPaymail, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailProfileContract(paymailAddress))
+}
+
+// updatePaymailProfile will update the public profile of the paymail address of the current user
+// Update paymail profile godoc
+// @Summary		Update paymail profile
+// @Description	Update the public profile (and its privacy settings) of the paymail address of the current user
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Param		UpdatePaymailProfile body UpdatePaymailProfile true "Public name, avatar and privacy settings of the paymail address"
+// @Success		200 {object} response.PaymailProfile "Updated paymail profile"
+// @Failure		400	"Bad request - Error while parsing UpdatePaymailProfile from request body"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while updating the paymail address"
+// @Router		/api/v1/users/current/paymails/{paymail}/profile [put]
+// @Security	x-auth-xpub
+func (a *Action) updatePaymailProfile(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	var requestBody UpdatePaymailProfile
+	if err := c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	paymailAddress, err := a.Services.SpvWalletEngine.UpdatePaymailProfile(
+		c.Request.Context(),
+		reqXPubID,
+		c.Param("paymail"),
+		&engine.PaymailProfile{
+			PublicName:           requestBody.PublicName,
+			Avatar:               requestBody.Avatar,
+			PublicProfileHidden:  requestBody.PublicProfileHidden,
+			VerifyPubKeyDisabled: requestBody.VerifyPubKeyDisabled,
+		},
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailProfileContract(paymailAddress))
+}
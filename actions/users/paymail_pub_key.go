@@ -0,0 +1,49 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// rotatePaymailPubKey will rotate the PKI public key of the paymail address of the current user
+// Rotate paymail PKI key godoc
+// @Summary		Rotate paymail PKI key
+// @Description	Rotate the PKI public key of the paymail address of the current user (previous key stays verifiable for a grace period) and notify the confirmed contacts
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Success		200 {object} response.PaymailPubKey "Rotated PKI public key of the paymail address"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while rotating the PKI key"
+// @Router		/api/v1/users/current/paymails/{paymail}/key-rotation [post]
+// @Security	x-auth-xpub
+func (a *Action) rotatePaymailPubKey(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	paymailAddress, err := a.Services.SpvWalletEngine.GetPaymailAddress(c.Request.Context(), c.Param("paymail"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if paymailAddress == nil || paymailAddress.XpubID != reqXPubID {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindPaymail, a.Services.Logger)
+		return
+	}
+
+	paymailAddress, err = a.Services.SpvWalletEngine.RotatePaymailPubKey(c.Request.Context(), paymailAddress.String())
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	pubKey, err := paymailAddress.GetPubKey()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailPubKeyContract(paymailAddress, pubKey))
+}
@@ -0,0 +1,82 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/mappings"
+	"github.com/bitcoin-sv/spv-wallet/server/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// getPaymailReceivePolicy will fetch the receive policy of the paymail address of the current user
+// Get paymail receive policy godoc
+// @Summary		Get paymail receive policy
+// @Description	Get the policy for receiving the P2P payments of the paymail address of the current user
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Success		200 {object} response.PaymailReceivePolicy "Paymail receive policy"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while fetching the paymail address"
+// @Router		/api/v1/users/current/paymails/{paymail}/receive-policy [get]
+// @Security	x-auth-xpub
+func (a *Action) getPaymailReceivePolicy(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	paymailAddress, err := a.Services.SpvWalletEngine.GetPaymailAddress(c.Request.Context(), c.Param("paymail"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	} else if paymailAddress == nil || paymailAddress.XpubID != reqXPubID {
+		spverrors.ErrorResponse(c, spverrors.ErrCouldNotFindPaymail, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailReceivePolicyContract(paymailAddress))
+}
+
+// updatePaymailReceivePolicy will update the receive policy of the paymail address of the current user
+// Update paymail receive policy godoc
+// @Summary		Update paymail receive policy
+// @Description	Update the policy for receiving the P2P payments (amount limits, daily destination quota, sender allow/deny lists, contacts-only mode) of the paymail address of the current user
+// @Tags		Users
+// @Produce		json
+// @Param		paymail path string true "Paymail address of the current user"
+// @Param		UpdatePaymailReceivePolicy body UpdatePaymailReceivePolicy true "Receive policy of the paymail address"
+// @Success		200 {object} response.PaymailReceivePolicy "Updated paymail receive policy"
+// @Failure		400	"Bad request - Error while parsing UpdatePaymailReceivePolicy from request body or invalid policy"
+// @Failure		404	"Not found - Paymail address of the current user not found"
+// @Failure 	500	"Internal Server Error - Error while updating the paymail address"
+// @Router		/api/v1/users/current/paymails/{paymail}/receive-policy [put]
+// @Security	x-auth-xpub
+func (a *Action) updatePaymailReceivePolicy(c *gin.Context) {
+	reqXPubID := c.GetString(auth.ParamXPubHashKey)
+
+	var requestBody UpdatePaymailReceivePolicy
+	if err := c.Bind(&requestBody); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, a.Services.Logger)
+		return
+	}
+
+	paymailAddress, err := a.Services.SpvWalletEngine.UpdatePaymailReceivePolicy(
+		c.Request.Context(),
+		reqXPubID,
+		c.Param("paymail"),
+		&engine.PaymailReceivePolicy{
+			MinSatoshis:           requestBody.MinSatoshis,
+			MaxSatoshis:           requestBody.MaxSatoshis,
+			DailyDestinationQuota: requestBody.DailyDestinationQuota,
+			AllowedSenders:        requestBody.AllowedSenders,
+			DeniedSenders:         requestBody.DeniedSenders,
+			ContactsOnly:          requestBody.ContactsOnly,
+		},
+	)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, a.Services.Logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappings.MapToPaymailReceivePolicyContract(paymailAddress))
+}
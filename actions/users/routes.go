@@ -33,6 +33,13 @@ func NewHandler(appConfig *config.AppConfig, services *config.AppServices) route
 		xpubGroup := router.Group("/users/current")
 		xpubGroup.GET("", action.get)
 		xpubGroup.PATCH("", action.update)
+		xpubGroup.GET("/paymails/:paymail/profile", action.getPaymailProfile)
+		xpubGroup.PUT("/paymails/:paymail/profile", action.updatePaymailProfile)
+		xpubGroup.POST("/paymails/:paymail/key-rotation", action.rotatePaymailPubKey)
+		xpubGroup.GET("/paymails/:paymail/receive-policy", action.getPaymailReceivePolicy)
+		xpubGroup.PUT("/paymails/:paymail/receive-policy", action.updatePaymailReceivePolicy)
+		xpubGroup.GET("/paymails/:paymail/output-template", action.getPaymailOutputTemplate)
+		xpubGroup.PUT("/paymails/:paymail/output-template", action.updatePaymailOutputTemplate)
 	})
 
 	return apiEndpoints
@@ -20,6 +20,13 @@ func (ts *TestSuite) TestXPubRegisterRoutes() {
 
 			{"GET", "/api/" + config.APIVersion + "/users/current"},
 			{"PATCH", "/api/" + config.APIVersion + "/users/current"},
+			{"GET", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/profile"},
+			{"PUT", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/profile"},
+			{"POST", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/key-rotation"},
+			{"GET", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/receive-policy"},
+			{"PUT", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/receive-policy"},
+			{"GET", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/output-template"},
+			{"PUT", "/api/" + config.APIVersion + "/users/current/paymails/:paymail/output-template"},
 		}
 
 		ts.Router.Routes()
@@ -192,24 +192,140 @@ type LoggingConfig struct {
 
 // PaymailConfig is the configuration for the built-in Paymail server
 type PaymailConfig struct {
+	// AliasPolicy is the policy for the aliases (and public names) of the paymail addresses.
+	AliasPolicy *AliasPolicyConfig `json:"alias_policy" mapstructure:"alias_policy"`
 	// Beef is for Background Evaluation Extended Format (BEEF) config.
 	Beef *BeefConfig `json:"beef" mapstructure:"beef"`
+	// ContactInvitationRateLimit is the max number of contact invitations per hour from a domain to a user (0 = no limit).
+	ContactInvitationRateLimit int `json:"contact_invitation_rate_limit" mapstructure:"contact_invitation_rate_limit"`
+	// ContactVerification is the config of the contact verification via the shared time-based codes.
+	ContactVerification *ContactVerificationConfig `json:"contact_verification" mapstructure:"contact_verification"`
 	// DefaultFromPaymail IE: from@domain.com.
 	DefaultFromPaymail string `json:"default_from_paymail" mapstructure:"default_from_paymail"`
 	// Domains is a list of allowed domains.
 	Domains []string `json:"domains" mapstructure:"domains"`
 	// DomainValidationEnabled should be turned off if hosted domain is not paymail related.
 	DomainValidationEnabled bool `json:"domain_validation_enabled" mapstructure:"domain_validation_enabled"`
+	// KeyProvider is the config of the xPrivs used to sign with the PKI keys of the paymails (optional).
+	KeyProvider *PaymailKeyProviderConfig `json:"key_provider" mapstructure:"key_provider"`
 	// SenderValidationEnabled should be turned on for extra security.
 	SenderValidationEnabled bool `json:"sender_validation_enabled" mapstructure:"sender_validation_enabled"`
+	// Outbound is the config of the requests to the counterpart paymail providers.
+	Outbound *OutboundPaymailConfig `json:"outbound" mapstructure:"outbound"`
+	// OutputSplit is the config for splitting the received payments into multiple outputs.
+	OutputSplit *OutputSplitConfig `json:"output_split" mapstructure:"output_split"`
+	// P2PDestinations is the config for the expiration of the P2P payment destinations.
+	P2PDestinations *P2PDestinationsConfig `json:"p2p_destinations" mapstructure:"p2p_destinations"`
+	// PubKeyGracePeriod is the time for which the rotated PKI keys stay verifiable.
+	PubKeyGracePeriod time.Duration `json:"pub_key_grace_period" mapstructure:"pub_key_grace_period"`
+}
+
+// PaymailKeyProviderConfig is the configuration of the xPrivs used to sign with the PKI keys of the paymails
+//
+// The signed basic address resolution and the contact verification codes need the PKI private keys,
+// with this config SPV Wallet holds the xPrivs of the listed xPubs.
+type PaymailKeyProviderConfig struct {
+	// XPrivsFile is the path of the file with the xPrivs (one per line), e.g. a mounted secret.
+	XPrivsFile string `json:"xprivs_file" mapstructure:"xprivs_file"`
+}
+
+func (k *PaymailKeyProviderConfig) enabled() bool {
+	return k != nil && k.XPrivsFile != ""
+}
+
+// ContactVerificationConfig is the configuration of the contact verification via the shared time-based codes
+type ContactVerificationConfig struct {
+	// Period is the validity of a code (the previous code is still accepted).
+	Period time.Duration `json:"period" mapstructure:"period"`
+	// Digits is the number of the digits of a code (6 or 8).
+	Digits uint `json:"digits" mapstructure:"digits"`
+	// MaxAttempts is the number of the failed codes after which the verification of the contact is locked.
+	MaxAttempts uint32 `json:"max_attempts" mapstructure:"max_attempts"`
+	// Lockout is the time for which the verification of the contact stays locked.
+	Lockout time.Duration `json:"lockout" mapstructure:"lockout"`
+}
+
+// OutputSplitConfig is the configuration for splitting the P2P payment destinations into multiple outputs
+type OutputSplitConfig struct {
+	// Strategy is the splitting strategy: none, denominations, random or max_size.
+	Strategy string `json:"strategy" mapstructure:"strategy"`
+	// Denominations are the output values (in satoshis) for the denominations strategy.
+	Denominations []uint64 `json:"denominations" mapstructure:"denominations"`
+	// MaxOutputSatoshis is the maximum value of an output for the max_size strategy.
+	MaxOutputSatoshis uint64 `json:"max_output_satoshis" mapstructure:"max_output_satoshis"`
+	// MaxOutputs is the limit of outputs (the number of outputs for the random strategy).
+	MaxOutputs int `json:"max_outputs" mapstructure:"max_outputs"`
+}
+
+// OutboundPaymailConfig is the configuration of the requests to the counterpart paymail providers
+type OutboundPaymailConfig struct {
+	// CapabilitiesTTL is the time for which the capabilities of the domains are cached (0 = not cached).
+	CapabilitiesTTL time.Duration `json:"capabilities_ttl" mapstructure:"capabilities_ttl"`
+	// PKITTL is the time for which the PKI of the paymails are cached (0 = not cached).
+	PKITTL time.Duration `json:"pki_ttl" mapstructure:"pki_ttl"`
+	// NegativeTTL is the time for which the failed lookups are cached (0 = not cached).
+	NegativeTTL time.Duration `json:"negative_ttl" mapstructure:"negative_ttl"`
+	// Timeout is the timeout of a request (0 = default timeout of the paymail client).
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// Retries is the number of retries of the failed lookups (capabilities, PKI, profiles).
+	Retries int `json:"retries" mapstructure:"retries"`
+	// BlockedDomains are the domains (and their subdomains) which are never contacted.
+	BlockedDomains []string `json:"blocked_domains" mapstructure:"blocked_domains"`
+	// Domains are the timeouts and retries of the domains (replacing the defaults for the domain).
+	Domains map[string]*OutboundDomainConfig `json:"domains" mapstructure:"domains"`
+	// NotificationAttempts is the number of the attempts to submit a P2P transaction to the receiver.
+	NotificationAttempts int `json:"notification_attempts" mapstructure:"notification_attempts"`
+	// NotificationBackoff is the delay before the second submission of a P2P transaction, doubled by each next attempt.
+	NotificationBackoff time.Duration `json:"notification_backoff" mapstructure:"notification_backoff"`
+}
+
+// OutboundDomainConfig is the configuration of the requests to a counterpart paymail domain
+type OutboundDomainConfig struct {
+	// Timeout is the timeout of a request (0 = timeout of the outbound config).
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// Retries is the number of retries of the failed lookups.
+	Retries int `json:"retries" mapstructure:"retries"`
+}
+
+// P2PDestinationsConfig is the configuration for the expiration of the P2P payment destinations
+type P2PDestinationsConfig struct {
+	// Expiry is the time for which the destinations wait for the transaction (unused ones are removed afterwards).
+	Expiry time.Duration `json:"expiry" mapstructure:"expiry"`
+	// AcceptExpired accepts (and flags) the late transactions instead of rejecting them.
+	AcceptExpired bool `json:"accept_expired" mapstructure:"accept_expired"`
+	// ExpiredRetention is the time for which the unused destinations are kept after the expiry if AcceptExpired is on.
+	ExpiredRetention time.Duration `json:"expired_retention" mapstructure:"expired_retention"`
+}
+
+// AliasPolicyConfig is the configuration of the policy for the aliases of the paymail addresses
+type AliasPolicyConfig struct {
+	// AllowPatterns are the regex patterns, the alias must match at least one of them (if any).
+	AllowPatterns []string `json:"allow_patterns" mapstructure:"allow_patterns"`
+	// DenyPatterns are the regex patterns the alias must not match (e.g. profanity).
+	DenyPatterns []string `json:"deny_patterns" mapstructure:"deny_patterns"`
+	// Reserved is the list of reserved aliases, which can't be used as alias or public name.
+	Reserved []string `json:"reserved" mapstructure:"reserved"`
+	// MinLength is the min length of the alias (0 = no limit).
+	MinLength int `json:"min_length" mapstructure:"min_length"`
+	// MaxLength is the max length of the alias (0 = no limit).
+	MaxLength int `json:"max_length" mapstructure:"max_length"`
+	// ConfusableCheck rejects unicode lookalikes and the aliases resembling the reserved ones.
+	ConfusableCheck bool `json:"confusable_check" mapstructure:"confusable_check"`
+	// MaxPaymailsPerXpub is the max number of paymails of an xPub (0 = no limit).
+	MaxPaymailsPerXpub int `json:"max_paymails_per_xpub" mapstructure:"max_paymails_per_xpub"`
+	// Domains are the policies of the domains (the unset fields are taken from this policy).
+	Domains map[string]*AliasPolicyConfig `json:"domains" mapstructure:"domains"`
 }
 
 // BeefConfig consists of components required to use beef, e.g. Block Headers Service for merkle roots validation
 type BeefConfig struct {
 	// BlockHeaderServiceHeaderValidationURL is the URL for merkle roots validation in Block Headers Service.
+	// The tip of the longest chain (for the lock time of the transactions) is requested from the same service.
 	BlockHeaderServiceHeaderValidationURL string `json:"block_header_service_url" mapstructure:"block_header_service_url"`
 	// BlockHeaderServiceAuthToken is the authentication token for validating merkle roots in Block Headers Service.
 	BlockHeaderServiceAuthToken string `json:"block_header_service_auth_token" mapstructure:"block_header_service_auth_token"`
+	// MaxAncestryDepth is the max generations of the unmined ancestors of the incoming transaction (0 = default depth).
+	MaxAncestryDepth int `json:"max_ancestry_depth" mapstructure:"max_ancestry_depth"`
 	// UseBeef is a flag for enabling BEEF transactions format.
 	UseBeef bool `json:"use_beef" mapstructure:"use_beef"`
 }
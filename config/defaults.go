@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/bitcoin-sv/spv-wallet/engine"
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
 	"github.com/google/uuid"
 )
@@ -137,15 +138,43 @@ func getNotificationDefaults() *NotificationsConfig {
 
 func getPaymailDefaults() *PaymailConfig {
 	return &PaymailConfig{
+		AliasPolicy: &AliasPolicyConfig{
+			Reserved:        engine.DefaultReservedAliases,
+			ConfusableCheck: true,
+		},
 		Beef: &BeefConfig{
 			UseBeef:                               true,
 			BlockHeaderServiceHeaderValidationURL: "http://localhost:8080/api/v1/chain/merkleroot/verify",
 			BlockHeaderServiceAuthToken:           "mQZQ6WmxURxWz5ch", // #nosec G101
 		},
+		ContactInvitationRateLimit: 20,
+		ContactVerification: &ContactVerificationConfig{
+			Period:      5 * time.Minute,
+			Digits:      6,
+			MaxAttempts: 5,
+			Lockout:     15 * time.Minute,
+		},
 		DefaultFromPaymail:      "from@domain.com",
 		Domains:                 []string{"localhost"},
 		DomainValidationEnabled: true,
 		SenderValidationEnabled: false,
+		Outbound: &OutboundPaymailConfig{
+			CapabilitiesTTL: 60 * time.Minute,
+			PKITTL:          10 * time.Minute,
+			NegativeTTL:     1 * time.Minute,
+
+			NotificationAttempts: 10,
+			NotificationBackoff:  1 * time.Minute,
+		},
+		OutputSplit: &OutputSplitConfig{
+			Strategy: string(engine.OutputSplitStrategyNone),
+		},
+		P2PDestinations: &P2PDestinationsConfig{
+			Expiry:           24 * time.Hour,
+			AcceptExpired:    false,
+			ExpiredRetention: 7 * 24 * time.Hour,
+		},
+		PubKeyGracePeriod: 72 * time.Hour,
 	}
 }
 
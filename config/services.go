@@ -174,7 +174,9 @@ func (s *AppServices) loadSPVWallet(ctx context.Context, appConfig *AppConfig, t
 		return err
 	}
 
-	options = loadPaymail(appConfig, options)
+	if options, err = loadPaymail(appConfig, options); err != nil {
+		return err
+	}
 
 	options = loadTaskManager(appConfig, options)
 
@@ -267,7 +269,7 @@ func loadCluster(appConfig *AppConfig, options []engine.ClientOps) ([]engine.Cli
 	return options, nil
 }
 
-func loadPaymail(appConfig *AppConfig, options []engine.ClientOps) []engine.ClientOps {
+func loadPaymail(appConfig *AppConfig, options []engine.ClientOps) ([]engine.ClientOps, error) {
 	pm := appConfig.Paymail
 	options = append(options, engine.WithPaymailSupport(
 		pm.Domains,
@@ -275,9 +277,41 @@ func loadPaymail(appConfig *AppConfig, options []engine.ClientOps) []engine.Clie
 		pm.DomainValidationEnabled,
 		pm.SenderValidationEnabled,
 	))
+	if pm.Outbound != nil {
+		options = append(options, engine.WithPaymailClientPolicy(pm.Outbound.toEngineOptions()))
+	}
+	if pm.OutputSplit != nil {
+		options = append(options, engine.WithPaymailOutputSplit(pm.OutputSplit.toEngineOptions()))
+	}
+	if pm.P2PDestinations != nil {
+		options = append(options, engine.WithPaymailP2PDestinationExpiry(pm.P2PDestinations.Expiry, pm.P2PDestinations.AcceptExpired),
+			engine.WithPaymailP2PExpiredRetention(pm.P2PDestinations.ExpiredRetention))
+	}
+	if pm.AliasPolicy != nil {
+		options = append(options, engine.WithPaymailAliasPolicy(pm.AliasPolicy.toEngineOptions()))
+	}
+	if pm.ContactInvitationRateLimit > 0 {
+		options = append(options, engine.WithPaymailContactInvitationRateLimit(pm.ContactInvitationRateLimit))
+	}
+	if pm.ContactVerification != nil {
+		options = append(options, engine.WithPaymailContactVerification(pm.ContactVerification.toEngineOptions()))
+	}
+	if pm.KeyProvider.enabled() {
+		keyProvider, err := engine.NewFileKeyProvider(pm.KeyProvider.XPrivsFile)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, engine.WithPaymailKeyProvider(keyProvider))
+	}
+	if pm.PubKeyGracePeriod > 0 {
+		options = append(options, engine.WithPaymailPubKeyGracePeriod(pm.PubKeyGracePeriod))
+	}
 	if pm.Beef.enabled() {
 		options = append(options, engine.WithPaymailBeefSupport(pm.Beef.BlockHeaderServiceHeaderValidationURL, pm.Beef.BlockHeaderServiceAuthToken))
 	}
+	if pm.Beef.enabled() && pm.Beef.MaxAncestryDepth > 0 {
+		options = append(options, engine.WithSPVPolicy(&engine.SPVPolicy{MaxAncestryDepth: pm.Beef.MaxAncestryDepth}))
+	}
 	if appConfig.ExperimentalFeatures.PikeContactsEnabled {
 		options = append(options, engine.WithPaymailPikeContactSupport())
 	}
@@ -285,7 +319,57 @@ func loadPaymail(appConfig *AppConfig, options []engine.ClientOps) []engine.Clie
 		options = append(options, engine.WithPaymailPikePaymentSupport())
 	}
 
-	return options
+	return options, nil
+}
+
+// toEngineOptions will convert the config to the engine output split options
+func (o *OutputSplitConfig) toEngineOptions() *engine.OutputSplitOptions {
+	if o == nil {
+		return nil
+	}
+	return &engine.OutputSplitOptions{
+		Strategy:          engine.OutputSplitStrategy(o.Strategy),
+		Denominations:     o.Denominations,
+		MaxOutputSatoshis: o.MaxOutputSatoshis,
+		MaxOutputs:        o.MaxOutputs,
+	}
+}
+
+// toEngineOptions will convert the config to the engine contact verification options
+func (v *ContactVerificationConfig) toEngineOptions() *engine.ContactVerificationOptions {
+	if v == nil {
+		return nil
+	}
+	return &engine.ContactVerificationOptions{
+		Period:      v.Period,
+		Digits:      v.Digits,
+		MaxAttempts: v.MaxAttempts,
+		Lockout:     v.Lockout,
+	}
+}
+
+// toEngineOptions will convert the config to the engine alias policy
+func (a *AliasPolicyConfig) toEngineOptions() *engine.AliasPolicy {
+	if a == nil {
+		return nil
+	}
+
+	policy := &engine.AliasPolicy{
+		AllowPatterns:      a.AllowPatterns,
+		DenyPatterns:       a.DenyPatterns,
+		Reserved:           a.Reserved,
+		MinLength:          a.MinLength,
+		MaxLength:          a.MaxLength,
+		ConfusableCheck:    a.ConfusableCheck,
+		MaxPaymailsPerXpub: a.MaxPaymailsPerXpub,
+	}
+	if len(a.Domains) > 0 {
+		policy.Domains = make(map[string]*engine.AliasPolicy, len(a.Domains))
+		for domain, domainPolicy := range a.Domains {
+			policy.Domains[domain] = domainPolicy.toEngineOptions()
+		}
+	}
+	return policy
 }
 
 // loadDatastore will load the correct datastore based on the engine
@@ -438,3 +522,35 @@ func isValidURL(rawURL string) bool {
 
 	return !isLocal(hostname)
 }
+
+// toEngineOptions will convert the config to the engine paymail client policy
+func (o *OutboundPaymailConfig) toEngineOptions() *engine.PaymailClientPolicy {
+	if o == nil {
+		return nil
+	}
+
+	policy := &engine.PaymailClientPolicy{
+		CapabilitiesTTL: o.CapabilitiesTTL,
+		PKITTL:          o.PKITTL,
+		NegativeTTL:     o.NegativeTTL,
+		Timeout:         o.Timeout,
+		Retries:         o.Retries,
+		BlockedDomains:  o.BlockedDomains,
+
+		NotificationAttempts: o.NotificationAttempts,
+		NotificationBackoff:  o.NotificationBackoff,
+	}
+	if len(o.Domains) > 0 {
+		policy.Domains = make(map[string]*engine.PaymailDomainPolicy, len(o.Domains))
+		for domain, domainConfig := range o.Domains {
+			if domainConfig == nil {
+				continue
+			}
+			policy.Domains[domain] = &engine.PaymailDomainPolicy{
+				Timeout: domainConfig.Timeout,
+				Retries: domainConfig.Retries,
+			}
+		}
+	}
+	return policy
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/mrz1836/go-sanitize"
 	"github.com/mrz1836/go-validate"
@@ -30,6 +31,36 @@ func (p *PaymailConfig) Validate() error {
 		}
 	}
 
+	if err = p.OutputSplit.toEngineOptions().Validate(); err != nil {
+		return spverrors.Wrapf(err, "invalid output_split")
+	}
+
+	if err = p.Outbound.toEngineOptions().Validate(); err != nil {
+		return spverrors.Wrapf(err, "invalid outbound")
+	}
+
+	if err = p.AliasPolicy.toEngineOptions().Validate(); err != nil {
+		return spverrors.Wrapf(err, "invalid alias_policy")
+	}
+
+	if p.ContactInvitationRateLimit < 0 {
+		return spverrors.Newf("contact_invitation_rate_limit cannot be negative")
+	}
+
+	if err = p.ContactVerification.toEngineOptions().Validate(); err != nil {
+		return spverrors.Wrapf(err, "invalid contact_verification")
+	}
+
+	if p.KeyProvider.enabled() {
+		if _, err = engine.NewFileKeyProvider(p.KeyProvider.XPrivsFile); err != nil {
+			return spverrors.Wrapf(err, "invalid key_provider")
+		}
+	}
+
+	if p.P2PDestinations != nil && (p.P2PDestinations.Expiry < 0 || p.P2PDestinations.ExpiredRetention < 0) {
+		return spverrors.Newf("p2p_destinations expiry and expired_retention cannot be negative")
+	}
+
 	// Todo: validate the default_from_paymail and default_note values
 
 	return nil
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -81,4 +83,145 @@ func TestPaymailConfig_Validate(t *testing.T) {
 		err := p.Validate()
 		require.NoError(t, err)
 	})
+
+	t.Run("invalid output split", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			OutputSplit: &OutputSplitConfig{
+				Strategy: "max_size",
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("valid output split", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			OutputSplit: &OutputSplitConfig{
+				Strategy:      "denominations",
+				Denominations: []uint64{1000, 100},
+			},
+		}
+		err := p.Validate()
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid outbound blocked domain", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			Outbound: &OutboundPaymailConfig{
+				BlockedDomains: []string{"not a domain"},
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("negative outbound notification attempts", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			Outbound: &OutboundPaymailConfig{
+				NotificationAttempts: -1,
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("negative contact invitation rate limit", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains:                    []string{"test.com"},
+			ContactInvitationRateLimit: -1,
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("invalid contact verification digits", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			ContactVerification: &ContactVerificationConfig{
+				Period:      time.Minute,
+				Digits:      4,
+				MaxAttempts: 5,
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("negative p2p destinations expiry", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			P2PDestinations: &P2PDestinationsConfig{
+				Expiry: -time.Hour,
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("negative p2p destinations expired retention", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			P2PDestinations: &P2PDestinationsConfig{
+				Expiry:           time.Hour,
+				AcceptExpired:    true,
+				ExpiredRetention: -time.Hour,
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("invalid alias policy pattern", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			AliasPolicy: &AliasPolicyConfig{
+				DenyPatterns: []string{"[a-z"},
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("invalid alias policy of domain", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			AliasPolicy: &AliasPolicyConfig{
+				Domains: map[string]*AliasPolicyConfig{
+					"test.com": {MinLength: 10, MaxLength: 5},
+				},
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
+
+	t.Run("valid alias policy", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			AliasPolicy: &AliasPolicyConfig{
+				AllowPatterns:   []string{"^[a-z0-9._-]+$"},
+				Reserved:        []string{"admin"},
+				MinLength:       3,
+				MaxLength:       32,
+				ConfusableCheck: true,
+			},
+		}
+		err := p.Validate()
+		require.NoError(t, err)
+	})
+
+	t.Run("missing key provider file", func(t *testing.T) {
+		p := PaymailConfig{
+			Domains: []string{"test.com"},
+			KeyProvider: &PaymailKeyProviderConfig{
+				XPrivsFile: filepath.Join(t.TempDir(), "missing"),
+			},
+		}
+		err := p.Validate()
+		require.Error(t, err)
+	})
 }
@@ -25,7 +25,7 @@ func (c *Client) UpsertContact(ctx context.Context, ctcFName, ctcPaymail, reques
 		return nil, spverrors.Wrapf(err, "requested contact paymail is invalid")
 	}
 
-	contact, err := c.upsertContact(ctx, pmSrvnt, requesterXPubID, ctcFName, contactPm, opts...)
+	contact, err := c.upsertContact(ctx, pmSrvnt, requesterXPubID, reqPm.String(), ctcFName, contactPm, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +48,10 @@ func (c *Client) UpsertContact(ctx context.Context, ctcFName, ctcPaymail, reques
 }
 
 // AddContactRequest adds a new contact invitation if contact not exits or just checking if contact has still the same pub key if contact exists.
-func (c *Client) AddContactRequest(ctx context.Context, fullName, paymailAdress, requesterXPubID string, opts ...ModelOps) (*Contact, error) {
+//
+// The invitations of the blocked paymails (and domains) are rejected, the invitations of the muted ones are dropped silently (nil contact).
+// The invitation of the existing contact is the notice about the rotated public key, so the cached PKI of the contact is refreshed.
+func (c *Client) AddContactRequest(ctx context.Context, fullName, paymailAdress, requesterXPubID, requesterPaymail string, opts ...ModelOps) (*Contact, error) {
 	pmSrvnt := &PaymailServant{
 		cs: c.Cachestore(),
 		pc: c.PaymailClient(),
@@ -60,10 +63,10 @@ func (c *Client) AddContactRequest(ctx context.Context, fullName, paymailAdress,
 		return nil, spverrors.ErrRequestedContactInvalid
 	}
 
-	contactPki, err := pmSrvnt.GetPkiForPaymail(ctx, contactPm)
+	muted, err := c.checkContactRequester(ctx, requesterXPubID, contactPm.Address)
 	if err != nil {
-		c.Logger().Error().Msgf("getting PKI for %s failed. Reason: %v", paymailAdress, err)
-		return nil, spverrors.ErrGettingPKIFailed
+		c.logContactWarining(requesterXPubID, contactPm.Address, fmt.Sprintf("contact invitation rejected: %s", err.Error()))
+		return nil, err
 	}
 
 	// check if exists already
@@ -72,9 +75,32 @@ func (c *Client) AddContactRequest(ctx context.Context, fullName, paymailAdress,
 		return nil, err
 	}
 
+	if contact == nil || contact.Status == ContactAwaitAccept {
+		if muted {
+			return nil, c.rejectMutedInvitation(ctx, contact)
+		}
+		if contact == nil {
+			if err = c.useContactInvitationQuota(ctx, requesterXPubID, contactPm.Address); err != nil {
+				c.logContactWarining(requesterXPubID, contactPm.Address, fmt.Sprintf("contact invitation rejected: %s", err.Error()))
+				return nil, err
+			}
+		}
+	}
+
+	if contact != nil {
+		pmSrvnt.evictPki(ctx, contactPm)
+	}
+
+	contactPki, err := pmSrvnt.GetPkiForPaymail(ctx, contactPm)
+	if err != nil {
+		c.Logger().Error().Msgf("getting PKI for %s failed. Reason: %v", paymailAdress, err)
+		return nil, spverrors.ErrGettingPKIFailed
+	}
+
 	var save bool
 	if contact != nil {
 		save = contact.UpdatePubKey(contactPki.PubKey)
+		save = contact.setOwnerPaymail(requesterPaymail) || save
 	} else {
 		contact = newContact(
 			fullName,
@@ -84,6 +110,7 @@ func (c *Client) AddContactRequest(ctx context.Context, fullName, paymailAdress,
 			ContactAwaitAccept,
 			c.DefaultModelOptions(append(opts, New())...)...,
 		)
+		contact.setOwnerPaymail(requesterPaymail)
 
 		save = true
 	}
@@ -98,6 +125,23 @@ func (c *Client) AddContactRequest(ctx context.Context, fullName, paymailAdress,
 	return contact, nil
 }
 
+// rejectMutedInvitation will reject the pending invitation of the muted requester (if any)
+func (c *Client) rejectMutedInvitation(ctx context.Context, contact *Contact) error {
+	if contact == nil {
+		return nil
+	}
+
+	if err := contact.Reject(); err != nil {
+		c.logContactWarining(contact.OwnerXpubID, contact.Paymail, err.Error())
+		return nil
+	}
+	if err := contact.Save(ctx); err != nil {
+		c.logContactError(contact.OwnerXpubID, contact.Paymail, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
+		return spverrors.ErrSaveContact
+	}
+	return nil
+}
+
 // GetContacts returns the contact filtered by conditions.
 func (c *Client) GetContacts(ctx context.Context, metadata *Metadata, conditions map[string]interface{}, queryParams *datastore.QueryParams) ([]*Contact, error) {
 	contacts, err := getContacts(ctx, metadata, conditions, queryParams, c.DefaultModelOptions()...)
@@ -295,8 +339,8 @@ func (c *Client) RejectContact(ctx context.Context, xPubID, paymail string) erro
 	return nil
 }
 
-// ConfirmContact marks the contact as confirmed.
-func (c *Client) ConfirmContact(ctx context.Context, xPubID, paymail string) error {
+// UnconfirmContact marks the contact as unconfirmed.
+func (c *Client) UnconfirmContact(ctx context.Context, xPubID, paymail string) error {
 	contact, err := getContact(ctx, paymail, xPubID, c.DefaultModelOptions()...)
 	if err != nil {
 		c.logContactError(xPubID, paymail, fmt.Sprintf("unexpected error while getting contact: %s", err.Error()))
@@ -306,7 +350,7 @@ func (c *Client) ConfirmContact(ctx context.Context, xPubID, paymail string) err
 		return spverrors.ErrContactNotFound
 	}
 
-	if err = contact.Confirm(); err != nil {
+	if err = contact.Unconfirm(); err != nil {
 		c.logContactWarining(xPubID, paymail, err.Error())
 		return spverrors.ErrContactIncorrectStatus
 	}
@@ -319,28 +363,26 @@ func (c *Client) ConfirmContact(ctx context.Context, xPubID, paymail string) err
 	return nil
 }
 
-// UnconfirmContact marks the contact as unconfirmed.
-func (c *Client) UnconfirmContact(ctx context.Context, xPubID, paymail string) error {
-	contact, err := getContact(ctx, paymail, xPubID, c.DefaultModelOptions()...)
+// GetContactProfile returns the public profile of the contact (cached)
+func (c *Client) GetContactProfile(ctx context.Context, xPubID, paymailAddress string) (*paymail.PublicProfilePayload, error) {
+	contact, err := getContact(ctx, paymailAddress, xPubID, c.DefaultModelOptions()...)
 	if err != nil {
-		c.logContactError(xPubID, paymail, fmt.Sprintf("unexpected error while getting contact: %s", err.Error()))
-		return err
+		return nil, err
 	}
 	if contact == nil {
-		return spverrors.ErrContactNotFound
+		return nil, spverrors.ErrContactNotFound
 	}
 
-	if err = contact.Unconfirm(); err != nil {
-		c.logContactWarining(xPubID, paymail, err.Error())
-		return spverrors.ErrContactIncorrectStatus
+	pmSrvnt := &PaymailServant{
+		cs: c.Cachestore(),
+		pc: c.PaymailClient(),
 	}
-
-	if err = contact.Save(ctx); err != nil {
-		c.logContactError(xPubID, paymail, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
-		return spverrors.ErrSaveContact
+	contactPm, err := pmSrvnt.GetSanitizedPaymail(contact.Paymail)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "contact paymail is invalid")
 	}
 
-	return nil
+	return pmSrvnt.GetPublicProfile(ctx, contactPm)
 }
 
 func (c *Client) getPaymail(ctx context.Context, xpubID, paymailAddr string) (*PaymailAddress, error) {
@@ -372,18 +414,23 @@ func (c *Client) getPaymail(ctx context.Context, xpubID, paymailAddr string) (*P
 	return paymails[0], nil
 }
 
-func (c *Client) upsertContact(ctx context.Context, pmSrvnt *PaymailServant, reqXPubID, ctcFName string, ctcPaymail *paymail.SanitisedPaymail, opts ...ModelOps) (*Contact, error) {
-	contactPki, err := pmSrvnt.GetPkiForPaymail(ctx, ctcPaymail)
-	if err != nil {
-		return nil, spverrors.ErrGettingPKIFailed
-	}
-
+func (c *Client) upsertContact(ctx context.Context, pmSrvnt *PaymailServant, reqXPubID, reqPaymail, ctcFName string, ctcPaymail *paymail.SanitisedPaymail, opts ...ModelOps) (*Contact, error) {
 	// check if exists already
 	contact, err := getContact(ctx, ctcPaymail.Address, reqXPubID, c.DefaultModelOptions()...)
 	if err != nil {
 		return nil, err
 	}
 
+	// the update of the existing contact refreshes its pub key, so the cached PKI is not used
+	if contact != nil {
+		pmSrvnt.evictPki(ctx, ctcPaymail)
+	}
+
+	contactPki, err := pmSrvnt.GetPkiForPaymail(ctx, ctcPaymail)
+	if err != nil {
+		return nil, spverrors.ErrGettingPKIFailed
+	}
+
 	if contact == nil { // insert
 		contact = newContact(
 			ctcFName,
@@ -399,6 +446,7 @@ func (c *Client) upsertContact(ctx context.Context, pmSrvnt *PaymailServant, req
 
 		contact.UpdatePubKey(contactPki.PubKey)
 	}
+	contact.setOwnerPaymail(reqPaymail)
 
 	if err = contact.Save(ctx); err != nil {
 		return nil, spverrors.ErrSaveContact
@@ -270,35 +270,6 @@ func TestRejectContactErrorPath(t *testing.T) {
 	}
 }
 
-func TestConfirmContactHappyPath(t *testing.T) {
-	ctx, client, deferMe := initContactTestCase(t)
-	defer deferMe()
-
-	t.Run("confirm contact", func(t *testing.T) {
-		// given
-		contact := newContact(
-			fullName,
-			paymailGeneric,
-			pubKey,
-			xPubGeneric,
-			ContactNotConfirmed,
-		)
-		contact.enrich(ModelContact, append(client.DefaultModelOptions(), New())...)
-		err := contact.Save(ctx)
-		require.NoError(t, err)
-
-		// when
-		err = client.ConfirmContact(ctx, contact.OwnerXpubID, contact.Paymail)
-
-		// then
-		require.NoError(t, err)
-
-		contact1, err := getContact(ctx, contact.Paymail, contact.OwnerXpubID, client.DefaultModelOptions()...)
-		require.NoError(t, err)
-		require.Equal(t, ContactConfirmed, contact1.Status)
-	})
-}
-
 func TestConfirmContactErrorPath(t *testing.T) {
 	tcs := []struct {
 		name          string
@@ -357,7 +328,7 @@ func TestConfirmContactErrorPath(t *testing.T) {
 			}
 
 			// when
-			err := client.ConfirmContact(ctx, ownerXpubID, paymail)
+			err := client.ConfirmContact(ctx, ownerXpubID, paymail, "", "123456")
 
 			// then
 			require.ErrorIs(t, err, tc.expectedError)
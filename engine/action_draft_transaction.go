@@ -4,6 +4,9 @@ import (
 	"context"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/signer"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
 )
 
 // GetDraftTransactionByID will get a draft transaction from the Datastore
@@ -59,3 +62,44 @@ func (c *Client) GetDraftTransactionsCount(ctx context.Context, metadataConditio
 
 	return count, nil
 }
+
+// GetSigningRequest will export the draft transaction of the xPub as a request which can be signed offline
+func (c *Client) GetSigningRequest(ctx context.Context, xPubID, draftID string) (*signer.Request, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_signing_request")
+
+	draftTransaction, err := getDraftTransactionID(
+		ctx, xPubID, draftID, c.DefaultModelOptions()...,
+	)
+	if err != nil {
+		return nil, err
+	} else if draftTransaction == nil {
+		return nil, spverrors.ErrCouldNotFindDraftTx
+	}
+
+	return draftTransaction.SigningRequest()
+}
+
+// RecordSignedTransaction will validate the transaction signed offline against its draft and record it
+//
+// xPubKey is the raw xPub key of the draft owner
+// draftID is the id of the draft the signing request was exported from
+// txHex is the signed transaction hex (returned by the signer)
+// opts are model options and can include "metadata"
+func (c *Client) RecordSignedTransaction(ctx context.Context, xPubKey, draftID, txHex string,
+	opts ...ModelOps,
+) (*Transaction, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "record_signed_transaction")
+
+	request, err := c.GetSigningRequest(ctx, utils.Hash(xPubKey), draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = signer.Verify(request, txHex); err != nil {
+		return nil, err //nolint:wrapcheck // Custom errors returned from signer
+	}
+
+	return c.RecordTransaction(ctx, xPubKey, txHex, draftID, opts...)
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+)
+
+// NewMultisigAccount will create a new m-of-n multisig account composed of the given (registered) xPubs
+//
+// rawXpubKey is the raw xPub key of the creator, who has to be one of the members
+// rawXpubKeys are the raw xPub keys of all the members
+// threshold is the number of signatures required to spend the funds of the account
+func (c *Client) NewMultisigAccount(ctx context.Context, rawXpubKey string, rawXpubKeys []string,
+	threshold uint16, opts ...ModelOps,
+) (*MultisigAccount, error) {
+	ctx = c.GetOrStartTxn(ctx, "new_multisig_account")
+
+	account, err := newMultisigAccount(
+		rawXpubKeys, threshold,
+		c.DefaultModelOptions(append(opts, New())...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.IsMember(utils.Hash(rawXpubKey)) {
+		return nil, spverrors.ErrMultisigNotAMember
+	}
+
+	// All the members have to be registered
+	for _, xPubID := range account.XpubIDs {
+		if _, err = getXpubWithCache(
+			ctx, c, "", xPubID, c.DefaultModelOptions()...,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	var existing *MultisigAccount
+	if existing, err = getMultisigAccount(
+		ctx, account.ID, c.DefaultModelOptions()...,
+	); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, spverrors.ErrMultisigAccountAlreadyExists
+	}
+
+	if err = account.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetMultisigAccount will get the multisig account by its ID, the xPub has to be one of the members
+func (c *Client) GetMultisigAccount(ctx context.Context, xPubID, id string) (*MultisigAccount, error) {
+	ctx = c.GetOrStartTxn(ctx, "get_multisig_account")
+
+	account, err := getMultisigAccount(ctx, id, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, spverrors.ErrCouldNotFindMultisigAccount
+	}
+	if !account.IsMember(xPubID) {
+		return nil, spverrors.ErrMultisigNotAMember
+	}
+
+	return account, nil
+}
+
+// NewMultisigDestination will create a new (external) destination of the multisig account
+func (c *Client) NewMultisigDestination(ctx context.Context, xPubID, accountID string,
+	opts ...ModelOps,
+) (*Destination, error) {
+	ctx = c.GetOrStartTxn(ctx, "new_multisig_destination")
+
+	account, err := c.GetMultisigAccount(ctx, xPubID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var destination *Destination
+	if destination, err = account.getNewDestination(
+		ctx, utils.ChainExternal,
+		append(opts, c.DefaultModelOptions()...)...,
+	); err != nil {
+		return nil, err
+	}
+
+	if err = destination.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return destination, nil
+}
+
+// NewMultisigTransaction will create a new draft transaction spending the funds of the multisig account
+//
+// The draft has to be signed by at least "threshold" members (see AddMultisigSignatures)
+func (c *Client) NewMultisigTransaction(ctx context.Context, xPubID, accountID string,
+	config *TransactionConfig, opts ...ModelOps,
+) (*DraftTransaction, error) {
+	ctx = c.GetOrStartTxn(ctx, "new_multisig_transaction")
+
+	account, err := c.GetMultisigAccount(ctx, xPubID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the lock and set the release for after the function completes
+	unlock, err := getWaitWriteLockForXpub(ctx, c.Cachestore(), account.ID)
+	defer unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	draftTransaction, err := newMultisigDraftTransaction(
+		account, config,
+		c.DefaultModelOptions(append(opts, New())...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = draftTransaction.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return draftTransaction, nil
+}
+
+// AddMultisigSignatures will verify and add the partial signatures of the member to the multisig draft
+func (c *Client) AddMultisigSignatures(ctx context.Context, xPubID, draftID string,
+	signatures []*MultisigSignature,
+) (*DraftTransaction, *MultisigAccount, error) {
+	ctx = c.GetOrStartTxn(ctx, "add_multisig_signatures")
+
+	draft, account, err := c.getMultisigDraft(ctx, xPubID, draftID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Signatures of the members are collected concurrently
+	unlock, err := getWaitWriteLockForXpub(ctx, c.Cachestore(), account.ID)
+	defer unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = draft.addMultisigSignatures(account, xPubID, signatures); err != nil {
+		return nil, nil, err
+	}
+
+	if err = draft.Save(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return draft, account, nil
+}
+
+// GetMultisigDraftTransaction will get the multisig draft and its account, the xPub has to be one of the members
+func (c *Client) GetMultisigDraftTransaction(ctx context.Context, xPubID, draftID string,
+) (*DraftTransaction, *MultisigAccount, error) {
+	ctx = c.GetOrStartTxn(ctx, "get_multisig_draft_transaction")
+
+	return c.getMultisigDraft(ctx, xPubID, draftID)
+}
+
+// getMultisigDraft will get the draft (by ID only) and the multisig account it belongs to
+func (c *Client) getMultisigDraft(ctx context.Context, xPubID, draftID string,
+) (*DraftTransaction, *MultisigAccount, error) {
+	draft, err := getDraftTransactionID(ctx, "", draftID, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, nil, err
+	} else if draft == nil {
+		return nil, nil, spverrors.ErrCouldNotFindDraftTx
+	}
+
+	var account *MultisigAccount
+	if account, err = getMultisigAccount(
+		ctx, draft.XpubID, c.DefaultModelOptions()...,
+	); err != nil {
+		return nil, nil, err
+	} else if account == nil {
+		return nil, nil, spverrors.ErrMultisigDraftNotMultisig
+	}
+
+	if !account.IsMember(xPubID) {
+		return nil, nil, spverrors.ErrMultisigNotAMember
+	}
+
+	return draft, account, nil
+}
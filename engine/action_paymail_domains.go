@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// NewPaymailDomain will add a new paymail domain served by the paymail server (without restart)
+func (c *Client) NewPaymailDomain(ctx context.Context, domain string, settings *PaymailDomainSettings,
+	opts ...ModelOps,
+) (*PaymailDomain, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "new_paymail_domain")
+
+	// Check if the paymail domain already exists (deleted domains are restored)
+	paymailDomain, err := getPaymailDomain(ctx, domain, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	} else if paymailDomain != nil && !paymailDomain.DeletedAt.Valid {
+		return nil, spverrors.ErrPaymailDomainAlreadyExists
+	}
+
+	if paymailDomain == nil {
+		paymailDomain = newPaymailDomain(domain, settings, c.DefaultModelOptions(append(opts, New())...)...)
+	} else {
+		paymailDomain.SetOptions(opts...)
+		paymailDomain.DeletedAt.Valid = false
+		paymailDomain.setSettings(settings)
+	}
+
+	// Save the model
+	if err = paymailDomain.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = c.notifyPaymailDomainsChanged(ctx); err != nil {
+		return nil, err
+	}
+
+	return paymailDomain, nil
+}
+
+// GetPaymailDomain will get a paymail domain model
+func (c *Client) GetPaymailDomain(ctx context.Context, domain string, opts ...ModelOps) (*PaymailDomain, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_paymail_domain")
+
+	// Get the paymail domain
+	paymailDomain, err := getPaymailDomain(ctx, domain, append(opts, c.DefaultModelOptions()...)...)
+	if err != nil {
+		return nil, err
+	} else if paymailDomain == nil || paymailDomain.DeletedAt.Valid {
+		return nil, spverrors.ErrPaymailDomainNotFound
+	}
+
+	return paymailDomain, nil
+}
+
+// GetPaymailDomains will get all the paymail domains from the Datastore
+func (c *Client) GetPaymailDomains(ctx context.Context, metadataConditions *Metadata,
+	conditions map[string]interface{}, queryParams *datastore.QueryParams, opts ...ModelOps,
+) ([]*PaymailDomain, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_paymail_domains")
+
+	// Get the paymail domains
+	paymailDomains, err := getPaymailDomains(
+		ctx, metadataConditions, conditions, queryParams,
+		c.DefaultModelOptions(opts...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return paymailDomains, nil
+}
+
+// GetPaymailDomainsCount will get a count of all the paymail domains from the Datastore
+func (c *Client) GetPaymailDomainsCount(ctx context.Context, metadataConditions *Metadata,
+	conditions map[string]interface{}, opts ...ModelOps,
+) (int64, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_paymail_domains_count")
+
+	// Get the paymail domains count
+	count, err := getPaymailDomainsCount(
+		ctx, metadataConditions, conditions,
+		c.DefaultModelOptions(opts...)...,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdatePaymailDomain will update the settings of the paymail domain
+func (c *Client) UpdatePaymailDomain(ctx context.Context, domain string, settings *PaymailDomainSettings,
+	opts ...ModelOps,
+) (*PaymailDomain, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "update_paymail_domain")
+
+	// Get the paymail domain
+	paymailDomain, err := c.GetPaymailDomain(ctx, domain, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	paymailDomain.setSettings(settings)
+
+	// Save the model
+	if err = paymailDomain.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = c.notifyPaymailDomainsChanged(ctx); err != nil {
+		return nil, err
+	}
+
+	return paymailDomain, nil
+}
+
+// DeletePaymailDomain will delete the paymail domain (it is not served anymore)
+func (c *Client) DeletePaymailDomain(ctx context.Context, domain string, opts ...ModelOps) error {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "delete_paymail_domain")
+
+	// Get the paymail domain
+	paymailDomain, err := c.GetPaymailDomain(ctx, domain, opts...)
+	if err != nil {
+		return err
+	}
+
+	// We will do a soft delete, the domain can be added again later
+	paymailDomain.DeletedAt.Valid = true
+	paymailDomain.DeletedAt.Time = time.Now()
+
+	if err = paymailDomain.Save(ctx); err != nil {
+		return err
+	}
+
+	return c.notifyPaymailDomainsChanged(ctx)
+}
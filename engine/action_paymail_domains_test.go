@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	paymailErrors "github.com/bitcoin-sv/go-paymail/errors"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_PaymailDomains will test the methods managing the paymail domains
+func TestClient_PaymailDomains(t *testing.T) {
+	const managedDomain = "kingslanding.com"
+
+	newClient := func(t *testing.T) (context.Context, ClientInterface, func()) {
+		return CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(),
+			WithPaymailSupport([]string{testDomain}, defaultSenderPaymail, false, false),
+		)
+	}
+
+	t.Run("new paymail domain - served without restart", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		// when
+		paymailDomain, err := client.NewPaymailDomain(ctx, "KingsLanding.com", &PaymailDomainSettings{
+			BeefEnabled:        true,
+			DefaultFromPaymail: "hand@kingslanding.com",
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, managedDomain, paymailDomain.ID)
+		require.ElementsMatch(t, []string{testDomain, managedDomain}, client.GetPaymailConfig().DomainNames())
+
+		settings := client.GetPaymailConfig().domainSettings(managedDomain)
+		require.NotNil(t, settings)
+		require.True(t, settings.BeefEnabled)
+		require.False(t, settings.PikeEnabled)
+		require.Equal(t, "hand@kingslanding.com", settings.DefaultFromPaymail)
+		require.Nil(t, client.GetPaymailConfig().domainSettings(testDomain))
+	})
+
+	t.Run("new paymail domain - already exists", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		_, err := client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+		require.NoError(t, err)
+
+		// when
+		_, err = client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+
+		// then
+		require.ErrorIs(t, err, spverrors.ErrPaymailDomainAlreadyExists)
+	})
+
+	t.Run("new paymail domain - invalid", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		// when
+		_, err := client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{DefaultFromPaymail: "invalid"})
+
+		// then
+		require.ErrorIs(t, err, spverrors.ErrPaymailAddressIsInvalid)
+		require.NotContains(t, client.GetPaymailConfig().DomainNames(), managedDomain)
+	})
+
+	t.Run("update paymail domain - settings reloaded", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		_, err := client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+		require.NoError(t, err)
+
+		// when
+		paymailDomain, err := client.UpdatePaymailDomain(ctx, managedDomain, &PaymailDomainSettings{
+			SenderValidationEnabled: true,
+			PikeEnabled:             true,
+		})
+
+		// then
+		require.NoError(t, err)
+		require.True(t, paymailDomain.SenderValidationEnabled)
+
+		settings := client.GetPaymailConfig().domainSettings(managedDomain)
+		require.True(t, settings.SenderValidationEnabled)
+		require.True(t, settings.PikeEnabled)
+	})
+
+	t.Run("update paymail domain - not found", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		// when
+		_, err := client.UpdatePaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+
+		// then
+		require.ErrorIs(t, err, spverrors.ErrPaymailDomainNotFound)
+	})
+
+	t.Run("delete paymail domain - not served anymore and can be restored", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		_, err := client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+		require.NoError(t, err)
+
+		// when
+		err = client.DeletePaymailDomain(ctx, managedDomain)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []string{testDomain}, client.GetPaymailConfig().DomainNames())
+
+		_, err = client.GetPaymailDomain(ctx, managedDomain)
+		require.ErrorIs(t, err, spverrors.ErrPaymailDomainNotFound)
+
+		// when
+		_, err = client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{PikeEnabled: true})
+
+		// then
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{testDomain, managedDomain}, client.GetPaymailConfig().DomainNames())
+
+		count, err := client.GetPaymailDomainsCount(ctx, nil, map[string]interface{}{deletedAtField: nil})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+
+	t.Run("domain settings - pike and beef disabled", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := newClient(t)
+		defer cleanup()
+
+		_, err := client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+		require.NoError(t, err)
+
+		provider := &PaymailDefaultServiceProvider{client: client}
+
+		// then
+		require.ErrorIs(t, checkPikeEnabled(client, managedDomain), spverrors.ErrPaymailDomainPikeDisabled)
+		require.NoError(t, checkPikeEnabled(client, testDomain))
+
+		err = provider.checkDomainSettings(
+			&paymail.P2PTransaction{Beef: "beef"},
+			&server.RequestMetadata{Domain: managedDomain},
+		)
+		require.ErrorIs(t, err, spverrors.ErrPaymailDomainBeefDisabled)
+
+		err = provider.checkDomainSettings(
+			&paymail.P2PTransaction{Beef: "beef"},
+			&server.RequestMetadata{Domain: testDomain},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("served domains - validated without changing the go-paymail configuration", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(),
+			WithPaymailSupport([]string{testDomain}, defaultSenderPaymail, true, false),
+		)
+		defer cleanup()
+
+		_, err := client.NewPaymailDomain(ctx, managedDomain, &PaymailDomainSettings{})
+		require.NoError(t, err)
+
+		config := client.GetPaymailConfig()
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		config.RegisterRoutes(engine)
+
+		request := func(address string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			path := fmt.Sprintf("/%s/%s/id/%s", config.APIVersion, config.ServiceName, address)
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			return w
+		}
+
+		// when
+		managed := request("jon@" + managedDomain)
+		unknown := request("jon@unknown.com")
+
+		// then
+		require.NotContains(t, managed.Body.String(), paymailErrors.ErrDomainUnknown.Code)
+		require.Equal(t, http.StatusBadRequest, unknown.Code)
+		require.Contains(t, unknown.Body.String(), paymailErrors.ErrDomainUnknown.Code)
+
+		require.True(t, config.IsAllowedDomain(managedDomain))
+		require.False(t, config.IsAllowedDomain("unknown.com"))
+		require.Len(t, config.PaymailDomains, 1)
+	})
+}
@@ -111,6 +111,11 @@ func (c *Client) NewPaymailAddress(ctx context.Context, xPubKey, address, public
 		return nil, err
 	}
 
+	// Check the alias (and the public name) against the alias policy
+	if err = c.checkPaymailAliasPolicy(ctx, xPub.ID, address, publicName); err != nil {
+		return nil, err
+	}
+
 	externalXpubDerivation, err := xPub.GetNextExternalDerivationNum(ctx)
 	if err != nil {
 		return nil, err
@@ -210,6 +215,9 @@ func (c *Client) UpdatePaymailAddress(ctx context.Context, address, publicName,
 
 	// Update the public name
 	if paymailAddress.PublicName != publicName {
+		if err = c.checkPaymailPublicNamePolicy(paymailAddress, publicName); err != nil {
+			return nil, err
+		}
 		paymailAddress.PublicName = publicName
 	}
 
@@ -225,3 +233,112 @@ func (c *Client) UpdatePaymailAddress(ctx context.Context, address, publicName,
 
 	return paymailAddress, nil
 }
+
+// UpdatePaymailProfile will update the public profile (and its privacy settings) of the paymail address of the xPub
+func (c *Client) UpdatePaymailProfile(ctx context.Context, xPubID, address string, profile *PaymailProfile,
+	opts ...ModelOps,
+) (*PaymailAddress, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "update_paymail_profile")
+
+	// Get the paymail address (only the owner can update it)
+	paymailAddress, err := getPaymailAddress(ctx, address, append(opts, c.DefaultModelOptions()...)...)
+	if err != nil {
+		return nil, err
+	} else if paymailAddress == nil || paymailAddress.XpubID != xPubID {
+		return nil, spverrors.ErrCouldNotFindPaymail
+	}
+
+	if err = c.checkPaymailPublicNamePolicy(paymailAddress, profile.PublicName); err != nil {
+		return nil, err
+	}
+	paymailAddress.setProfile(profile)
+
+	// Save the model
+	if err = paymailAddress.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return paymailAddress, nil
+}
+
+// UpdatePaymailReceivePolicy will update the policy for receiving the P2P payments of the paymail address of the xPub
+func (c *Client) UpdatePaymailReceivePolicy(ctx context.Context, xPubID, address string, policy *PaymailReceivePolicy,
+	opts ...ModelOps,
+) (*PaymailAddress, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "update_paymail_receive_policy")
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get the paymail address (only the owner can update it)
+	paymailAddress, err := getPaymailAddress(ctx, address, append(opts, c.DefaultModelOptions()...)...)
+	if err != nil {
+		return nil, err
+	} else if paymailAddress == nil || paymailAddress.XpubID != xPubID {
+		return nil, spverrors.ErrCouldNotFindPaymail
+	}
+
+	paymailAddress.setReceivePolicy(policy)
+
+	// Save the model
+	if err = paymailAddress.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return paymailAddress, nil
+}
+
+// UpdatePaymailOutputTemplate will update the template of the PIKE outputs of the paymail address of the xPub,
+// nil (or empty) template is the single P2PKH output
+func (c *Client) UpdatePaymailOutputTemplate(ctx context.Context, xPubID, address string,
+	outputTemplate *PaymailOutputTemplate, opts ...ModelOps,
+) (*PaymailAddress, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "update_paymail_output_template")
+
+	if err := outputTemplate.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get the paymail address (only the owner can update it)
+	paymailAddress, err := getPaymailAddress(ctx, address, append(opts, c.DefaultModelOptions()...)...)
+	if err != nil {
+		return nil, err
+	} else if paymailAddress == nil || paymailAddress.XpubID != xPubID {
+		return nil, spverrors.ErrCouldNotFindPaymail
+	}
+
+	paymailAddress.setOutputTemplate(outputTemplate)
+
+	// Save the model
+	if err = paymailAddress.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return paymailAddress, nil
+}
+
+// RotatePaymailPubKey will rotate the PKI public key of the paymail address and notify its confirmed contacts
+func (c *Client) RotatePaymailPubKey(ctx context.Context, address string, opts ...ModelOps) (*PaymailAddress, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "rotate_paymail_pub_key")
+
+	// Get the paymail address
+	paymailAddress, err := getPaymailAddress(ctx, address, append(opts, c.DefaultModelOptions()...)...)
+	if err != nil {
+		return nil, err
+	} else if paymailAddress == nil {
+		return nil, spverrors.ErrCouldNotFindPaymail
+	}
+
+	if err = paymailAddress.RotatePubKey(ctx); err != nil {
+		return nil, err
+	}
+
+	c.notifyContactsAboutPubKeyRotation(ctx, paymailAddress)
+
+	return paymailAddress, nil
+}
@@ -212,3 +212,69 @@ func (ts *EmbeddedDBTestSuite) TestClient_UpdatePaymailAddress() {
 		})
 	}
 }
+
+// TestClient_UpdatePaymailProfile will test the method UpdatePaymailProfile()
+func (ts *EmbeddedDBTestSuite) TestClient_UpdatePaymailProfile() {
+	for _, testCase := range dbTestCases {
+		ts.T().Run(testCase.name+" - valid", func(t *testing.T) {
+			tc := ts.genericDBClient(t, testCase.database, false)
+			defer tc.Close(tc.ctx)
+
+			opts := tc.client.DefaultModelOptions()
+
+			// Create xPub (required to add a paymail address)
+			xPub, err := tc.client.NewXpub(tc.ctx, testXPub, opts...)
+			require.NotNil(t, xPub)
+			require.NoError(t, err)
+
+			_, err = tc.client.NewPaymailAddress(tc.ctx, testXPub, testPaymail, testPublicName, testAvatar, opts...)
+			require.NoError(t, err)
+
+			paymailAddress, err := tc.client.UpdatePaymailProfile(tc.ctx, testXPubID, testPaymail, &PaymailProfile{
+				PublicName:          testPublicName + "2",
+				Avatar:              testAvatar2,
+				PublicProfileHidden: true,
+			}, opts...)
+			require.NoError(t, err)
+			assert.Equal(t, testPublicName+"2", paymailAddress.PublicName)
+
+			var p2 *PaymailAddress
+			p2, err = getPaymailAddress(tc.ctx, testPaymail, tc.client.DefaultModelOptions()...)
+			require.NoError(t, err)
+			require.NotNil(t, p2)
+			assert.Equal(t, &PaymailProfile{
+				PublicName:          testPublicName + "2",
+				Avatar:              testAvatar2,
+				PublicProfileHidden: true,
+			}, p2.Profile())
+
+			// Unset privacy settings are removed
+			paymailAddress, err = tc.client.UpdatePaymailProfile(tc.ctx, testXPubID, testPaymail, &PaymailProfile{
+				PublicName:           testPublicName,
+				VerifyPubKeyDisabled: true,
+			}, opts...)
+			require.NoError(t, err)
+			assert.False(t, paymailAddress.Profile().PublicProfileHidden)
+			assert.True(t, paymailAddress.Profile().VerifyPubKeyDisabled)
+		})
+
+		ts.T().Run(testCase.name+" - paymail of another xpub", func(t *testing.T) {
+			tc := ts.genericDBClient(t, testCase.database, false)
+			defer tc.Close(tc.ctx)
+
+			opts := tc.client.DefaultModelOptions()
+
+			xPub, err := tc.client.NewXpub(tc.ctx, testXPub, opts...)
+			require.NotNil(t, xPub)
+			require.NoError(t, err)
+
+			_, err = tc.client.NewPaymailAddress(tc.ctx, testXPub, testPaymail, testPublicName, testAvatar, opts...)
+			require.NoError(t, err)
+
+			_, err = tc.client.UpdatePaymailProfile(tc.ctx, "other-xpub-id", testPaymail, &PaymailProfile{
+				PublicName: testPublicName + "2",
+			}, opts...)
+			require.ErrorIs(t, err, spverrors.ErrCouldNotFindPaymail)
+		})
+	}
+}
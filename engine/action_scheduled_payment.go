@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// NewScheduledPayment will create a new scheduled payment for the given xPub
+//
+// rawXpubKey is the raw xPub key, it's needed to create the drafts (change destinations)
+// config is the ScheduledPaymentConfig
+// opts are model options and can include "metadata", which is copied to every created draft
+func (c *Client) NewScheduledPayment(ctx context.Context, rawXpubKey string, config *ScheduledPaymentConfig,
+	opts ...ModelOps,
+) (*ScheduledPayment, error) {
+	ctx = c.GetOrStartTxn(ctx, "new_scheduled_payment")
+
+	scheduledPayment, err := newScheduledPayment(
+		rawXpubKey, config,
+		c.DefaultModelOptions(append(opts, New())...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = scheduledPayment.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return scheduledPayment, nil
+}
+
+// GetScheduledPayment will get the scheduled payment of the xPub by its ID
+func (c *Client) GetScheduledPayment(ctx context.Context, xPubID, id string) (*ScheduledPayment, error) {
+	ctx = c.GetOrStartTxn(ctx, "get_scheduled_payment")
+
+	scheduledPayment, err := getScheduledPayment(ctx, xPubID, id, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if scheduledPayment == nil {
+		return nil, spverrors.ErrCouldNotFindScheduledPayment
+	}
+
+	return scheduledPayment, nil
+}
+
+// GetScheduledPayments will get all the scheduled payments from the Datastore
+func (c *Client) GetScheduledPayments(ctx context.Context, metadataConditions *Metadata,
+	conditions map[string]interface{}, queryParams *datastore.QueryParams, opts ...ModelOps,
+) ([]*ScheduledPayment, error) {
+	ctx = c.GetOrStartTxn(ctx, "get_scheduled_payments")
+
+	return getScheduledPayments(
+		ctx, metadataConditions, conditions, queryParams,
+		c.DefaultModelOptions(opts...)...,
+	)
+}
+
+// GetScheduledPaymentsByXpubID will get all the scheduled payments of the given xPub
+func (c *Client) GetScheduledPaymentsByXpubID(ctx context.Context, xPubID string, metadataConditions *Metadata,
+	conditions map[string]interface{}, queryParams *datastore.QueryParams,
+) ([]*ScheduledPayment, error) {
+	ctx = c.GetOrStartTxn(ctx, "get_scheduled_payments_by_xpub_id")
+
+	dbConditions := map[string]interface{}{}
+	for key, value := range conditions {
+		dbConditions[key] = value
+	}
+	dbConditions[xPubIDField] = xPubID
+
+	return getScheduledPayments(
+		ctx, metadataConditions, dbConditions, queryParams,
+		c.DefaultModelOptions()...,
+	)
+}
+
+// GetScheduledPaymentsByXpubIDCount will get a count of all the scheduled payments of the given xPub
+func (c *Client) GetScheduledPaymentsByXpubIDCount(ctx context.Context, xPubID string, metadataConditions *Metadata,
+	conditions map[string]interface{},
+) (int64, error) {
+	ctx = c.GetOrStartTxn(ctx, "count_scheduled_payments_by_xpub_id")
+
+	dbConditions := map[string]interface{}{}
+	for key, value := range conditions {
+		dbConditions[key] = value
+	}
+	dbConditions[xPubIDField] = xPubID
+
+	return getScheduledPaymentsCount(
+		ctx, metadataConditions, dbConditions,
+		c.DefaultModelOptions()...,
+	)
+}
+
+// CancelScheduledPayment will stop the scheduled payment, drafts created before are not affected
+func (c *Client) CancelScheduledPayment(ctx context.Context, xPubID, id string) (*ScheduledPayment, error) {
+	ctx = c.GetOrStartTxn(ctx, "cancel_scheduled_payment")
+
+	scheduledPayment, err := c.GetScheduledPayment(ctx, xPubID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = scheduledPayment.Cancel(); err != nil {
+		c.Logger().Warn().
+			Str("scheduledPaymentID", id).
+			Msg(err.Error())
+		return nil, spverrors.ErrScheduledPaymentIncorrectStatus
+	}
+
+	if err = scheduledPayment.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	return scheduledPayment, nil
+}
+
+// processScheduledPayments will create the drafts for all scheduled payments which are due
+func processScheduledPayments(ctx context.Context, maxPayments int, opts ...ModelOps) error {
+	queryParams := &datastore.QueryParams{
+		Page:          1,
+		PageSize:      maxPayments,
+		OrderByField:  nextRunAtField,
+		SortDirection: datastore.SortAsc,
+	}
+
+	scheduledPayments, err := getDueScheduledPayments(ctx, queryParams, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, scheduledPayment := range scheduledPayments {
+		if err = scheduledPayment.run(ctx); err != nil {
+			scheduledPayment.Client().Logger().Error().
+				Str("scheduledPaymentID", scheduledPayment.ID).
+				Msgf("error running scheduled payment: %s", err.Error())
+		}
+	}
+
+	return nil
+}
@@ -17,15 +17,21 @@ import (
 
 // RecordTransaction will parse the outgoing transaction and save it into the Datastore
 // xPubKey is the raw public xPub
-// txHex is the raw transaction hex
+// txHex is the raw transaction hex (or BEEF of the transaction, version 1, 2 or Atomic BEEF)
 // draftID is the unique draft id from a previously started New() transaction (draft_transaction.ID)
 // opts are model options and can include "metadata"
+//
+// Without draftID, the transaction given as BEEF or EF is verified and recorded as the incoming transaction of the xPub
 func (c *Client) RecordTransaction(ctx context.Context, xPubKey, txHex, draftID string, opts ...ModelOps) (*Transaction, error) {
 	ctx = c.GetOrStartTxn(ctx, "record_transaction")
 
-	tx, err := bt.NewTxFromString(txHex)
+	if draftID == "" && isVerifiableTxHex(txHex) {
+		return recordVerifiedTransaction(ctx, c, utils.Hash(xPubKey), txHex, opts...)
+	}
+
+	tx, err := parseRecordedTx(txHex)
 	if err != nil {
-		return nil, spverrors.ErrInvalidHex
+		return nil, err
 	}
 
 	rts, err := getOutgoingTxRecordStrategy(xPubKey, tx, draftID)
@@ -36,17 +42,45 @@ func (c *Client) RecordTransaction(ctx context.Context, xPubKey, txHex, draftID
 	return recordTransaction(ctx, c, rts, opts...)
 }
 
+// parseRecordedTx will parse the raw transaction hex or the processed transaction of BEEF (version 1, 2 or Atomic BEEF)
+func parseRecordedTx(txHex string) (*bt.Tx, error) {
+	if !isBeefHex(txHex) {
+		tx, err := bt.NewTxFromString(txHex)
+		if err != nil {
+			return nil, spverrors.ErrInvalidHex
+		}
+		return tx, nil
+	}
+
+	beef, err := decodeBeefHex(txHex)
+	if err != nil {
+		return nil, spverrors.Wrapf(spverrors.ErrInvalidBeef, "%s", err.Error())
+	}
+	tx, err := beef.subjectTx()
+	if err != nil {
+		return nil, spverrors.Wrapf(spverrors.ErrInvalidBeef, "%s", err.Error())
+	}
+	return tx, nil
+}
+
 // RecordRawTransaction will parse the transaction and save it into the Datastore directly, without any checks or broadcast but SPV Wallet Engine will ask network for information if transaction was mined
 // The transaction is treat as external incoming transaction - transaction without a draft
 // Only use this function when you know what you are doing!
 //
-// txHex is the raw transaction hex
+// The transaction given as BEEF or EF is verified (scripts and merkle roots) and broadcast instead,
+// the ancestors from BEEF are saved with their BUMPs
+//
+// txHex is the raw transaction hex (or BEEF or EF of the transaction)
 // opts are model options and can include "metadata"
 func (c *Client) RecordRawTransaction(ctx context.Context, txHex string,
 	opts ...ModelOps,
 ) (*Transaction, error) {
 	ctx = c.GetOrStartTxn(ctx, "record_raw_transaction")
 
+	if isVerifiableTxHex(txHex) {
+		return recordVerifiedTransaction(ctx, c, "", txHex, opts...)
+	}
+
 	return saveRawTransaction(ctx, c, true, txHex, opts...)
 }
 
@@ -90,6 +124,22 @@ func (c *Client) NewTransaction(ctx context.Context, rawXpubKey string, config *
 	return draftTransaction, nil
 }
 
+// SimulateTransaction will preview the draft transaction NewTransaction would create (fee, inputs and outputs)
+//
+// Nothing is reserved, persisted or allocated: the utxos are not reserved, the change destinations are not
+// saved (the derivation counters are not incremented) and only the capabilities of the paymail providers are looked up
+func (c *Client) SimulateTransaction(ctx context.Context, rawXpubKey string, config *TransactionConfig,
+	opts ...ModelOps,
+) (*DraftTransaction, error) {
+	// Check for existing NewRelic draftTransaction
+	ctx = c.GetOrStartTxn(ctx, "simulate_transaction")
+
+	return newSimulatedDraftTransaction(
+		ctx, rawXpubKey, config,
+		c.DefaultModelOptions(append(opts, New())...)...,
+	)
+}
+
 // GetTransaction will get a transaction by its ID from the Datastore
 func (c *Client) GetTransaction(ctx context.Context, xPubID, txID string) (*Transaction, error) {
 	// Check for existing NewRelic transaction
@@ -109,6 +159,43 @@ func (c *Client) GetTransaction(ctx context.Context, xPubID, txID string) (*Tran
 	return transaction, nil
 }
 
+// P2PDelivery is the delivery state of the transaction to the paymail providers of the P2P receivers
+type P2PDelivery struct {
+	Status        SyncStatus    `json:"status"`
+	Attempts      uint32        `json:"attempts"`
+	NextAttemptAt *time.Time    `json:"next_attempt_at,omitempty"`
+	Results       []*SyncResult `json:"results"`
+}
+
+// GetTransactionP2PDelivery will get the P2P delivery state of the transaction (nil if it has no P2P receivers)
+func (c *Client) GetTransactionP2PDelivery(ctx context.Context, txID string) (*P2PDelivery, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_transaction_p2p_delivery")
+
+	syncTx, err := GetSyncTransactionByID(ctx, txID, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	} else if syncTx == nil || syncTx.P2PStatus == SyncStatusSkipped {
+		return nil, nil
+	}
+
+	delivery := &P2PDelivery{
+		Status:   syncTx.P2PStatus,
+		Attempts: syncTx.P2PAttempts,
+		Results:  make([]*SyncResult, 0),
+	}
+	if syncTx.P2PStatus == SyncStatusReady && syncTx.P2PNextAttemptAt.Valid {
+		delivery.NextAttemptAt = &syncTx.P2PNextAttemptAt.Time
+	}
+	for _, result := range syncTx.Results.Results {
+		if result != nil && result.Action == syncActionP2P {
+			delivery.Results = append(delivery.Results, result)
+		}
+	}
+
+	return delivery, nil
+}
+
 // GetTransactionsByIDs returns array of transactions by their IDs from the Datastore
 func (c *Client) GetTransactionsByIDs(ctx context.Context, txIDs []string) ([]*Transaction, error) {
 	// Check for existing NewRelic transaction
@@ -248,6 +248,56 @@ func Test_RecordTransaction(t *testing.T) {
 	})
 }
 
+func Test_SimulateTransaction(t *testing.T) {
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+	defer deferMe()
+	prepareAdditionalModels(ctx, t, client, true)
+
+	newConfig := func() *TransactionConfig {
+		return &TransactionConfig{
+			Outputs: []*TransactionOutput{{
+				To:       "1A1PjKqjWMNBzTVdcBru27EV1PHcXWc63W",
+				Satoshis: 1000,
+			}},
+		}
+	}
+
+	// when
+	preview, err := client.SimulateTransaction(ctx, testXPub, newConfig(), client.DefaultModelOptions()...)
+
+	// then
+	require.NoError(t, err)
+	assert.NotEmpty(t, preview.Hex)
+	assert.Positive(t, preview.Configuration.Fee)
+	require.Len(t, preview.Configuration.Inputs, 1)
+	require.Len(t, preview.Configuration.ChangeDestinations, 1)
+
+	// nothing has been persisted or reserved
+	draft, err := getDraftTransactionID(ctx, testXPubID, preview.ID, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+	assert.Nil(t, draft)
+
+	utxos, err := getSpendableUtxos(ctx, testXPubID, utils.ScriptTypePubKeyHash, nil, nil, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+	assert.Len(t, utxos, 3)
+
+	xPub, err := getXpubByID(ctx, testXPubID, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), xPub.NextInternalNum)
+
+	change := preview.Configuration.ChangeDestinations[0]
+	destination, err := getDestinationByLockingScript(ctx, change.LockingScript, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+	assert.Nil(t, destination)
+
+	// the draft matches the preview
+	draft, err = newDraftTransaction(testXPub, newConfig(), append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, err)
+	assert.Equal(t, preview.Configuration.Fee, draft.Configuration.Fee)
+	assert.Equal(t, preview.Configuration.Inputs[0].ID, draft.Configuration.Inputs[0].ID)
+	assert.Equal(t, change.LockingScript, draft.Configuration.ChangeDestinations[0].LockingScript)
+}
+
 func initRevertTransactionData(t *testing.T, clientOpts ...ClientOps) (context.Context, ClientInterface, *Transaction, *bip32.ExtendedKey, func()) {
 	// this creates an xpub, destination and utxo
 	ctx, client, deferMe := initSimpleTestCase(t, clientOpts...)
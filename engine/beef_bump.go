@@ -17,7 +17,7 @@ func calculateMergedBUMP(txs []*Transaction) (BUMPs, error) {
 			continue
 		}
 
-		bumps[tx.BlockHeight] = append(bumps[tx.BlockHeight], tx.BUMP)
+		bumps[tx.BUMP.BlockHeight] = append(bumps[tx.BUMP.BlockHeight], tx.BUMP)
 	}
 
 	// ensure that BUMPs are sorted by block height and will always be put in beef in the same order
@@ -97,7 +97,9 @@ func prepareBEEFFactors(ctx context.Context, tx *Transaction, store TransactionG
 func checkParentTransactions(ctx context.Context, store TransactionGetter, btTx *bt.Tx) ([]*bt.Tx, []*Transaction, error) {
 	parentTxIDs := make([]string, 0, len(btTx.Inputs))
 	for _, txIn := range btTx.Inputs {
-		parentTxIDs = append(parentTxIDs, txIn.PreviousTxIDStr())
+		if !contains(parentTxIDs, func(txID string) bool { return txID == txIn.PreviousTxIDStr() }) {
+			parentTxIDs = append(parentTxIDs, txIn.PreviousTxIDStr())
+		}
 	}
 
 	parentTxs, err := getRequiredTransactions(ctx, parentTxIDs, store)
@@ -10,14 +10,47 @@ import (
 
 const maxBeefVer = uint32(0xFFFF) // value from BRC-62
 
+// Versions of the BEEF format
+const (
+	beefVersion1 = uint32(1) // BRC-62
+	beefVersion2 = uint32(2) // BRC-96 (transactions known to the recipient can be referenced by the txid only)
+)
+
+// atomicBeefPrefix is the prefix of the Atomic BEEF (BRC-95), followed by the subject txid
+var atomicBeefPrefix = []byte{0x01, 0x01, 0x01, 0x01}
+
+// BeefOptions are the options of the generated BEEF
+type BeefOptions struct {
+	Version    uint32   // Version of the BEEF (1 or 2, default is 1)
+	Atomic     bool     // Wrap the BEEF into the Atomic BEEF with the processed transaction as the subject
+	KnownTxIDs []string // Ancestors known to the recipient, written as the txid only entries (version 2)
+}
+
 type beefTx struct {
 	version      uint32
+	atomic       bool
 	bumps        BUMPs
 	transactions []*bt.Tx
+	txIDOnly     map[string]bool
 }
 
 // ToBeef generates BEEF Hex for transaction
 func ToBeef(ctx context.Context, tx *Transaction, store TransactionGetter) (string, error) {
+	return ToBeefWithOptions(ctx, tx, store, nil)
+}
+
+// ToBeefWithOptions generates BEEF Hex for transaction in the version (and optionally Atomic) given by the options
+func ToBeefWithOptions(ctx context.Context, tx *Transaction, store TransactionGetter, options *BeefOptions) (string, error) {
+	if options == nil {
+		options = &BeefOptions{Version: beefVersion1}
+	}
+	if options.Version != beefVersion1 && options.Version != beefVersion2 {
+		return "", spverrors.Newf("unsupported BEEF version %d", options.Version)
+	}
+	if len(options.KnownTxIDs) > 0 && options.Version != beefVersion2 {
+		return "", spverrors.Newf("txid only transactions require BEEF version %d", beefVersion2)
+	}
+
 	if err := hydrateTransaction(ctx, tx); err != nil {
 		return "", err
 	}
@@ -27,12 +60,47 @@ func ToBeef(ctx context.Context, tx *Transaction, store TransactionGetter) (stri
 		return "", spverrors.Wrapf(err, "prepareBUMPFactors() error")
 	}
 
+	return beefHexFromFactors(bumpBtFactors, bumpFactors, options)
+}
+
+// toBeefFromInputs generates BEEF Hex for the stored transaction from the inputs of its hex (no draft is required),
+// the transactions written into the BEEF are returned as well (the processed transaction first)
+func toBeefFromInputs(ctx context.Context, tx *Transaction, store TransactionGetter, options *BeefOptions) (string, []*Transaction, error) {
+	bumpBtFactors, bumpFactors, err := initializeRequiredTxsCollection(tx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	parentBtTxs, parentTxs, err := checkParentTransactions(ctx, store, bumpBtFactors[0])
+	if err != nil {
+		return "", nil, spverrors.Wrapf(err, "checkParentTransactions() error")
+	}
+
+	// the ancestors shared by more parents are written once
+	included := map[string]bool{tx.ID: true}
+	for index, parentTx := range parentTxs {
+		if !included[parentTx.ID] {
+			included[parentTx.ID] = true
+			bumpFactors = append(bumpFactors, parentTx)
+			bumpBtFactors = append(bumpBtFactors, parentBtTxs[index])
+		}
+	}
+
+	beefHex, err := beefHexFromFactors(bumpBtFactors, bumpFactors, options)
+	if err != nil {
+		return "", nil, err
+	}
+	return beefHex, bumpFactors, nil
+}
+
+// beefHexFromFactors will merge the BUMPs and write the sorted transactions as BEEF
+func beefHexFromFactors(bumpBtFactors []*bt.Tx, bumpFactors []*Transaction, options *BeefOptions) (string, error) {
 	bumps, err := calculateMergedBUMP(bumpFactors)
 	if err != nil {
 		return "", err
 	}
 	sortedTxs := kahnTopologicalSortTransactions(bumpBtFactors)
-	beefHex, err := toBeefHex(bumps, sortedTxs)
+	beefHex, err := toBeefHex(bumps, sortedTxs, options)
 	if err != nil {
 		return "", spverrors.Wrapf(err, "ToBeef() error")
 	}
@@ -40,11 +108,13 @@ func ToBeef(ctx context.Context, tx *Transaction, store TransactionGetter) (stri
 	return beefHex, nil
 }
 
-func toBeefHex(bumps BUMPs, parentTxs []*bt.Tx) (string, error) {
-	beef, err := newBeefTx(1, bumps, parentTxs)
+func toBeefHex(bumps BUMPs, parentTxs []*bt.Tx, options *BeefOptions) (string, error) {
+	beef, err := newBeefTx(options.Version, bumps, parentTxs)
 	if err != nil {
 		return "", spverrors.Wrapf(err, "ToBeefHex() error")
 	}
+	beef.atomic = options.Atomic
+	beef.pruneKnownTransactions(options.KnownTxIDs)
 
 	beefBytes, err := beef.toBeefBytes()
 	if err != nil {
@@ -72,6 +142,42 @@ func newBeefTx(version uint32, bumps BUMPs, parentTxs []*bt.Tx) (*beefTx, error)
 	return beef, nil
 }
 
+// pruneKnownTransactions will mark the known transactions as the txid only entries
+// and remove the ancestors that are needed only by them (the last transaction is the processed one)
+func (beefTx *beefTx) pruneKnownTransactions(knownTxIDs []string) {
+	if len(knownTxIDs) == 0 || len(beefTx.transactions) == 0 {
+		return
+	}
+
+	processedTx := beefTx.transactions[len(beefTx.transactions)-1]
+	beefTx.txIDOnly = make(map[string]bool)
+	for _, txID := range knownTxIDs {
+		if txID != processedTx.TxID() {
+			beefTx.txIDOnly[txID] = true
+		}
+	}
+
+	// walk the ancestors from the processed transaction (transactions are sorted, parents first)
+	required := map[string]bool{processedTx.TxID(): true}
+	for i := len(beefTx.transactions) - 1; i >= 0; i-- {
+		tx := beefTx.transactions[i]
+		if !required[tx.TxID()] || beefTx.txIDOnly[tx.TxID()] {
+			continue
+		}
+		for _, input := range tx.Inputs {
+			required[input.PreviousTxIDStr()] = true
+		}
+	}
+
+	transactions := make([]*bt.Tx, 0, len(beefTx.transactions))
+	for _, tx := range beefTx.transactions {
+		if required[tx.TxID()] {
+			transactions = append(transactions, tx)
+		}
+	}
+	beefTx.transactions = transactions
+}
+
 func hydrateTransaction(ctx context.Context, tx *Transaction) error {
 	if tx.draftTransaction == nil {
 		dTx, err := getDraftTransactionID(
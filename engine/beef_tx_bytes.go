@@ -10,6 +10,13 @@ var (
 	hasNoBUMP = byte(0x00)
 )
 
+// Formats of the transactions in BEEF version 2
+var (
+	rawTx         = byte(0x00)
+	rawTxWithBUMP = byte(0x01)
+	txIDOnly      = byte(0x02)
+)
+
 func (beefTx *beefTx) toBeefBytes() ([]byte, error) {
 	if len(beefTx.bumps) == 0 || len(beefTx.transactions) < 2 { // valid BEEF contains at least two transactions (new transaction and one parent transaction)
 		return nil, spverrors.Newf("beef tx is incomplete")
@@ -35,13 +42,26 @@ func (beefTx *beefTx) toBeefBytes() ([]byte, error) {
 	transactions := make([][]byte, 0, len(beefTx.transactions))
 
 	for _, t := range beefTx.transactions {
-		txBytes := toBeefBytes(t, beefTx.bumps)
+		var txBytes []byte
+		if beefTx.version == beefVersion2 {
+			txBytes = toBeefV2Bytes(t, beefTx.bumps, beefTx.txIDOnly[t.TxID()])
+		} else {
+			txBytes = toBeefBytes(t, beefTx.bumps)
+		}
 		transactions = append(transactions, txBytes)
 		beefSize += len(txBytes)
 	}
 
+	var atomic []byte
+	if beefTx.atomic {
+		atomic = append(atomic, atomicBeefPrefix...)
+		atomic = append(atomic, bt.ReverseBytes(beefTx.transactions[len(beefTx.transactions)-1].TxIDBytes())...) // subject txid in the internal byte order
+		beefSize += len(atomic)
+	}
+
 	// compose beef
 	buffer := make([]byte, 0, beefSize)
+	buffer = append(buffer, atomic...)
 	buffer = append(buffer, ver...)
 	buffer = append(buffer, nBUMPS...)
 	buffer = append(buffer, bumps...)
@@ -69,6 +89,21 @@ func toBeefBytes(tx *bt.Tx, bumps BUMPs) []byte {
 	return txBeefBytes
 }
 
+func toBeefV2Bytes(tx *bt.Tx, bumps BUMPs, onlyTxID bool) []byte {
+	if onlyTxID {
+		return append([]byte{txIDOnly}, bt.ReverseBytes(tx.TxIDBytes())...) // txid in the internal byte order
+	}
+
+	bumpIdx := getBumpPathIndex(tx, bumps)
+	if bumpIdx > -1 {
+		txBeefBytes := []byte{rawTxWithBUMP}
+		txBeefBytes = append(txBeefBytes, bt.VarInt(bumpIdx).Bytes()...)
+		return append(txBeefBytes, tx.Bytes()...)
+	}
+
+	return append([]byte{rawTx}, tx.Bytes()...)
+}
+
 func getBumpPathIndex(tx *bt.Tx, bumps BUMPs) int {
 	bumpIndex := -1
 
@@ -0,0 +1,352 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/libsv/go-bc"
+	"github.com/libsv/go-bt/v2"
+)
+
+// decodedBeefTx is the transaction of the decoded BEEF
+type decodedBeefTx struct {
+	txID      string
+	tx        *bt.Tx // nil for the txid only entries (version 2)
+	bumpIndex int    // -1 if the transaction has no BUMP
+}
+
+// decodedBeef is the BEEF (version 1 or 2) decoded from bytes, optionally wrapped in the Atomic BEEF
+type decodedBeef struct {
+	version      uint32
+	subjectTxID  string // set for the Atomic BEEF
+	bumps        BUMPs
+	transactions []*decodedBeefTx
+}
+
+// isBeefHex will return true if the hex is BEEF (any version) or Atomic BEEF
+func isBeefHex(beefHex string) bool {
+	beefBytes, err := hex.DecodeString(beefHex)
+	if err != nil {
+		return false
+	}
+	if bytes.HasPrefix(beefBytes, atomicBeefPrefix) {
+		return true
+	}
+	return len(beefBytes) >= 4 && beefBytes[2] == 0xBE && beefBytes[3] == 0xEF
+}
+
+// decodeBeefHex will decode BEEF (version 1 or 2) or Atomic BEEF from the hex
+func decodeBeefHex(beefHex string) (*decodedBeef, error) {
+	beefBytes, err := hex.DecodeString(beefHex)
+	if err != nil {
+		return nil, spverrors.Newf("BEEF is not a hex string")
+	}
+	return decodeBeefBytes(beefBytes)
+}
+
+func decodeBeefBytes(beefBytes []byte) (*decodedBeef, error) {
+	beef := &decodedBeef{}
+
+	if bytes.HasPrefix(beefBytes, atomicBeefPrefix) {
+		if len(beefBytes) < len(atomicBeefPrefix)+32 {
+			return nil, spverrors.Newf("atomic BEEF is too short")
+		}
+		beef.subjectTxID = hex.EncodeToString(bt.ReverseBytes(beefBytes[4:36]))
+		beefBytes = beefBytes[36:]
+	}
+
+	if len(beefBytes) < 4 || beefBytes[2] != 0xBE || beefBytes[3] != 0xEF {
+		return nil, spverrors.Newf("BEEF marker not found")
+	}
+	beef.version = uint32(binary.LittleEndian.Uint16(beefBytes[:2]))
+	if beef.version != beefVersion1 && beef.version != beefVersion2 {
+		return nil, spverrors.Newf("unsupported BEEF version %d", beef.version)
+	}
+	beefBytes = beefBytes[4:]
+
+	var err error
+	if beef.bumps, beefBytes, err = decodeBeefBumps(beefBytes); err != nil {
+		return nil, err
+	}
+	if beef.transactions, beefBytes, err = decodeBeefTransactions(beefBytes, beef.version, len(beef.bumps)); err != nil {
+		return nil, err
+	}
+	if len(beefBytes) > 0 {
+		return nil, spverrors.Newf("unexpected %d bytes after the BEEF transactions", len(beefBytes))
+	}
+	if len(beef.transactions) == 0 {
+		return nil, spverrors.Newf("BEEF has no transactions")
+	}
+
+	if beef.subjectTxID != "" {
+		if err = beef.validateAtomic(); err != nil {
+			return nil, err
+		}
+	}
+	return beef, nil
+}
+
+func decodeBeefBumps(beefBytes []byte) (BUMPs, []byte, error) {
+	nBumps, size, err := readVarInt(beefBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	beefBytes = beefBytes[size:]
+
+	bumps := make(BUMPs, 0)
+	for i := uint64(0); i < nBumps; i++ {
+		bump, size, err := parseBump(beefBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		beefBytes = beefBytes[size:]
+
+		parsed := bcBumpToBUMP(bump)
+		bumps = append(bumps, &parsed)
+	}
+	return bumps, beefBytes, nil
+}
+
+func decodeBeefTransactions(beefBytes []byte, version uint32, nBumps int) ([]*decodedBeefTx, []byte, error) {
+	nTransactions, size, err := readVarInt(beefBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	beefBytes = beefBytes[size:]
+
+	transactions := make([]*decodedBeefTx, 0)
+	for i := uint64(0); i < nTransactions; i++ {
+		var tx *decodedBeefTx
+		if version == beefVersion2 {
+			tx, beefBytes, err = decodeBeefV2Transaction(beefBytes)
+		} else {
+			tx, beefBytes, err = decodeBeefV1Transaction(beefBytes)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if tx.bumpIndex >= nBumps {
+			return nil, nil, spverrors.Newf("BUMP index %d of transaction %s is out of range", tx.bumpIndex, tx.txID)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, beefBytes, nil
+}
+
+func decodeBeefV1Transaction(beefBytes []byte) (*decodedBeefTx, []byte, error) {
+	tx, beefBytes, err := readBeefTx(beefBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(beefBytes) == 0 {
+		return nil, nil, spverrors.Newf("BUMP flag of transaction %s is missing", tx.TxID())
+	}
+
+	decoded := &decodedBeefTx{txID: tx.TxID(), tx: tx, bumpIndex: -1}
+	switch beefBytes[0] {
+	case hasNoBUMP:
+		beefBytes = beefBytes[1:]
+	case hasBUMP:
+		index, size, err := readVarInt(beefBytes[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		decoded.bumpIndex = int(index)
+		beefBytes = beefBytes[1+size:]
+	default:
+		return nil, nil, spverrors.Newf("invalid BUMP flag 0x%02X of transaction %s", beefBytes[0], tx.TxID())
+	}
+	return decoded, beefBytes, nil
+}
+
+func decodeBeefV2Transaction(beefBytes []byte) (*decodedBeefTx, []byte, error) {
+	if len(beefBytes) == 0 {
+		return nil, nil, spverrors.Newf("BEEF transaction format is missing")
+	}
+
+	switch beefBytes[0] {
+	case rawTx:
+		tx, rest, err := readBeefTx(beefBytes[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		return &decodedBeefTx{txID: tx.TxID(), tx: tx, bumpIndex: -1}, rest, nil
+
+	case rawTxWithBUMP:
+		index, size, err := readVarInt(beefBytes[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		tx, rest, err := readBeefTx(beefBytes[1+size:])
+		if err != nil {
+			return nil, nil, err
+		}
+		return &decodedBeefTx{txID: tx.TxID(), tx: tx, bumpIndex: int(index)}, rest, nil
+
+	case txIDOnly:
+		if len(beefBytes) < 33 {
+			return nil, nil, spverrors.Newf("txid only transaction is too short")
+		}
+		txID := hex.EncodeToString(bt.ReverseBytes(beefBytes[1:33]))
+		return &decodedBeefTx{txID: txID, bumpIndex: -1}, beefBytes[33:], nil
+
+	default:
+		return nil, nil, spverrors.Newf("invalid BEEF transaction format 0x%02X", beefBytes[0])
+	}
+}
+
+func readBeefTx(beefBytes []byte) (*bt.Tx, []byte, error) {
+	tx, size, err := bt.NewTxFromStream(beefBytes)
+	if err != nil {
+		return nil, nil, spverrors.Wrapf(err, "cannot parse BEEF transaction")
+	}
+	return tx, beefBytes[size:], nil
+}
+
+// readVarInt will read the VarInt from the bytes (the length is checked before go-bt reads the value)
+func readVarInt(beefBytes []byte) (uint64, int, error) {
+	if len(beefBytes) == 0 {
+		return 0, 0, spverrors.Newf("BEEF is too short")
+	}
+
+	size := 1
+	switch beefBytes[0] {
+	case 0xff:
+		size = 9
+	case 0xfe:
+		size = 5
+	case 0xfd:
+		size = 3
+	}
+	if len(beefBytes) < size {
+		return 0, 0, spverrors.Newf("BEEF is too short")
+	}
+
+	value, _ := bt.NewVarIntFromBytes(beefBytes)
+	return uint64(value), size, nil
+}
+
+// parseBump will parse the BUMP from the bytes (go-bc does not check the bounds of malformed BUMPs)
+func parseBump(beefBytes []byte) (bump *bc.BUMP, size int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			bump, size, err = nil, 0, spverrors.Newf("malformed BUMP")
+		}
+	}()
+
+	bump, size, err = bc.NewBUMPFromStream(beefBytes)
+	if err != nil {
+		return nil, 0, spverrors.Wrapf(err, "cannot parse BUMP")
+	}
+	return bump, size, nil
+}
+
+// validateAtomic will check that the subject is the last transaction and all other transactions are its ancestors
+func (beef *decodedBeef) validateAtomic() error {
+	subject := beef.transactions[len(beef.transactions)-1]
+	if subject.txID != beef.subjectTxID {
+		return spverrors.Newf("subject transaction %s of atomic BEEF is not the last transaction", beef.subjectTxID)
+	}
+	if subject.tx == nil {
+		return spverrors.Newf("subject transaction %s of atomic BEEF is txid only", beef.subjectTxID)
+	}
+
+	ancestors := map[string]bool{subject.txID: true}
+	for i := len(beef.transactions) - 1; i >= 0; i-- {
+		tx := beef.transactions[i]
+		if !ancestors[tx.txID] {
+			return spverrors.Newf("transaction %s of atomic BEEF is not an ancestor of the subject transaction", tx.txID)
+		}
+		if tx.tx == nil {
+			continue
+		}
+		for _, input := range tx.tx.Inputs {
+			ancestors[input.PreviousTxIDStr()] = true
+		}
+	}
+	return nil
+}
+
+// subjectTx will return the processed transaction of the BEEF (the last one)
+func (beef *decodedBeef) subjectTx() (*bt.Tx, error) {
+	subject := beef.transactions[len(beef.transactions)-1]
+	if subject.tx == nil {
+		return nil, spverrors.Newf("processed transaction %s is txid only", subject.txID)
+	}
+	return subject.tx, nil
+}
+
+// resolveTxIDOnly will replace the txid only entries with the mined transactions (and their BUMPs) from the store
+func (beef *decodedBeef) resolveTxIDOnly(ctx context.Context, store TransactionGetter) error {
+	txIDs := make([]string, 0)
+	for _, tx := range beef.transactions {
+		if tx.tx == nil {
+			txIDs = append(txIDs, tx.txID)
+		}
+	}
+	if len(txIDs) == 0 {
+		return nil
+	}
+	if store == nil {
+		return spverrors.Newf("transactions referenced by txid only cannot be resolved")
+	}
+
+	storedTxs, err := getRequiredTransactions(ctx, txIDs, store)
+	if err != nil {
+		return err
+	}
+	storedByID := make(map[string]*Transaction, len(storedTxs))
+	for _, storedTx := range storedTxs {
+		storedByID[storedTx.ID] = storedTx
+	}
+
+	for _, tx := range beef.transactions {
+		if tx.tx != nil {
+			continue
+		}
+		storedTx := storedByID[tx.txID]
+		if storedTx.BUMP.BlockHeight == 0 || len(storedTx.BUMP.Path) == 0 {
+			return spverrors.Newf("transaction %s referenced by txid is not mined", tx.txID)
+		}
+		if tx.tx, err = bt.NewTxFromString(storedTx.Hex); err != nil {
+			return spverrors.Wrapf(err, "cannot convert to bt.Tx from hex (tx.ID: %s)", storedTx.ID)
+		}
+		bump := storedTx.BUMP
+		beef.bumps = append(beef.bumps, &bump)
+		tx.bumpIndex = len(beef.bumps) - 1
+	}
+	return nil
+}
+
+// toV1Hex will encode the decoded BEEF as BEEF version 1 (txid only entries must be resolved first)
+func (beef *decodedBeef) toV1Hex() (string, error) {
+	txs := make([]*bt.Tx, 0, len(beef.transactions))
+	for _, tx := range beef.transactions {
+		if tx.tx == nil {
+			return "", spverrors.Newf("transaction %s is txid only", tx.txID)
+		}
+		txs = append(txs, tx.tx)
+	}
+
+	return toBeefHex(beef.bumps, txs, &BeefOptions{Version: beefVersion1})
+}
+
+// normalizeBeefHex will convert BEEF version 2 or Atomic BEEF into BEEF version 1,
+// the transactions referenced by txid only are taken from the store
+func normalizeBeefHex(ctx context.Context, beefHex string, store TransactionGetter) (string, error) {
+	beef, err := decodeBeefHex(beefHex)
+	if err != nil {
+		return "", err
+	}
+	if beef.version == beefVersion1 && beef.subjectTxID == "" {
+		return beefHex, nil
+	}
+
+	if err = beef.resolveTxIDOnly(ctx, store); err != nil {
+		return "", err
+	}
+	return beef.toV1Hex()
+}
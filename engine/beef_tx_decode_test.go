@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeBeefHex(t *testing.T) {
+	t.Run("decode BEEF version 1", func(t *testing.T) {
+		// when
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, beefVersion1, beef.version)
+		assert.Equal(t, "", beef.subjectTxID)
+		assert.Len(t, beef.bumps, 2)
+		require.Len(t, beef.transactions, 3)
+		for _, tx := range beef.transactions {
+			assert.NotNil(t, tx.tx)
+		}
+		assert.Equal(t, -1, beef.transactions[2].bumpIndex)
+	})
+
+	for name, options := range map[string]*BeefOptions{
+		"atomic BEEF version 1": {Version: beefVersion1, Atomic: true},
+		"BEEF version 2":        {Version: beefVersion2},
+		"atomic BEEF version 2": {Version: beefVersion2, Atomic: true},
+	} {
+		t.Run("encode and decode "+name, func(t *testing.T) {
+			// given
+			beef, err := decodeBeefHex(expectedBeefHex[1])
+			require.NoError(t, err)
+			subject, err := beef.subjectTx()
+			require.NoError(t, err)
+
+			// when
+			encoded, err := toBeefHex(beef.bumps, beefTxs(beef), options)
+			require.NoError(t, err)
+			decoded, err := decodeBeefHex(encoded)
+			require.NoError(t, err)
+
+			// then
+			assert.True(t, isBeefHex(encoded))
+			assert.Equal(t, options.Version, decoded.version)
+			if options.Atomic {
+				assert.Equal(t, subject.TxID(), decoded.subjectTxID)
+			} else {
+				assert.Equal(t, "", decoded.subjectTxID)
+			}
+
+			normalized, err := normalizeBeefHex(context.Background(), encoded, nil)
+			require.NoError(t, err)
+			assert.Equal(t, expectedBeefHex[1], normalized)
+		})
+	}
+
+	t.Run("raw transaction is not BEEF", func(t *testing.T) {
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+
+		assert.False(t, isBeefHex(beef.transactions[0].tx.String()))
+		assert.False(t, isBeefHex("not-hex"))
+	})
+
+	t.Run("truncated BEEF", func(t *testing.T) {
+		_, err := decodeBeefHex(expectedBeefHex[1][:len(expectedBeefHex[1])-20])
+		require.Error(t, err)
+	})
+
+	t.Run("truncated VarInt", func(t *testing.T) {
+		for _, beefHex := range []string{"0100beef", "0100beeffd", "0100beeffd01", "0100beeffe010203", "0100beefff01020304050607"} {
+			_, err := decodeBeefHex(beefHex)
+			require.ErrorContains(t, err, "BEEF is too short", beefHex)
+		}
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := decodeBeefHex("0300beef" + expectedBeefHex[1][8:])
+		require.ErrorContains(t, err, "unsupported BEEF version 3")
+	})
+
+	t.Run("atomic BEEF with other subject", func(t *testing.T) {
+		// given
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		encoded, err := toBeefHex(beef.bumps, beefTxs(beef), &BeefOptions{Version: beefVersion2, Atomic: true})
+		require.NoError(t, err)
+
+		// when
+		_, err = decodeBeefHex("01010101" + strings.Repeat("00", 32) + encoded[72:])
+
+		// then
+		require.ErrorContains(t, err, "is not the last transaction")
+	})
+
+	t.Run("atomic BEEF with unrelated transaction", func(t *testing.T) {
+		// given
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		unrelated, err := decodeBeefHex(expectedBeefHex[2])
+		require.NoError(t, err)
+		txs := append([]*decodedBeefTx{unrelated.transactions[0]}, beef.transactions...)
+		bumps := append(beef.bumps, unrelated.bumps...)
+
+		// when
+		encoded, err := toBeefHex(bumps, beefTxs(&decodedBeef{transactions: txs}), &BeefOptions{Version: beefVersion2, Atomic: true})
+		require.NoError(t, err)
+		_, err = decodeBeefHex(encoded)
+
+		// then
+		require.ErrorContains(t, err, "is not an ancestor of the subject transaction")
+	})
+}
+
+func Test_normalizeBeefHex_txIDOnly(t *testing.T) {
+	// given
+	beef, err := decodeBeefHex(expectedBeefHex[1])
+	require.NoError(t, err)
+	known := beef.transactions[0]
+	require.GreaterOrEqual(t, known.bumpIndex, 0)
+
+	store := NewMockTransactionStore()
+	store.AddToStore(&Transaction{
+		TransactionBase: TransactionBase{ID: known.txID, Hex: known.tx.String()},
+		BlockHeight:     beef.bumps[known.bumpIndex].BlockHeight,
+		BUMP:            *beef.bumps[known.bumpIndex],
+	})
+
+	encoded, err := toBeefHex(beef.bumps, beefTxs(beef), &BeefOptions{Version: beefVersion2, KnownTxIDs: []string{known.txID}})
+	require.NoError(t, err)
+
+	t.Run("known transaction is txid only", func(t *testing.T) {
+		decoded, err := decodeBeefHex(encoded)
+		require.NoError(t, err)
+
+		require.Len(t, decoded.transactions, 3)
+		assert.Equal(t, known.txID, decoded.transactions[0].txID)
+		assert.Nil(t, decoded.transactions[0].tx)
+	})
+
+	t.Run("txid only transaction is resolved from the store", func(t *testing.T) {
+		normalized, err := normalizeBeefHex(context.Background(), encoded, store)
+		require.NoError(t, err)
+
+		decoded, err := decodeBeefHex(normalized)
+		require.NoError(t, err)
+		assert.Equal(t, beefVersion1, decoded.version)
+		require.Len(t, decoded.transactions, 3)
+		for _, tx := range decoded.transactions {
+			assert.NotNil(t, tx.tx)
+		}
+	})
+
+	t.Run("txid only transaction is missing in the store", func(t *testing.T) {
+		_, err := normalizeBeefHex(context.Background(), encoded, NewMockTransactionStore())
+		require.ErrorContains(t, err, "not found in database")
+	})
+}
+
+func beefTxs(beef *decodedBeef) []*bt.Tx {
+	txs := make([]*bt.Tx, 0, len(beef.transactions))
+	for _, tx := range beef.transactions {
+		txs = append(txs, tx.tx)
+	}
+	return txs
+}
@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math"
+	"sort"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/chainstate"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript/interpreter"
+)
+
+// SPVPolicy is the policy of the local SPV verification of the incoming transactions (BEEF and EF)
+type SPVPolicy struct {
+	FeeUnit          *utils.FeeUnit // Min fee of the unmined transactions (nil = fee unit of the chainstate)
+	MaxAncestryDepth int            // Max generations of the unmined ancestors in BEEF (0 = default depth)
+}
+
+// defaultSPVPolicy is used when no policy is set
+var defaultSPVPolicy = &SPVPolicy{MaxAncestryDepth: defaultSPVMaxAncestryDepth}
+
+// maxAncestryDepth will return the max generations of the unmined ancestors
+func (p *SPVPolicy) maxAncestryDepth() int {
+	if p.MaxAncestryDepth > 0 {
+		return p.MaxAncestryDepth
+	}
+	return defaultSPVMaxAncestryDepth
+}
+
+// feeUnit will return the min fee of the unmined transactions (nil if the fee is not checked)
+func (p *SPVPolicy) feeUnit(c ClientInterface) *utils.FeeUnit {
+	if p.FeeUnit != nil {
+		return p.FeeUnit
+	}
+	if c.Chainstate() != nil {
+		return c.Chainstate().FeeUnit()
+	}
+	return nil
+}
+
+// efMarker is the marker of the Extended Format (BRC-30) after the version of the transaction
+var efMarker = []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0xEF}
+
+// isExtendedFormatHex will return true if the hex is the transaction in the Extended Format
+func isExtendedFormatHex(txHex string) bool {
+	if len(txHex) < 20 {
+		return false
+	}
+	marker, err := hex.DecodeString(txHex[8:20])
+	return err == nil && bytes.Equal(marker, efMarker)
+}
+
+// verifyBeef will verify the unmined transactions of the BEEF against the outputs of their parents
+// (scripts, value of the outputs, fee and the generations of the unmined ancestors)
+// and the merkle roots of the BUMPs of the mined transactions (through the Block Headers Service)
+func verifyBeef(ctx context.Context, c ClientInterface, beef *decodedBeef) error {
+	policy := c.SPVPolicy()
+	feeUnit := policy.feeUnit(c)
+
+	txByID := make(map[string]*bt.Tx, len(beef.transactions))
+	depths := make(map[string]int, len(beef.transactions))
+	usedBumps := make(map[int]bool)
+	for _, tx := range beef.transactions {
+		if tx.tx == nil {
+			return spverrors.Newf("transaction %s is txid only", tx.txID)
+		}
+		if len(tx.tx.Inputs) == 0 {
+			return spverrors.Wrapf(spverrors.ErrSPVNoInputs, "transaction %s", tx.txID)
+		}
+		if len(tx.tx.Outputs) == 0 {
+			return spverrors.Wrapf(spverrors.ErrSPVNoOutputs, "transaction %s", tx.txID)
+		}
+		txByID[tx.txID] = tx.tx
+
+		if tx.bumpIndex > -1 {
+			if !bumpContainsTx(beef.bumps[tx.bumpIndex], tx.txID) {
+				return spverrors.Wrapf(spverrors.ErrSPVInvalidBump, "transaction %s", tx.txID)
+			}
+			usedBumps[tx.bumpIndex] = true
+			continue
+		}
+
+		// the parents precede their children in BEEF
+		depth := 0
+		for index, input := range tx.tx.Inputs {
+			parentID := input.PreviousTxIDStr()
+			parent, ok := txByID[parentID]
+			if !ok {
+				return spverrors.Wrapf(spverrors.ErrSPVMissingParent, "input %d of unmined transaction %s has no parent in BEEF", index, tx.txID)
+			}
+			if int(input.PreviousTxOutIndex) >= len(parent.Outputs) {
+				return spverrors.Wrapf(spverrors.ErrSPVMissingParent, "input %d of transaction %s spends missing output %d", index, tx.txID, input.PreviousTxOutIndex)
+			}
+			output := parent.Outputs[input.PreviousTxOutIndex]
+			input.PreviousTxSatoshis = output.Satoshis
+			input.PreviousTxScript = output.LockingScript
+			depth = max(depth, depths[parentID]+1)
+		}
+		if depth > policy.maxAncestryDepth() {
+			return spverrors.Wrapf(spverrors.ErrSPVAncestryTooDeep, "transaction %s has %d generations of unmined ancestors (max %d)", tx.txID, depth, policy.maxAncestryDepth())
+		}
+		depths[tx.txID] = depth
+
+		if err := verifyUnminedTx(tx.tx, feeUnit); err != nil {
+			return err
+		}
+	}
+
+	return verifyBumps(ctx, c, beef.bumps, usedBumps)
+}
+
+// verifyExtendedFormat will verify the transaction in the Extended Format against the outputs it contains
+// (scripts, value of the outputs and fee)
+func verifyExtendedFormat(c ClientInterface, tx *bt.Tx) error {
+	if len(tx.Inputs) == 0 {
+		return spverrors.Wrapf(spverrors.ErrSPVNoInputs, "transaction %s", tx.TxID())
+	}
+	if len(tx.Outputs) == 0 {
+		return spverrors.Wrapf(spverrors.ErrSPVNoOutputs, "transaction %s", tx.TxID())
+	}
+	for index, input := range tx.Inputs {
+		if input.PreviousTxScript == nil {
+			return spverrors.Wrapf(spverrors.ErrSPVMissingParent, "input %d of transaction %s has no previous output", index, tx.TxID())
+		}
+	}
+	return verifyUnminedTx(tx, c.SPVPolicy().feeUnit(c))
+}
+
+// verifyUnminedTx will check the value of the outputs, the fee and the scripts of all inputs (previous outputs must be set)
+func verifyUnminedTx(tx *bt.Tx, feeUnit *utils.FeeUnit) error {
+	inputsValue, outputsValue := tx.TotalInputSatoshis(), tx.TotalOutputSatoshis()
+	if outputsValue > inputsValue {
+		return spverrors.Wrapf(spverrors.ErrSPVOutputValueTooHigh, "transaction %s spends %d satoshis of %d", tx.TxID(), outputsValue, inputsValue)
+	}
+	if fee, required := inputsValue-outputsValue, requiredFee(tx.Size(), feeUnit); fee < required {
+		return spverrors.Wrapf(spverrors.ErrSPVFeeTooLow, "transaction %s pays %d satoshis of fee, required %d", tx.TxID(), fee, required)
+	}
+
+	for index, input := range tx.Inputs {
+		if err := interpreter.NewEngine().Execute(
+			interpreter.WithTx(tx, index, &bt.Output{
+				LockingScript: input.PreviousTxScript,
+				Satoshis:      input.PreviousTxSatoshis,
+			}),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		); err != nil {
+			return spverrors.Wrapf(spverrors.ErrSPVInvalidScript, "script of input %d of transaction %s: %s", index, tx.TxID(), err.Error())
+		}
+	}
+	return nil
+}
+
+// requiredFee will return the min fee of the transaction of the given size (0 if the fee unit is not set)
+func requiredFee(size int, feeUnit *utils.FeeUnit) uint64 {
+	if feeUnit == nil || !feeUnit.IsValid() || feeUnit.IsZero() {
+		return 0
+	}
+	return uint64(math.Ceil(float64(size) * (float64(feeUnit.Satoshis) / float64(feeUnit.Bytes))))
+}
+
+// verifyBumps will verify the merkle roots of the used BUMPs in the Block Headers Service
+func verifyBumps(ctx context.Context, c ClientInterface, bumps BUMPs, used map[int]bool) error {
+	indexes := make([]int, 0, len(used))
+	for index := range used {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	merkleRoots := make([]chainstate.MerkleRootConfirmationRequestItem, 0, len(indexes))
+	for _, index := range indexes {
+		merkleRoot, err := bumps[index].calculateMerkleRoot()
+		if err != nil {
+			return spverrors.Wrapf(spverrors.ErrSPVInvalidBump, "cannot calculate merkle root of BUMP %d: %s", index, err.Error())
+		}
+		merkleRoots = append(merkleRoots, chainstate.MerkleRootConfirmationRequestItem{
+			MerkleRoot:  merkleRoot,
+			BlockHeight: bumps[index].BlockHeight,
+		})
+	}
+	if len(merkleRoots) == 0 {
+		return nil
+	}
+
+	if err := c.Chainstate().VerifyMerkleRoots(ctx, merkleRoots); err != nil {
+		return spverrors.Wrapf(spverrors.ErrSPVInvalidMerkleRoots, "%s", err.Error())
+	}
+	return nil
+}
+
+// bumpContainsTx will return true if the BUMP proves the transaction
+func bumpContainsTx(bump *BUMP, txID string) bool {
+	if len(bump.Path) == 0 {
+		return false
+	}
+	for _, leaf := range bump.Path[0] {
+		if leaf.Hash == txID && leaf.TxID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/chainstate"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/jarcoal/httpmock"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_verifyBeef(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// childBeef will return the BEEF fixture with an unmined child of the processed transaction
+	childBeef := func(t *testing.T) *decodedBeef {
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		parent := beef.transactions[2]
+
+		child := bt.NewTx()
+		require.NoError(t, child.From(parent.txID, 0, parent.tx.Outputs[0].LockingScriptHexString(), parent.tx.Outputs[0].Satoshis))
+		child.Inputs[0].UnlockingScript = bscript.NewFromBytes([]byte{bscript.OpTRUE})
+		require.NoError(t, child.AddP2PKHOutputFromScript(parent.tx.Outputs[0].LockingScript, parent.tx.Outputs[0].Satoshis-1))
+
+		beef.transactions = append(beef.transactions, &decodedBeefTx{txID: child.TxID(), tx: child, bumpIndex: -1})
+		return beef
+	}
+
+	t.Run("valid BEEF", func(t *testing.T) {
+		mockMerkleRootsVerification(chainstate.Confirmed)
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+		defer deferMe()
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+
+		require.NoError(t, verifyBeef(ctx, client, beef))
+	})
+
+	t.Run("outputs are higher than inputs", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+		defer deferMe()
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		beef.transactions[2].tx.Outputs[0].Satoshis += 1_000_000_000
+
+		err = verifyBeef(ctx, client, beef)
+
+		require.ErrorIs(t, err, spverrors.ErrSPVOutputValueTooHigh)
+	})
+
+	t.Run("fee is lower than the fee unit of the policy", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup(),
+			WithSPVPolicy(&SPVPolicy{FeeUnit: &utils.FeeUnit{Satoshis: 1000, Bytes: 1}}))
+		defer deferMe()
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+
+		err = verifyBeef(ctx, client, beef)
+
+		require.ErrorIs(t, err, spverrors.ErrSPVFeeTooLow)
+		assert.ErrorContains(t, err, beef.transactions[2].txID)
+	})
+
+	t.Run("too many generations of unmined ancestors", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup(),
+			WithSPVPolicy(&SPVPolicy{MaxAncestryDepth: 1}))
+		defer deferMe()
+		beef := childBeef(t)
+
+		err := verifyBeef(ctx, client, beef)
+
+		require.ErrorIs(t, err, spverrors.ErrSPVAncestryTooDeep)
+		assert.ErrorContains(t, err, "has 2 generations")
+	})
+
+	t.Run("unmined ancestors within the default depth", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+		defer deferMe()
+		beef := childBeef(t)
+
+		err := verifyBeef(ctx, client, beef)
+
+		// the child passes the depth check and fails on its (fake) unlocking script
+		require.ErrorIs(t, err, spverrors.ErrSPVInvalidScript)
+	})
+
+	t.Run("BUMP without the transaction", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+		defer deferMe()
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		beef.transactions[0].bumpIndex, beef.transactions[1].bumpIndex = beef.transactions[1].bumpIndex, beef.transactions[0].bumpIndex
+
+		err = verifyBeef(ctx, client, beef)
+
+		require.ErrorIs(t, err, spverrors.ErrSPVInvalidBump)
+	})
+}
+
+func Test_requiredFee(t *testing.T) {
+	assert.Equal(t, uint64(0), requiredFee(250, nil))
+	assert.Equal(t, uint64(0), requiredFee(250, &utils.FeeUnit{Satoshis: 0, Bytes: 1000}))
+	assert.Equal(t, uint64(1), requiredFee(250, &utils.FeeUnit{Satoshis: 1, Bytes: 1000}))
+	assert.Equal(t, uint64(13), requiredFee(250, &utils.FeeUnit{Satoshis: 50, Bytes: 1000}))
+}
@@ -37,6 +37,12 @@ const (
 	requiredOnChain   = "on-chain" // Requirement for tx query (has to be == on-chain)
 )
 
+// Block Headers Service endpoints (the tip URL is resolved from the configured merkle roots verification URL)
+const (
+	blockHeadersServiceTipPath    = "/chain/header/state/tip"
+	blockHeadersServiceVerifyPath = "/chain/merkleroot/verify"
+)
+
 // List of providers
 const (
 	ProviderAll             = "all"             // All providers (used for errors etc)
@@ -34,6 +34,7 @@ type ProviderServices interface {
 // HeaderService is header services interface
 type HeaderService interface {
 	VerifyMerkleRoots(ctx context.Context, merkleRoots []MerkleRootConfirmationRequestItem) error
+	GetTipHeight(ctx context.Context) (uint32, error)
 }
 
 // ClientInterface is the chainstate client interface
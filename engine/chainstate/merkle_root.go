@@ -30,3 +30,12 @@ func (c *Client) VerifyMerkleRoots(ctx context.Context, merkleRoots []MerkleRoot
 
 	return nil
 }
+
+// GetTipHeight will return the height of the tip of the longest chain known to the Block Headers Service
+func (c *Client) GetTipHeight(ctx context.Context) (uint32, error) {
+	pc := c.options.config.blockHedersServiceClient
+	if pc == nil {
+		return 0, spverrors.Newf("no block headers service client found")
+	}
+	return pc.tipHeight(ctx, c.options.logger)
+}
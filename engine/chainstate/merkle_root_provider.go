@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/rs/zerolog"
@@ -46,6 +47,12 @@ type MerkleRootsConfirmationsResponse struct {
 	Confirmations     []MerkleRootConfirmation    `json:"confirmations"`
 }
 
+// BlockHeaderState is an API response of the state of a block header (the tip of the longest chain)
+type BlockHeaderState struct {
+	State  string `json:"state"`
+	Height uint32 `json:"height"`
+}
+
 type blockHeadersServiceClientProvider struct {
 	url        string
 	authToken  string
@@ -98,6 +105,50 @@ func (p *blockHeadersServiceClientProvider) verifyMerkleRoots(
 	return &merkleRootsRes, nil
 }
 
+// tipURL will return the URL of the tip of the longest chain (the provider is configured with the merkle roots verification URL)
+func (p *blockHeadersServiceClientProvider) tipURL() (string, error) {
+	if !strings.HasSuffix(p.url, blockHeadersServiceVerifyPath) {
+		return "", spverrors.Newf("cannot resolve the tip URL of the Block Headers Service from %s", p.url)
+	}
+	return strings.TrimSuffix(p.url, blockHeadersServiceVerifyPath) + blockHeadersServiceTipPath, nil
+}
+
+func (p *blockHeadersServiceClientProvider) tipHeight(ctx context.Context, logger *zerolog.Logger) (uint32, error) {
+	url, err := p.tipURL()
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, _fmtAndLogTipError(err, logger, "Error occurred while creating request for the Block Headers Service client.")
+	}
+
+	if p.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.authToken)
+	}
+	res, err := p.httpClient.Do(req)
+	if res != nil {
+		defer func() {
+			_ = res.Body.Close()
+		}()
+	}
+	if err != nil {
+		return 0, _fmtAndLogTipError(err, logger, "Error occurred while sending request to the Block Headers Service.")
+	}
+
+	if res.StatusCode != 200 {
+		return 0, _fmtAndLogTipError(_statusError(res.StatusCode), logger, "Received unexpected status code from Block Headers Service.")
+	}
+
+	var tip BlockHeaderState
+	if err = json.NewDecoder(res.Body).Decode(&tip); err != nil {
+		return 0, _fmtAndLogTipError(err, logger, "Error occurred while parsing response from the Block Headers Service.")
+	}
+
+	return tip.Height, nil
+}
+
 // _fmtAndLogError returns brief error for http response message and logs detailed information with original error
 func _fmtAndLogError(err error, logger *zerolog.Logger, message string) error {
 	logger.Error().Err(err).Msg("[verifyMerkleRoots] " + message)
@@ -107,3 +158,9 @@ func _fmtAndLogError(err error, logger *zerolog.Logger, message string) error {
 func _statusError(statusCode int) error {
 	return spverrors.Newf("Block Headers Service client returned status code %d - check Block Headers Service configuration and status", statusCode)
 }
+
+// _fmtAndLogTipError returns brief error for the tip request and logs detailed information with original error
+func _fmtAndLogTipError(err error, logger *zerolog.Logger, message string) error {
+	logger.Error().Err(err).Msg("[tipHeight] " + message)
+	return spverrors.Newf("cannot get the tip of the longest chain - %s", message)
+}
@@ -129,3 +129,55 @@ func newBuffLogger() *buffLogger {
 func (l *buffLogger) contains(expected string) bool {
 	return bytes.Contains(l.buf.Bytes(), []byte(expected))
 }
+
+func TestGetTipHeight(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mockURL := "http://block-headers-service.test/api/v1/chain/merkleroot/verify"
+	mockTipURL := "http://block-headers-service.test/api/v1/chain/header/state/tip"
+
+	t.Run("no block headers service client", func(t *testing.T) {
+		c, _ := initMockClient()
+
+		_, err := c.GetTipHeight(context.Background())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown tip URL", func(t *testing.T) {
+		httpmock.Reset()
+		c, _ := initMockClient(WithConnectionToBlockHeaderService("http://block-headers-service.test/verify", ""))
+
+		_, err := c.GetTipHeight(context.Background())
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("block headers service is not online", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", mockTipURL,
+			httpmock.NewStringResponder(500, `{"error":"Internal Server Error"}`),
+		)
+		c, bLogger := initMockClient(WithConnectionToBlockHeaderService(mockURL, ""))
+
+		_, err := c.GetTipHeight(context.Background())
+
+		assert.Error(t, err)
+		assert.True(t, bLogger.contains("Block Headers Service client returned status code 500"))
+	})
+
+	t.Run("tip of the longest chain", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", mockTipURL,
+			httpmock.NewJsonResponderOrPanic(200, BlockHeaderState{State: "LONGEST_CHAIN", Height: 850000}),
+		)
+		c, _ := initMockClient(WithConnectionToBlockHeaderService(mockURL, ""))
+
+		height, err := c.GetTipHeight(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(850000), height)
+	})
+}
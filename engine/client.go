@@ -42,6 +42,7 @@ type (
 		newRelic      *newRelicOptions      // Configuration options for NewRelic
 		notifications *notificationsOptions // Configuration options for Notifications
 		paymail       *paymailOptions       // Paymail options & client
+		spvPolicy     *SPVPolicy            // Policy of the local SPV verification of the incoming transactions (optional)
 		taskManager   *taskManagerOptions   // Configuration options for the TaskManager (TaskQ, etc.)
 		userAgent     string                // User agent for all outgoing requests
 	}
@@ -100,14 +101,28 @@ type (
 	// paymailOptions holds the configuration for Paymail
 	paymailOptions struct {
 		client       paymail.ClientInterface // Paymail client for communicating with Paymail providers
+		clientPolicy *PaymailClientPolicy    // Policy of the outbound paymail requests (optional)
 		serverConfig *PaymailServerOptions   // Server configuration if Paymail is enabled
 	}
 
 	// PaymailServerOptions is the options for the Paymail server
 	PaymailServerOptions struct {
-		*server.Configuration                    // Server configuration if Paymail is enabled
-		options               []server.ConfigOps // Options for the paymail server
-		DefaultFromPaymail    string             // IE: from@domain.com
+		*server.Configuration                             // Server configuration if Paymail is enabled
+		options               []server.ConfigOps          // Options for the paymail server
+		DefaultFromPaymail    string                      // IE: from@domain.com
+		KeyProvider           PaymailKeyProvider          // Provides the xPrivs for signing with the PKI keys of the paymails (optional)
+		OutputSplit           *OutputSplitOptions         // Splitting of the received satoshis into multiple P2P outputs (optional)
+		PubKeyGracePeriod     time.Duration               // Time for which the rotated PKI keys stay verifiable (optional)
+		AliasPolicy           *AliasPolicy                // Policy for the aliases of the new paymail addresses (optional)
+		P2PReferenceExpiry    time.Duration               // Time for which the P2P payment destinations wait for the transaction (optional)
+		AcceptExpiredP2P      bool                        // Accept (and flag) the transactions of the expired P2P references instead of rejecting them
+		P2PExpiredRetention   time.Duration               // Time for which the destinations of the expired P2P references are kept when the late transactions are accepted (optional)
+		PikePayments          bool                        // Pay the confirmed contacts via PIKE (and serve the PIKE outputs)
+		ContactVerification   *ContactVerificationOptions // Verification of the contacts via the shared time-based codes (optional)
+		InvitationRateLimit   int                         // Max contact invitations per hour from a domain to an xPub (0 = no limit)
+		domains               *paymailDomains             // Domains managed at runtime (admin API) with their settings
+		transactions          TransactionGetter           // Source of the transactions referenced by txid only in the incoming BEEF
+		verifier              ClientInterface             // Client for the local SPV verification of the incoming BEEF
 	}
 
 	// taskManagerOptions holds the configuration for taskmanager
@@ -194,6 +209,18 @@ func NewClient(ctx context.Context, opts ...ClientOps) (ClientInterface, error)
 		}
 	}
 
+	// Transactions referenced by txid only in the incoming BEEF (version 2) are taken from the datastore,
+	// the incoming BEEF is verified locally before the paymail server accepts it
+	if config := client.GetPaymailConfig(); config != nil {
+		config.transactions = client
+		config.verifier = client
+	}
+
+	// Load the paymail domains managed at runtime (served next to the domains from config)
+	if err = client.loadPaymailDomains(ctx); err != nil {
+		return nil, err
+	}
+
 	// Return the client
 	return client, nil
 }
@@ -369,6 +396,14 @@ func (c *Client) IsIUCEnabled() bool {
 	return c.options.iuc
 }
 
+// SPVPolicy will return the policy of the local SPV verification (default policy if none is set)
+func (c *Client) SPVPolicy() *SPVPolicy {
+	if c.options.spvPolicy != nil {
+		return c.options.spvPolicy
+	}
+	return defaultSPVPolicy
+}
+
 // IsEncryptionKeySet will return the flag (bool) if the encryption key has been set
 func (c *Client) IsEncryptionKeySet() bool {
 	return len(c.options.encryptionKey) > 0
@@ -68,9 +68,12 @@ func (c *Client) loadDatastore(ctx context.Context) (err error) {
 				[]string{ // Array fields
 					"xpub_in_ids",
 					"xpub_out_ids",
+					"groups",
 				}, []string{ // Object fields
 					"xpub_metadata",
 					"xpub_output_value",
+					"xpub_contact_ids",
+					"xpub_contact_sats",
 				},
 			))
 
@@ -131,7 +134,21 @@ func (c *Client) GetWebhooks(ctx context.Context) ([]notifications.ModelWebhook,
 func (c *Client) loadPaymailClient() (err error) {
 	// Only load if it's not set (the client can be overloaded)
 	if c.options.paymail.client == nil {
-		c.options.paymail.client, err = paymail.NewClient()
+		var client paymail.ClientInterface
+		if client, err = paymail.NewClient(); err != nil {
+			return
+		}
+
+		// The timeouts of the policy are applied by the HTTP client (a custom paymail client keeps its own)
+		if c.options.paymail.clientPolicy != nil {
+			client = client.WithCustomHTTPClient(newPaymailHTTPClient(c.options.paymail.clientPolicy))
+		}
+		c.options.paymail.client = client
+	}
+
+	// Apply the policy of the outbound requests (blocklist, timeouts and retries)
+	if c.options.paymail.clientPolicy != nil {
+		c.options.paymail.client = newPolicyPaymailClient(c.options.paymail.client, c.options.paymail.clientPolicy)
 	}
 	return
 }
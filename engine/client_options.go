@@ -148,6 +148,13 @@ func (o *clientOptions) addModels(list string, models ...interface{}) {
 	}
 }
 
+// addPaymailModels will add the paymail_address, paymail_domain, paymail_p2p_reference and contact_block models
+// (the models used by the paymail server) in SPV Wallet Engine
+func (o *clientOptions) addPaymailModels() {
+	o.addModels(migrateList, newPaymail("", 0), newPaymailDomain("", nil),
+		&P2PReference{Model: *NewBaseModel(ModelP2PReference)}, &ContactBlock{Model: *NewBaseModel(ModelContactBlock)})
+}
+
 // DefaultModelOptions will set any default model options (from Client options->model)
 func (c *Client) DefaultModelOptions(opts ...ModelOps) []ModelOps {
 	// Set the Client from the spvwalletengine.Client onto the model
@@ -431,6 +438,16 @@ func WithPaymailClient(client paymail.ClientInterface) ClientOps {
 	}
 }
 
+// WithPaymailClientPolicy will set the policy of the outbound paymail requests
+// (cache TTLs, negative caching, timeouts and retries per domain and the domain blocklist)
+func WithPaymailClientPolicy(policy *PaymailClientPolicy) ClientOps {
+	return func(c *clientOptions) {
+		if policy != nil {
+			c.paymail.clientPolicy = policy
+		}
+	}
+}
+
 // WithPaymailSupport will set the configuration for Paymail support (as a server)
 func WithPaymailSupport(domains []string, defaultFromPaymail string, domainValidation, senderValidation bool) ClientOps {
 	return func(c *clientOptions) {
@@ -457,12 +474,13 @@ func WithPaymailSupport(domains []string, defaultFromPaymail string, domainValid
 			c.paymail.serverConfig.DefaultFromPaymail = defaultFromPaymail
 		}
 
-		// Add the paymail_address model in SPV Wallet Engine
-		c.addModels(migrateList, newPaymail("", 0))
+		c.addPaymailModels()
 	}
 }
 
 // WithPaymailBeefSupport will enable Paymail BEEF format support (as a server) and create a Block Headers Service client for Merkle Roots verification.
+//
+// BEEF version 1, version 2 and Atomic BEEF are accepted (advertised by the BeefFormatsCapability)
 func WithPaymailBeefSupport(blockHeadersServiceURL, blockHeadersServiceAuthToken string) ClientOps {
 	return func(c *clientOptions) {
 		_, err := url.ParseRequestURI(blockHeadersServiceURL)
@@ -470,7 +488,7 @@ func WithPaymailBeefSupport(blockHeadersServiceURL, blockHeadersServiceAuthToken
 			panic(err)
 		}
 		c.chainstate.options = append(c.chainstate.options, chainstate.WithConnectionToBlockHeaderService(blockHeadersServiceURL, blockHeadersServiceAuthToken))
-		c.paymail.serverConfig.options = append(c.paymail.serverConfig.options, server.WithBeefCapabilities())
+		c.paymail.serverConfig.options = append(c.paymail.serverConfig.options, server.WithBeefCapabilities(), server.WithCapabilities(beefFormatsCapability()))
 	}
 }
 
@@ -486,8 +504,84 @@ func WithPaymailServerConfig(config *server.Configuration, defaultFromPaymail st
 			c.paymail.serverConfig.DefaultFromPaymail = defaultFromPaymail
 		}
 
-		// Add the paymail_address model in SPV Wallet Engine
-		c.addModels(migrateList, newPaymail("", 0))
+		c.addPaymailModels()
+	}
+}
+
+// WithPaymailKeyProvider will set the provider of the xPrivs used to sign with the PKI keys of the paymails
+//
+// This enables signing of the basic address resolution (sender validation and signed responses)
+func WithPaymailKeyProvider(keyProvider PaymailKeyProvider) ClientOps {
+	return func(c *clientOptions) {
+		if keyProvider != nil {
+			c.paymail.serverConfig.KeyProvider = keyProvider
+		}
+	}
+}
+
+// WithPaymailOutputSplit will set the strategy for splitting the received satoshis into multiple P2P outputs
+func WithPaymailOutputSplit(options *OutputSplitOptions) ClientOps {
+	return func(c *clientOptions) {
+		if options != nil {
+			c.paymail.serverConfig.OutputSplit = options
+		}
+	}
+}
+
+// WithPaymailPubKeyGracePeriod will set the time for which the rotated PKI keys stay verifiable
+func WithPaymailPubKeyGracePeriod(gracePeriod time.Duration) ClientOps {
+	return func(c *clientOptions) {
+		if gracePeriod > 0 {
+			c.paymail.serverConfig.PubKeyGracePeriod = gracePeriod
+		}
+	}
+}
+
+// WithPaymailP2PDestinationExpiry will set the expiry of the P2P payment destinations (unused ones are removed by a cron job),
+// late transactions are rejected or accepted and flagged (acceptExpired)
+func WithPaymailP2PDestinationExpiry(expiry time.Duration, acceptExpired bool) ClientOps {
+	return func(c *clientOptions) {
+		if expiry > 0 {
+			c.paymail.serverConfig.P2PReferenceExpiry = expiry
+		}
+		c.paymail.serverConfig.AcceptExpiredP2P = acceptExpired
+	}
+}
+
+// WithPaymailP2PExpiredRetention will set the time for which the unused destinations of the expired P2P references are kept
+// when the late transactions are accepted (see WithPaymailP2PDestinationExpiry)
+func WithPaymailP2PExpiredRetention(retention time.Duration) ClientOps {
+	return func(c *clientOptions) {
+		if retention > 0 {
+			c.paymail.serverConfig.P2PExpiredRetention = retention
+		}
+	}
+}
+
+// WithPaymailAliasPolicy will set the policy for the aliases (and public names) of the paymail addresses
+func WithPaymailAliasPolicy(policy *AliasPolicy) ClientOps {
+	return func(c *clientOptions) {
+		if policy != nil {
+			c.paymail.serverConfig.AliasPolicy = policy
+		}
+	}
+}
+
+// WithPaymailContactVerification will set the options of the contact verification via the shared time-based codes
+func WithPaymailContactVerification(options *ContactVerificationOptions) ClientOps {
+	return func(c *clientOptions) {
+		if options != nil {
+			c.paymail.serverConfig.ContactVerification = options
+		}
+	}
+}
+
+// WithPaymailContactInvitationRateLimit will set the max contact invitations per hour from a domain to an xPub
+func WithPaymailContactInvitationRateLimit(limit int) ClientOps {
+	return func(c *clientOptions) {
+		if limit > 0 {
+			c.paymail.serverConfig.InvitationRateLimit = limit
+		}
 	}
 }
 
@@ -499,9 +593,11 @@ func WithPaymailPikeContactSupport() ClientOps {
 }
 
 // WithPaymailPikePaymentSupport will enable Paymail Pike Payment support
+// (the PIKE outputs are served and the confirmed contacts are paid via PIKE)
 func WithPaymailPikePaymentSupport() ClientOps {
 	return func(c *clientOptions) {
 		c.paymail.serverConfig.options = append(c.paymail.serverConfig.options, server.WithPikePaymentCapabilities())
+		c.paymail.serverConfig.PikePayments = true
 	}
 }
 
@@ -613,6 +709,16 @@ func WithFeeQuotes(enabled bool) ClientOps {
 	}
 }
 
+// WithSPVPolicy will set the policy of the local SPV verification of the incoming transactions
+// (min fee of the unmined transactions and max generations of their unmined ancestors)
+func WithSPVPolicy(policy *SPVPolicy) ClientOps {
+	return func(c *clientOptions) {
+		if policy != nil {
+			c.spvPolicy = policy
+		}
+	}
+}
+
 // WithFeeUnit will set the fee unit to use for broadcasting
 func WithFeeUnit(feeUnit *utils.FeeUnit) ClientOps {
 	return func(c *clientOptions) {
@@ -9,6 +9,7 @@ import (
 
 	broadcast_client_mock "github.com/bitcoin-sv/go-broadcast-client/broadcast/broadcast-client-mock"
 	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
 	"github.com/bitcoin-sv/spv-wallet/engine/logging"
 	"github.com/bitcoin-sv/spv-wallet/engine/taskmanager"
@@ -486,6 +487,24 @@ func TestWithPaymailClient(t *testing.T) {
 	})
 }
 
+// TestWithPaymailServerConfig will test the method WithPaymailServerConfig()
+func TestWithPaymailServerConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("registers the same models as WithPaymailSupport", func(t *testing.T) {
+		withConfig := defaultClientOptions()
+		WithPaymailServerConfig(&server.Configuration{}, defaultSenderPaymail)(withConfig)
+
+		withSupport := defaultClientOptions()
+		WithPaymailSupport([]string{testDomain}, defaultSenderPaymail, false, false)(withSupport)
+
+		assert.ElementsMatch(t, withSupport.models.migrateModelNames, withConfig.models.migrateModelNames)
+		for _, model := range []ModelName{ModelPaymailAddress, ModelPaymailDomain, ModelP2PReference, ModelContactBlock} {
+			assert.True(t, withConfig.modelExists(model.String(), migrateList), model.String())
+		}
+	})
+}
+
 // TestWithTaskQ will test the method WithTaskQ()
 func TestWithTaskQ(t *testing.T) {
 	t.Parallel()
@@ -601,6 +620,7 @@ func TestWithModels(t *testing.T) {
 			ModelDraftTransaction.String(), ModelTransaction.String(),
 			ModelSyncTransaction.String(), ModelDestination.String(),
 			ModelUtxo.String(), ModelContact.String(), ModelWebhook.String(),
+			ModelScheduledPayment.String(), ModelMultisigAccount.String(),
 		}, tc.GetModelNames())
 	})
 
@@ -618,7 +638,8 @@ func TestWithModels(t *testing.T) {
 			ModelXPub.String(), ModelAccessKey.String(),
 			ModelDraftTransaction.String(), ModelTransaction.String(),
 			ModelSyncTransaction.String(), ModelDestination.String(),
-			ModelUtxo.String(), ModelContact.String(), ModelWebhook.String(), ModelPaymailAddress.String(),
+			ModelUtxo.String(), ModelContact.String(), ModelWebhook.String(),
+			ModelScheduledPayment.String(), ModelMultisigAccount.String(), ModelPaymailAddress.String(),
 		}, tc.GetModelNames())
 	})
 }
@@ -814,6 +835,8 @@ func TestWithAutoMigrate(t *testing.T) {
 			ModelUtxo.String(),
 			ModelContact.String(),
 			ModelWebhook.String(),
+			ModelScheduledPayment.String(),
+			ModelMultisigAccount.String(),
 		}, tc.GetModelNames())
 	})
 
@@ -837,6 +860,8 @@ func TestWithAutoMigrate(t *testing.T) {
 			ModelUtxo.String(),
 			ModelContact.String(),
 			ModelWebhook.String(),
+			ModelScheduledPayment.String(),
+			ModelMultisigAccount.String(),
 			ModelPaymailAddress.String(),
 		}, tc.GetModelNames())
 	})
@@ -19,6 +19,9 @@ type Channel string
 var (
 	// DestinationNew is a message sent when a new destination is created
 	DestinationNew Channel = "new-destination"
+
+	// PaymailDomainsReload is a message sent when the paymail domains are changed
+	PaymailDomainsReload Channel = "reload-paymail-domains"
 )
 
 // ClientInterface interface for the internal pub/sub functionality for clusters
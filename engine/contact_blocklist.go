@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// BlockContact will block (or mute) the contact invitations of the paymail or the whole domain,
+// the pending invitations of the blocked paymails are rejected (empty xPub for the global block set by the admin)
+func (c *Client) BlockContact(ctx context.Context, xPubID, subject string, action ContactBlockAction,
+	opts ...ModelOps,
+) (*ContactBlock, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "block_contact")
+
+	if xPubID == "" && action != ContactBlockActionBlock {
+		return nil, spverrors.Wrapf(spverrors.ErrInvalidContactBlock, "global block cannot be muted")
+	}
+
+	block, err := newContactBlock(xPubID, subject, action, c.DefaultModelOptions(append(opts, New())...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The block of the subject can be set again (after unblocking)
+	existing, err := getContactBlock(ctx, block.ID, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		existing.Action = action
+		existing.DeletedAt.Valid = false
+		existing.SetOptions(opts...)
+		block = existing
+	}
+
+	if err = block.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	if xPubID != "" {
+		if err = c.rejectBlockedInvitations(ctx, block); err != nil {
+			return nil, err
+		}
+	}
+	return block, nil
+}
+
+// UnblockContact will remove the block of the paymail or domain (empty xPub for the global block)
+func (c *Client) UnblockContact(ctx context.Context, xPubID, subject string) error {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "unblock_contact")
+
+	// Validate and standardize the subject
+	block, err := newContactBlock(xPubID, subject, ContactBlockActionBlock)
+	if err != nil {
+		return err
+	}
+
+	existing, err := getContactBlock(ctx, block.ID, c.DefaultModelOptions()...)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.DeletedAt.Valid {
+		return spverrors.ErrContactBlockNotFound
+	}
+
+	existing.DeletedAt.Valid = true
+	existing.DeletedAt.Time = time.Now()
+	return existing.Save(ctx)
+}
+
+// GetContactBlocks will get the blocked paymails and domains of the xPub (empty xPub for the global blocks)
+func (c *Client) GetContactBlocks(ctx context.Context, xPubID string) ([]*ContactBlock, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_contact_blocks")
+
+	return getContactBlocks(ctx, xPubID, c.DefaultModelOptions()...)
+}
+
+// rejectBlockedInvitations will reject the pending invitations of the blocked paymail (or domain)
+func (c *Client) rejectBlockedInvitations(ctx context.Context, block *ContactBlock) error {
+	contacts, err := getContactsByXpubID(ctx, block.XpubID, nil, map[string]interface{}{
+		statusField:    ContactAwaitAccept,
+		deletedAtField: nil,
+	}, nil, c.DefaultModelOptions()...)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		if !block.matches(contact.Paymail) {
+			continue
+		}
+		contact.enrich(ModelContact, c.DefaultModelOptions()...)
+		if err = contact.Reject(); err != nil {
+			c.logContactWarining(contact.OwnerXpubID, contact.Paymail, err.Error())
+			continue
+		}
+		if err = contact.Save(ctx); err != nil {
+			c.logContactError(contact.OwnerXpubID, contact.Paymail, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
+			return spverrors.ErrSaveContact
+		}
+	}
+	return nil
+}
+
+// findContactBlock will find the block of the requester paymail (the block of the paymail, its domain or a parent domain)
+func (c *Client) findContactBlock(ctx context.Context, xPubID, requesterPaymail string) (*ContactBlock, error) {
+	_, domain, address := paymail.SanitizePaymail(requesterPaymail)
+
+	subjects := []string{address}
+	for labels := strings.Split(domain, "."); len(labels) > 1; labels = labels[1:] {
+		subjects = append(subjects, strings.Join(labels, "."))
+	}
+
+	for _, subject := range subjects {
+		block, err := getContactBlock(ctx, contactBlockID(xPubID, subject), c.DefaultModelOptions()...)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil && !block.DeletedAt.Valid {
+			return block, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkContactRequester will check the requester of the contact invitation against the global and the xPub blocks,
+// returns true if the requester is muted (the invitation is dropped silently)
+func (c *Client) checkContactRequester(ctx context.Context, xPubID, requesterPaymail string) (bool, error) {
+	block, err := c.findContactBlock(ctx, "", requesterPaymail)
+	if err != nil {
+		return false, err
+	} else if block != nil {
+		return false, spverrors.ErrContactRequesterBlocked
+	}
+
+	if block, err = c.findContactBlock(ctx, xPubID, requesterPaymail); err != nil {
+		return false, err
+	} else if block == nil {
+		return false, nil
+	}
+
+	if block.Action == ContactBlockActionMute {
+		return true, nil
+	}
+	return false, spverrors.ErrContactRequesterBlocked
+}
+
+// useContactInvitationQuota will count the invitation from the domain of the requester (fails if the hourly limit is exceeded)
+func (c *Client) useContactInvitationQuota(ctx context.Context, xPubID, requesterPaymail string) error {
+	limit := c.GetPaymailConfig().InvitationRateLimit
+	if limit <= 0 {
+		return nil
+	}
+
+	_, domain, _ := paymail.SanitizePaymail(requesterPaymail)
+	cs := c.Cachestore()
+	key := fmt.Sprintf(cacheKeyContactInvitationQuota, xPubID, domain, time.Now().UTC().Format("2006010215"))
+
+	unlock, err := newWaitWriteLock(ctx, "lock-"+key, cs)
+	defer unlock()
+	if err != nil {
+		return err
+	}
+
+	var used int
+	if value, _ := cs.Get(ctx, key); value != "" {
+		used, _ = strconv.Atoi(value)
+	}
+	if used >= limit {
+		return spverrors.ErrContactInvitationRateLimited
+	}
+
+	err = cs.SetTTL(ctx, key, strconv.Itoa(used+1), time.Hour)
+	return spverrors.Wrapf(err, "failed to count the contact invitation")
+}
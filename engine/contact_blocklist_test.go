@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContactPubKey = "04c85162f06f5391028211a3683d669301fc72085458ce94d0a9e77ba4ff61f90a"
+
+func initContactBlocklistTestCase(t *testing.T, paymails []string, opts ...ClientOps) (context.Context, ClientInterface, func()) {
+	pt := &paymailTestMock{}
+	pt.setup(t, "winterfell.com", true)
+	for _, paymailAddr := range paymails {
+		pt.mockPki(paymailAddr, testContactPubKey)
+	}
+
+	opts = append([]ClientOps{withTaskManagerMockup(), WithFreeCache(), WithPaymailClient(pt.paymailClient)}, opts...)
+	ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, opts...)
+
+	_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+
+	return ctx, client, func() {
+		cleanup()
+		pt.cleanup()
+	}
+}
+
+// TestClient_AddContactRequest_Blocklist will test the blocked and muted requesters of the contact invitations
+func TestClient_AddContactRequest_Blocklist(t *testing.T) {
+	t.Run("blocked paymail is rejected", func(t *testing.T) {
+		ctx, client, cleanup := initContactBlocklistTestCase(t, nil)
+		defer cleanup()
+
+		_, err := client.BlockContact(ctx, csXpubHash, "Spammer@Winterfell.com", ContactBlockActionBlock)
+		require.NoError(t, err)
+
+		contact, err := client.AddContactRequest(ctx, "Spammer", "spammer@winterfell.com", csXpubHash, "")
+		require.ErrorIs(t, err, spverrors.ErrContactRequesterBlocked)
+		assert.Nil(t, contact)
+	})
+
+	t.Run("blocked parent domain is rejected", func(t *testing.T) {
+		ctx, client, cleanup := initContactBlocklistTestCase(t, nil)
+		defer cleanup()
+
+		_, err := client.BlockContact(ctx, csXpubHash, "winterfell.com", ContactBlockActionBlock)
+		require.NoError(t, err)
+
+		_, err = client.AddContactRequest(ctx, "Spammer", "spammer@pay.winterfell.com", csXpubHash, "")
+		require.ErrorIs(t, err, spverrors.ErrContactRequesterBlocked)
+	})
+
+	t.Run("global block is applied to all xPubs", func(t *testing.T) {
+		ctx, client, cleanup := initContactBlocklistTestCase(t, nil)
+		defer cleanup()
+
+		_, err := client.BlockContact(ctx, "", "winterfell.com", ContactBlockActionBlock)
+		require.NoError(t, err)
+
+		_, err = client.AddContactRequest(ctx, "Spammer", "spammer@winterfell.com", csXpubHash, "")
+		require.ErrorIs(t, err, spverrors.ErrContactRequesterBlocked)
+
+		_, err = client.BlockContact(ctx, "", "winterfell.com", ContactBlockActionMute)
+		require.ErrorIs(t, err, spverrors.ErrInvalidContactBlock)
+	})
+
+	t.Run("muted paymail is dropped silently", func(t *testing.T) {
+		ctx, client, cleanup := initContactBlocklistTestCase(t, nil)
+		defer cleanup()
+
+		_, err := client.BlockContact(ctx, csXpubHash, "spammer@winterfell.com", ContactBlockActionMute)
+		require.NoError(t, err)
+
+		contact, err := client.AddContactRequest(ctx, "Spammer", "spammer@winterfell.com", csXpubHash, "")
+		require.NoError(t, err)
+		assert.Nil(t, contact)
+
+		contact, err = getContact(ctx, "spammer@winterfell.com", csXpubHash, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Nil(t, contact)
+	})
+
+	t.Run("pending invitation is rejected by the block", func(t *testing.T) {
+		paymailAddr := "sansa_stark@winterfell.com"
+		ctx, client, cleanup := initContactBlocklistTestCase(t, []string{paymailAddr})
+		defer cleanup()
+
+		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, "")
+		require.NoError(t, err)
+		require.Equal(t, ContactAwaitAccept, contact.Status)
+
+		_, err = client.BlockContact(ctx, csXpubHash, "winterfell.com", ContactBlockActionMute)
+		require.NoError(t, err)
+
+		contact, err = getContact(ctx, paymailAddr, csXpubHash, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Nil(t, contact)
+	})
+
+	t.Run("unblocked paymail is accepted", func(t *testing.T) {
+		paymailAddr := "sansa_stark@winterfell.com"
+		ctx, client, cleanup := initContactBlocklistTestCase(t, []string{paymailAddr})
+		defer cleanup()
+
+		_, err := client.BlockContact(ctx, csXpubHash, paymailAddr, ContactBlockActionBlock)
+		require.NoError(t, err)
+		require.NoError(t, client.UnblockContact(ctx, csXpubHash, paymailAddr))
+		require.ErrorIs(t, client.UnblockContact(ctx, csXpubHash, paymailAddr), spverrors.ErrContactBlockNotFound)
+
+		blocks, err := client.GetContactBlocks(ctx, csXpubHash)
+		require.NoError(t, err)
+		assert.Empty(t, blocks)
+
+		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, "")
+		require.NoError(t, err)
+		require.NotNil(t, contact)
+
+		// the paymail can be blocked again
+		block, err := client.BlockContact(ctx, csXpubHash, paymailAddr, ContactBlockActionMute)
+		require.NoError(t, err)
+		assert.Equal(t, ContactBlockActionMute, block.Action)
+
+		blocks, err = client.GetContactBlocks(ctx, csXpubHash)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, paymailAddr, blocks[0].Paymail)
+		assert.Equal(t, "winterfell.com", blocks[0].Domain)
+	})
+
+	t.Run("invalid subject", func(t *testing.T) {
+		ctx, client, cleanup := initContactBlocklistTestCase(t, nil)
+		defer cleanup()
+
+		_, err := client.BlockContact(ctx, csXpubHash, "not a domain", ContactBlockActionBlock)
+		require.ErrorIs(t, err, spverrors.ErrInvalidContactBlock)
+
+		_, err = client.BlockContact(ctx, csXpubHash, "winterfell.com", ContactBlockAction("ignore"))
+		require.ErrorIs(t, err, spverrors.ErrInvalidContactBlock)
+	})
+}
+
+// TestClient_AddContactRequest_RateLimit will test the hourly limit of the invitations from a domain
+func TestClient_AddContactRequest_RateLimit(t *testing.T) {
+	paymails := []string{"sansa_stark@winterfell.com", "arya_stark@winterfell.com", "bran_stark@winterfell.com"}
+	ctx, client, cleanup := initContactBlocklistTestCase(t, paymails, WithPaymailContactInvitationRateLimit(2))
+	defer cleanup()
+
+	for _, paymailAddr := range paymails[:2] {
+		_, err := client.AddContactRequest(ctx, "Stark", paymailAddr, csXpubHash, "")
+		require.NoError(t, err)
+	}
+
+	_, err := client.AddContactRequest(ctx, "Stark", paymails[2], csXpubHash, "")
+	require.ErrorIs(t, err, spverrors.ErrContactInvitationRateLimited)
+
+	// the repeated invitation of the existing contact is not counted
+	_, err = client.AddContactRequest(ctx, "Stark", paymails[0], csXpubHash, "")
+	require.NoError(t, err)
+}
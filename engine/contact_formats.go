@@ -0,0 +1,277 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+)
+
+// ContactsFormat is the format of the imported and exported contacts
+type ContactsFormat string
+
+const (
+	// ContactsFormatCSV is the CSV file with the header (full_name, paymail, groups separated by semicolons)
+	ContactsFormatCSV ContactsFormat = "csv"
+
+	// ContactsFormatVCard is the vCard file (the paymail in X-PAYMAIL or EMAIL, the groups in CATEGORIES)
+	ContactsFormatVCard ContactsFormat = "vcard"
+)
+
+const (
+	csvGroupsSeparator = ";"
+	vCardPaymail       = "X-PAYMAIL"
+	vCardLineBreak     = "\r\n"
+)
+
+// csvColumns are the accepted names of the CSV columns (the first name is used by the export)
+var csvColumns = map[string][]string{
+	fullNameField:      {fullNameField, "fullname", "name"},
+	paymailField:       {paymailField, "email", "address"},
+	contactGroupsField: {contactGroupsField, "group", "tags", "categories"},
+}
+
+// contactEntry is the contact parsed from (or written to) the contacts file
+type contactEntry struct {
+	FullName string
+	Paymail  string
+	Groups   []string
+	Status   ContactStatus
+}
+
+// parseContacts will parse the contacts file of the given format
+func parseContacts(format ContactsFormat, data []byte) ([]*contactEntry, error) {
+	switch format {
+	case ContactsFormatCSV:
+		return parseContactsCSV(data)
+	case ContactsFormatVCard:
+		return parseContactsVCard(data)
+	default:
+		return nil, spverrors.ErrUnsupportedContactsFormat
+	}
+}
+
+// writeContacts will write the contacts file of the given format
+func writeContacts(format ContactsFormat, entries []*contactEntry) ([]byte, error) {
+	switch format {
+	case ContactsFormatCSV:
+		return writeContactsCSV(entries)
+	case ContactsFormatVCard:
+		return writeContactsVCard(entries), nil
+	default:
+		return nil, spverrors.ErrUnsupportedContactsFormat
+	}
+}
+
+// parseContactsCSV will parse the CSV file, the header is required (the columns are matched by their names)
+func parseContactsCSV(data []byte) ([]*contactEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "failed to read CSV header: %s", err.Error())
+	}
+
+	columns := make(map[string]int)
+	for index, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for column, names := range csvColumns {
+			if _, found := columns[column]; !found && utils.StringInSlice(name, names) {
+				columns[column] = index
+			}
+		}
+	}
+	if _, found := columns[paymailField]; !found {
+		return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "missing %s column in CSV header", paymailField)
+	}
+
+	column := func(record []string, name string) string {
+		index, found := columns[name]
+		if !found || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	var entries []*contactEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "failed to read CSV record: %s", err.Error())
+		}
+
+		entry := &contactEntry{
+			FullName: column(record, fullNameField),
+			Paymail:  column(record, paymailField),
+		}
+		if groups := column(record, contactGroupsField); groups != "" {
+			entry.Groups = strings.Split(groups, csvGroupsSeparator)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// writeContactsCSV will write the CSV file with the header
+func writeContactsCSV(entries []*contactEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	records := [][]string{{fullNameField, paymailField, contactGroupsField, contactStatusField}}
+	for _, entry := range entries {
+		records = append(records, []string{
+			entry.FullName, entry.Paymail, strings.Join(entry.Groups, csvGroupsSeparator), string(entry.Status),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return nil, spverrors.Wrapf(err, "failed to write contacts CSV")
+	}
+	return buffer.Bytes(), nil
+}
+
+// parseContactsVCard will parse the vCard file (one or more cards)
+func parseContactsVCard(data []byte) ([]*contactEntry, error) {
+	// Unfold the lines (the line starting with a space or a tab continues the previous one)
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\n ", "")
+	content = strings.ReplaceAll(content, "\n\t", "")
+
+	var entries []*contactEntry
+	var entry *contactEntry
+	var name string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		property, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "invalid vCard line: %s", line)
+		}
+		// Skip the parameters (EMAIL;TYPE=INTERNET) and the group of the property (item1.EMAIL)
+		property, _, _ = strings.Cut(property, ";")
+		if index := strings.LastIndex(property, "."); index >= 0 {
+			property = property[index+1:]
+		}
+		property = strings.ToUpper(property)
+
+		switch {
+		case property == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			if entry != nil {
+				return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "vCard is not ended")
+			}
+			entry, name = &contactEntry{}, ""
+		case property == "END" && strings.EqualFold(value, "VCARD"):
+			if entry == nil {
+				return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "vCard is not started")
+			}
+			if entry.FullName == "" {
+				entry.FullName = name
+			}
+			entries = append(entries, entry)
+			entry = nil
+		case entry == nil:
+			return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "property %s outside of vCard", property)
+		case property == "FN":
+			entry.FullName = vCardUnescape(value)
+		case property == "N":
+			// Family name; given name; additional names; prefixes; suffixes
+			parts := vCardSplit(value, ';')
+			if len(parts) > 1 {
+				parts[0], parts[1] = parts[1], parts[0]
+			}
+			name = strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+		case property == vCardPaymail:
+			entry.Paymail = vCardUnescape(value)
+		case property == "EMAIL" && entry.Paymail == "":
+			entry.Paymail = vCardUnescape(value)
+		case property == "CATEGORIES":
+			entry.Groups = append(entry.Groups, vCardSplit(value, ',')...)
+		}
+	}
+
+	if entry != nil {
+		return nil, spverrors.Wrapf(spverrors.ErrInvalidContactsFile, "vCard is not ended")
+	}
+	return entries, nil
+}
+
+// writeContactsVCard will write the vCard (version 3.0) file with the card of each contact
+func writeContactsVCard(entries []*contactEntry) []byte {
+	var builder strings.Builder
+	for _, entry := range entries {
+		builder.WriteString("BEGIN:VCARD" + vCardLineBreak)
+		builder.WriteString("VERSION:3.0" + vCardLineBreak)
+		builder.WriteString("FN:" + vCardEscape(entry.FullName) + vCardLineBreak)
+		builder.WriteString("N:;" + vCardEscape(entry.FullName) + ";;;" + vCardLineBreak)
+		builder.WriteString(vCardPaymail + ":" + vCardEscape(entry.Paymail) + vCardLineBreak)
+		if len(entry.Groups) > 0 {
+			groups := make([]string, 0, len(entry.Groups))
+			for _, group := range entry.Groups {
+				groups = append(groups, vCardEscape(group))
+			}
+			builder.WriteString("CATEGORIES:" + strings.Join(groups, ",") + vCardLineBreak)
+		}
+		builder.WriteString("END:VCARD" + vCardLineBreak)
+	}
+	return []byte(builder.String())
+}
+
+// vCardEscape will escape the text value of the vCard property
+func vCardEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`).Replace(value)
+}
+
+// vCardUnescape will unescape the text value of the vCard property
+func vCardUnescape(value string) string {
+	parts := vCardSplit(value, 0)
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+// vCardSplit will split the value by the unescaped separator and unescape the parts (empty parts are skipped)
+func vCardSplit(value string, separator rune) []string {
+	var parts []string
+	var part strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(part.String()); text != "" {
+			parts = append(parts, text)
+		}
+		part.Reset()
+	}
+
+	escaped := false
+	for _, char := range value {
+		switch {
+		case escaped:
+			if char == 'n' || char == 'N' {
+				char = '\n'
+			}
+			part.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case separator != 0 && char == separator:
+			flush()
+		default:
+			part.WriteRune(char)
+		}
+	}
+	flush()
+	return parts
+}
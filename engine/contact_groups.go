@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+)
+
+// maxContactGroupLength is the max length of the name of the contact group (tag)
+const maxContactGroupLength = 64
+
+// contactGroupForbiddenChars are the separators of the groups in the imported and exported contacts (and the JSON quotes)
+const contactGroupForbiddenChars = `,;"\`
+
+// SetContactGroups will replace the groups (tags) of the contact, the empty list removes the contact from all the groups
+func (c *Client) SetContactGroups(ctx context.Context, xPubID, paymailAddress string, groups []string) (*Contact, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "set_contact_groups")
+
+	normalized, err := normalizeContactGroups(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := getContact(ctx, paymailAddress, xPubID, c.DefaultModelOptions()...)
+	if err != nil {
+		c.logContactError(xPubID, paymailAddress, fmt.Sprintf("unexpected error while getting contact: %s", err.Error()))
+		return nil, err
+	}
+	if contact == nil {
+		return nil, spverrors.ErrContactNotFound
+	}
+
+	contact.Groups = normalized
+	if err = contact.Save(ctx); err != nil {
+		c.logContactError(xPubID, paymailAddress, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
+		return nil, spverrors.ErrSaveContact
+	}
+
+	return contact, nil
+}
+
+// normalizeContactGroups will trim the names of the groups and remove the empty and the duplicated ones
+func normalizeContactGroups(groups []string) (IDs, error) {
+	normalized := IDs{}
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group == "" || utils.StringInSlice(group, normalized) {
+			continue
+		}
+		if len(group) > maxContactGroupLength {
+			return nil, spverrors.Wrapf(spverrors.ErrInvalidContactGroup, "group %s is longer than %d characters", group, maxContactGroupLength)
+		}
+		if strings.ContainsAny(group, contactGroupForbiddenChars) {
+			return nil, spverrors.Wrapf(spverrors.ErrInvalidContactGroup, "group %s cannot contain any of %s", group, contactGroupForbiddenChars)
+		}
+		normalized = append(normalized, group)
+	}
+	return normalized, nil
+}
+
+// addGroups will add the groups to the contact (the existing groups are kept)
+func (m *Contact) addGroups(groups IDs) {
+	for _, group := range groups {
+		if !utils.StringInSlice(group, m.Groups) {
+			m.Groups = append(m.Groups, group)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// maxImportedContacts is the max number of the contacts in a single import
+const maxImportedContacts = 1000
+
+// ContactsImportResult is the result of the import of the contacts
+type ContactsImportResult struct {
+	Imported []*Contact              // Added (or updated) contacts
+	Failed   []*ContactImportFailure // Entries of the file which were not imported (or not invited)
+}
+
+// ContactImportFailure is the entry of the imported file which failed
+type ContactImportFailure struct {
+	Entry   int    // Position of the entry in the file (starting from 1)
+	Paymail string // Paymail of the entry
+	Reason  string // Reason of the failure
+}
+
+// ImportContacts will add (or update) the contacts from the CSV or vCard file,
+// the contacts are invited via PIKE if requested (the same as UpsertContact), otherwise they are only saved
+func (c *Client) ImportContacts(ctx context.Context, xPubID, requesterPaymail string, format ContactsFormat,
+	data []byte, invite bool, opts ...ModelOps,
+) (*ContactsImportResult, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "import_contacts")
+
+	entries, err := parseContacts(format, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > maxImportedContacts {
+		return nil, spverrors.Wrapf(spverrors.ErrTooManyContactsToImport, "max %d contacts can be imported at once", maxImportedContacts)
+	}
+
+	// The invitations are sent from the paymail of the user
+	if invite {
+		if _, err = c.getPaymail(ctx, xPubID, requesterPaymail); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ContactsImportResult{
+		Imported: make([]*Contact, 0, len(entries)),
+		Failed:   make([]*ContactImportFailure, 0),
+	}
+	for index, entry := range entries {
+		contact, err := c.importContact(ctx, xPubID, requesterPaymail, entry, invite, opts...)
+		if contact != nil {
+			result.Imported = append(result.Imported, contact)
+		}
+		if err != nil {
+			c.logContactWarining(xPubID, entry.Paymail, fmt.Sprintf("contact import failed: %s", err.Error()))
+			result.Failed = append(result.Failed, &ContactImportFailure{
+				Entry:   index + 1,
+				Paymail: entry.Paymail,
+				Reason:  err.Error(),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// importContact will add (or update) the contact of the imported entry,
+// returns the contact with the error if the contact was saved but the invitation failed
+func (c *Client) importContact(ctx context.Context, xPubID, requesterPaymail string, entry *contactEntry,
+	invite bool, opts ...ModelOps,
+) (*Contact, error) {
+	groups, err := normalizeContactGroups(entry.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	pmSrvnt := &PaymailServant{
+		cs: c.Cachestore(),
+		pc: c.PaymailClient(),
+	}
+	contactPm, err := pmSrvnt.GetSanitizedPaymail(entry.Paymail)
+	if err != nil {
+		return nil, spverrors.ErrInvalidContactPaymail
+	}
+
+	fullName := entry.FullName
+	if fullName == "" {
+		fullName = contactPm.Address
+	}
+
+	var contact *Contact
+	var inviteErr error
+	if invite {
+		contact, err = c.UpsertContact(ctx, fullName, contactPm.Address, xPubID, requesterPaymail, opts...)
+		if errors.Is(err, spverrors.ErrAddingContactRequest) && contact != nil {
+			inviteErr, err = err, nil
+		}
+	} else {
+		contact, err = c.upsertContact(ctx, pmSrvnt, xPubID, "", fullName, contactPm, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) > 0 {
+		contact.addGroups(groups)
+		if err = contact.Save(ctx); err != nil {
+			c.logContactError(xPubID, contact.Paymail, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
+			return nil, spverrors.ErrSaveContact
+		}
+	}
+
+	return contact, inviteErr
+}
+
+// ExportContacts will export the contacts of the xPub (filtered by the conditions, e.g. by the group) to the CSV or vCard file
+func (c *Client) ExportContacts(ctx context.Context, xPubID string, format ContactsFormat, metadata *Metadata,
+	conditions map[string]interface{},
+) ([]byte, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "export_contacts")
+
+	dbConditions := map[string]interface{}{}
+	for key, value := range conditions {
+		dbConditions[key] = value
+	}
+	dbConditions[deletedAtField] = nil
+
+	contacts, err := getContactsByXpubID(ctx, xPubID, metadata, dbConditions, nil, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*contactEntry, 0, len(contacts))
+	for _, contact := range contacts {
+		entries = append(entries, &contactEntry{
+			FullName: contact.FullName,
+			Paymail:  contact.Paymail,
+			Groups:   contact.Groups,
+			Status:   contact.Status,
+		})
+	}
+
+	return writeContacts(format, entries)
+}
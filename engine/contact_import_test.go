@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseContacts will test the parsing of the imported contacts files
+func TestParseContacts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("csv with header", func(t *testing.T) {
+		data := "\ufeffName, Paymail, Tags\n" +
+			"Sansa Stark,sansa_stark@winterfell.com,family;north\n" +
+			"\"Stark, Arya\",arya_stark@winterfell.com,\n"
+
+		entries, err := parseContacts(ContactsFormatCSV, []byte(data))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, &contactEntry{FullName: "Sansa Stark", Paymail: "sansa_stark@winterfell.com", Groups: []string{"family", "north"}}, entries[0])
+		assert.Equal(t, &contactEntry{FullName: "Stark, Arya", Paymail: "arya_stark@winterfell.com"}, entries[1])
+	})
+
+	t.Run("csv without paymail column", func(t *testing.T) {
+		_, err := parseContacts(ContactsFormatCSV, []byte("name,phone\nSansa,123\n"))
+		require.ErrorIs(t, err, spverrors.ErrInvalidContactsFile)
+	})
+
+	t.Run("vcard", func(t *testing.T) {
+		data := "BEGIN:VCARD\r\nVERSION:3.0\r\nN:Stark;Sansa;;;\r\nitem1.EMAIL;TYPE=INTERNET:sansa_stark@winterfell.com\r\n" +
+			"CATEGORIES:family,north\r\nEND:VCARD\r\n" +
+			"BEGIN:VCARD\nVERSION:3.0\nFN:Arya\\, the\n  Stark\nEMAIL:arya@gmail.com\nX-PAYMAIL:arya_stark@winterfell.com\nEND:VCARD\n"
+
+		entries, err := parseContacts(ContactsFormatVCard, []byte(data))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, &contactEntry{FullName: "Sansa Stark", Paymail: "sansa_stark@winterfell.com", Groups: []string{"family", "north"}}, entries[0])
+		assert.Equal(t, &contactEntry{FullName: "Arya, the Stark", Paymail: "arya_stark@winterfell.com"}, entries[1])
+	})
+
+	t.Run("vcard not ended", func(t *testing.T) {
+		_, err := parseContacts(ContactsFormatVCard, []byte("BEGIN:VCARD\nFN:Sansa\n"))
+		require.ErrorIs(t, err, spverrors.ErrInvalidContactsFile)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := parseContacts(ContactsFormat("xml"), []byte("<contacts/>"))
+		require.ErrorIs(t, err, spverrors.ErrUnsupportedContactsFormat)
+	})
+
+	t.Run("exported contacts can be imported", func(t *testing.T) {
+		entries := []*contactEntry{
+			{FullName: "Stark; Sansa", Paymail: "sansa_stark@winterfell.com", Groups: []string{"family", "north"}},
+			{FullName: "Arya", Paymail: "arya_stark@winterfell.com"},
+		}
+		for _, format := range []ContactsFormat{ContactsFormatCSV, ContactsFormatVCard} {
+			data, err := writeContacts(format, entries)
+			require.NoError(t, err)
+
+			parsed, err := parseContacts(format, data)
+			require.NoError(t, err)
+			assert.Equal(t, entries, parsed, format)
+		}
+	})
+}
+
+// TestNormalizeContactGroups will test the method normalizeContactGroups()
+func TestNormalizeContactGroups(t *testing.T) {
+	t.Parallel()
+
+	groups, err := normalizeContactGroups([]string{" family ", "", "friends", "family"})
+	require.NoError(t, err)
+	assert.Equal(t, IDs{"family", "friends"}, groups)
+
+	_, err = normalizeContactGroups([]string{"family;friends"})
+	require.ErrorIs(t, err, spverrors.ErrInvalidContactGroup)
+}
+
+// TestClient_ImportContacts will test the import, the groups and the export of the contacts
+func TestClient_ImportContacts(t *testing.T) {
+	paymails := []string{"sansa_stark@winterfell.com", "arya_stark@winterfell.com"}
+	ctx, client, cleanup := initContactBlocklistTestCase(t, paymails)
+	defer cleanup()
+
+	data := "full_name,paymail,groups\n" +
+		"Sansa Stark,sansa_stark@winterfell.com,family;north\n" +
+		"Arya Stark,arya_stark@winterfell.com,family\n" +
+		"Jon Snow,not a paymail,\n"
+
+	result, err := client.ImportContacts(ctx, csXpubHash, "", ContactsFormatCSV, []byte(data), false)
+	require.NoError(t, err)
+	require.Len(t, result.Imported, 2)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, 3, result.Failed[0].Entry)
+	assert.Equal(t, ContactNotConfirmed, result.Imported[0].Status)
+	assert.Equal(t, IDs{"family", "north"}, result.Imported[0].Groups)
+
+	// the groups of the imported contacts are merged
+	result, err = client.ImportContacts(ctx, csXpubHash, "", ContactsFormatCSV,
+		[]byte("paymail,groups\narya_stark@winterfell.com,travel\n"), false)
+	require.NoError(t, err)
+	require.Len(t, result.Imported, 1)
+	assert.Equal(t, IDs{"family", "travel"}, result.Imported[0].Groups)
+
+	contact, err := client.SetContactGroups(ctx, csXpubHash, paymails[0], []string{"north"})
+	require.NoError(t, err)
+	assert.Equal(t, IDs{"north"}, contact.Groups)
+
+	contacts, err := client.GetContactsByXpubID(ctx, csXpubHash, nil, map[string]interface{}{contactGroupsField: "family"}, nil)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, paymails[1], contacts[0].Paymail)
+
+	exported, err := client.ExportContacts(ctx, csXpubHash, ContactsFormatVCard, nil, map[string]interface{}{contactGroupsField: "north"})
+	require.NoError(t, err)
+	entries, err := parseContacts(ContactsFormatVCard, exported)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Sansa Stark", entries[0].FullName)
+
+	_, err = client.SetContactGroups(ctx, csXpubHash, "jon_snow@winterfell.com", nil)
+	require.ErrorIs(t, err, spverrors.ErrContactNotFound)
+}
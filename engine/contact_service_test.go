@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/bitcoin-sv/go-paymail"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
@@ -179,6 +180,43 @@ func Test_ClientService_UpsertContact(t *testing.T) {
 		// status should back to unconfirmed
 		require.Equal(t, ContactNotConfirmed, updatedContact.Status)
 	})
+
+	t.Run("update contact - cached PKI is refreshed", func(t *testing.T) {
+		// given
+		paymailAddr := "rickon_stark@winterfell.com"
+		updatedPki := "03c85162f06f5391028211a3683d669301fc72085458ce94d0a9e77ba4ff61f90b"
+
+		pt := &paymailTestMock{}
+		pt.setup(t, "winterfell.com", true)
+		defer pt.cleanup()
+
+		pt.mockPki(paymailAddr, "04c85162f06f5391028211a3683d669301fc72085458ce94d0a9e77ba4ff61f90a")
+		pt.mockPike(paymailAddr)
+
+		paymailClient := newPolicyPaymailClient(pt.paymailClient, &PaymailClientPolicy{PKITTL: time.Hour})
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithFreeCache(), WithPaymailClient(paymailClient))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "lady_stoneheart@winterfell.com", "Catelyn Stark", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		contact, err := client.UpsertContact(ctx, "Rickon Stark", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.NotNil(t, contact)
+
+		// when
+		pt.mockPki(paymailAddr, updatedPki)
+
+		updatedContact, err := client.UpsertContact(ctx, "Rickon Stark", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, updatedContact)
+		require.Equal(t, updatedPki, updatedContact.PubKey)
+	})
 }
 
 func TestClientService_AddContactRequest(t *testing.T) {
@@ -203,7 +241,7 @@ func TestClientService_AddContactRequest(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		res, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, client.DefaultModelOptions()...)
+		res, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
 
 		// then
 		require.NoError(t, err)
@@ -231,7 +269,7 @@ func TestClientService_AddContactRequest(t *testing.T) {
 		_, err = client.NewPaymailAddress(ctx, csXpub, "lady_stoneheart@winterfell.com", "Catelyn Stark", "", client.DefaultModelOptions()...)
 		require.NoError(t, err)
 
-		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, client.DefaultModelOptions()...)
+		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		require.NotNil(t, contact)
 
@@ -241,7 +279,7 @@ func TestClientService_AddContactRequest(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		updatedContact, err := client.AddContactRequest(ctx, "Alayne Stone", paymailAddr, csXpubHash, client.DefaultModelOptions()...)
+		updatedContact, err := client.AddContactRequest(ctx, "Alayne Stone", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
 
 		// then
 		require.NoError(t, err)
@@ -273,7 +311,7 @@ func TestClientService_AddContactRequest(t *testing.T) {
 		_, err = client.NewPaymailAddress(ctx, csXpub, "lady_stoneheart@winterfell.com", "Catelyn Stark", "", client.DefaultModelOptions()...)
 		require.NoError(t, err)
 
-		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, client.DefaultModelOptions()...)
+		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		require.NotNil(t, contact)
 
@@ -286,7 +324,7 @@ func TestClientService_AddContactRequest(t *testing.T) {
 		// change PKI
 		pt.mockPki(paymailAddr, updatedPki)
 
-		updatedContact, err := client.AddContactRequest(ctx, "Alayne Stone", paymailAddr, csXpubHash, client.DefaultModelOptions()...)
+		updatedContact, err := client.AddContactRequest(ctx, "Alayne Stone", paymailAddr, csXpubHash, "", client.DefaultModelOptions()...)
 
 		// then
 		require.NoError(t, err)
@@ -298,6 +336,95 @@ func TestClientService_AddContactRequest(t *testing.T) {
 		// status should back to awaiting
 		require.Equal(t, ContactNotConfirmed, updatedContact.Status)
 	})
+
+	t.Run("add contact - already exist, cached PKI is refreshed", func(t *testing.T) {
+		// given
+		paymailAddr := "sansa_stark@winterfell.com"
+		updatedPki := "03c85162f06f5391028211a3683d669301fc72085458ce94d0a9e77ba4ff61f90b"
+
+		pt := &paymailTestMock{}
+		pt.setup(t, "winterfell.com", true)
+		defer pt.cleanup()
+
+		pt.mockPki(paymailAddr, "04c85162f06f5391028211a3683d669301fc72085458ce94d0a9e77ba4ff61f90a")
+		pt.mockPike(paymailAddr)
+
+		paymailClient := newPolicyPaymailClient(pt.paymailClient, &PaymailClientPolicy{PKITTL: time.Hour})
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithFreeCache(), WithPaymailClient(paymailClient))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		requesterPaymail := "lady_stoneheart@winterfell.com"
+		_, err = client.NewPaymailAddress(ctx, csXpub, requesterPaymail, "Catelyn Stark", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		contact, err := client.AddContactRequest(ctx, "Sansa Stark", paymailAddr, csXpubHash, requesterPaymail, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.NotNil(t, contact)
+		require.Equal(t, requesterPaymail, contact.OwnerPaymail)
+
+		// when
+		pt.mockPki(paymailAddr, updatedPki)
+
+		updatedContact, err := client.AddContactRequest(ctx, "Alayne Stone", paymailAddr, csXpubHash, requesterPaymail, client.DefaultModelOptions()...)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, updatedContact)
+		require.Equal(t, updatedPki, updatedContact.PubKey)
+	})
+}
+
+func TestClientService_GetContactProfile(t *testing.T) {
+	t.Run("get contact profile", func(t *testing.T) {
+		// given
+		paymailAddr := "bran_the_broken@winterfell.com"
+
+		pt := &paymailTestMock{}
+		pt.setup(t, "winterfell.com", true)
+		defer pt.cleanup()
+
+		pt.mockPublicProfile(paymailAddr, "Bran Stark", "https://winterfell.com/bran.png")
+
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailClient(pt.paymailClient))
+		defer cleanup()
+
+		contact := newContact("Bran", paymailAddr, pubKey, csXpubHash, ContactConfirmed)
+		contact.enrich(ModelContact, append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, contact.Save(ctx))
+
+		// when
+		profile, err := client.GetContactProfile(ctx, csXpubHash, paymailAddr)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, "Bran Stark", profile.Name)
+		require.Equal(t, "https://winterfell.com/bran.png", profile.Avatar)
+
+		// when
+		// profile is cached
+		httpmock.Reset()
+		profile, err = client.GetContactProfile(ctx, csXpubHash, paymailAddr)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, "Bran Stark", profile.Name)
+	})
+
+	t.Run("get contact profile - contact not found", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup())
+		defer cleanup()
+
+		// when
+		profile, err := client.GetContactProfile(ctx, csXpubHash, "bran_the_broken@winterfell.com")
+
+		// then
+		require.ErrorIs(t, err, spverrors.ErrContactNotFound)
+		require.Nil(t, profile)
+	})
 }
 
 type paymailTestMock struct {
@@ -311,8 +438,11 @@ func (p *paymailTestMock) setup(t *testing.T, domain string, supportPike bool) {
 
 	wellKnownURL := fmt.Sprintf("https://%s:443/.well-known/%s", domain, paymail.DefaultServiceName)
 	wellKnownBody := paymail.CapabilitiesPayload{
-		BsvAlias:     paymail.DefaultBsvAliasVersion,
-		Capabilities: map[string]interface{}{paymail.BRFCPki: fmt.Sprintf("%s/id/{alias}@{domain.tld}", serverURL)},
+		BsvAlias: paymail.DefaultBsvAliasVersion,
+		Capabilities: map[string]interface{}{
+			paymail.BRFCPki:           fmt.Sprintf("%s/id/{alias}@{domain.tld}", serverURL),
+			paymail.BRFCPublicProfile: fmt.Sprintf("%s/public-profile/{alias}@{domain.tld}", serverURL),
+		},
 	}
 
 	if supportPike {
@@ -344,6 +474,15 @@ func (p *paymailTestMock) mockPki(paymail, pubkey string) {
 	)
 }
 
+func (p *paymailTestMock) mockPublicProfile(paymail, name, avatar string) {
+	httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("%s/public-profile/%s", p.serverURL, paymail),
+		httpmock.NewStringResponder(
+			200,
+			`{"name":"`+name+`","avatar":"`+avatar+`"}`,
+		),
+	)
+}
+
 func (p *paymailTestMock) mockPike(paymail string) {
 	httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf("%s/contact/invite/%s", p.serverURL, paymail),
 		httpmock.NewStringResponder(
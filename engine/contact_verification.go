@@ -0,0 +1,281 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/types/type42"
+	"github.com/libsv/go-bk/bec"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	defaultContactVerificationPeriod      = 5 * time.Minute
+	defaultContactVerificationDigits      = 6
+	defaultContactVerificationMaxAttempts = 5
+	defaultContactVerificationLockout     = 15 * time.Minute
+
+	// contactVerificationInvoice is the invoice number of the type42 keys linked for the contact verification
+	contactVerificationInvoice = "contact-verification"
+)
+
+// ContactVerificationOptions are the options of the contact verification via the shared time-based codes
+//
+// Both parties derive the same secret from the type42 keys linked to their PKI keys (ECDH of the own linked private key
+// and the linked public key of the contact), each party shares its code with the contact (e.g. by phone)
+// and the contact is confirmed only with a valid code.
+// The PKI private keys are derived from the xPrivs, so the codes require the paymail key provider (WithPaymailKeyProvider),
+// without the key provider the contacts are confirmed without the code
+type ContactVerificationOptions struct {
+	Period      time.Duration // Validity of the code (the previous code is still accepted)
+	Digits      uint          // Number of the digits of the code (6 or 8)
+	MaxAttempts uint32        // Number of the failed codes after which the verification is locked
+	Lockout     time.Duration // Time for which the verification stays locked
+}
+
+// defaultContactVerificationOptions will return the default options of the contact verification
+func defaultContactVerificationOptions() *ContactVerificationOptions {
+	return &ContactVerificationOptions{
+		Period:      defaultContactVerificationPeriod,
+		Digits:      defaultContactVerificationDigits,
+		MaxAttempts: defaultContactVerificationMaxAttempts,
+		Lockout:     defaultContactVerificationLockout,
+	}
+}
+
+// Validate will check the options of the contact verification
+func (o *ContactVerificationOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Period < time.Second {
+		return spverrors.Wrapf(spverrors.ErrInvalidContactVerificationOptions, "period must be at least one second")
+	}
+	if o.Digits != uint(otp.DigitsSix) && o.Digits != uint(otp.DigitsEight) {
+		return spverrors.Wrapf(spverrors.ErrInvalidContactVerificationOptions, "digits must be 6 or 8")
+	}
+	if o.MaxAttempts == 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidContactVerificationOptions, "max attempts must be greater than zero")
+	}
+	if o.Lockout < 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidContactVerificationOptions, "lockout cannot be negative")
+	}
+	return nil
+}
+
+// validateOpts will return the options of the generated and validated codes
+func (o *ContactVerificationOptions) validateOpts() totp.ValidateOpts {
+	return totp.ValidateOpts{
+		Period:    uint(o.Period / time.Second),
+		Skew:      1,
+		Digits:    otp.Digits(o.Digits),
+		Algorithm: otp.AlgorithmSHA1,
+	}
+}
+
+// ContactTotp is the verification code which the user shares with the contact
+type ContactTotp struct {
+	Passcode  string        // Code to share with the contact
+	Period    time.Duration // Validity of the code
+	ExpiresAt time.Time     // Time after which the contact does not accept the code
+}
+
+// getContactVerificationOptions will get the options of the contact verification (the defaults if not set)
+func (c *Client) getContactVerificationOptions() *ContactVerificationOptions {
+	if config := c.GetPaymailConfig(); config != nil && config.ContactVerification != nil {
+		return config.ContactVerification
+	}
+	return defaultContactVerificationOptions()
+}
+
+// GenerateContactTotp will generate the code which the user shares with the contact to be confirmed by the contact
+func (c *Client) GenerateContactTotp(ctx context.Context, xPubID, paymailAddress, requesterPaymail string) (*ContactTotp, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "generate_contact_totp")
+
+	contact, err := getContact(ctx, paymailAddress, xPubID, c.DefaultModelOptions()...)
+	if err != nil {
+		c.logContactError(xPubID, paymailAddress, fmt.Sprintf("unexpected error while getting contact: %s", err.Error()))
+		return nil, err
+	}
+	if contact == nil {
+		return nil, spverrors.ErrContactNotFound
+	}
+	if contact.Status != ContactNotConfirmed && contact.Status != ContactConfirmed {
+		return nil, spverrors.ErrContactIncorrectStatus
+	}
+
+	// The code is directed to the contact (the contact validates it with its own PKI key)
+	secret, _, err := c.contactSharedSecret(ctx, contact, requesterPaymail)
+	if err != nil {
+		return nil, err
+	}
+
+	options := c.getContactVerificationOptions()
+	now := time.Now().UTC()
+	passcode, err := totp.GenerateCodeCustom(directedSecret(secret, contact.PubKey), now, options.validateOpts())
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to generate contact verification code")
+	}
+
+	// The code is valid until the end of its period and the next period (skew)
+	periodStart := now.Truncate(options.Period)
+	return &ContactTotp{
+		Passcode:  passcode,
+		Period:    options.Period,
+		ExpiresAt: periodStart.Add(2 * options.Period),
+	}, nil
+}
+
+// ConfirmContact marks the contact as confirmed after validating the code generated (and shared) by the contact,
+// without the paymail key provider the codes are not available and the contact is confirmed without the code
+func (c *Client) ConfirmContact(ctx context.Context, xPubID, paymailAddress, requesterPaymail, passcode string) error {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "confirm_contact")
+
+	withPasscode := c.GetPaymailConfig().KeyProvider != nil
+	if withPasscode && passcode == "" {
+		return spverrors.ErrMissingContactPasscode
+	}
+
+	contact, err := getContact(ctx, paymailAddress, xPubID, c.DefaultModelOptions()...)
+	if err != nil {
+		c.logContactError(xPubID, paymailAddress, fmt.Sprintf("unexpected error while getting contact: %s", err.Error()))
+		return err
+	}
+	if contact == nil {
+		return spverrors.ErrContactNotFound
+	}
+	if contact.Status != ContactNotConfirmed {
+		c.logContactWarining(xPubID, paymailAddress, fmt.Sprintf("cannot confirm contact. Reason: status: %s, expected: %s", contact.Status, ContactNotConfirmed))
+		return spverrors.ErrContactIncorrectStatus
+	}
+
+	if withPasscode {
+		if err = c.verifyContactPasscode(ctx, contact, requesterPaymail, passcode); err != nil {
+			return err
+		}
+	}
+
+	if err = contact.Confirm(); err != nil {
+		c.logContactWarining(xPubID, paymailAddress, err.Error())
+		return spverrors.ErrContactIncorrectStatus
+	}
+	contact.resetVerification()
+
+	if err = contact.Save(ctx); err != nil {
+		c.logContactError(xPubID, paymailAddress, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
+		return spverrors.ErrSaveContact
+	}
+
+	return nil
+}
+
+// verifyContactPasscode will validate the code of the contact, the failed codes are counted and lock the verification
+func (c *Client) verifyContactPasscode(ctx context.Context, contact *Contact, requesterPaymail, passcode string) error {
+	options := c.getContactVerificationOptions()
+	now := time.Now().UTC()
+	if contact.VerificationLockedUntil.Valid && now.Before(contact.VerificationLockedUntil.Time) {
+		return spverrors.ErrContactVerificationLocked
+	}
+
+	// The code of the contact is directed to the user (validated with the PKI key of the user)
+	secret, ownPubKey, err := c.contactSharedSecret(ctx, contact, requesterPaymail)
+	if err != nil {
+		return err
+	}
+
+	valid, err := totp.ValidateCustom(passcode, directedSecret(secret, ownPubKey), now, options.validateOpts())
+	if err != nil || !valid {
+		locked := contact.failVerification(options, now)
+		if err = contact.Save(ctx); err != nil {
+			c.logContactError(contact.OwnerXpubID, contact.Paymail, fmt.Sprintf("unexpected error while saving contact: %s", err.Error()))
+			return spverrors.ErrSaveContact
+		}
+		if locked {
+			return spverrors.ErrContactVerificationLocked
+		}
+		return spverrors.ErrContactVerificationFailed
+	}
+
+	return nil
+}
+
+// contactSharedSecret will derive the secret shared with the contact (ECDH of the type42 keys linked to the PKI keys
+// of the both paymails), returns also the PKI key of the user's paymail
+func (c *Client) contactSharedSecret(ctx context.Context, contact *Contact, requesterPaymail string) ([]byte, string, error) {
+	pm, err := c.getPaymail(ctx, contact.OwnerXpubID, requesterPaymail)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// SPV Wallet holds only the xPubs, the PKI keys are available only with the key provider (paymail key_provider config)
+	keyProvider := c.GetPaymailConfig().KeyProvider
+	if keyProvider == nil {
+		return nil, "", spverrors.ErrContactVerificationUnavailable
+	}
+	xPriv, err := keyProvider.GetXPriv(ctx, pm.XpubID)
+	if err != nil {
+		return nil, "", spverrors.Wrapf(err, "failed to get xPriv of the paymail")
+	}
+	privateKey, err := pm.getPKIPrivateKey(xPriv)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contactPubKey, err := getPublicKey(contact.PubKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Own key linked with the contact's PKI key, and the contact's key linked with the own PKI key (the same on both sides)
+	linkedPrivateKey, err := type42.DeriveLinkedPrivateKey(contactPubKey, privateKey, contactVerificationInvoice)
+	if err != nil {
+		return nil, "", spverrors.Wrapf(err, "failed to derive the linked private key")
+	}
+	linkedContactPubKey, err := type42.DeriveLinkedKey(privateKey.PubKey(), contactPubKey, contactVerificationInvoice)
+	if err != nil {
+		return nil, "", spverrors.Wrapf(err, "failed to derive the linked public key of the contact")
+	}
+
+	return sharedSecret(linkedPrivateKey, linkedContactPubKey), hex.EncodeToString(privateKey.PubKey().SerialiseCompressed()), nil
+}
+
+// sharedSecret will compute the ECDH shared secret (the same for both parties)
+func sharedSecret(privateKey *bec.PrivateKey, pubKey *bec.PublicKey) []byte {
+	x, y := bec.S256().ScalarMult(pubKey.X, pubKey.Y, privateKey.D.Bytes())
+	return (&bec.PublicKey{Curve: bec.S256(), X: x, Y: y}).SerialiseCompressed()
+}
+
+// directedSecret will derive the secret of the codes validated by the owner of the public key,
+// so the code of the user cannot be replayed as the code of the contact
+func directedSecret(secret []byte, pubKey string) string {
+	hash := sha256.Sum256(append(append([]byte{}, secret...), []byte(pubKey)...))
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(hash[:])
+}
+
+// failVerification will count the failed code, returns true if the verification gets locked
+func (m *Contact) failVerification(options *ContactVerificationOptions, now time.Time) bool {
+	m.VerificationAttempts++
+	if m.VerificationAttempts < options.MaxAttempts {
+		return false
+	}
+
+	m.VerificationAttempts = 0
+	m.VerificationLockedUntil.Valid = true
+	m.VerificationLockedUntil.Time = now.Add(options.Lockout)
+	return true
+}
+
+// resetVerification will reset the failed codes of the contact
+func (m *Contact) resetVerification() {
+	m.VerificationAttempts = 0
+	m.VerificationLockedUntil.Valid = false
+	m.VerificationLockedUntil.Time = time.Time{}
+}
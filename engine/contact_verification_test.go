@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testXPubKeyProvider is a PaymailKeyProvider with the xPrivs of multiple xPubs
+type testXPubKeyProvider map[string]*bip32.ExtendedKey
+
+func (p testXPubKeyProvider) GetXPriv(_ context.Context, xPubID string) (*bip32.ExtendedKey, error) {
+	return p[xPubID], nil
+}
+
+// contactVerificationTestCase are two paymails having each other as the unconfirmed contact
+type contactVerificationTestCase struct {
+	alice, bob               *PaymailAddress
+	aliceContact, bobContact *Contact // contact of Alice (owned by Bob) and contact of Bob (owned by Alice)
+}
+
+func initContactVerificationTestCase(t *testing.T, opts ...ClientOps) (context.Context, ClientInterface, *contactVerificationTestCase, func()) {
+	xPrivs := generateTestXPrivs(t, 2)
+	xPubs := neuterTestXPrivs(t, xPrivs)
+
+	keyProvider := testXPubKeyProvider{}
+	opts = append([]ClientOps{WithFreeCache(), WithPaymailKeyProvider(keyProvider)}, opts...)
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, false, opts...)
+
+	tc := &contactVerificationTestCase{}
+	tc.alice = newPaymail("alice@"+testDomain, 0, WithClient(client), WithXPub(xPubs[0]), New())
+	tc.bob = newPaymail("bob@"+testDomain, 0, WithClient(client), WithXPub(xPubs[1]), New())
+	for i, pm := range []*PaymailAddress{tc.alice, tc.bob} {
+		require.NoError(t, pm.Save(ctx))
+		keyProvider[pm.XpubID] = xPrivs[i]
+	}
+
+	alicePubKey, err := tc.alice.GetPubKey()
+	require.NoError(t, err)
+	bobPubKey, err := tc.bob.GetPubKey()
+	require.NoError(t, err)
+
+	tc.aliceContact = newContact("Alice", tc.alice.String(), alicePubKey, tc.bob.XpubID, ContactNotConfirmed,
+		append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, tc.aliceContact.Save(ctx))
+	tc.bobContact = newContact("Bob", tc.bob.String(), bobPubKey, tc.alice.XpubID, ContactNotConfirmed,
+		append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, tc.bobContact.Save(ctx))
+
+	return ctx, client, tc, deferMe
+}
+
+// TestClient_ConfirmContact will test the contact verification via the shared time-based codes
+func TestClient_ConfirmContact(t *testing.T) {
+	t.Run("code of the contact confirms the contact", func(t *testing.T) {
+		ctx, client, tc, deferMe := initContactVerificationTestCase(t)
+		defer deferMe()
+
+		// Bob generates the code and shares it with Alice
+		code, err := client.GenerateContactTotp(ctx, tc.bob.XpubID, tc.alice.String(), "")
+		require.NoError(t, err)
+		assert.Len(t, code.Passcode, defaultContactVerificationDigits)
+		assert.Equal(t, defaultContactVerificationPeriod, code.Period)
+		assert.True(t, code.ExpiresAt.After(time.Now()))
+
+		// Alice confirms Bob with the code
+		require.NoError(t, client.ConfirmContact(ctx, tc.alice.XpubID, tc.bob.String(), "", code.Passcode))
+
+		contact, err := getContact(ctx, tc.bob.String(), tc.alice.XpubID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, ContactConfirmed, contact.Status)
+
+		// Bob's contact of Alice is confirmed only with the code of Alice
+		contact, err = getContact(ctx, tc.alice.String(), tc.bob.XpubID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, ContactNotConfirmed, contact.Status)
+	})
+
+	t.Run("own code does not confirm the contact", func(t *testing.T) {
+		ctx, client, tc, deferMe := initContactVerificationTestCase(t)
+		defer deferMe()
+
+		code, err := client.GenerateContactTotp(ctx, tc.alice.XpubID, tc.bob.String(), "")
+		require.NoError(t, err)
+
+		err = client.ConfirmContact(ctx, tc.alice.XpubID, tc.bob.String(), "", code.Passcode)
+		require.ErrorIs(t, err, spverrors.ErrContactVerificationFailed)
+
+		contact, err := getContact(ctx, tc.bob.String(), tc.alice.XpubID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, ContactNotConfirmed, contact.Status)
+		assert.Equal(t, uint32(1), contact.VerificationAttempts)
+	})
+
+	t.Run("verification is locked after too many failed codes", func(t *testing.T) {
+		ctx, client, tc, deferMe := initContactVerificationTestCase(t, WithPaymailContactVerification(&ContactVerificationOptions{
+			Period:      time.Minute,
+			Digits:      6,
+			MaxAttempts: 2,
+			Lockout:     time.Hour,
+		}))
+		defer deferMe()
+
+		err := client.ConfirmContact(ctx, tc.alice.XpubID, tc.bob.String(), "", "000000")
+		require.ErrorIs(t, err, spverrors.ErrContactVerificationFailed)
+		err = client.ConfirmContact(ctx, tc.alice.XpubID, tc.bob.String(), "", "000000")
+		require.ErrorIs(t, err, spverrors.ErrContactVerificationLocked)
+
+		// even the valid code is rejected while the verification is locked
+		code, err := client.GenerateContactTotp(ctx, tc.bob.XpubID, tc.alice.String(), "")
+		require.NoError(t, err)
+		err = client.ConfirmContact(ctx, tc.alice.XpubID, tc.bob.String(), "", code.Passcode)
+		require.ErrorIs(t, err, spverrors.ErrContactVerificationLocked)
+	})
+
+	t.Run("missing passcode", func(t *testing.T) {
+		ctx, client, tc, deferMe := initContactVerificationTestCase(t)
+		defer deferMe()
+
+		err := client.ConfirmContact(ctx, tc.alice.XpubID, tc.bob.String(), "", "")
+		require.ErrorIs(t, err, spverrors.ErrMissingContactPasscode)
+	})
+
+	t.Run("without the key provider", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		alice := newPaymail("alice@"+testDomain, 0, WithClient(client), WithXPub(testXPub), New())
+		require.NoError(t, alice.Save(ctx))
+		bob := newContact("Bob", "bob@"+testDomain, testPkiPubKey, alice.XpubID, ContactNotConfirmed,
+			append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, bob.Save(ctx))
+
+		_, err := client.GenerateContactTotp(ctx, alice.XpubID, bob.Paymail, "")
+		require.ErrorIs(t, err, spverrors.ErrContactVerificationUnavailable)
+
+		// the codes are not available, the contact is confirmed without the code
+		require.NoError(t, client.ConfirmContact(ctx, alice.XpubID, bob.Paymail, "", ""))
+
+		contact, err := getContact(ctx, bob.Paymail, alice.XpubID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, ContactConfirmed, contact.Status)
+	})
+
+	t.Run("both parties derive the same secret", func(t *testing.T) {
+		ctx, client, tc, deferMe := initContactVerificationTestCase(t)
+		defer deferMe()
+
+		aliceSecret, alicePubKey, err := client.(*Client).contactSharedSecret(ctx, tc.bobContact, "")
+		require.NoError(t, err)
+		bobSecret, bobPubKey, err := client.(*Client).contactSharedSecret(ctx, tc.aliceContact, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, aliceSecret, bobSecret)
+		assert.Equal(t, tc.aliceContact.PubKey, alicePubKey)
+		assert.Equal(t, tc.bobContact.PubKey, bobPubKey)
+
+		// the secret is derived from the linked keys, not from the PKI keys directly
+		privateKey, err := tc.alice.getPKIPrivateKey(client.GetPaymailConfig().KeyProvider.(testXPubKeyProvider)[tc.alice.XpubID])
+		require.NoError(t, err)
+		bobKey, err := getPublicKey(tc.bobContact.PubKey)
+		require.NoError(t, err)
+		assert.NotEqual(t, sharedSecret(privateKey, bobKey), aliceSecret)
+	})
+}
+
+// TestContactVerificationOptions_Validate will test the method Validate()
+func TestContactVerificationOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, defaultContactVerificationOptions().Validate())
+	require.NoError(t, (*ContactVerificationOptions)(nil).Validate())
+
+	require.ErrorIs(t, (&ContactVerificationOptions{Period: time.Minute, Digits: 4, MaxAttempts: 1}).Validate(),
+		spverrors.ErrInvalidContactVerificationOptions)
+	require.ErrorIs(t, (&ContactVerificationOptions{Period: time.Millisecond, Digits: 6, MaxAttempts: 1}).Validate(),
+		spverrors.ErrInvalidContactVerificationOptions)
+	require.ErrorIs(t, (&ContactVerificationOptions{Period: time.Minute, Digits: 6}).Validate(),
+		spverrors.ErrInvalidContactVerificationOptions)
+}
@@ -12,7 +12,10 @@ const (
 	CronJobNameDraftTransactionCleanUp  = "draft_transaction_clean_up"
 	CronJobNameSyncTransactionBroadcast = "sync_transaction_broadcast"
 	CronJobNameSyncTransactionSync      = "sync_transaction_sync"
+	CronJobNameSyncTransactionP2P       = "sync_transaction_p2p"
 	CronJobNameCalculateMetrics         = "calculate_metrics"
+	CronJobNameScheduledPayments        = "scheduled_payments"
+	CronJobNameP2PDestinationsCleanUp   = "p2p_destinations_clean_up"
 )
 
 type cronJobHandler func(ctx context.Context, client *Client) error
@@ -56,6 +59,24 @@ func (c *Client) cronJobs() taskmanager.CronJobs {
 		5*time.Minute,
 		taskSyncTransactions,
 	)
+	addJob(
+		CronJobNameSyncTransactionP2P,
+		1*time.Minute,
+		taskNotifyP2PTransactions,
+	)
+	addJob(
+		CronJobNameScheduledPayments,
+		1*time.Minute,
+		taskProcessScheduledPayments,
+	)
+
+	if c.options.modelExists(ModelP2PReference.String(), migrateList) {
+		addJob(
+			CronJobNameP2PDestinationsCleanUp,
+			10*time.Minute,
+			taskCleanupP2PDestinations,
+		)
+	}
 
 	if _, enabled := c.Metrics(); enabled {
 		addJob(
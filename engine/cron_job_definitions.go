@@ -87,6 +87,79 @@ func taskSyncTransactions(ctx context.Context, client *Client) error {
 	return err
 }
 
+// taskProcessScheduledPayments will create the drafts of all due scheduled payments
+func taskProcessScheduledPayments(ctx context.Context, client *Client) error {
+	logClient := client.Logger()
+	logClient.Info().Msg("running scheduled payments task...")
+
+	// Prevent concurrent running (the same payment would create two drafts)
+	unlock, err := newWriteLock(
+		ctx, lockKeyProcessScheduledPayments, client.Cachestore(),
+	)
+	defer unlock()
+	if err != nil {
+		logClient.Warn().Msg("cannot run scheduled payments task, previous run is not complete yet...")
+		return nil //nolint:nilerr // previous run is not complete yet
+	}
+
+	return processScheduledPayments(ctx, 100, WithClient(client))
+}
+
+// taskCleanupP2PDestinations will remove the unused destinations of the expired P2P references
+// (delayed if the late transactions are accepted)
+func taskCleanupP2PDestinations(ctx context.Context, client *Client) error {
+	logClient := client.Logger()
+	logClient.Info().Msg("running cleanup P2P destinations task...")
+
+	// Prevent concurrent running
+	unlock, err := newWriteLock(
+		ctx, lockKeyProcessP2PDestinations, client.Cachestore(),
+	)
+	defer unlock()
+	if err != nil {
+		logClient.Warn().Msg("cannot run cleanup P2P destinations task, previous run is not complete yet...")
+		return nil //nolint:nilerr // previous run is not complete yet
+	}
+
+	delay := client.GetPaymailConfig().p2pCleanupDelay()
+	expired, deleted, err := processExpiredP2PReferences(ctx, 100, delay, WithClient(client))
+	if err != nil {
+		return err
+	}
+
+	if m, enabled := client.Metrics(); enabled {
+		m.AddExpiredP2PReferences(expired)
+		m.AddDeletedP2PDestinations(deleted)
+	}
+	return nil
+}
+
+// taskNotifyP2PTransactions will retry the failed P2P notifications
+func taskNotifyP2PTransactions(ctx context.Context, client *Client) error {
+	logClient := client.Logger()
+	logClient.Info().Msg("running P2P notification(s) task...")
+
+	// Prevent concurrent running
+	unlock, err := newWriteLock(
+		ctx, lockKeyProcessP2PNotifications, client.Cachestore(),
+	)
+	defer unlock()
+	if err != nil {
+		logClient.Warn().Msg("cannot run P2P notification(s) task, previous run is not complete yet...")
+		return nil //nolint:nilerr // previous run is not complete yet
+	}
+
+	notified, err := processP2PNotifications(ctx, 100, WithClient(client))
+	if err != nil {
+		return err
+	}
+
+	if notified > 0 {
+		logClient.Info().Msgf("notified %d P2P transaction(s)", notified)
+	}
+	return nil
+}
+
 func taskCalculateMetrics(ctx context.Context, client *Client) error {
 	m, enabled := client.Metrics()
 	if !enabled {
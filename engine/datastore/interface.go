@@ -20,6 +20,8 @@ type StorageService interface {
 		timeout time.Duration) (int64, error)
 	GetModelsAggregate(ctx context.Context, models interface{}, conditions map[string]interface{},
 		aggregateColumn string, timeout time.Duration) (map[string]interface{}, error)
+	GetModelJSONValueSums(ctx context.Context, model interface{}, conditions map[string]interface{},
+		jsonColumn, key string, timeout time.Duration) (positive, negative int64, err error)
 	HasMigratedModel(modelType string) bool
 	IncrementModel(ctx context.Context, model interface{},
 		fieldName string, increment int64) (newValue int64, err error)
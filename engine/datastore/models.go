@@ -219,6 +219,19 @@ func (c *Client) GetModelsAggregate(ctx context.Context, models interface{},
 	return c.aggregate(ctx, models, conditions, aggregateColumn, timeout)
 }
 
+// GetModelJSONValueSums will return the sums of the positive and of the negative values of the key
+// of the JSON object column in the models matching conditions
+func (c *Client) GetModelJSONValueSums(ctx context.Context, model interface{}, conditions map[string]interface{},
+	jsonColumn, key string, timeout time.Duration,
+) (positive, negative int64, err error) {
+	// Switch on the datastore engines
+	if !IsSQLEngine(c.Engine()) {
+		return 0, 0, ErrUnsupportedEngine
+	}
+
+	return c.jsonValueSums(ctx, model, conditions, jsonColumn, key, timeout)
+}
+
 // find will get records and return
 func (c *Client) find(ctx context.Context, result interface{}, conditions map[string]interface{},
 	queryParams *QueryParams, fieldResults interface{}, timeout time.Duration,
@@ -343,6 +356,48 @@ func (c *Client) aggregate(ctx context.Context, model interface{}, conditions ma
 	return aggregateResult, nil
 }
 
+// jsonValueSums will sum the positive and the negative values of the key of the JSON object column
+func (c *Client) jsonValueSums(ctx context.Context, model interface{}, conditions map[string]interface{},
+	jsonColumn, key string, timeout time.Duration,
+) (int64, int64, error) {
+	// The value of the key (records without the key count as zero)
+	var value string
+	var arg interface{}
+	switch c.Engine() {
+	case PostgreSQL:
+		value, arg = "COALESCE(("+jsonColumn+"->>?)::bigint, 0)", key
+	case SQLite:
+		value, arg = "COALESCE(JSON_EXTRACT("+jsonColumn+", ?), 0)", "$."+key
+	default:
+		return 0, 0, ErrUnsupportedEngine
+	}
+
+	// Create a new context, and new db tx
+	ctxDB, cancel := createCtx(ctx, c.options.db, timeout, c.IsDebug(), c.options.loggerDB)
+	defer cancel()
+
+	tx := ctxDB.Model(model)
+
+	if len(conditions) > 0 {
+		var err error
+		if tx, err = ApplyCustomWhere(c, tx, conditions, model); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	var sums struct {
+		Positive int64
+		Negative int64
+	}
+	query := fmt.Sprintf(
+		"COALESCE(SUM(CASE WHEN %[1]s > 0 THEN %[1]s ELSE 0 END), 0) AS positive, "+
+			"COALESCE(SUM(CASE WHEN %[1]s < 0 THEN %[1]s ELSE 0 END), 0) AS negative", value,
+	)
+	err := checkResult(tx.Select(query, arg, arg, arg, arg).Scan(&sums))
+
+	return sums.Positive, sums.Negative, err
+}
+
 // Execute a SQL query
 func (c *Client) Execute(query string) *gorm.DB {
 	if IsSQLEngine(c.Engine()) {
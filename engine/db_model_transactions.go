@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"errors"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
 	"github.com/bitcoin-sv/spv-wallet/engine/notifications"
@@ -87,7 +88,19 @@ func (m *Transaction) AfterCreated(ctx context.Context) error {
 	for xPubID, balance := range m.XpubOutputValue {
 		// todo: run this in a go routine? (move this into a function on the xpub model?)
 		xPub, err := getXpubWithCache(ctx, m.Client(), "", xPubID, opts...)
-		if err != nil {
+		if errors.Is(err, spverrors.ErrCouldNotFindXpub) {
+			// the funds could belong to a multisig account (tracked under the account ID)
+			var account *MultisigAccount
+			if account, err = getMultisigAccount(ctx, xPubID, opts...); err != nil {
+				return err
+			} else if account == nil {
+				return spverrors.ErrMissingFieldXpub
+			}
+			if err = account.incrementBalance(ctx, balance); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
 			return err
 		} else if xPub == nil {
 			return spverrors.ErrMissingFieldXpub
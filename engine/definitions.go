@@ -22,6 +22,31 @@ const (
 	version                    = "v0.14.2"                // SPV Wallet Engine version
 )
 
+// Defaults for scheduled payments
+const (
+	defaultScheduledPaymentDraftExpiresIn = 10 * time.Minute // Default TTL for drafts created by scheduled payments (time for the owner to sign)
+	maxScheduledPaymentFailures           = 5                // Consecutive failed runs after which the scheduled payment is stopped
+	maxScheduledPaymentRunsHistory        = 50               // Number of runs kept in the scheduled payment history
+	minScheduledPaymentInterval           = time.Minute      // Minimal interval between scheduled payment runs
+)
+
+// Defaults for the local SPV verification
+const (
+	defaultSPVMaxAncestryDepth = 100 // Max generations of the unmined ancestors of the incoming transaction in BEEF
+)
+
+// Defaults for multisig accounts
+const (
+	defaultMultisigDraftExpiresIn = 24 * time.Hour // Default TTL for multisig drafts (time for the members to sign)
+	maxMultisigKeys               = 16             // Maximum number of keys in a bare-multisig script (OP_16)
+)
+
+// Defaults for lock time
+const (
+	lockTimeThreshold = uint32(500000000) // Lock time below this value is a block height, above it is a unix timestamp
+	medianTimePastLag = time.Hour         // Approximate lag of the median time past (of the last 11 blocks) behind the current time
+)
+
 // All the base models
 const (
 	ModelAccessKey        ModelName = "access_key"
@@ -36,6 +61,11 @@ const (
 	ModelXPub             ModelName = "xpub"
 	ModelContact          ModelName = "contact"
 	ModelWebhook          ModelName = "webhook"
+	ModelScheduledPayment ModelName = "scheduled_payment"
+	ModelMultisigAccount  ModelName = "multisig_account"
+	ModelPaymailDomain    ModelName = "paymail_domain"
+	ModelP2PReference     ModelName = "paymail_p2p_reference"
+	ModelContactBlock     ModelName = "contact_block"
 )
 
 // AllModelNames is a list of all models
@@ -51,6 +81,11 @@ var AllModelNames = []ModelName{
 	ModelXPub,
 	ModelContact,
 	ModelWebhook,
+	ModelScheduledPayment,
+	ModelMultisigAccount,
+	ModelPaymailDomain,
+	ModelP2PReference,
+	ModelContactBlock,
 }
 
 // Internal table names
@@ -65,6 +100,11 @@ const (
 	tableXPubs             = "xpubs"
 	tableContacts          = "contacts"
 	tableWebhooks          = "webhooks"
+	tableScheduledPayments = "scheduled_payments"
+	tableMultisigAccounts  = "multisig_accounts"
+	tablePaymailDomains    = "paymail_domains"
+	tableP2PReferences     = "paymail_p2p_references"
+	tableContactBlocks     = "contact_blocks"
 )
 
 const (
@@ -84,6 +124,7 @@ const (
 	nextExternalNumField = "next_external_num"
 	nextInternalNumField = "next_internal_num"
 	p2pStatusField       = "p2p_status"
+	p2pNextAttemptField  = "p2p_next_attempt_at"
 	satoshisField        = "satoshis"
 	spendingTxIDField    = "spending_tx_id"
 	statusField          = "status"
@@ -91,13 +132,27 @@ const (
 	typeField            = "type"
 	xPubIDField          = "xpub_id"
 	xPubMetadataField    = "xpub_metadata"
+	xPubContactIDsField  = "xpub_contact_ids"
+	xPubContactSatsField = "xpub_contact_sats"
 	blockHeightField     = "block_height"
 	blockHashField       = "block_hash"
 	merkleProofField     = "merkle_proof"
 	bumpField            = "bump"
 	fullNameField        = "full_name"
+	ownerPaymailField    = "owner_paymail"
 	paymailField         = "paymail"
+	pubKeyField          = "pub_key"
 	contactStatusField   = "status"
+	contactGroupsField   = "groups"
+	nextRunAtField       = "next_run_at"
+	expiresAtField       = "expires_at"
+	scriptPubKeyField    = "script_pub_key"
+
+	scheduledPaymentIDField = "scheduled_payment_id"
+
+	// Privacy settings of the paymail (metadata)
+	publicProfileHiddenField  = "public_profile_hidden"
+	verifyPubKeyDisabledField = "verify_pubkey_disabled"
 
 	// Universal statuses
 	statusCanceled   = "canceled"
@@ -113,13 +168,27 @@ const (
 	// Paymail / Handles
 	cacheKeyAddressResolution = "paymail-address-resolution-"
 	cacheKeyCapabilities      = "paymail-capabilities-"
+	cacheKeyCapabilitiesError = "paymail-capabilities-error-"
+	cacheKeyPKI               = "paymail-pki-"
+	cacheKeyPKIError          = "paymail-pki-error-"
+	cacheKeyPKIDomain         = "paymail-pki-domain-" // + domain (list of the cached PKI paymails)
+	cacheKeyPublicProfile     = "paymail-public-profile-"
+	cacheKeyDestinationQuota  = "paymail-destination-quota-%s-%s" // + paymail ID, day
+	cacheKeyTransactionBeef   = "transaction-beef-%s-%t"          // + tx ID, atomic
 	cacheTTLAddressResolution = 2 * time.Minute
 	cacheTTLCapabilities      = 60 * time.Minute
+	cacheTTLPublicProfile     = 60 * time.Minute
+	cacheTTLTransactionBeef   = 24 * time.Hour     // Refreshes the BEEF of the unmined transactions (their ancestors may be mined since)
+	defaultPubKeyGracePeriod  = 72 * time.Hour     // Time for which the rotated PKI keys stay verifiable
+	defaultP2PReferenceExpiry = 24 * time.Hour     // Time for which the P2P payment destinations wait for the transaction
+	defaultP2PCleanupDelay    = 7 * 24 * time.Hour // Time for which the destinations of the expired P2P references are kept (accepted late transactions)
 	defaultSenderPaymail      = "example@example.com"
 	handleHandcashPrefix      = "$"
 	handleMaxLength           = 25
 	handleRelayPrefix         = "1"
 	p2pMetadataField          = "p2p_tx_metadata"
+	p2pReferenceExpiredField  = "p2p_reference_expired"                              // Metadata flag of the transactions received for an expired P2P reference
+	previewLockingScript      = "76a914000000000000000000000000000000000000000088ac" // Placeholder P2PKH script of the outputs resolved in simulated drafts
 
 	// Misc
 	gormTypeText = "text"
@@ -127,6 +196,11 @@ const (
 	modelList    = "models"
 )
 
+// Cache keys for the contact invitations
+const (
+	cacheKeyContactInvitationQuota = "contact-invitation-quota-%s-%s-%s" // + xPub ID, requester domain, hour
+)
+
 // Cache keys for model caching
 const (
 	cacheKeyDestinationModel                = "destination-id-%s"             // model-id-<destination_id>
@@ -180,6 +254,16 @@ var BaseModels = []interface{}{
 		Model: *NewBaseModel(ModelWebhook),
 	},
 
+	// Recurring payments which create drafts when due
+	&ScheduledPayment{
+		Model: *NewBaseModel(ModelScheduledPayment),
+	},
+
+	// Multisig accounts composed of several xPubs
+	&MultisigAccount{
+		Model: *NewBaseModel(ModelMultisigAccount),
+	},
+
 	// Paymail addresses related to XPubs (automatically added when paymail is enabled)
 	/*&PaymailAddress{
 		Model: *NewBaseModel(ModelPaymailAddress),
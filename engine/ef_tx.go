@@ -40,14 +40,16 @@ func hydrate(ctx context.Context, tx *bt.Tx, store TransactionGetter) (ok bool)
 	txToGet := make([]string, 0, len(tx.Inputs))
 
 	for _, input := range tx.Inputs {
-		txToGet = append(txToGet, input.PreviousTxIDStr())
+		if !contains(txToGet, func(txID string) bool { return txID == input.PreviousTxIDStr() }) {
+			txToGet = append(txToGet, input.PreviousTxIDStr())
+		}
 	}
 
 	parentTxs, err := store.GetTransactionsByIDs(ctx, txToGet)
 	if err != nil {
 		return false
 	}
-	if len(parentTxs) != len(tx.Inputs) {
+	if len(parentTxs) != len(txToGet) {
 		return false
 	}
 
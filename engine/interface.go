@@ -11,6 +11,7 @@ import (
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
 	"github.com/bitcoin-sv/spv-wallet/engine/metrics"
 	"github.com/bitcoin-sv/spv-wallet/engine/notifications"
+	"github.com/bitcoin-sv/spv-wallet/engine/signer"
 	"github.com/bitcoin-sv/spv-wallet/engine/taskmanager"
 	"github.com/mrz1836/go-cachestore"
 	"github.com/rs/zerolog"
@@ -60,7 +61,7 @@ type ClientService interface {
 // ContactService is the service for managing contacts
 type ContactService interface {
 	UpsertContact(ctx context.Context, fullName, paymailAdress, requesterXPubID, requesterPaymail string, opts ...ModelOps) (*Contact, error)
-	AddContactRequest(ctx context.Context, fullName, paymailAdress, requesterXPubID string, opts ...ModelOps) (*Contact, error)
+	AddContactRequest(ctx context.Context, fullName, paymailAdress, requesterXPubID, requesterPaymail string, opts ...ModelOps) (*Contact, error)
 
 	AdminChangeContactStatus(ctx context.Context, id string, status ContactStatus) (*Contact, error)
 	UpdateContact(ctx context.Context, id, fullName string, metadata *Metadata) (*Contact, error)
@@ -69,13 +70,22 @@ type ContactService interface {
 	DeleteContact(ctx context.Context, xPubID, paymail string) error
 	AcceptContact(ctx context.Context, xPubID, paymail string) error
 	RejectContact(ctx context.Context, xPubID, paymail string) error
-	ConfirmContact(ctx context.Context, xPubID, paymail string) error
+	ConfirmContact(ctx context.Context, xPubID, paymail, requesterPaymail, passcode string) error
+	GenerateContactTotp(ctx context.Context, xPubID, paymail, requesterPaymail string) (*ContactTotp, error)
+	BlockContact(ctx context.Context, xPubID, subject string, action ContactBlockAction, opts ...ModelOps) (*ContactBlock, error)
+	UnblockContact(ctx context.Context, xPubID, subject string) error
+	GetContactBlocks(ctx context.Context, xPubID string) ([]*ContactBlock, error)
 	UnconfirmContact(ctx context.Context, xPubID, paymail string) error
+	SetContactGroups(ctx context.Context, xPubID, paymail string, groups []string) (*Contact, error)
+	ImportContacts(ctx context.Context, xPubID, requesterPaymail string, format ContactsFormat, data []byte, invite bool, opts ...ModelOps) (*ContactsImportResult, error)
+	ExportContacts(ctx context.Context, xPubID string, format ContactsFormat, metadata *Metadata, conditions map[string]interface{}) ([]byte, error)
 
 	GetContacts(ctx context.Context, metadata *Metadata, conditions map[string]interface{}, queryParams *datastore.QueryParams) ([]*Contact, error)
 	GetContactsByXpubID(ctx context.Context, xPubID string, metadata *Metadata, conditions map[string]interface{}, queryParams *datastore.QueryParams) ([]*Contact, error)
 	GetContactsByXPubIDCount(ctx context.Context, xPubID string, metadata *Metadata, conditions map[string]interface{}, opts ...ModelOps) (int64, error)
 	GetContactsCount(ctx context.Context, metadata *Metadata, conditions map[string]interface{}, opts ...ModelOps) (int64, error)
+	GetContactProfile(ctx context.Context, xPubID, paymailAddress string) (*paymail.PublicProfilePayload, error)
+	GetContactTransactions(ctx context.Context, xPubID, paymailAddress string, queryParams *datastore.QueryParams) (*ContactTransactions, error)
 }
 
 // DestinationService is the destination actions
@@ -108,6 +118,9 @@ type DraftTransactionService interface {
 		queryParams *datastore.QueryParams, opts ...ModelOps) ([]*DraftTransaction, error)
 	GetDraftTransactionsCount(ctx context.Context, metadata *Metadata,
 		conditions map[string]interface{}, opts ...ModelOps) (int64, error)
+	GetSigningRequest(ctx context.Context, xPubID, draftID string) (*signer.Request, error)
+	RecordSignedTransaction(ctx context.Context, xPubKey, draftID, txHex string,
+		opts ...ModelOps) (*Transaction, error)
 }
 
 // HTTPInterface is the HTTP client interface
@@ -122,26 +135,79 @@ type ModelService interface {
 	GetModelNames() []string
 }
 
+// MultisigService is the multisig accounts actions
+type MultisigService interface {
+	NewMultisigAccount(ctx context.Context, rawXpubKey string, rawXpubKeys []string,
+		threshold uint16, opts ...ModelOps) (*MultisigAccount, error)
+	GetMultisigAccount(ctx context.Context, xPubID, id string) (*MultisigAccount, error)
+	NewMultisigDestination(ctx context.Context, xPubID, accountID string, opts ...ModelOps) (*Destination, error)
+	NewMultisigTransaction(ctx context.Context, xPubID, accountID string, config *TransactionConfig,
+		opts ...ModelOps) (*DraftTransaction, error)
+	AddMultisigSignatures(ctx context.Context, xPubID, draftID string,
+		signatures []*MultisigSignature) (*DraftTransaction, *MultisigAccount, error)
+	GetMultisigDraftTransaction(ctx context.Context, xPubID, draftID string) (*DraftTransaction, *MultisigAccount, error)
+}
+
 // PaymailService is the paymail actions & services
 type PaymailService interface {
 	DeletePaymailAddress(ctx context.Context, address string, opts ...ModelOps) error
+	FlushPaymailDomainCache(ctx context.Context, domain string) error
 	GetPaymailConfig() *PaymailServerOptions
 	GetPaymailAddress(ctx context.Context, address string, opts ...ModelOps) (*PaymailAddress, error)
 	GetPaymailAddressesByXPubID(ctx context.Context, xPubID string, metadataConditions *Metadata,
 		conditions map[string]interface{}, queryParams *datastore.QueryParams) ([]*PaymailAddress, error)
+	GetPaymailDomainCache(ctx context.Context, domain string) (*PaymailDomainCache, error)
 	NewPaymailAddress(ctx context.Context, key, address, publicName,
 		avatar string, opts ...ModelOps) (*PaymailAddress, error)
+	RotatePaymailPubKey(ctx context.Context, address string, opts ...ModelOps) (*PaymailAddress, error)
 	UpdatePaymailAddress(ctx context.Context, address, publicName,
 		avatar string, opts ...ModelOps) (*PaymailAddress, error)
 	UpdatePaymailAddressMetadata(ctx context.Context, address string,
 		metadata Metadata, opts ...ModelOps) (*PaymailAddress, error)
+	UpdatePaymailProfile(ctx context.Context, xPubID, address string, profile *PaymailProfile,
+		opts ...ModelOps) (*PaymailAddress, error)
+	UpdatePaymailReceivePolicy(ctx context.Context, xPubID, address string, policy *PaymailReceivePolicy,
+		opts ...ModelOps) (*PaymailAddress, error)
+	UpdatePaymailOutputTemplate(ctx context.Context, xPubID, address string, outputTemplate *PaymailOutputTemplate,
+		opts ...ModelOps) (*PaymailAddress, error)
+}
+
+// PaymailDomainService is the paymail domains (managed at runtime) actions
+type PaymailDomainService interface {
+	DeletePaymailDomain(ctx context.Context, domain string, opts ...ModelOps) error
+	GetPaymailDomain(ctx context.Context, domain string, opts ...ModelOps) (*PaymailDomain, error)
+	GetPaymailDomains(ctx context.Context, metadataConditions *Metadata, conditions map[string]interface{},
+		queryParams *datastore.QueryParams, opts ...ModelOps) ([]*PaymailDomain, error)
+	GetPaymailDomainsCount(ctx context.Context, metadataConditions *Metadata,
+		conditions map[string]interface{}, opts ...ModelOps) (int64, error)
+	NewPaymailDomain(ctx context.Context, domain string, settings *PaymailDomainSettings,
+		opts ...ModelOps) (*PaymailDomain, error)
+	UpdatePaymailDomain(ctx context.Context, domain string, settings *PaymailDomainSettings,
+		opts ...ModelOps) (*PaymailDomain, error)
+}
+
+// ScheduledPaymentService is the scheduled (recurring) payments actions
+type ScheduledPaymentService interface {
+	NewScheduledPayment(ctx context.Context, rawXpubKey string, config *ScheduledPaymentConfig,
+		opts ...ModelOps) (*ScheduledPayment, error)
+	GetScheduledPayment(ctx context.Context, xPubID, id string) (*ScheduledPayment, error)
+	GetScheduledPayments(ctx context.Context, metadataConditions *Metadata, conditions map[string]interface{},
+		queryParams *datastore.QueryParams, opts ...ModelOps) ([]*ScheduledPayment, error)
+	GetScheduledPaymentsByXpubID(ctx context.Context, xPubID string, metadataConditions *Metadata,
+		conditions map[string]interface{}, queryParams *datastore.QueryParams) ([]*ScheduledPayment, error)
+	GetScheduledPaymentsByXpubIDCount(ctx context.Context, xPubID string, metadataConditions *Metadata,
+		conditions map[string]interface{}) (int64, error)
+	CancelScheduledPayment(ctx context.Context, xPubID, id string) (*ScheduledPayment, error)
 }
 
 // TransactionService is the transaction actions
 type TransactionService interface {
+	ExportTransaction(ctx context.Context, xPubID, txID string, format TransactionExportFormat,
+		atomic bool) (*TransactionExport, error)
 	GetTransaction(ctx context.Context, xPubID, txID string) (*Transaction, error)
 	GetTransactionsByIDs(ctx context.Context, txIDs []string) ([]*Transaction, error)
 	GetTransactionByHex(ctx context.Context, hex string) (*Transaction, error)
+	GetTransactionP2PDelivery(ctx context.Context, txID string) (*P2PDelivery, error)
 	GetTransactions(ctx context.Context, metadata *Metadata, conditions map[string]interface{},
 		queryParams *datastore.QueryParams, opts ...ModelOps) ([]*Transaction, error)
 	GetTransactionsCount(ctx context.Context, metadata *Metadata,
@@ -158,6 +224,8 @@ type TransactionService interface {
 	UpdateTransaction(ctx context.Context, txInfo *broadcast.SubmittedTx) error
 	UpdateTransactionMetadata(ctx context.Context, xPubID, id string, metadata Metadata) (*Transaction, error)
 	RevertTransaction(ctx context.Context, id string) error
+	SimulateTransaction(ctx context.Context, rawXpubKey string, config *TransactionConfig,
+		opts ...ModelOps) (*DraftTransaction, error)
 }
 
 // UTXOService is the utxo actions
@@ -189,7 +257,10 @@ type ClientInterface interface {
 	DestinationService
 	DraftTransactionService
 	ModelService
+	MultisigService
 	PaymailService
+	PaymailDomainService
+	ScheduledPaymentService
 	TransactionService
 	UTXOService
 	XPubService
@@ -205,6 +276,7 @@ type ClientInterface interface {
 	IsIUCEnabled() bool
 	IsMigrationEnabled() bool
 	IsNewRelicEnabled() bool
+	SPVPolicy() *SPVPolicy
 	UserAgent() string
 	Version() string
 	Metrics() (metrics *metrics.Metrics, enabled bool)
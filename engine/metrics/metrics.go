@@ -26,6 +26,9 @@ type Metrics struct {
 	// each cronJob is observed by the duration it takes to execute and the last time it was executed
 	cronHistogram     *prometheus.HistogramVec
 	cronLastExecution *prometheus.GaugeVec
+
+	// the counter of the expired P2P references and the removed (unused) P2P destinations
+	p2pDestinationsCleanup *prometheus.CounterVec
 }
 
 // NewMetrics is a constructor for the Metrics struct
@@ -39,6 +42,8 @@ func NewMetrics(collector Collector) *Metrics {
 		addContact:        collector.RegisterHistogramVec(addContactHistogramName, "classification"),
 		cronHistogram:     collector.RegisterHistogramVec(cronHistogramName, "name", "classification"),
 		cronLastExecution: collector.RegisterGaugeVec(cronLastExecutionGaugeName, "name"),
+
+		p2pDestinationsCleanup: collector.RegisterCounterVec(p2pDestinationsCleanupCounterName, "name"),
 	}
 }
 
@@ -17,3 +17,7 @@ const (
 const (
 	statsGaugeName = domainPrefix + "stats_total"
 )
+
+const (
+	p2pDestinationsCleanupCounterName = domainPrefix + "p2p_destinations_cleanup_total"
+)
@@ -24,3 +24,13 @@ func (m *Metrics) SetDestinationCount(value int64) {
 func (m *Metrics) SetAccessKeyCount(value int64) {
 	m.stats.WithLabelValues("access_key").Set(float64(value))
 }
+
+// AddExpiredP2PReferences adds a value to the P2P destinations cleanup counter with the label "expired_reference"
+func (m *Metrics) AddExpiredP2PReferences(value int) {
+	m.p2pDestinationsCleanup.WithLabelValues("expired_reference").Add(float64(value))
+}
+
+// AddDeletedP2PDestinations adds a value to the P2P destinations cleanup counter with the label "deleted_destination"
+func (m *Metrics) AddDeletedP2PDestinations(value int) {
+	m.p2pDestinationsCleanup.WithLabelValues("deleted_destination").Add(float64(value))
+}
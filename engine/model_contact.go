@@ -8,6 +8,7 @@ import (
 
 	"github.com/bitcoin-sv/go-paymail"
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	customTypes "github.com/bitcoin-sv/spv-wallet/engine/datastore/customtypes"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/google/uuid"
 )
@@ -24,6 +25,12 @@ type Contact struct {
 	Paymail     string        `json:"paymail" toml:"paymail" yaml:"paymail" gorm:"<-create;comment:This is the paymail address alias@domain.com" bson:"paymail"`
 	PubKey      string        `json:"pub_key" toml:"pub_key" yaml:"pub_key" gorm:"<-:create;index;comment:This is the related public key" bson:"pub_key"`
 	Status      ContactStatus `json:"status" toml:"status" yaml:"status" gorm:"<-create;type:varchar(20);default:not confirmed;comment:This is the contact status" bson:"status"`
+	Groups      IDs           `json:"groups" toml:"groups" yaml:"groups" gorm:"<-;type:json;comment:This is the groups (tags) of the contact" bson:"groups,omitempty"`
+
+	OwnerPaymail string `json:"owner_paymail" toml:"owner_paymail" yaml:"owner_paymail" gorm:"<-;index;comment:This is the paymail address of the owner known by the contact" bson:"owner_paymail,omitempty"`
+
+	VerificationAttempts    uint32               `json:"verification_attempts" toml:"verification_attempts" yaml:"verification_attempts" gorm:"<-;comment:This is the number of failed verification codes" bson:"verification_attempts"`
+	VerificationLockedUntil customTypes.NullTime `json:"verification_locked_until" toml:"verification_locked_until" yaml:"verification_locked_until" gorm:"<-;comment:This is the time until the verification of the contact is locked" bson:"verification_locked_until,omitempty"`
 }
 
 func newContact(fullName, paymailAddress, pubKey, ownerXpubID string, status ContactStatus, opts ...ModelOps) *Contact {
@@ -203,6 +210,7 @@ func (m *Contact) Unconfirm() error {
 	}
 
 	m.Status = ContactNotConfirmed
+	m.resetVerification()
 	return nil
 }
 
@@ -213,6 +221,16 @@ func (m *Contact) Delete() {
 }
 
 // UpdatePubKey updates the contact's public key
+// setOwnerPaymail will set the paymail address of the owner known by the contact (returns true if it was changed)
+func (m *Contact) setOwnerPaymail(ownerPaymail string) bool {
+	ownerPaymail = paymail.SanitizeEmail(ownerPaymail)
+	if ownerPaymail == "" || m.OwnerPaymail == ownerPaymail {
+		return false
+	}
+	m.OwnerPaymail = ownerPaymail
+	return true
+}
+
 func (m *Contact) UpdatePubKey(pk string) (updated bool) {
 	if m.PubKey != pk {
 		m.PubKey = pk
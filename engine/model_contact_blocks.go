@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+)
+
+// ContactBlockAction is the action applied to the contact invitations of the blocked paymail (or domain)
+type ContactBlockAction string
+
+const (
+	// ContactBlockActionBlock rejects the invitations with an error returned to the requester
+	ContactBlockActionBlock ContactBlockAction = "block"
+
+	// ContactBlockActionMute rejects the invitations silently (the requester is not told)
+	ContactBlockActionMute ContactBlockAction = "mute"
+)
+
+// ContactBlock is a paymail (or a whole domain) whose contact invitations are rejected,
+// the blocks without xPub are the global blocks (set by the admin) applied to all the users
+//
+// Gorm related models & indexes: https://gorm.io/docs/models.html - https://gorm.io/docs/indexes.html
+type ContactBlock struct {
+	// Base model
+	Model `bson:",inline"`
+
+	// Model specific fields
+	ID      string             `json:"id" toml:"id" yaml:"id" gorm:"<-:create;type:char(64);primaryKey;comment:This is the hash of the xPub and the blocked paymail or domain" bson:"_id"`
+	XpubID  string             `json:"xpub_id" toml:"xpub_id" yaml:"xpub_id" gorm:"<-:create;type:char(64);index;comment:This is the related xPub (empty for the global blocks)" bson:"xpub_id"`
+	Paymail string             `json:"paymail" toml:"paymail" yaml:"paymail" gorm:"<-:create;type:varchar(255);comment:This is the blocked paymail (empty if the whole domain is blocked)" bson:"paymail,omitempty"`
+	Domain  string             `json:"domain" toml:"domain" yaml:"domain" gorm:"<-:create;type:varchar(255);comment:This is the blocked domain (or the domain of the blocked paymail)" bson:"domain"`
+	Action  ContactBlockAction `json:"action" toml:"action" yaml:"action" gorm:"<-;type:varchar(20);comment:This is the action applied to the invitations" bson:"action"`
+}
+
+// newContactBlock will start a new contact block model of the paymail or domain (the subject)
+func newContactBlock(xPubID, subject string, action ContactBlockAction, opts ...ModelOps) (*ContactBlock, error) {
+	m := &ContactBlock{
+		XpubID: xPubID,
+		Action: action,
+		Model:  *NewBaseModel(ModelContactBlock, opts...),
+	}
+
+	if strings.Contains(subject, "@") {
+		m.Paymail = paymail.SanitizeEmail(subject)
+		if err := paymail.ValidatePaymail(m.Paymail); err != nil {
+			return nil, spverrors.ErrInvalidContactBlock
+		}
+		_, m.Domain, _ = paymail.SanitizePaymail(m.Paymail)
+	} else {
+		domain, err := paymail.SanitizeDomain(subject)
+		if err != nil || paymail.ValidateDomain(domain) != nil {
+			return nil, spverrors.ErrInvalidContactBlock
+		}
+		m.Domain = domain
+	}
+
+	m.ID = contactBlockID(xPubID, m.subject())
+	return m, nil
+}
+
+// contactBlockID is the ID of the block of the subject (one block per xPub and subject)
+func contactBlockID(xPubID, subject string) string {
+	return utils.Hash(xPubID + "|" + subject)
+}
+
+// getContactBlock will get the contact block with the given ID (including the deleted one)
+func getContactBlock(ctx context.Context, id string, opts ...ModelOps) (*ContactBlock, error) {
+	conditions := map[string]interface{}{
+		idField: id,
+	}
+
+	block := &ContactBlock{Model: *NewBaseModel(ModelContactBlock, opts...)}
+	if err := Get(ctx, block, conditions, false, defaultDatabaseReadTimeout, false); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// getContactBlocks will get the contact blocks of the xPub (the global blocks for an empty xPub)
+func getContactBlocks(ctx context.Context, xPubID string, opts ...ModelOps) ([]*ContactBlock, error) {
+	conditions := map[string]interface{}{
+		xPubIDField:    xPubID,
+		deletedAtField: nil,
+	}
+
+	var modelItems []*ContactBlock
+	if err := getModels(
+		ctx, NewBaseModel(ModelNameEmpty, opts...).Client().Datastore(),
+		&modelItems, conditions, nil, defaultDatabaseReadTimeout,
+	); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for index := range modelItems {
+		modelItems[index].enrich(ModelContactBlock, opts...)
+	}
+
+	return modelItems, nil
+}
+
+// subject will return the blocked paymail or domain
+func (m *ContactBlock) subject() string {
+	if m.Paymail != "" {
+		return m.Paymail
+	}
+	return m.Domain
+}
+
+// matches will return true if the paymail is blocked (the paymail itself, its domain or the parent domain is blocked)
+func (m *ContactBlock) matches(paymailAddress string) bool {
+	paymailAddress = paymail.SanitizeEmail(paymailAddress)
+	if m.Paymail != "" {
+		return m.Paymail == paymailAddress
+	}
+
+	_, domain, _ := paymail.SanitizePaymail(paymailAddress)
+	return domain == m.Domain || strings.HasSuffix(domain, "."+m.Domain)
+}
+
+// validate will check the action of the contact block
+func (m *ContactBlock) validate() error {
+	if m.Action != ContactBlockActionBlock && m.Action != ContactBlockActionMute {
+		return spverrors.ErrInvalidContactBlock
+	}
+	return nil
+}
+
+// GetModelName will get the name of the current model
+func (m *ContactBlock) GetModelName() string {
+	return ModelContactBlock.String()
+}
+
+// GetModelTableName will get the db table name of the current model
+func (m *ContactBlock) GetModelTableName() string {
+	return tableContactBlocks
+}
+
+// Save will save the model into the Datastore
+func (m *ContactBlock) Save(ctx context.Context) error {
+	return Save(ctx, m)
+}
+
+// GetID will get the ID
+func (m *ContactBlock) GetID() string {
+	return m.ID
+}
+
+// BeforeCreating will fire before the model is being inserted into the Datastore
+func (m *ContactBlock) BeforeCreating(_ context.Context) error {
+	return m.validate()
+}
+
+// BeforeUpdating will fire before the model is being updated in the Datastore
+func (m *ContactBlock) BeforeUpdating(_ context.Context) error {
+	return m.validate()
+}
+
+// Migrate model specific migration on startup
+func (m *ContactBlock) Migrate(client datastore.ClientInterface) error {
+	err := client.IndexMetadata(client.GetTableName(tableContactBlocks), metadataField)
+	return spverrors.Wrapf(err, "failed to index metadata column on model %s", m.GetModelName())
+}
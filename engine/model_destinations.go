@@ -32,6 +32,7 @@ type Destination struct {
 	DerivationMethod             string  `json:"derivation_method" toml:"derivation_method" yaml:"derivation_method" gorm:"<-:create;type:varchar(64);index;comment:This is the derivation method BIP32 or PIKE" bson:"derivation_method,omitempty"`
 	SenderXpub                   string  `json:"sender_xpub" toml:"sender_xpub" yaml:"sender_xpub" gorm:"<-:create;type:varchar(64);index;comment:This is the related sender xpub" bson:"sender_xpub,omitempty"`
 	OutputIndex                  uint32  `json:"output_index" toml:"output_index" yaml:"output_index" gorm:"<-:create;type:int;index;comment:This is the index of script from output templates" bson:"output_index,omitempty"`
+	Reference                    string  `json:"reference" toml:"reference" yaml:"reference" gorm:"<-:create;type:varchar(64);comment:This is the reference of the PIKE payment the script was derived with" bson:"reference,omitempty"`
 }
 
 const (
@@ -37,10 +37,34 @@ type DraftTransaction struct {
 	Configuration TransactionConfig `json:"configuration" toml:"configuration" yaml:"configuration" gorm:"<-;type:text;comment:This is the configuration struct in JSON" bson:"configuration"`
 	Status        DraftStatus       `json:"status" toml:"status" yaml:"status" gorm:"<-;type:varchar(10);index;comment:This is the status of the draft" bson:"status"`
 	FinalTxID     string            `json:"final_tx_id,omitempty" toml:"final_tx_id" yaml:"final_tx_id" gorm:"<-;type:char(64);index;comment:This is the final tx ID" bson:"final_tx_id,omitempty"`
+
+	// Multisig fields
+	Signatures MultisigSignatures `json:"signatures,omitempty" toml:"signatures" yaml:"signatures" gorm:"<-;type:text;comment:This is the partial signatures of multisig inputs in JSON" bson:"signatures,omitempty"`
+
+	// Private fields
+	multisigAccount *MultisigAccount // Set if the draft spends the funds of a multisig account
+	simulate        bool             // Set if the draft is only a preview (nothing is reserved or persisted)
 }
 
 // newDraftTransaction will start a new draft tx
 func newDraftTransaction(rawXpubKey string, config *TransactionConfig, opts ...ModelOps) (*DraftTransaction, error) {
+	return buildDraftTransaction(context.Background(), rawXpubKey, config, false, opts...)
+}
+
+// newSimulatedDraftTransaction will build a preview of the draft tx (fee, inputs and outputs)
+//
+// Nothing is reserved or persisted: utxos are only selected, change destinations are not saved
+// and the P2P destinations are not requested from the paymail providers
+func newSimulatedDraftTransaction(ctx context.Context, rawXpubKey string, config *TransactionConfig,
+	opts ...ModelOps,
+) (*DraftTransaction, error) {
+	return buildDraftTransaction(ctx, rawXpubKey, config, true, opts...)
+}
+
+// buildDraftTransaction will create the draft tx model and its transaction hex
+func buildDraftTransaction(ctx context.Context, rawXpubKey string, config *TransactionConfig, simulate bool,
+	opts ...ModelOps,
+) (*DraftTransaction, error) {
 	// Random GUID
 	id, _ := utils.RandomHex(32)
 
@@ -61,6 +85,40 @@ func newDraftTransaction(rawXpubKey string, config *TransactionConfig, opts ...M
 			ModelDraftTransaction,
 			append(opts, WithXPub(rawXpubKey))...,
 		),
+		simulate: simulate,
+	}
+
+	if config.FeeUnit == nil {
+		draft.Configuration.FeeUnit = draft.Client().Chainstate().FeeUnit()
+	}
+
+	err := draft.createTransactionHex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// newMultisigDraftTransaction will start a new draft tx spending the funds of the multisig account
+func newMultisigDraftTransaction(account *MultisigAccount, config *TransactionConfig, opts ...ModelOps) (*DraftTransaction, error) {
+	// Random GUID
+	id, _ := utils.RandomHex(32)
+
+	// Set the expires time (default, long enough to collect the signatures of the members)
+	expiresAt := time.Now().UTC().Add(defaultMultisigDraftExpiresIn)
+	if config.ExpiresIn > 0 {
+		expiresAt = time.Now().UTC().Add(config.ExpiresIn)
+	}
+
+	// Start the model (the account ID is used in place of the xPub ID)
+	draft := &DraftTransaction{
+		Configuration:   *config,
+		ExpiresAt:       expiresAt,
+		Status:          DraftStatusDraft,
+		TransactionBase: TransactionBase{ID: id},
+		XpubID:          account.ID,
+		Model:           *NewBaseModel(ModelDraftTransaction, opts...),
+		multisigAccount: account,
 	}
 
 	if config.FeeUnit == nil {
@@ -170,16 +228,40 @@ func (m *DraftTransaction) processConfigOutputs(ctx context.Context) error {
 	// Get the sender's paymail from the metadata, this help when sender has multiple paymails
 	senderPaymail, ok := m.Metadata["sender"].(string)
 	if ok {
-		alias, _, address := paymail.SanitizePaymail(senderPaymail)
+		alias, domain, address := paymail.SanitizePaymail(senderPaymail)
 		if address != "" {
 			conditions["alias"] = alias
+
+			// The paymail domain can have its own default sender (for the xPubs without paymail)
+			if settings := c.GetPaymailConfig().domainSettings(domain); settings != nil && settings.DefaultFromPaymail != "" {
+				paymailFrom = settings.DefaultFromPaymail
+			}
 		}
 	}
 
+	// The sender request of the basic address resolution is signed with the PKI key of the sender's paymail,
+	// the confirmed contacts are paid via PIKE (derived with the PKI key of the sender's paymail)
+	var sign senderRequestSigner
+	var pike *pikeSender
 	paymails, err := c.GetPaymailAddressesByXPubID(ctx, m.XpubID, nil, conditions, nil)
 	if err == nil && len(paymails) != 0 {
 		paymailFrom = fmt.Sprintf("%s@%s", paymails[0].Alias, paymails[0].Domain)
+		sign = newSenderRequestSigner(ctx, c.GetPaymailConfig().KeyProvider, paymails[0])
+		pike = newPikeSender(c, paymails[0])
 	}
+	// A simulated draft does not request the destinations (only the capabilities are looked up)
+	processOutput := func(output *TransactionOutput, checkSatoshis bool) error {
+		if m.Configuration.LockTime != 0 {
+			if err := output.checkLockTimeResolution(ctx, c.Cachestore(), c.PaymailClient()); err != nil {
+				return err
+			}
+		}
+		if m.simulate {
+			return output.previewOutput(ctx, c.Cachestore(), c.PaymailClient(), paymailFrom, pike, checkSatoshis)
+		}
+		return output.processOutput(ctx, c.Cachestore(), c.PaymailClient(), paymailFrom, sign, pike, checkSatoshis)
+	}
+
 	// Special case where we are sending all funds to a single (address, paymail, handle)
 	if m.Configuration.SendAllTo != nil {
 		outputs := m.Configuration.Outputs
@@ -188,24 +270,14 @@ func (m *DraftTransaction) processConfigOutputs(ctx context.Context) error {
 		m.Configuration.SendAllTo.Satoshis = 0
 		m.Configuration.Outputs = []*TransactionOutput{m.Configuration.SendAllTo}
 
-		if err := m.Configuration.Outputs[0].processOutput(
-			ctx, c.Cachestore(),
-			c.PaymailClient(),
-			paymailFrom,
-			false,
-		); err != nil {
+		if err := processOutput(m.Configuration.Outputs[0], false); err != nil {
 			return err
 		}
 
 		// re-add the other outputs we had before
 		for _, output := range outputs {
 			output.UseForChange = false // make sure we do not add change to this output
-			if err := output.processOutput(
-				ctx, c.Cachestore(),
-				c.PaymailClient(),
-				paymailFrom,
-				true,
-			); err != nil {
+			if err := processOutput(output, true); err != nil {
 				return err
 			}
 			m.Configuration.Outputs = append(m.Configuration.Outputs, output)
@@ -220,12 +292,7 @@ func (m *DraftTransaction) processConfigOutputs(ctx context.Context) error {
 			}
 
 			// Process the outputs
-			if err := m.Configuration.Outputs[index].processOutput(
-				ctx, c.Cachestore(),
-				c.PaymailClient(),
-				paymailFrom,
-				true,
-			); err != nil {
+			if err := processOutput(m.Configuration.Outputs[index], true); err != nil {
 				return err
 			}
 		}
@@ -247,6 +314,11 @@ func (m *DraftTransaction) createTransactionHex(ctx context.Context) (err error)
 	// Set opts
 	opts := m.GetOptions(false)
 
+	// Check the lock time before requesting any destinations or making any reservations
+	if err = m.Configuration.validateLockTime(); err != nil {
+		return
+	}
+
 	// Process the outputs first
 	// if an error occurs in processing the outputs, we have at least not made any reservations yet
 	if err = m.processConfigOutputs(ctx); err != nil {
@@ -264,6 +336,12 @@ func (m *DraftTransaction) createTransactionHex(ctx context.Context) (err error)
 		return
 	}
 
+	// Set the lock time and the sequence numbers of the inputs
+	tx.LockTime = m.Configuration.LockTime
+	for _, input := range tx.Inputs {
+		input.SequenceNumber = m.Configuration.inputSequence(input.PreviousTxIDStr(), input.PreviousTxOutIndex)
+	}
+
 	// Estimate the fee for the transaction
 	err = m.calculateAndSetFee(ctx, satoshisReserved, satoshisNeeded)
 	if err != nil {
@@ -366,9 +444,16 @@ func (m *DraftTransaction) prepareSeparateUtxos(ctx context.Context, opts []Mode
 			Msg("amount of satoshis to send less than the dust limit")
 		return nil, 0, err
 	}
-	if reservedUtxos, err = reserveUtxos(
-		ctx, m.XpubID, m.ID, reserveSatoshis, feePerByte, m.Configuration.FromUtxos, opts...,
-	); err != nil {
+	if m.simulate {
+		reservedUtxos, err = selectUtxos(
+			ctx, m.XpubID, m.inputsDestinationType(), reserveSatoshis, feePerByte, m.Configuration.FromUtxos, opts...,
+		)
+	} else {
+		reservedUtxos, err = reserveUtxos(
+			ctx, m.XpubID, m.ID, m.inputsDestinationType(), reserveSatoshis, feePerByte, m.Configuration.FromUtxos, opts...,
+		)
+	}
+	if err != nil {
 		return nil, 0, err
 	}
 
@@ -394,12 +479,17 @@ func (m *DraftTransaction) prepareSeparateUtxos(ctx context.Context, opts []Mode
 func (m *DraftTransaction) prepareSendAllToUtxos(ctx context.Context, opts []ModelOps) ([]*bt.UTXO, uint64, error) {
 	// todo should all utxos be sent to the SendAllTo address, not only the p2pkhs?
 	spendableUtxos, err := getSpendableUtxos(
-		ctx, m.XpubID, utils.ScriptTypePubKeyHash, nil, m.Configuration.FromUtxos, opts...,
+		ctx, m.XpubID, m.inputsDestinationType(), nil, m.Configuration.FromUtxos, opts...,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
 	for _, utxo := range spendableUtxos {
+		m.Configuration.Outputs[0].Satoshis += utxo.Satoshis
+		if m.simulate {
+			continue
+		}
+
 		// Reserve the utxos
 		utxo.DraftID.Valid = true
 		utxo.DraftID.String = m.ID
@@ -410,8 +500,6 @@ func (m *DraftTransaction) prepareSendAllToUtxos(ctx context.Context, opts []Mod
 		if err = utxo.Save(ctx); err != nil {
 			return nil, 0, err
 		}
-
-		m.Configuration.Outputs[0].Satoshis += utxo.Satoshis
 	}
 
 	// Get the inputUtxos (in bt.UTXO format) and the total amount of satoshis from the utxos
@@ -561,12 +649,17 @@ func (m *DraftTransaction) addOutputsToTx(tx *bt.Tx) (err error) {
 					Satoshis:      0,
 				})
 			} else if scriptType == utils.ScriptTypePubKeyHash {
-				// sending to a p2pkh
+				// sending to a p2pkh (with the data after OP_RETURN, it's not accepted by AddP2PKHOutputFromScript)
 				if sc.Satoshis == 0 {
 					return spverrors.ErrOutputValueTooLow
 				}
 
-				if err = tx.AddP2PKHOutputFromScript(
+				if utils.IsP2PKHData(sc.Script) {
+					tx.AddOutput(&bt.Output{
+						LockingScript: s,
+						Satoshis:      sc.Satoshis,
+					})
+				} else if err = tx.AddP2PKHOutputFromScript(
 					s, sc.Satoshis,
 				); err != nil {
 					return
@@ -622,7 +715,7 @@ func (m *DraftTransaction) setChangeDestination(ctx context.Context, satoshisCha
 			numberOfDestinations = 1
 		}
 
-		newFee = m.estimateFee(m.Configuration.FeeUnit, uint64(numberOfDestinations)*changeOutputSize)
+		newFee = m.estimateFee(m.Configuration.FeeUnit, uint64(numberOfDestinations)*m.changeOutputSize())
 		satoshisChange -= newFee - fee
 		m.Configuration.ChangeSatoshis = satoshisChange
 
@@ -646,7 +739,7 @@ func (m *DraftTransaction) setChangeDestination(ctx context.Context, satoshisCha
 					Address:    destination.Address,
 					Satoshis:   changeSatoshis[destination.LockingScript],
 					Script:     destination.LockingScript,
-					ScriptType: utils.ScriptTypePubKeyHash,
+					ScriptType: destination.Type,
 				}},
 				Satoshis: changeSatoshis[destination.LockingScript],
 			})
@@ -709,6 +802,24 @@ func (m *DraftTransaction) setChangeDestinations(ctx context.Context, numberOfDe
 
 	// Loop for each destination
 	for i := 0; i < numberOfDestinations; i++ {
+		// Change of a multisig account goes back to the account
+		if m.multisigAccount != nil {
+			var destination *Destination
+			if destination, err = m.multisigAccount.getNewDestination(
+				ctx, utils.ChainInternal, opts...,
+			); err != nil {
+				return err
+			}
+
+			destination.DraftID = m.ID
+			if err = destination.Save(ctx); err != nil {
+				return err
+			}
+
+			m.Configuration.ChangeDestinations = append(m.Configuration.ChangeDestinations, destination)
+			continue
+		}
+
 		if xPub, err = getXpubWithCache(
 			ctx, c, m.rawXpubKey, "", opts...,
 		); err != nil {
@@ -717,7 +828,10 @@ func (m *DraftTransaction) setChangeDestinations(ctx context.Context, numberOfDe
 			return spverrors.ErrMissingFieldXpub
 		}
 
-		if num, err = xPub.incrementNextNum(
+		// A simulated draft previews the next change destinations without allocating them
+		if m.simulate {
+			num = xPub.NextInternalNum + uint32(i)
+		} else if num, err = xPub.incrementNextNum(
 			ctx, utils.ChainInternal,
 		); err != nil {
 			return err
@@ -731,8 +845,10 @@ func (m *DraftTransaction) setChangeDestinations(ctx context.Context, numberOfDe
 		}
 
 		destination.DraftID = m.ID
-		if err = destination.Save(ctx); err != nil {
-			return err
+		if !m.simulate {
+			if err = destination.Save(ctx); err != nil {
+				return err
+			}
 		}
 
 		m.Configuration.ChangeDestinations = append(m.Configuration.ChangeDestinations, destination)
@@ -0,0 +1,288 @@
+package engine
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/libsv/go-bt/v2/sighash"
+)
+
+// MultisigSignature is a partial signature of a multisig input made by one of the account members
+type MultisigSignature struct {
+	InputIndex uint32 `json:"input_index"` // Index of the signed input
+	XpubID     string `json:"xpub_id"`     // Member who made the signature
+	Signature  string `json:"signature"`   // DER signature with the sighash flag appended, in hex
+}
+
+// MultisigSignatures is the list of partial signatures collected for a draft transaction
+type MultisigSignatures []*MultisigSignature
+
+// Scan will scan the value into Struct, implements sql.Scanner interface
+func (s *MultisigSignatures) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	byteValue, err := utils.ToByteArray(value)
+	if err != nil || bytes.Equal(byteValue, []byte("")) || bytes.Equal(byteValue, []byte("\"\"")) {
+		return nil
+	}
+
+	err = json.Unmarshal(byteValue, &s)
+	return spverrors.Wrapf(err, "failed to parse MultisigSignatures from JSON")
+}
+
+// Value return json value, implement driver.Valuer interface
+func (s MultisigSignatures) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	marshal, err := json.Marshal(s)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to convert MultisigSignatures to JSON")
+	}
+
+	return string(marshal), nil
+}
+
+// inputsDestinationType will return the type of the destinations whose utxos can be spent by the draft
+func (m *DraftTransaction) inputsDestinationType() string {
+	if m.multisigAccount != nil {
+		return utils.ScriptTypeMultiSig
+	}
+	return utils.ScriptTypePubKeyHash
+}
+
+// changeOutputSize will return the estimated size of a single change output
+func (m *DraftTransaction) changeOutputSize() uint64 {
+	if m.multisigAccount != nil {
+		return m.multisigAccount.outputSize()
+	}
+	return changeOutputSize
+}
+
+// prepareTxToSign will parse the draft hex and set the previous outputs of the inputs (needed for the sighash)
+func (m *DraftTransaction) prepareTxToSign() (*bt.Tx, error) {
+	tx, err := bt.NewTxFromString(m.Hex)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to parse draft transaction hex")
+	}
+
+	for index, input := range m.Configuration.Inputs {
+		var ls *bscript.Script
+		if ls, err = bscript.NewFromHexString(
+			input.Destination.LockingScript,
+		); err != nil {
+			return nil, spverrors.Wrapf(err, "failed to parse locking script of input %d", index)
+		}
+		tx.Inputs[index].PreviousTxScript = ls
+		tx.Inputs[index].PreviousTxSatoshis = input.Satoshis
+	}
+
+	return tx, nil
+}
+
+// addMultisigSignatures will verify and store the partial signatures made by the member of the account
+func (m *DraftTransaction) addMultisigSignatures(account *MultisigAccount, xPubID string,
+	signatures []*MultisigSignature,
+) error {
+	if m.Status != DraftStatusDraft {
+		return spverrors.ErrMultisigDraftIncorrectStatus
+	}
+
+	memberIndex := account.memberIndex(xPubID)
+	if memberIndex < 0 {
+		return spverrors.ErrMultisigNotAMember
+	}
+
+	tx, err := m.prepareTxToSign()
+	if err != nil {
+		return err
+	}
+
+	for _, signature := range signatures {
+		if int(signature.InputIndex) >= len(m.Configuration.Inputs) {
+			return spverrors.ErrMultisigInvalidInputIndex
+		}
+		input := m.Configuration.Inputs[signature.InputIndex]
+		if input.Destination.XpubID != account.ID {
+			return spverrors.ErrMultisigInvalidInputIndex
+		}
+
+		// Derive the key of the member which was used in the locking script
+		var pubKeys []*bec.PublicKey
+		if pubKeys, err = account.derivePublicKeys(
+			input.Destination.Chain, input.Destination.Num,
+		); err != nil {
+			return err
+		}
+
+		if err = verifyMultisigSignature(
+			tx, signature, pubKeys[memberIndex],
+		); err != nil {
+			return err
+		}
+
+		m.setMultisigSignature(&MultisigSignature{
+			InputIndex: signature.InputIndex,
+			XpubID:     xPubID,
+			Signature:  signature.Signature,
+		})
+	}
+
+	return nil
+}
+
+// setMultisigSignature will add the signature, replacing the previous one of the member for the same input
+func (m *DraftTransaction) setMultisigSignature(signature *MultisigSignature) {
+	for index, s := range m.Signatures {
+		if s.InputIndex == signature.InputIndex && s.XpubID == signature.XpubID {
+			m.Signatures[index] = signature
+			return
+		}
+	}
+	m.Signatures = append(m.Signatures, signature)
+}
+
+// IsMultisigComplete will check if enough signatures have been collected for all the inputs
+func (m *DraftTransaction) IsMultisigComplete(account *MultisigAccount) bool {
+	for index := range m.Configuration.Inputs {
+		if len(m.inputSignatures(account, uint32(index))) < int(account.Threshold) {
+			return false
+		}
+	}
+	return len(m.Configuration.Inputs) > 0
+}
+
+// inputSignatures will return the signatures of the input ordered as the keys in the locking script
+func (m *DraftTransaction) inputSignatures(account *MultisigAccount, inputIndex uint32) [][]byte {
+	signatures := make([][]byte, 0, account.Threshold)
+	for _, xPubID := range account.XpubIDs {
+		for _, s := range m.Signatures {
+			if s.InputIndex != inputIndex || s.XpubID != xPubID {
+				continue
+			}
+			if signature, err := hex.DecodeString(s.Signature); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	return signatures
+}
+
+// CombineMultisigSignatures will create the signed transaction hex from the collected signatures
+func (m *DraftTransaction) CombineMultisigSignatures(account *MultisigAccount) (string, error) {
+	if !m.IsMultisigComplete(account) {
+		return "", spverrors.ErrMultisigThresholdNotMet
+	}
+
+	tx, err := bt.NewTxFromString(m.Hex)
+	if err != nil {
+		return "", spverrors.Wrapf(err, "failed to parse draft transaction hex")
+	}
+
+	for index := range m.Configuration.Inputs {
+		signatures := m.inputSignatures(account, uint32(index))[:account.Threshold]
+
+		// OP_0 <signature 1> ... <signature m> (OP_0 is consumed by the OP_CHECKMULTISIG bug)
+		s := &bscript.Script{}
+		if err = s.AppendOpcodes(bscript.Op0); err != nil {
+			return "", spverrors.Wrapf(err, "failed to create multisig unlocking script")
+		}
+		if err = s.AppendPushDataArray(signatures); err != nil {
+			return "", spverrors.Wrapf(err, "failed to create multisig unlocking script")
+		}
+
+		if err = tx.InsertInputUnlockingScript(uint32(index), s); err != nil {
+			return "", spverrors.Wrapf(err, "failed to insert multisig unlocking script")
+		}
+	}
+
+	return tx.String(), nil
+}
+
+// SignMultisigInputs will create the partial signatures of all the inputs using the xPriv of an account member
+func (m *DraftTransaction) SignMultisigInputs(xPriv *bip32.ExtendedKey) ([]*MultisigSignature, error) {
+	tx, err := m.prepareTxToSign()
+	if err != nil {
+		return nil, err
+	}
+
+	xPub, err := xPriv.Neuter()
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to get xPub from xPriv")
+	}
+	xPubID := utils.Hash(xPub.String())
+
+	signatures := make([]*MultisigSignature, 0, len(m.Configuration.Inputs))
+	for index, input := range m.Configuration.Inputs {
+		// Derive the child key (chain/num)
+		var numKey *bip32.ExtendedKey
+		if numKey, err = bitcoin.GetHDKeyByPath(
+			xPriv, input.Destination.Chain, input.Destination.Num,
+		); err != nil {
+			return nil, spverrors.Wrapf(err, "failed to derive key of input %d", index)
+		}
+
+		var privateKey *bec.PrivateKey
+		if privateKey, err = bitcoin.GetPrivateKeyFromHDKey(numKey); err != nil {
+			return nil, spverrors.Wrapf(err, "failed to get private key of input %d", index)
+		}
+
+		var sigHash []byte
+		if sigHash, err = tx.CalcInputSignatureHash(uint32(index), sighash.AllForkID); err != nil {
+			return nil, spverrors.Wrapf(err, "failed to calculate signature hash")
+		}
+
+		var sig *bec.Signature
+		if sig, err = privateKey.Sign(sigHash); err != nil {
+			return nil, spverrors.Wrapf(err, "failed to sign transaction")
+		}
+
+		signatures = append(signatures, &MultisigSignature{
+			InputIndex: uint32(index),
+			XpubID:     xPubID,
+			Signature:  hex.EncodeToString(append(sig.Serialise(), byte(sighash.AllForkID))),
+		})
+	}
+
+	return signatures, nil
+}
+
+// verifyMultisigSignature will check that the signature of the input was made by the given key
+func verifyMultisigSignature(tx *bt.Tx, signature *MultisigSignature, pubKey *bec.PublicKey) error {
+	sigBytes, err := hex.DecodeString(signature.Signature)
+	if err != nil || len(sigBytes) < 2 {
+		return spverrors.ErrMultisigInvalidSignature
+	}
+
+	flag := sighash.Flag(sigBytes[len(sigBytes)-1])
+	if flag != sighash.AllForkID {
+		return spverrors.ErrMultisigInvalidSignature
+	}
+
+	var sig *bec.Signature
+	if sig, err = bec.ParseDERSignature(sigBytes[:len(sigBytes)-1], bec.S256()); err != nil {
+		return spverrors.ErrMultisigInvalidSignature
+	}
+
+	var sigHash []byte
+	if sigHash, err = tx.CalcInputSignatureHash(signature.InputIndex, flag); err != nil {
+		return spverrors.Wrapf(err, "failed to calculate signature hash")
+	}
+
+	if !sig.Verify(sigHash, pubKey) {
+		return spverrors.ErrMultisigInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"encoding/hex"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/signer"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// SigningRequest will export the draft as a portable request which can be signed offline (see the signer package)
+func (m *DraftTransaction) SigningRequest() (*signer.Request, error) {
+	if m.Status != DraftStatusDraft {
+		return nil, spverrors.ErrSigningRequestDraftIncorrectStatus
+	}
+
+	tx, err := m.prepareTxToSign()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &signer.Request{
+		Version:   signer.RequestVersion,
+		DraftID:   m.ID,
+		XpubID:    m.XpubID,
+		EF:        hex.EncodeToString(tx.ExtendedBytes()),
+		Inputs:    make([]*signer.Input, 0, len(m.Configuration.Inputs)),
+		ExpiresAt: m.ExpiresAt,
+	}
+
+	for index, input := range m.Configuration.Inputs {
+		signingInput := &signer.Input{
+			Index:            uint32(index),
+			TxID:             input.TransactionID,
+			OutputIndex:      input.OutputIndex,
+			Satoshis:         input.Satoshis,
+			LockingScript:    input.Destination.LockingScript,
+			ScriptType:       input.Destination.Type,
+			DerivationMethod: signer.DerivationBIP32,
+			Chain:            input.Destination.Chain,
+			Num:              input.Destination.Num,
+		}
+		if input.Destination.DerivationMethod == PIKEDerivationMethod {
+			signingInput.DerivationMethod = signer.DerivationPIKE
+			signingInput.Pike = &signer.PikeReference{
+				SenderPubKey: input.Destination.SenderXpub,
+				Reference:    input.Destination.Reference,
+				OutputIndex:  input.Destination.OutputIndex,
+			}
+			// The destinations saved without the paymail key derivation can't be signed offline
+			if input.Destination.PaymailExternalDerivationNum != nil {
+				signingInput.Pike.PubKeyNum = *input.Destination.PaymailExternalDerivationNum
+			} else {
+				signingInput.Pike.Reference = ""
+			}
+		}
+		request.Inputs = append(request.Inputs, signingInput)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/signer"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RecordSignedTransaction(t *testing.T) {
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+	defer deferMe()
+
+	xPrivString := "xprv9s21ZrQH143K31pvNoYNcRZjtdJXnNVEc5NmBbgJmEg27YWbZVL7jTLQhPELqAR7tcJTnF9AJLwVN5w3ABZvrfeDLm4vnBDw76bkx8a2NxK"
+	xPrivHD, err := bitcoin.GenerateHDKeyFromString(xPrivString)
+	require.NoError(t, err)
+	xPubHD, _ := xPrivHD.Neuter()
+	xPubID := utils.Hash(xPubHD.String())
+
+	xPub := newXpub(xPubHD.String(), client.DefaultModelOptions(New())...)
+	require.NoError(t, xPub.Save(ctx))
+
+	// destination & utxo of the key 0/0
+	lockingScript := "76a91447868e6b13de36e2739d8f2a9e0e0a323ad9b8ff88ac"
+	destination := newDestination(xPubID, lockingScript, append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, destination.Save(ctx))
+
+	utxo := newUtxo(xPubID, testTxID, lockingScript, 0, 12229, client.DefaultModelOptions(New())...)
+	require.NoError(t, utxo.Save(ctx))
+
+	draft, err := client.NewTransaction(ctx, xPubHD.String(), &TransactionConfig{
+		Outputs: []*TransactionOutput{{
+			To:       "1AqYEDUf16CHaD2guBLHHhosfV2AyYJLz",
+			Satoshis: 1000,
+		}},
+	}, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+
+	_, err = client.GetSigningRequest(ctx, testXPubID, draft.ID)
+	require.ErrorIs(t, err, spverrors.ErrCouldNotFindDraftTx)
+
+	request, err := client.GetSigningRequest(ctx, xPubID, draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, signer.RequestVersion, request.Version)
+	assert.Equal(t, draft.ID, request.DraftID)
+	assert.Equal(t, xPubID, request.XpubID)
+	require.Len(t, request.Inputs, 1)
+	assert.Equal(t, testTxID, request.Inputs[0].TxID)
+	assert.Equal(t, lockingScript, request.Inputs[0].LockingScript)
+	assert.Equal(t, signer.DerivationBIP32, request.Inputs[0].DerivationMethod)
+
+	// signed offline
+	signedHex, err := signer.Sign(request, xPrivHD)
+	require.NoError(t, err)
+
+	// a modified transaction is rejected
+	tx, err := bt.NewTxFromString(signedHex)
+	require.NoError(t, err)
+	tx.Outputs[0].Satoshis++
+	_, err = client.RecordSignedTransaction(ctx, xPubHD.String(), draft.ID, tx.String())
+	require.ErrorIs(t, err, spverrors.ErrSignedTxDoesNotMatchDraft)
+
+	transaction, err := client.RecordSignedTransaction(ctx, xPubHD.String(), draft.ID, signedHex)
+	require.NoError(t, err)
+	assert.Equal(t, draft.ID, transaction.DraftID)
+
+	// the draft is complete, it can't be signed anymore
+	_, err = client.GetSigningRequest(ctx, xPubID, draft.ID)
+	require.ErrorIs(t, err, spverrors.ErrSigningRequestDraftIncorrectStatus)
+}
+
+func TestClient_GetSigningRequest_pike(t *testing.T) {
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), WithFreeCache())
+	defer deferMe()
+
+	xPub := newXpub(testXPub, append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, xPub.Save(ctx))
+
+	receiver := newPaymail("receiver@"+testDomain, 0, WithClient(client), WithXPub(testXPub))
+	require.NoError(t, receiver.Save(ctx))
+	receiverPubKey, err := receiver.GetPubKey()
+	require.NoError(t, err)
+
+	// PIKE destination & utxo of the paymail
+	provider := &PikePaymentServiceProvider{client: client}
+	response, err := provider.CreatePikeOutputResponse(ctx, receiver.Alias, receiver.Domain, testPkiPubKey, 12229, nil)
+	require.NoError(t, err)
+	sender := &pikeSender{client: client, paymail: "sender@" + testDomain, pubKey: testPkiPubKey}
+	scripts, err := sender.lockingScripts(response, receiverPubKey, 12229)
+	require.NoError(t, err)
+	require.Len(t, scripts, 1)
+
+	utxo := newUtxo(xPub.ID, testTxID, scripts[0], 0, 12229, client.DefaultModelOptions(New())...)
+	require.NoError(t, utxo.Save(ctx))
+
+	draft, err := client.NewTransaction(ctx, testXPub, &TransactionConfig{
+		Outputs: []*TransactionOutput{{
+			To:       "1AqYEDUf16CHaD2guBLHHhosfV2AyYJLz",
+			Satoshis: 1000,
+		}},
+	}, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+
+	request, err := client.GetSigningRequest(ctx, xPub.ID, draft.ID)
+	require.NoError(t, err)
+	require.Len(t, request.Inputs, 1)
+	assert.Equal(t, signer.DerivationPIKE, request.Inputs[0].DerivationMethod)
+	require.NotNil(t, request.Inputs[0].Pike)
+	assert.Equal(t, response.Reference, request.Inputs[0].Pike.Reference)
+
+	// signed offline
+	signedHex, err := signer.SignWithKey(request, testXPriv)
+	require.NoError(t, err)
+	require.NoError(t, signer.Verify(request, signedHex))
+
+	transaction, err := client.RecordSignedTransaction(ctx, testXPub, draft.ID, signedHex)
+	require.NoError(t, err)
+	assert.Equal(t, draft.ID, transaction.DraftID)
+}
@@ -521,6 +521,85 @@ func TestDraftTransaction_createTransaction(t *testing.T) {
 		assert.Equal(t, uint64(lockingScriptAmount2+lockingScriptAmount3-expectedFee), draftTransaction.Configuration.Outputs[0].Scripts[0].Satoshis)
 	})
 
+	t.Run("lock time and sequences", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+		defer deferMe()
+		prepareAdditionalModels(ctx, t, client, true)
+
+		draftTransaction, err := newDraftTransaction(testXPub, &TransactionConfig{
+			SendAllTo: &TransactionOutput{To: testExternalAddress},
+			FromUtxos: []*UtxoPointer{{
+				TransactionID: testTxID,
+				OutputIndex:   1,
+			}, {
+				TransactionID: testTxID,
+				OutputIndex:   2,
+			}},
+			LockTime: 800000,
+			Sequences: []*InputSequence{{
+				UtxoPointer: UtxoPointer{TransactionID: testTxID, OutputIndex: 2},
+				Sequence:    1,
+			}},
+		}, append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, err)
+
+		tx, err := bt.NewTxFromString(draftTransaction.Hex)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(800000), tx.LockTime)
+		require.Len(t, tx.Inputs, 2)
+		for _, input := range tx.Inputs {
+			if input.PreviousTxOutIndex == 2 {
+				assert.Equal(t, uint32(1), input.SequenceNumber)
+			} else {
+				assert.Equal(t, bt.DefaultSequenceNumber, input.SequenceNumber)
+			}
+		}
+	})
+
+	t.Run("lock time - invalid sequences", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+		defer deferMe()
+		prepareAdditionalModels(ctx, t, client, true)
+
+		_, err := newDraftTransaction(testXPub, &TransactionConfig{
+			SendAllTo: &TransactionOutput{To: testExternalAddress},
+			Sequences: []*InputSequence{{
+				UtxoPointer: UtxoPointer{TransactionID: testTxID, OutputIndex: 2},
+				Sequence:    1,
+			}},
+		}, append(client.DefaultModelOptions(), New())...)
+		require.ErrorIs(t, err, spverrors.ErrSequenceForUnknownInput)
+	})
+
+	t.Run("lock time - p2p output", func(t *testing.T) {
+		p := newTestPaymailClient(t, []string{"handcash.io"})
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true,
+			withTaskManagerMockup(),
+			WithPaymailClient(p),
+		)
+		defer deferMe()
+		prepareAdditionalModels(ctx, t, client, true)
+
+		httpmock.Reset()
+		mockValidResponse(http.StatusOK, true, "handcash.io")
+		destinationURL := "https://handcash.io/api/v1/bsvalias/p2p-payment-destination/mrzz@handcash.io"
+		httpmock.RegisterResponder(http.MethodPost, destinationURL,
+			httpmock.NewStringResponder(
+				200,
+				`{"outputs": [{"script": "76a9143e2d1d795f8acaa7957045cc59376177eb04a3c588ac","satoshis": 100}],"reference": "z0bac4ec-6f15-42de-9ef4-e60bfdabf4f7"}`,
+			),
+		)
+
+		_, err := newDraftTransaction(testXPub, &TransactionConfig{
+			SendAllTo: &TransactionOutput{To: "mrzz@handcash.io"},
+			LockTime:  800000,
+		}, append(client.DefaultModelOptions(), New())...)
+		require.ErrorIs(t, err, spverrors.ErrLockTimeWithP2POutputs)
+
+		// The receiver was not asked for the destinations
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()[http.MethodPost+" "+destinationURL])
+	})
+
 	t.Run("include utxos - tokens", func(t *testing.T) {
 		const expectedFeeLockingScript = 3
 		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
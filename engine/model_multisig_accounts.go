@@ -0,0 +1,302 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bt/v2/bscript"
+)
+
+// MultisigAccount is an m-of-n account composed of several registered xPubs.
+// Destinations of the account are bare-multisig scripts derived in lockstep (same chain/num) from all the xPubs,
+// funds sent to them are tracked under the account ID (used in place of the xPub ID)
+//
+// Gorm related models & indexes: https://gorm.io/docs/models.html - https://gorm.io/docs/indexes.html
+type MultisigAccount struct {
+	// Base model
+	Model `bson:",inline"`
+
+	// Model specific fields
+	ID              string `json:"id" toml:"id" yaml:"id" gorm:"<-:create;type:char(64);primaryKey;comment:This is the sha256 hash of the threshold and the xPub IDs" bson:"_id"`
+	Threshold       uint16 `json:"threshold" toml:"threshold" yaml:"threshold" gorm:"<-:create;type:int;comment:This is the number of signatures required to spend" bson:"threshold"`
+	XpubIDs         IDs    `json:"xpub_ids" toml:"xpub_ids" yaml:"xpub_ids" gorm:"<-:create;type:text;comment:This is the list of the member xPub IDs (in key order)" bson:"xpub_ids"`
+	XpubKeys        IDs    `json:"-" toml:"-" yaml:"-" gorm:"<-:create;type:text;comment:This is the list of the member xPubs (in key order), encryption optional" bson:"xpub_keys"`
+	CurrentBalance  uint64 `json:"current_balance" toml:"current_balance" yaml:"current_balance" gorm:"<-;comment:The current balance of unspent satoshis" bson:"current_balance"`
+	NextInternalNum uint32 `json:"next_internal_num" toml:"next_internal_num" yaml:"next_internal_num" gorm:"<-;type:int;default:0;comment:The index derivation number use to generate NEXT internal destination (change)" bson:"next_internal_num"`
+	NextExternalNum uint32 `json:"next_external_num" toml:"next_external_num" yaml:"next_external_num" gorm:"<-;type:int;default:0;comment:The index derivation number use to generate NEXT external destination" bson:"next_external_num"`
+
+	// Private fields
+	xPubKeysDecrypted []string
+}
+
+// newMultisigAccount will start a new multisig account model for the given (registered) xPubs
+func newMultisigAccount(rawXpubKeys []string, threshold uint16, opts ...ModelOps) (*MultisigAccount, error) {
+	if len(rawXpubKeys) < 2 || len(rawXpubKeys) > maxMultisigKeys {
+		return nil, spverrors.ErrMultisigInvalidNumberOfKeys
+	}
+	if threshold < 1 || int(threshold) > len(rawXpubKeys) {
+		return nil, spverrors.ErrMultisigInvalidThreshold
+	}
+
+	// Keys are ordered by the xPub ID, so the same set of keys always results in the same scripts
+	keys := make([]string, len(rawXpubKeys))
+	copy(keys, rawXpubKeys)
+	sort.Slice(keys, func(i, j int) bool {
+		return utils.Hash(keys[i]) < utils.Hash(keys[j])
+	})
+
+	xPubIDs := make(IDs, 0, len(keys))
+	for _, key := range keys {
+		if _, err := utils.ValidateXPub(key); err != nil {
+			return nil, err //nolint:wrapcheck // Already wrapped in Validate
+		}
+		xPubID := utils.Hash(key)
+		if utils.StringInSlice(xPubID, xPubIDs) {
+			return nil, spverrors.ErrMultisigInvalidNumberOfKeys
+		}
+		xPubIDs = append(xPubIDs, xPubID)
+	}
+
+	m := &MultisigAccount{
+		ID:        utils.Hash(fmt.Sprintf("%d:%s", threshold, strings.Join(xPubIDs, ","))),
+		Threshold: threshold,
+		XpubIDs:   xPubIDs,
+		Model:     *NewBaseModel(ModelMultisigAccount, opts...),
+	}
+
+	if err := m.setXpubKeys(keys); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// getMultisigAccount will get the multisig account with the given ID
+func getMultisigAccount(ctx context.Context, id string, opts ...ModelOps) (*MultisigAccount, error) {
+	conditions := map[string]interface{}{
+		idField: id,
+	}
+
+	account := &MultisigAccount{Model: *NewBaseModel(ModelMultisigAccount, opts...)}
+	if err := Get(ctx, account, conditions, false, defaultDatabaseReadTimeout, false); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// setXpubKeys will set the "XpubKeys" from the raw xPubs,
+// encrypted with the given encryption key (if a key is set)
+func (m *MultisigAccount) setXpubKeys(rawXpubKeys []string) error {
+	m.xPubKeysDecrypted = rawXpubKeys
+	m.XpubKeys = make(IDs, 0, len(rawXpubKeys))
+	for _, key := range rawXpubKeys {
+		if len(m.encryptionKey) > 0 {
+			encrypted, err := utils.Encrypt(m.encryptionKey, key)
+			if err != nil {
+				return spverrors.Wrapf(err, "failed to encrypt xPub of multisig account")
+			}
+			key = encrypted
+		}
+		m.XpubKeys = append(m.XpubKeys, key)
+	}
+
+	return nil
+}
+
+// getXpubKeys will get the raw xPubs of the members (in key order)
+func (m *MultisigAccount) getXpubKeys() ([]string, error) {
+	if len(m.xPubKeysDecrypted) > 0 {
+		return m.xPubKeysDecrypted, nil
+	}
+
+	keys := make([]string, 0, len(m.XpubKeys))
+	for _, key := range m.XpubKeys {
+		if len(key) != utils.XpubKeyLength {
+			decrypted, err := utils.Decrypt(m.encryptionKey, key)
+			if err != nil {
+				return nil, spverrors.Wrapf(err, "failed to decrypt xPub of multisig account")
+			}
+			key = decrypted
+		}
+		keys = append(keys, key)
+	}
+
+	m.xPubKeysDecrypted = keys
+	return m.xPubKeysDecrypted, nil
+}
+
+// memberIndex will return the position of the xPub in the account keys, -1 if it is not a member
+func (m *MultisigAccount) memberIndex(xPubID string) int {
+	for index, id := range m.XpubIDs {
+		if id == xPubID {
+			return index
+		}
+	}
+	return -1
+}
+
+// IsMember will check if the xPub ID is one of the account members
+func (m *MultisigAccount) IsMember(xPubID string) bool {
+	return m.memberIndex(xPubID) >= 0
+}
+
+// derivePublicKeys will derive the public keys of all the members for the given chain/num (in key order)
+func (m *MultisigAccount) derivePublicKeys(chain, num uint32) ([]*bec.PublicKey, error) {
+	keys, err := m.getXpubKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeys := make([]*bec.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		hdKey, err := utils.ValidateXPub(key)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Already wrapped in Validate
+		}
+
+		var pubKey *bec.PublicKey
+		if pubKey, err = utils.DerivePublicKey(hdKey, chain, num); err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	return pubKeys, nil
+}
+
+// lockingScript will create the bare-multisig locking script for the given chain/num
+//
+// OP_<threshold> <pubKey 1> ... <pubKey n> OP_<n> OP_CHECKMULTISIG
+func (m *MultisigAccount) lockingScript(chain, num uint32) (string, error) {
+	pubKeys, err := m.derivePublicKeys(chain, num)
+	if err != nil {
+		return "", err
+	}
+
+	s := &bscript.Script{}
+	if err = s.AppendOpcodes(bscript.Op1 + byte(m.Threshold) - 1); err != nil {
+		return "", spverrors.Wrapf(err, "failed to create multisig locking script")
+	}
+	for _, pubKey := range pubKeys {
+		if err = s.AppendPushData(pubKey.SerialiseCompressed()); err != nil {
+			return "", spverrors.Wrapf(err, "failed to create multisig locking script")
+		}
+	}
+	if err = s.AppendOpcodes(bscript.Op1+byte(len(pubKeys))-1, bscript.OpCHECKMULTISIG); err != nil {
+		return "", spverrors.Wrapf(err, "failed to create multisig locking script")
+	}
+
+	return s.String(), nil
+}
+
+// outputSize will get an estimated size of an output to the account
+func (m *MultisigAccount) outputSize() uint64 {
+	// 8 bytes value + 1 byte script length
+	// + 3 bytes opcodes (threshold, number of keys, OP_CHECKMULTISIG)
+	// + 34 bytes for every compressed public key (with push data)
+	return 12 + 34*uint64(len(m.XpubIDs))
+}
+
+// getNewDestination will create a new destination of the account, incrementing the num of the chain
+func (m *MultisigAccount) getNewDestination(ctx context.Context, chain uint32, opts ...ModelOps) (*Destination, error) {
+	num, err := m.incrementNextNum(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	lockingScript, err := m.lockingScript(chain, num)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := newDestination(m.ID, lockingScript, append(opts, New())...)
+	destination.Chain = chain
+	destination.Num = num
+
+	return destination, nil
+}
+
+// incrementNextNum will atomically update the num of the given chain of the account and return it
+func (m *MultisigAccount) incrementNextNum(ctx context.Context, chain uint32) (uint32, error) {
+	fieldName := nextExternalNumField
+	if chain == utils.ChainInternal {
+		fieldName = nextInternalNumField
+	}
+
+	newNum, err := incrementField(ctx, m, fieldName, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if chain == utils.ChainInternal {
+		m.NextInternalNum = uint32(newNum)
+	} else {
+		m.NextExternalNum = uint32(newNum)
+	}
+
+	return uint32(newNum - 1), nil
+}
+
+// incrementBalance will atomically update the balance of the account
+func (m *MultisigAccount) incrementBalance(ctx context.Context, balanceIncrement int64) error {
+	newBalance, err := incrementField(ctx, m, currentBalanceField, balanceIncrement)
+	if err != nil {
+		return err
+	}
+
+	m.CurrentBalance = uint64(newBalance)
+	return nil
+}
+
+// GetModelName will get the name of the current model
+func (m *MultisigAccount) GetModelName() string {
+	return ModelMultisigAccount.String()
+}
+
+// GetModelTableName will get the db table name of the current model
+func (m *MultisigAccount) GetModelTableName() string {
+	return tableMultisigAccounts
+}
+
+// Save will save the model into the Datastore
+func (m *MultisigAccount) Save(ctx context.Context) error {
+	return Save(ctx, m)
+}
+
+// GetID will get the ID
+func (m *MultisigAccount) GetID() string {
+	return m.ID
+}
+
+// BeforeCreating will fire before the model is being inserted into the Datastore
+func (m *MultisigAccount) BeforeCreating(_ context.Context) error {
+	m.Client().Logger().Debug().
+		Str("multisigAccountID", m.ID).
+		Msgf("starting: %s BeforeCreating hook...", m.Name())
+
+	if len(m.ID) == 0 {
+		return spverrors.ErrMissingFieldID
+	}
+
+	m.Client().Logger().Debug().
+		Str("multisigAccountID", m.ID).
+		Msgf("end: %s BeforeCreating hook", m.Name())
+	return nil
+}
+
+// Migrate model specific migration on startup
+func (m *MultisigAccount) Migrate(client datastore.ClientInterface) error {
+	err := client.IndexMetadata(client.GetTableName(tableMultisigAccounts), metadataField)
+	return spverrors.Wrapf(err, "failed to index metadata column on model %s", m.GetModelName())
+}
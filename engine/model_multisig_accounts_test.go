@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/libsv/go-bt/v2/bscript/interpreter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newMultisigAccount(t *testing.T) {
+	xPrivs := generateTestXPrivs(t, 3)
+	xPubs := neuterTestXPrivs(t, xPrivs)
+
+	t.Run("valid account", func(t *testing.T) {
+		account, err := newMultisigAccount(xPubs, 2)
+		require.NoError(t, err)
+		require.NotNil(t, account)
+
+		assert.Equal(t, ModelMultisigAccount.String(), account.GetModelName())
+		assert.Equal(t, 64, len(account.GetID()))
+		assert.Equal(t, uint16(2), account.Threshold)
+		assert.Len(t, account.XpubIDs, 3)
+		for _, xPub := range xPubs {
+			assert.True(t, account.IsMember(utils.Hash(xPub)))
+		}
+		assert.False(t, account.IsMember(testXPubID))
+
+		// same keys in a different order are the same account
+		var reversed *MultisigAccount
+		reversed, err = newMultisigAccount([]string{xPubs[2], xPubs[1], xPubs[0]}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, account.ID, reversed.ID)
+
+		var lockingScript string
+		lockingScript, err = account.lockingScript(utils.ChainExternal, 0)
+		require.NoError(t, err)
+		assert.True(t, utils.IsMultiSig(lockingScript))
+		assert.Equal(t, int(account.outputSize()), len(lockingScript)/2+9)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := newMultisigAccount(xPubs, 0)
+		require.ErrorIs(t, err, spverrors.ErrMultisigInvalidThreshold)
+
+		_, err = newMultisigAccount(xPubs, 4)
+		require.ErrorIs(t, err, spverrors.ErrMultisigInvalidThreshold)
+	})
+
+	t.Run("invalid number of keys", func(t *testing.T) {
+		_, err := newMultisigAccount(xPubs[:1], 1)
+		require.ErrorIs(t, err, spverrors.ErrMultisigInvalidNumberOfKeys)
+
+		_, err = newMultisigAccount([]string{xPubs[0], xPubs[0]}, 1)
+		require.ErrorIs(t, err, spverrors.ErrMultisigInvalidNumberOfKeys)
+	})
+}
+
+func TestClient_NewMultisigTransaction(t *testing.T) {
+	xPrivs := generateTestXPrivs(t, 3)
+	xPubs := neuterTestXPrivs(t, xPrivs)
+	xPubIDs := []string{utils.Hash(xPubs[0]), utils.Hash(xPubs[1]), utils.Hash(xPubs[2])}
+
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+	defer deferMe()
+
+	_, err := client.NewMultisigAccount(ctx, xPubs[0], xPubs, 2)
+	require.ErrorIs(t, err, spverrors.ErrCouldNotFindXpub)
+
+	for _, xPub := range xPubs {
+		_, err = client.NewXpub(ctx, xPub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+	}
+
+	_, err = client.NewMultisigAccount(ctx, testXPub, xPubs, 2)
+	require.ErrorIs(t, err, spverrors.ErrMultisigNotAMember)
+
+	account, err := client.NewMultisigAccount(ctx, xPubs[0], xPubs, 2)
+	require.NoError(t, err)
+
+	_, err = client.NewMultisigAccount(ctx, xPubs[1], xPubs, 2)
+	require.ErrorIs(t, err, spverrors.ErrMultisigAccountAlreadyExists)
+
+	_, err = client.GetMultisigAccount(ctx, testXPubID, account.ID)
+	require.ErrorIs(t, err, spverrors.ErrMultisigNotAMember)
+
+	destination, err := client.NewMultisigDestination(ctx, xPubIDs[1], account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.ID, destination.XpubID)
+	assert.Equal(t, utils.ScriptTypeMultiSig, destination.Type)
+	assert.Equal(t, utils.ChainExternal, destination.Chain)
+
+	// fund the account
+	utxo := newUtxo(account.ID, testTxID, destination.LockingScript, 0, 100000,
+		append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, utxo.Save(ctx))
+	require.NoError(t, account.incrementBalance(ctx, 100000))
+
+	draft, err := client.NewMultisigTransaction(ctx, xPubIDs[2], account.ID, &TransactionConfig{
+		Outputs: []*TransactionOutput{{
+			To:       "1AqYEDUf16CHaD2guBLHHhosfV2AyYJLz",
+			Satoshis: 1000,
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, account.ID, draft.XpubID)
+	require.Len(t, draft.Configuration.Inputs, 1)
+	require.Len(t, draft.Configuration.ChangeDestinations, 1)
+	assert.Equal(t, account.ID, draft.Configuration.ChangeDestinations[0].XpubID)
+	assert.Equal(t, utils.ScriptTypeMultiSig, draft.Configuration.ChangeDestinations[0].Type)
+	assert.Equal(t, utils.ChainInternal, draft.Configuration.ChangeDestinations[0].Chain)
+
+	// signatures of a non-member are rejected
+	_, _, err = client.AddMultisigSignatures(ctx, testXPubID, draft.ID, nil)
+	require.ErrorIs(t, err, spverrors.ErrMultisigNotAMember)
+
+	// first signature
+	signatures, err := draft.SignMultisigInputs(xPrivs[0])
+	require.NoError(t, err)
+	draft, _, err = client.AddMultisigSignatures(ctx, xPubIDs[0], draft.ID, signatures)
+	require.NoError(t, err)
+	assert.False(t, draft.IsMultisigComplete(account))
+	_, err = draft.CombineMultisigSignatures(account)
+	require.ErrorIs(t, err, spverrors.ErrMultisigThresholdNotMet)
+
+	// signatures made by another key are rejected
+	signatures, err = draft.SignMultisigInputs(xPrivs[2])
+	require.NoError(t, err)
+	_, _, err = client.AddMultisigSignatures(ctx, xPubIDs[1], draft.ID, signatures)
+	require.ErrorIs(t, err, spverrors.ErrMultisigInvalidSignature)
+
+	// second signature meets the threshold
+	draft, account, err = client.AddMultisigSignatures(ctx, xPubIDs[2], draft.ID, signatures)
+	require.NoError(t, err)
+	assert.True(t, draft.IsMultisigComplete(account))
+	assert.Len(t, draft.Signatures, 2)
+
+	signedHex, err := draft.CombineMultisigSignatures(account)
+	require.NoError(t, err)
+
+	// the signed transaction has to be valid
+	tx, err := bt.NewTxFromString(signedHex)
+	require.NoError(t, err)
+	lockingScript, err := bscript.NewFromHexString(destination.LockingScript)
+	require.NoError(t, err)
+	err = interpreter.NewEngine().Execute(
+		interpreter.WithTx(tx, 0, &bt.Output{LockingScript: lockingScript, Satoshis: 100000}),
+		interpreter.WithForkID(),
+		interpreter.WithAfterGenesis(),
+	)
+	require.NoError(t, err)
+
+	// the change of the transaction belongs to the account
+	_, err = client.RecordTransaction(ctx, xPubs[1], signedHex, draft.ID, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+
+	account, err = client.GetMultisigAccount(ctx, xPubIDs[0], account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, draft.Configuration.ChangeSatoshis, account.CurrentBalance)
+
+	spent, err := getUtxo(ctx, utxo.TransactionID, utxo.OutputIndex, client.DefaultModelOptions()...)
+	require.NoError(t, err)
+	assert.True(t, spent.SpendingTxID.Valid)
+}
+
+func generateTestXPrivs(t *testing.T, count int) []*bip32.ExtendedKey {
+	xPrivs := make([]*bip32.ExtendedKey, 0, count)
+	for i := 0; i < count; i++ {
+		xPriv, err := bitcoin.GenerateHDKey(bitcoin.RecommendedSeedLength)
+		require.NoError(t, err)
+		xPrivs = append(xPrivs, xPriv)
+	}
+	return xPrivs
+}
+
+func neuterTestXPrivs(t *testing.T, xPrivs []*bip32.ExtendedKey) []string {
+	xPubs := make([]string, 0, len(xPrivs))
+	for _, xPriv := range xPrivs {
+		xPub, err := xPriv.Neuter()
+		require.NoError(t, err)
+		xPubs = append(xPubs, xPub.String())
+	}
+	return xPubs
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// P2PReferenceStatus is the status of the reference of the P2P payment destinations
+type P2PReferenceStatus string
+
+const (
+	// P2PReferencePending is when the destinations wait for the transaction
+	P2PReferencePending P2PReferenceStatus = "pending"
+
+	// P2PReferenceReceived is when the transaction of the reference was received
+	P2PReferenceReceived P2PReferenceStatus = "received"
+
+	// P2PReferenceExpired is when no transaction arrived before the expiration (unused destinations are removed)
+	P2PReferenceExpired P2PReferenceStatus = "expired"
+)
+
+// P2PReference is the reference of the P2P payment destinations created for a paymail (P2P destination request),
+// it is tracked so the unused destinations can be removed after the expiration
+//
+// Gorm related models & indexes: https://gorm.io/docs/models.html - https://gorm.io/docs/indexes.html
+type P2PReference struct {
+	// Base model
+	Model `bson:",inline"`
+
+	// Model specific fields
+	ID             string             `json:"id" toml:"id" yaml:"id" gorm:"<-:create;type:char(32);primaryKey;comment:This is the reference ID of the P2P payment destinations" bson:"_id"`
+	XpubID         string             `json:"xpub_id" toml:"xpub_id" yaml:"xpub_id" gorm:"<-:create;type:char(64);index;comment:This is the related xPub" bson:"xpub_id"`
+	Paymail        string             `json:"paymail" toml:"paymail" yaml:"paymail" gorm:"<-:create;type:varchar(255);comment:This is the paymail of the destinations" bson:"paymail"`
+	Satoshis       uint64             `json:"satoshis" toml:"satoshis" yaml:"satoshis" gorm:"<-:create;comment:This is the requested amount" bson:"satoshis"`
+	DestinationIDs IDs                `json:"destination_ids" toml:"destination_ids" yaml:"destination_ids" gorm:"<-:create;type:text;comment:This is the list of the destinations of the reference" bson:"destination_ids"`
+	ExpiresAt      time.Time          `json:"expires_at" toml:"expires_at" yaml:"expires_at" gorm:"<-:create;index;comment:This is the time when the destinations expire" bson:"expires_at"`
+	Status         P2PReferenceStatus `json:"status" toml:"status" yaml:"status" gorm:"<-;type:varchar(20);index;comment:This is the status of the reference" bson:"status"`
+	TxID           string             `json:"tx_id" toml:"tx_id" yaml:"tx_id" gorm:"<-;type:char(64);comment:This is the received transaction" bson:"tx_id,omitempty"`
+}
+
+// newP2PReference will start a new P2P reference model
+func newP2PReference(referenceID string, pm *PaymailAddress, satoshis uint64, destinationIDs []string,
+	expiry time.Duration, opts ...ModelOps,
+) *P2PReference {
+	return &P2PReference{
+		ID:             referenceID,
+		XpubID:         pm.XpubID,
+		Paymail:        pm.String(),
+		Satoshis:       satoshis,
+		DestinationIDs: destinationIDs,
+		ExpiresAt:      time.Now().UTC().Add(expiry),
+		Status:         P2PReferencePending,
+		Model:          *NewBaseModel(ModelP2PReference, opts...),
+	}
+}
+
+// getP2PReference will get the P2P reference with the given ID
+func getP2PReference(ctx context.Context, id string, opts ...ModelOps) (*P2PReference, error) {
+	conditions := map[string]interface{}{
+		idField: id,
+	}
+
+	reference := &P2PReference{Model: *NewBaseModel(ModelP2PReference, opts...)}
+	if err := Get(ctx, reference, conditions, false, defaultDatabaseReadTimeout, false); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return reference, nil
+}
+
+// getExpiredP2PReferences will get the pending P2P references which expired before the given time
+func getExpiredP2PReferences(ctx context.Context, expiredBefore time.Time, queryParams *datastore.QueryParams,
+	opts ...ModelOps,
+) ([]*P2PReference, error) {
+	conditions := map[string]interface{}{
+		statusField: P2PReferencePending,
+		expiresAtField: map[string]interface{}{
+			"$lte": expiredBefore,
+		},
+	}
+
+	var modelItems []*P2PReference
+	if err := getModels(
+		ctx, NewBaseModel(ModelNameEmpty, opts...).Client().Datastore(),
+		&modelItems, conditions, queryParams, defaultDatabaseReadTimeout,
+	); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for index := range modelItems {
+		modelItems[index].enrich(ModelP2PReference, opts...)
+	}
+
+	return modelItems, nil
+}
+
+// isExpired will return true if the reference expired (the transaction is late)
+func (m *P2PReference) isExpired() bool {
+	return m.Status == P2PReferenceExpired ||
+		(m.Status == P2PReferencePending && time.Now().UTC().After(m.ExpiresAt))
+}
+
+// GetModelName will get the name of the current model
+func (m *P2PReference) GetModelName() string {
+	return ModelP2PReference.String()
+}
+
+// GetModelTableName will get the db table name of the current model
+func (m *P2PReference) GetModelTableName() string {
+	return tableP2PReferences
+}
+
+// Save will save the model into the Datastore
+func (m *P2PReference) Save(ctx context.Context) error {
+	return Save(ctx, m)
+}
+
+// GetID will get the ID
+func (m *P2PReference) GetID() string {
+	return m.ID
+}
+
+// BeforeCreating will fire before the model is being inserted into the Datastore
+func (m *P2PReference) BeforeCreating(_ context.Context) error {
+	m.Client().Logger().Debug().
+		Str("p2pReferenceID", m.ID).
+		Msgf("starting: %s BeforeCreate hook...", m.Name())
+
+	if m.ID == "" {
+		return spverrors.ErrMissingFieldID
+	}
+	if m.XpubID == "" {
+		return spverrors.ErrMissingFieldXpubID
+	}
+
+	m.Client().Logger().Debug().
+		Str("p2pReferenceID", m.ID).
+		Msgf("end: %s BeforeCreate hook", m.Name())
+	return nil
+}
+
+// Migrate model specific migration on startup
+func (m *P2PReference) Migrate(client datastore.ClientInterface) error {
+	err := client.IndexMetadata(client.GetTableName(tableP2PReferences), metadataField)
+	return spverrors.Wrapf(err, "failed to index metadata column on model %s", m.GetModelName())
+}
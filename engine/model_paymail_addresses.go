@@ -36,6 +36,10 @@ type PaymailAddress struct {
 	PubKeyNum          uint32 `json:"pubkey_num" toml:"pubkey_num" yaml:"pubkey_num" gorm:"<-;type:int;default:0;comment:Derivation number use to create PKI public key:pubkey_num"`
 	XpubDerivationSeq  uint32 `json:"xpub_derivation_seq" toml:"xpub_derivation_seq" yaml:"xpub_derivation_seq" gorm:"<-;type:int;default:0;comment:The index derivation number use to generate new external xpub child keys and rotate PubKey:xpub_derivation_seq"`
 
+	PreviousPubKeys PaymailPreviousPubKeys `json:"previous_pub_keys,omitempty" toml:"previous_pub_keys" yaml:"previous_pub_keys" gorm:"<-;type:text;comment:This is the rotated PKI public keys which are still verifiable in JSON" bson:"previous_pub_keys,omitempty"`
+	ReceivePolicy   *PaymailReceivePolicy  `json:"receive_policy,omitempty" toml:"receive_policy" yaml:"receive_policy" gorm:"<-;type:text;comment:This is the policy for receiving the P2P payments in JSON" bson:"receive_policy,omitempty"`
+	OutputTemplate  *PaymailOutputTemplate `json:"output_template,omitempty" toml:"output_template" yaml:"output_template" gorm:"<-;type:text;comment:This is the template of the PIKE outputs in JSON" bson:"output_template,omitempty"`
+
 	// Private fields
 	externalXpubKeyDecrypted string
 	externalHdXpub           *bip32.ExtendedKey
@@ -239,6 +243,8 @@ func (m *PaymailAddress) GetNextXpub(ctx context.Context) (*bip32.ExtendedKey, e
 }
 
 // RotatePubKey will rotate the public key
+//
+// The previous public key stays verifiable during the grace period (for in-flight payments)
 func (m *PaymailAddress) RotatePubKey(ctx context.Context) error {
 	unlock, err := getWaitWriteLockForPaymail(ctx, m.client.Cachestore(), m.ID)
 	defer unlock()
@@ -246,10 +252,16 @@ func (m *PaymailAddress) RotatePubKey(ctx context.Context) error {
 		return err
 	}
 
+	previousPubKey, err := m.GetPubKey()
+	if err != nil {
+		return err
+	}
+
 	if err = m.incrementExternalXpubDerivationSeq(ctx); err != nil {
 		return err
 	}
 
+	m.addPreviousPubKey(previousPubKey, m.pubKeyGracePeriod())
 	m.PubKeyNum = m.XpubDerivationSeq
 	return m.Save(ctx)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/utils"
 	"github.com/bitcoinschema/go-bitcoin/v2"
@@ -248,6 +249,40 @@ func TestNewPaymail(t *testing.T) {
 		require.NoError(t, err)
 
 		require.Equal(t, hex.EncodeToString(expectedPubKey.SerialiseCompressed()), secondPubKey)
+
+		// previous key stays verifiable for the grace period
+		require.Len(t, pm.PreviousPubKeys, 1)
+		require.Equal(t, firstPubKey, pm.PreviousPubKeys[0].PubKey)
+		require.Equal(t, initialDerivationSeq, pm.PreviousPubKeys[0].PubKeyNum)
+		require.True(t, pm.isPreviousPubKey(firstPubKey))
+		require.False(t, pm.isPreviousPubKey(secondPubKey))
+	})
+
+	t.Run("RotatePubKey() - expired previous keys are removed", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		// given
+		pm := newPaymail("paymail@domain.sc", randDerivationNum(), WithClient(c), WithXPub(testXPub))
+		err := pm.Save(ctx)
+		require.NoError(t, err)
+
+		firstPubKey, err := pm.GetPubKey()
+		require.NoError(t, err)
+
+		err = pm.RotatePubKey(ctx)
+		require.NoError(t, err)
+
+		pm.PreviousPubKeys[0].ValidUntil = time.Now().Add(-time.Minute)
+
+		// when
+		err = pm.RotatePubKey(ctx)
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, pm.PreviousPubKeys, 1)
+		require.NotEqual(t, firstPubKey, pm.PreviousPubKeys[0].PubKey)
+		require.False(t, pm.isPreviousPubKey(firstPubKey))
 	})
 
 	t.Run("ExternalXPub and PubKey rotation test", func(t *testing.T) {
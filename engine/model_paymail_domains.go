@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// PaymailDomain is a paymail domain served by the paymail server (managed at runtime, next to the domains from config)
+//
+// Gorm related models & indexes: https://gorm.io/docs/models.html - https://gorm.io/docs/indexes.html
+type PaymailDomain struct {
+	// Base model
+	Model `bson:",inline"`
+
+	// Model specific fields
+	ID                      string `json:"id" toml:"id" yaml:"id" gorm:"<-:create;type:varchar(255);primaryKey;comment:This is the domain name" bson:"_id"`
+	SenderValidationEnabled bool   `json:"sender_validation_enabled" toml:"sender_validation_enabled" yaml:"sender_validation_enabled" gorm:"<-;comment:Requires signed sender requests for the paymails of the domain" bson:"sender_validation_enabled"`
+	BeefEnabled             bool   `json:"beef_enabled" toml:"beef_enabled" yaml:"beef_enabled" gorm:"<-;comment:Accepts BEEF transactions for the paymails of the domain" bson:"beef_enabled"`
+	PikeEnabled             bool   `json:"pike_enabled" toml:"pike_enabled" yaml:"pike_enabled" gorm:"<-;comment:Accepts PIKE requests for the paymails of the domain" bson:"pike_enabled"`
+	DefaultFromPaymail      string `json:"default_from_paymail" toml:"default_from_paymail" yaml:"default_from_paymail" gorm:"<-;comment:Sender paymail used for the xPubs without paymail sending from the domain" bson:"default_from_paymail"`
+}
+
+// PaymailDomainSettings are the per-domain settings of the paymail domain
+type PaymailDomainSettings struct {
+	SenderValidationEnabled bool   // Require signed sender requests
+	BeefEnabled             bool   // Accept BEEF transactions
+	PikeEnabled             bool   // Accept PIKE requests
+	DefaultFromPaymail      string // Sender paymail used for the xPubs without paymail
+}
+
+// newPaymailDomain will start a new paymail domain model
+func newPaymailDomain(domain string, settings *PaymailDomainSettings, opts ...ModelOps) *PaymailDomain {
+	// Standardize the domain name (validated before creating)
+	sanitized, err := paymail.SanitizeDomain(domain)
+	if err != nil {
+		sanitized = domain
+	}
+
+	m := &PaymailDomain{
+		ID:    sanitized,
+		Model: *NewBaseModel(ModelPaymailDomain, opts...),
+	}
+	m.setSettings(settings)
+	return m
+}
+
+// getPaymailDomain will get the paymail domain with the given name
+func getPaymailDomain(ctx context.Context, domain string, opts ...ModelOps) (*PaymailDomain, error) {
+	sanitized, err := paymail.SanitizeDomain(domain)
+	if err != nil {
+		return nil, spverrors.ErrPaymailDomainInvalid
+	}
+
+	conditions := map[string]interface{}{
+		idField: sanitized,
+	}
+
+	paymailDomain := &PaymailDomain{Model: *NewBaseModel(ModelPaymailDomain, opts...)}
+	if err = Get(ctx, paymailDomain, conditions, false, defaultDatabaseReadTimeout, false); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return paymailDomain, nil
+}
+
+// getPaymailDomains will get all the paymail domains with the given conditions
+func getPaymailDomains(ctx context.Context, metadata *Metadata, conditions map[string]interface{},
+	queryParams *datastore.QueryParams, opts ...ModelOps,
+) ([]*PaymailDomain, error) {
+	modelItems := make([]*PaymailDomain, 0)
+	if err := getModelsByConditions(ctx, ModelPaymailDomain, &modelItems, metadata, conditions, queryParams, opts...); err != nil {
+		return nil, err
+	}
+
+	return modelItems, nil
+}
+
+// getPaymailDomainsCount will get a count of all the paymail domains with the given conditions
+func getPaymailDomainsCount(ctx context.Context, metadata *Metadata, conditions map[string]interface{},
+	opts ...ModelOps,
+) (int64, error) {
+	return getModelCountByConditions(ctx, ModelPaymailDomain, PaymailDomain{}, metadata, conditions, opts...)
+}
+
+// Settings will return the per-domain settings of the paymail domain
+func (m *PaymailDomain) Settings() *PaymailDomainSettings {
+	return &PaymailDomainSettings{
+		SenderValidationEnabled: m.SenderValidationEnabled,
+		BeefEnabled:             m.BeefEnabled,
+		PikeEnabled:             m.PikeEnabled,
+		DefaultFromPaymail:      m.DefaultFromPaymail,
+	}
+}
+
+// setSettings will set the per-domain settings of the paymail domain (nil keeps the current settings)
+func (m *PaymailDomain) setSettings(settings *PaymailDomainSettings) {
+	if settings == nil {
+		return
+	}
+	m.SenderValidationEnabled = settings.SenderValidationEnabled
+	m.BeefEnabled = settings.BeefEnabled
+	m.PikeEnabled = settings.PikeEnabled
+	m.DefaultFromPaymail = settings.DefaultFromPaymail
+}
+
+// validate will check the domain name and the settings of the paymail domain
+func (m *PaymailDomain) validate() error {
+	if err := paymail.ValidateDomain(m.ID); err != nil {
+		return spverrors.Wrapf(spverrors.ErrPaymailDomainInvalid, err.Error())
+	}
+
+	if m.DefaultFromPaymail != "" {
+		if err := paymail.ValidatePaymail(m.DefaultFromPaymail); err != nil {
+			return spverrors.ErrPaymailAddressIsInvalid
+		}
+	}
+
+	return nil
+}
+
+// GetModelName will get the name of the current model
+func (m *PaymailDomain) GetModelName() string {
+	return ModelPaymailDomain.String()
+}
+
+// GetModelTableName will get the db table name of the current model
+func (m *PaymailDomain) GetModelTableName() string {
+	return tablePaymailDomains
+}
+
+// Save will save the model into the Datastore
+func (m *PaymailDomain) Save(ctx context.Context) error {
+	return Save(ctx, m)
+}
+
+// GetID will get the ID
+func (m *PaymailDomain) GetID() string {
+	return m.ID
+}
+
+// BeforeCreating will fire before the model is being inserted into the Datastore
+func (m *PaymailDomain) BeforeCreating(_ context.Context) error {
+	m.Client().Logger().Debug().
+		Str("paymailDomainID", m.ID).
+		Msgf("starting: %s BeforeCreate hook...", m.Name())
+
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	m.Client().Logger().Debug().
+		Str("paymailDomainID", m.ID).
+		Msgf("end: %s BeforeCreate hook", m.Name())
+	return nil
+}
+
+// BeforeUpdating will fire before the model is being updated in the Datastore
+func (m *PaymailDomain) BeforeUpdating(_ context.Context) error {
+	return m.validate()
+}
+
+// Migrate model specific migration on startup
+func (m *PaymailDomain) Migrate(client datastore.ClientInterface) error {
+	err := client.IndexMetadata(client.GetTableName(tablePaymailDomains), metadataField)
+	return spverrors.Wrapf(err, "failed to index metadata column on model %s", m.GetModelName())
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"database/sql/driver"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+)
+
+// ScheduledPaymentStatus represents statuses of scheduled payment model.
+type ScheduledPaymentStatus string
+
+const (
+	// ScheduledPaymentActive is a status telling that the schedule creates drafts when due.
+	ScheduledPaymentActive ScheduledPaymentStatus = "active"
+	// ScheduledPaymentCompleted is a status telling that the schedule reached its end date or max runs.
+	ScheduledPaymentCompleted ScheduledPaymentStatus = "completed"
+	// ScheduledPaymentCanceled is a status telling that the schedule was canceled by the user.
+	ScheduledPaymentCanceled ScheduledPaymentStatus = "canceled"
+	// ScheduledPaymentFailed is a status telling that the schedule was stopped after too many consecutive failures.
+	ScheduledPaymentFailed ScheduledPaymentStatus = "failed"
+)
+
+var scheduledPaymentStatusMapper = NewEnumStringMapper(
+	ScheduledPaymentActive,
+	ScheduledPaymentCompleted,
+	ScheduledPaymentCanceled,
+	ScheduledPaymentFailed,
+)
+
+// Scan will scan the value into Struct, implements sql.Scanner interface
+func (t *ScheduledPaymentStatus) Scan(value interface{}) error {
+	stringValue, err := utils.StrOrBytesToString(value)
+	if err != nil {
+		return nil
+	}
+
+	status, ok := scheduledPaymentStatusMapper.Get(stringValue)
+	if !ok {
+		return spverrors.Newf("invalid scheduled payment status: %s", stringValue)
+	}
+	*t = status
+
+	return nil
+}
+
+// Value return json value, implement driver.Valuer interface
+func (t ScheduledPaymentStatus) Value() (driver.Value, error) {
+	return string(t), nil
+}
+
+// String is the string version of the status
+func (t ScheduledPaymentStatus) String() string {
+	return string(t)
+}
@@ -0,0 +1,443 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	customTypes "github.com/bitcoin-sv/spv-wallet/engine/datastore/customtypes"
+	"github.com/bitcoin-sv/spv-wallet/engine/notifications"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduledPayment is a recurring payment definition. When it is due, the engine creates a draft transaction
+// and notifies the owner, who has to sign and record it (the server never holds the xPriv).
+//
+// Gorm related models & indexes: https://gorm.io/docs/models.html - https://gorm.io/docs/indexes.html
+type ScheduledPayment struct {
+	// Base model
+	Model `bson:",inline"`
+
+	// Model specific fields
+	ID             string                 `json:"id" toml:"id" yaml:"id" gorm:"<-:create;type:char(64);primaryKey;comment:This is the unique scheduled payment id" bson:"_id"`
+	XpubID         string                 `json:"xpub_id" toml:"xpub_id" yaml:"xpub_id" gorm:"<-:create;type:char(64);index;comment:This is the related xPub" bson:"xpub_id"`
+	XpubKey        string                 `json:"-" toml:"-" yaml:"-" gorm:"<-:create;type:varchar(512);comment:This is the xPub used to create drafts, encryption optional" bson:"xpub_key"`
+	Recipient      string                 `json:"recipient" toml:"recipient" yaml:"recipient" gorm:"<-:create;type:varchar(255);comment:This is the paymail, address or handle of the recipient" bson:"recipient"`
+	Satoshis       uint64                 `json:"satoshis" toml:"satoshis" yaml:"satoshis" gorm:"<-:create;comment:This is the amount sent on every run" bson:"satoshis"`
+	CronExpression string                 `json:"cron_expression,omitempty" toml:"cron_expression" yaml:"cron_expression" gorm:"<-:create;type:varchar(64);comment:This is the standard cron expression of the schedule" bson:"cron_expression,omitempty"`
+	Interval       time.Duration          `json:"interval,omitempty" toml:"interval" yaml:"interval" gorm:"<-:create;comment:This is the interval between runs (used when no cron expression is set)" bson:"interval,omitempty"`
+	EndAt          customTypes.NullTime   `json:"end_at" toml:"end_at" yaml:"end_at" gorm:"<-:create;comment:This is the time after which no more runs are made" bson:"end_at,omitempty"`
+	MaxRuns        uint32                 `json:"max_runs" toml:"max_runs" yaml:"max_runs" gorm:"<-:create;type:int;default:0;comment:This is the maximum number of runs, 0 means unlimited" bson:"max_runs"`
+	RunCount       uint32                 `json:"run_count" toml:"run_count" yaml:"run_count" gorm:"<-;type:int;default:0;comment:This is the number of successful runs" bson:"run_count"`
+	FailureCount   uint32                 `json:"failure_count" toml:"failure_count" yaml:"failure_count" gorm:"<-;type:int;default:0;comment:This is the number of consecutive failed runs" bson:"failure_count"`
+	NextRunAt      customTypes.NullTime   `json:"next_run_at" toml:"next_run_at" yaml:"next_run_at" gorm:"<-;index;comment:This is the time of the next run" bson:"next_run_at,omitempty"`
+	LastRunAt      customTypes.NullTime   `json:"last_run_at" toml:"last_run_at" yaml:"last_run_at" gorm:"<-;comment:This is the time of the last run" bson:"last_run_at,omitempty"`
+	Status         ScheduledPaymentStatus `json:"status" toml:"status" yaml:"status" gorm:"<-;type:varchar(20);index;comment:This is the status of the schedule" bson:"status"`
+	Runs           ScheduledPaymentRuns   `json:"runs" toml:"runs" yaml:"runs" gorm:"<-;type:text;comment:This is the history of the runs in JSON" bson:"runs"`
+
+	// Private fields
+	xPubKeyDecrypted string
+}
+
+// ScheduledPaymentConfig is the configuration used to create a scheduled payment
+type ScheduledPaymentConfig struct {
+	Recipient      string        // Paymail, address or handle of the recipient
+	Satoshis       uint64        // Amount sent on every run
+	CronExpression string        // Standard (5 fields) cron expression, takes precedence over Interval
+	Interval       time.Duration // Interval between runs
+	StartAt        time.Time     // Time of the first run (defaults to now)
+	EndAt          time.Time     // Time after which no more runs are made (optional)
+	MaxRuns        uint32        // Maximum number of runs, 0 means unlimited
+}
+
+// ScheduledPaymentRuns is the history of the runs of a scheduled payment
+type ScheduledPaymentRuns struct {
+	Results []*ScheduledPaymentRun `json:"results"`
+}
+
+// ScheduledPaymentRun is a single attempt to create a draft for a scheduled payment
+type ScheduledPaymentRun struct {
+	ExecutedAt time.Time `json:"executed_at"`        // Time it was executed
+	DraftID    string    `json:"draft_id,omitempty"` // Draft created in this run
+	Error      string    `json:"error,omitempty"`    // Failure reason
+}
+
+// newScheduledPayment will start a new scheduled payment model
+func newScheduledPayment(rawXpubKey string, config *ScheduledPaymentConfig, opts ...ModelOps) (*ScheduledPayment, error) {
+	id, _ := utils.RandomHex(32)
+
+	m := &ScheduledPayment{
+		ID:             id,
+		XpubID:         utils.Hash(rawXpubKey),
+		Recipient:      config.Recipient,
+		Satoshis:       config.Satoshis,
+		CronExpression: config.CronExpression,
+		Interval:       config.Interval,
+		MaxRuns:        config.MaxRuns,
+		Status:         ScheduledPaymentActive,
+		Model:          *NewBaseModel(ModelScheduledPayment, append(opts, WithXPub(rawXpubKey))...),
+	}
+
+	if !config.EndAt.IsZero() {
+		m.EndAt.Valid = true
+		m.EndAt.Time = config.EndAt.UTC()
+	}
+
+	startAt := config.StartAt.UTC()
+	if config.StartAt.IsZero() {
+		startAt = time.Now().UTC()
+	}
+	m.NextRunAt.Valid = true
+	m.NextRunAt.Time = startAt
+
+	if err := m.setXpubKey(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// getScheduledPayment will get the scheduled payment with the given ID (and xPubID if set)
+func getScheduledPayment(ctx context.Context, xPubID, id string, opts ...ModelOps) (*ScheduledPayment, error) {
+	conditions := map[string]interface{}{
+		idField: id,
+	}
+	if len(xPubID) > 0 {
+		conditions[xPubIDField] = xPubID
+	}
+
+	scheduledPayment := &ScheduledPayment{Model: *NewBaseModel(ModelScheduledPayment, opts...)}
+	if err := Get(ctx, scheduledPayment, conditions, false, defaultDatabaseReadTimeout, false); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return scheduledPayment, nil
+}
+
+// getScheduledPayments will get all the scheduled payments with the given conditions
+func getScheduledPayments(ctx context.Context, metadata *Metadata, conditions map[string]interface{},
+	queryParams *datastore.QueryParams, opts ...ModelOps,
+) ([]*ScheduledPayment, error) {
+	modelItems := make([]*ScheduledPayment, 0)
+	if err := getModelsByConditions(ctx, ModelScheduledPayment, &modelItems, metadata, conditions, queryParams, opts...); err != nil {
+		return nil, err
+	}
+
+	return modelItems, nil
+}
+
+// getScheduledPaymentsCount will get a count of all the scheduled payments with the given conditions
+func getScheduledPaymentsCount(ctx context.Context, metadata *Metadata, conditions map[string]interface{},
+	opts ...ModelOps,
+) (int64, error) {
+	return getModelCountByConditions(ctx, ModelScheduledPayment, ScheduledPayment{}, metadata, conditions, opts...)
+}
+
+// getDueScheduledPayments will get the active scheduled payments which next run time has passed
+func getDueScheduledPayments(ctx context.Context, queryParams *datastore.QueryParams,
+	opts ...ModelOps,
+) ([]*ScheduledPayment, error) {
+	conditions := map[string]interface{}{
+		statusField: ScheduledPaymentActive,
+		nextRunAtField: map[string]interface{}{
+			"$lte": time.Now().UTC(),
+		},
+	}
+
+	var modelItems []*ScheduledPayment
+	if err := getModels(
+		ctx, NewBaseModel(ModelNameEmpty, opts...).Client().Datastore(),
+		&modelItems, conditions, queryParams, defaultDatabaseReadTimeout,
+	); err != nil {
+		if errors.Is(err, datastore.ErrNoResults) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for index := range modelItems {
+		modelItems[index].enrich(ModelScheduledPayment, opts...)
+	}
+
+	return modelItems, nil
+}
+
+// setXpubKey will set the "XpubKey" from the raw xPub,
+// encrypted with the given encryption key (if a key is set)
+func (m *ScheduledPayment) setXpubKey() (err error) {
+	m.xPubKeyDecrypted = m.rawXpubKey
+	if len(m.encryptionKey) > 0 {
+		m.XpubKey, err = utils.Encrypt(m.encryptionKey, m.rawXpubKey)
+	} else {
+		m.XpubKey = m.rawXpubKey
+	}
+
+	return spverrors.Wrapf(err, "failed to encrypt xPub of scheduled payment")
+}
+
+// getXpubKey will get the raw xPub used to create the drafts
+func (m *ScheduledPayment) getXpubKey() (string, error) {
+	if len(m.xPubKeyDecrypted) > 0 {
+		return m.xPubKeyDecrypted, nil
+	}
+
+	if len(m.XpubKey) == utils.XpubKeyLength {
+		m.xPubKeyDecrypted = m.XpubKey
+		return m.xPubKeyDecrypted, nil
+	}
+
+	decrypted, err := utils.Decrypt(m.encryptionKey, m.XpubKey)
+	if err != nil {
+		return "", spverrors.Wrapf(err, "failed to decrypt xPub of scheduled payment")
+	}
+	m.xPubKeyDecrypted = decrypted
+	return m.xPubKeyDecrypted, nil
+}
+
+// nextRunAfter will calculate the time of the run following the given time
+func (m *ScheduledPayment) nextRunAfter(t time.Time) (time.Time, error) {
+	if len(m.CronExpression) > 0 {
+		schedule, err := cron.ParseStandard(m.CronExpression)
+		if err != nil {
+			return time.Time{}, spverrors.ErrScheduledPaymentInvalidCron
+		}
+		return schedule.Next(t).UTC(), nil
+	}
+
+	return t.Add(m.Interval).UTC(), nil
+}
+
+// run will create the draft transaction for the scheduled payment and notify the owner
+func (m *ScheduledPayment) run(ctx context.Context) error {
+	run := &ScheduledPaymentRun{ExecutedAt: time.Now().UTC()}
+
+	draft, err := m.createDraft(ctx)
+	if err != nil {
+		run.Error = err.Error()
+		m.FailureCount++
+	} else {
+		run.DraftID = draft.ID
+		m.RunCount++
+		m.FailureCount = 0
+	}
+
+	m.Runs.add(run)
+	m.LastRunAt.Valid = true
+	m.LastRunAt.Time = run.ExecutedAt
+
+	if err = m.advance(run.ExecutedAt); err != nil {
+		return err
+	}
+
+	if err = m.Save(ctx); err != nil {
+		return err
+	}
+
+	m.notify(run)
+	return nil
+}
+
+// createDraft will create a draft transaction paying the recipient
+func (m *ScheduledPayment) createDraft(ctx context.Context) (*DraftTransaction, error) {
+	rawXpubKey, err := m.getXpubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &TransactionConfig{
+		ExpiresIn: defaultScheduledPaymentDraftExpiresIn,
+		Outputs: []*TransactionOutput{{
+			To:       m.Recipient,
+			Satoshis: m.Satoshis,
+		}},
+	}
+
+	metadata := Metadata{}
+	for key, value := range m.Metadata {
+		metadata[key] = value
+	}
+	metadata[scheduledPaymentIDField] = m.ID
+
+	return m.Client().NewTransaction(ctx, rawXpubKey, config, WithMetadatas(metadata))
+}
+
+// advance will move the schedule to the next run or finish it
+func (m *ScheduledPayment) advance(now time.Time) error {
+	if m.FailureCount >= maxScheduledPaymentFailures {
+		m.Status = ScheduledPaymentFailed
+		m.NextRunAt.Valid = false
+		return nil
+	}
+
+	if m.MaxRuns > 0 && m.RunCount >= m.MaxRuns {
+		m.Status = ScheduledPaymentCompleted
+		m.NextRunAt.Valid = false
+		return nil
+	}
+
+	next, err := m.nextRunAfter(now)
+	if err != nil {
+		return err
+	}
+
+	if m.EndAt.Valid && next.After(m.EndAt.Time) {
+		m.Status = ScheduledPaymentCompleted
+		m.NextRunAt.Valid = false
+		return nil
+	}
+
+	m.NextRunAt.Valid = true
+	m.NextRunAt.Time = next
+	return nil
+}
+
+// Cancel marks the scheduled payment as canceled, no more drafts will be created
+func (m *ScheduledPayment) Cancel() error {
+	if m.Status != ScheduledPaymentActive {
+		return spverrors.Newf("cannot cancel scheduled payment. Reason: status: %s, expected: %s", m.Status, ScheduledPaymentActive)
+	}
+
+	m.Status = ScheduledPaymentCanceled
+	m.NextRunAt.Valid = false
+	return nil
+}
+
+// notify will send the result of the run to the owner of the scheduled payment
+func (m *ScheduledPayment) notify(run *ScheduledPaymentRun) {
+	n := m.Client().Notifications()
+	if n == nil {
+		return
+	}
+
+	status := "draft_created"
+	if len(run.Error) > 0 {
+		status = "failed"
+	}
+
+	notifications.Notify(n, &models.ScheduledPaymentEvent{
+		UserEvent: models.UserEvent{
+			XPubID: m.XpubID,
+		},
+		ScheduledPaymentID: m.ID,
+		DraftID:            run.DraftID,
+		Status:             status,
+		Error:              run.Error,
+	})
+}
+
+func (m *ScheduledPayment) validate() error {
+	if m.ID == "" {
+		return spverrors.ErrMissingFieldID
+	}
+
+	if m.XpubID == "" {
+		return spverrors.ErrMissingFieldXpubID
+	}
+
+	if m.Recipient == "" {
+		return spverrors.ErrScheduledPaymentMissingRecipient
+	}
+
+	if m.Satoshis <= dustLimit {
+		return spverrors.ErrOutputValueTooLow
+	}
+
+	if len(m.CronExpression) > 0 {
+		if _, err := cron.ParseStandard(m.CronExpression); err != nil {
+			return spverrors.ErrScheduledPaymentInvalidCron
+		}
+	} else if m.Interval < minScheduledPaymentInterval {
+		return spverrors.ErrScheduledPaymentInvalidInterval
+	}
+
+	if m.EndAt.Valid && m.NextRunAt.Valid && m.EndAt.Time.Before(m.NextRunAt.Time) {
+		return spverrors.ErrScheduledPaymentEndBeforeStart
+	}
+
+	return nil
+}
+
+// add will append the run to the history, keeping only the most recent runs
+func (r *ScheduledPaymentRuns) add(run *ScheduledPaymentRun) {
+	r.Results = append(r.Results, run)
+	if len(r.Results) > maxScheduledPaymentRunsHistory {
+		r.Results = r.Results[len(r.Results)-maxScheduledPaymentRunsHistory:]
+	}
+}
+
+// Scan will scan the value into Struct, implements sql.Scanner interface
+func (r *ScheduledPaymentRuns) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	byteValue, err := utils.ToByteArray(value)
+	if err != nil || bytes.Equal(byteValue, []byte("")) || bytes.Equal(byteValue, []byte("\"\"")) {
+		return nil
+	}
+
+	err = json.Unmarshal(byteValue, &r)
+	return spverrors.Wrapf(err, "failed to parse ScheduledPaymentRuns from JSON")
+}
+
+// Value return json value, implement driver.Valuer interface
+func (r ScheduledPaymentRuns) Value() (driver.Value, error) {
+	marshal, err := json.Marshal(r)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to convert ScheduledPaymentRuns to JSON")
+	}
+
+	return string(marshal), nil
+}
+
+// GetModelName will get the name of the current model
+func (m *ScheduledPayment) GetModelName() string {
+	return ModelScheduledPayment.String()
+}
+
+// GetModelTableName will get the db table name of the current model
+func (m *ScheduledPayment) GetModelTableName() string {
+	return tableScheduledPayments
+}
+
+// Save will save the model into the Datastore
+func (m *ScheduledPayment) Save(ctx context.Context) error {
+	return Save(ctx, m)
+}
+
+// GetID will get the ID
+func (m *ScheduledPayment) GetID() string {
+	return m.ID
+}
+
+// BeforeCreating will fire before the model is being inserted into the Datastore
+func (m *ScheduledPayment) BeforeCreating(_ context.Context) (err error) {
+	m.Client().Logger().Debug().
+		Str("scheduledPaymentID", m.ID).
+		Msgf("starting: %s BeforeCreate hook...", m.Name())
+
+	if err = m.validate(); err != nil {
+		return
+	}
+
+	m.Client().Logger().Debug().
+		Str("scheduledPaymentID", m.ID).
+		Msgf("end: %s BeforeCreate hook", m.Name())
+	return
+}
+
+// Migrate model specific migration on startup
+func (m *ScheduledPayment) Migrate(client datastore.ClientInterface) error {
+	err := client.IndexMetadata(client.GetTableName(tableScheduledPayments), metadataField)
+	return spverrors.Wrapf(err, "failed to index metadata column on model %s", m.GetModelName())
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newScheduledPayment(t *testing.T) {
+	t.Run("valid scheduled payment", func(t *testing.T) {
+		startAt := time.Now().Add(time.Hour).UTC()
+		scheduledPayment, err := newScheduledPayment(testXPub, &ScheduledPaymentConfig{
+			Recipient: "alice@example.com",
+			Satoshis:  1000,
+			Interval:  24 * time.Hour,
+			StartAt:   startAt,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, scheduledPayment)
+
+		assert.Equal(t, testXPubID, scheduledPayment.XpubID)
+		assert.Equal(t, ModelScheduledPayment.String(), scheduledPayment.GetModelName())
+		assert.Equal(t, 64, len(scheduledPayment.GetID()))
+		assert.Equal(t, ScheduledPaymentActive, scheduledPayment.Status)
+		assert.Equal(t, startAt, scheduledPayment.NextRunAt.Time)
+		assert.False(t, scheduledPayment.EndAt.Valid)
+
+		rawXpubKey, err := scheduledPayment.getXpubKey()
+		require.NoError(t, err)
+		assert.Equal(t, testXPub, rawXpubKey)
+		require.NoError(t, scheduledPayment.validate())
+	})
+
+	t.Run("save and get", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup())
+		defer deferMe()
+
+		scheduledPayment, err := newScheduledPayment(testXPub, &ScheduledPaymentConfig{
+			Recipient:      "alice@example.com",
+			Satoshis:       1000,
+			CronExpression: "0 12 1 * *",
+		}, append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, err)
+		require.NoError(t, scheduledPayment.Save(ctx))
+
+		var stored *ScheduledPayment
+		stored, err = getScheduledPayment(ctx, testXPubID, scheduledPayment.ID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "0 12 1 * *", stored.CronExpression)
+		assert.Equal(t, ScheduledPaymentActive, stored.Status)
+
+		stored, err = getScheduledPayment(ctx, "other-xpub-id", scheduledPayment.ID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+}
+
+func Test_scheduledPayment_validate_returns_error(t *testing.T) {
+	tcs := []struct {
+		name          string
+		config        *ScheduledPaymentConfig
+		expectedError error
+	}{
+		{
+			name:          "empty recipient",
+			config:        &ScheduledPaymentConfig{Satoshis: 1000, Interval: time.Hour},
+			expectedError: spverrors.ErrScheduledPaymentMissingRecipient,
+		},
+		{
+			name:          "value below dust limit",
+			config:        &ScheduledPaymentConfig{Recipient: "alice@example.com", Satoshis: 1, Interval: time.Hour},
+			expectedError: spverrors.ErrOutputValueTooLow,
+		},
+		{
+			name:          "invalid cron expression",
+			config:        &ScheduledPaymentConfig{Recipient: "alice@example.com", Satoshis: 1000, CronExpression: "every day"},
+			expectedError: spverrors.ErrScheduledPaymentInvalidCron,
+		},
+		{
+			name:          "interval too short",
+			config:        &ScheduledPaymentConfig{Recipient: "alice@example.com", Satoshis: 1000, Interval: time.Second},
+			expectedError: spverrors.ErrScheduledPaymentInvalidInterval,
+		},
+		{
+			name: "end before start",
+			config: &ScheduledPaymentConfig{
+				Recipient: "alice@example.com", Satoshis: 1000, Interval: time.Hour,
+				StartAt: time.Now().Add(time.Hour), EndAt: time.Now(),
+			},
+			expectedError: spverrors.ErrScheduledPaymentEndBeforeStart,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduledPayment, err := newScheduledPayment(testXPub, tc.config)
+			require.NoError(t, err)
+
+			err = scheduledPayment.validate()
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_scheduledPayment_advance(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	newTestScheduledPayment := func(config *ScheduledPaymentConfig) *ScheduledPayment {
+		config.Recipient = "alice@example.com"
+		config.Satoshis = 1000
+		scheduledPayment, err := newScheduledPayment(testXPub, config)
+		require.NoError(t, err)
+		return scheduledPayment
+	}
+
+	t.Run("next run by interval", func(t *testing.T) {
+		scheduledPayment := newTestScheduledPayment(&ScheduledPaymentConfig{Interval: time.Hour})
+
+		require.NoError(t, scheduledPayment.advance(now))
+		assert.Equal(t, ScheduledPaymentActive, scheduledPayment.Status)
+		assert.Equal(t, now.Add(time.Hour), scheduledPayment.NextRunAt.Time)
+	})
+
+	t.Run("next run by cron expression", func(t *testing.T) {
+		scheduledPayment := newTestScheduledPayment(&ScheduledPaymentConfig{CronExpression: "0 12 1 * *"})
+
+		require.NoError(t, scheduledPayment.advance(now))
+		assert.Equal(t, ScheduledPaymentActive, scheduledPayment.Status)
+		assert.Equal(t, time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), scheduledPayment.NextRunAt.Time)
+	})
+
+	t.Run("completed after max runs", func(t *testing.T) {
+		scheduledPayment := newTestScheduledPayment(&ScheduledPaymentConfig{Interval: time.Hour, MaxRuns: 2})
+		scheduledPayment.RunCount = 2
+
+		require.NoError(t, scheduledPayment.advance(now))
+		assert.Equal(t, ScheduledPaymentCompleted, scheduledPayment.Status)
+		assert.False(t, scheduledPayment.NextRunAt.Valid)
+	})
+
+	t.Run("completed after end date", func(t *testing.T) {
+		scheduledPayment := newTestScheduledPayment(&ScheduledPaymentConfig{Interval: time.Hour, EndAt: now.Add(time.Minute)})
+
+		require.NoError(t, scheduledPayment.advance(now))
+		assert.Equal(t, ScheduledPaymentCompleted, scheduledPayment.Status)
+	})
+
+	t.Run("failed after too many failures", func(t *testing.T) {
+		scheduledPayment := newTestScheduledPayment(&ScheduledPaymentConfig{Interval: time.Hour})
+		scheduledPayment.FailureCount = maxScheduledPaymentFailures
+
+		require.NoError(t, scheduledPayment.advance(now))
+		assert.Equal(t, ScheduledPaymentFailed, scheduledPayment.Status)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		scheduledPayment := newTestScheduledPayment(&ScheduledPaymentConfig{Interval: time.Hour})
+
+		require.NoError(t, scheduledPayment.Cancel())
+		assert.Equal(t, ScheduledPaymentCanceled, scheduledPayment.Status)
+		require.Error(t, scheduledPayment.Cancel())
+	})
+}
@@ -22,6 +22,9 @@ const (
 	syncActionSync      = "sync"      // Get on-chain data about the transaction (IE: block hash, height, etc)
 )
 
+// p2pSuccessPrefix is the prefix of the status message of the receiver notified about the transaction
+const p2pSuccessPrefix = "success: "
+
 // SyncResult is the complete attempt/result to sync (multiple providers and strategies)
 type SyncResult struct {
 	Action        string    `json:"action"`             // type: broadcast, sync etc
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	customTypes "github.com/bitcoin-sv/spv-wallet/engine/datastore/customtypes"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 )
 
@@ -22,6 +23,9 @@ type SyncTransaction struct {
 	P2PStatus       SyncStatus  `json:"p2p_status" toml:"p2p_status" yaml:"p2p_status" gorm:"<-;column:p2p_status;type:varchar(10);index;comment:This is the status of the p2p paymail requests" bson:"p2p_status"`
 	SyncStatus      SyncStatus  `json:"sync_status" toml:"sync_status" yaml:"sync_status" gorm:"<-;type:varchar(10);index;comment:This is the status of the on-chain sync" bson:"sync_status"`
 
+	P2PAttempts      uint32               `json:"p2p_attempts" toml:"p2p_attempts" yaml:"p2p_attempts" gorm:"<-;column:p2p_attempts;comment:This is the number of the p2p paymail requests attempts" bson:"p2p_attempts"`
+	P2PNextAttemptAt customTypes.NullTime `json:"p2p_next_attempt_at" toml:"p2p_next_attempt_at" yaml:"p2p_next_attempt_at" gorm:"<-;column:p2p_next_attempt_at;index;comment:This is the time of the next p2p paymail requests attempt" bson:"p2p_next_attempt_at,omitempty"`
+
 	// internal fields
 	transaction *Transaction
 }
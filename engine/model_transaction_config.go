@@ -14,6 +14,7 @@ import (
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/bitcoin-sv/spv-wallet/engine/utils"
 	magic "github.com/bitcoinschema/go-map"
+	"github.com/libsv/go-bt/v2"
 	"github.com/libsv/go-bt/v2/bscript"
 	"github.com/mrz1836/go-cachestore"
 )
@@ -31,12 +32,19 @@ type TransactionConfig struct {
 	FromUtxos                  []*UtxoPointer       `json:"from_utxos" toml:"from_utxos" yaml:"from_utxos" bson:"from_utxos"`                     // Use these specific utxos for the transaction
 	IncludeUtxos               []*UtxoPointer       `json:"include_utxos" toml:"include_utxos" yaml:"include_utxos" bson:"include_utxos"`         // Include these utxos for the transaction, among others necessary if more is needed for fees
 	Inputs                     []*TransactionInput  `json:"inputs" toml:"inputs" yaml:"inputs" bson:"inputs"`                                     // All transaction inputs
+	LockTime                   uint32               `json:"lock_time,omitempty" toml:"lock_time" yaml:"lock_time" bson:"lock_time,omitempty"`     // nLockTime of the transaction (block height or unix timestamp), 0 is final
 	Outputs                    []*TransactionOutput `json:"outputs" toml:"outputs" yaml:"outputs" bson:"outputs"`                                 // All transaction outputs
 	SendAllTo                  *TransactionOutput   `json:"send_all_to,omitempty" toml:"send_all_to" yaml:"send_all_to" bson:"send_all_to"`       // Send ALL utxos to the output
+	Sequences                  []*InputSequence     `json:"sequences,omitempty" toml:"sequences" yaml:"sequences" bson:"sequences,omitempty"`     // Sequence numbers of the inputs (from "FromUtxos" or "IncludeUtxos")
 	Sync                       *SyncConfig          `json:"sync" toml:"sync" yaml:"sync" bson:"sync"`                                             // Sync config for broadcasting and on-chain sync
 	// Future ideas:
 	// Conditions (utxo strategy, chain limit, split utxos)
-	// NlockTime uint32
+}
+
+// InputSequence is the sequence number of an input selected with "FromUtxos" or "IncludeUtxos"
+type InputSequence struct {
+	UtxoPointer
+	Sequence uint32 `json:"sequence" toml:"sequence" yaml:"sequence" bson:"sequence"`
 }
 
 // TransactionInput is an input on the transaction config
@@ -78,6 +86,8 @@ type PaymailPayloadFormat uint32
 const (
 	BasicPaymailPayloadFormat PaymailPayloadFormat = iota
 	BeefPaymailPayloadFormat
+	BeefV2PaymailPayloadFormat
+	AtomicBeefPaymailPayloadFormat
 )
 
 func (format PaymailPayloadFormat) String() string {
@@ -88,11 +98,34 @@ func (format PaymailPayloadFormat) String() string {
 	case BeefPaymailPayloadFormat:
 		return "BeefPaymailPayloadFormat"
 
+	case BeefV2PaymailPayloadFormat:
+		return "BeefV2PaymailPayloadFormat"
+
+	case AtomicBeefPaymailPayloadFormat:
+		return "AtomicBeefPaymailPayloadFormat"
+
 	default:
 		return fmt.Sprintf("%d", uint32(format))
 	}
 }
 
+// isBeef will return true if the transaction is sent in any of the BEEF formats
+func (format PaymailPayloadFormat) isBeef() bool {
+	return format == BeefPaymailPayloadFormat || format == BeefV2PaymailPayloadFormat || format == AtomicBeefPaymailPayloadFormat
+}
+
+// beefOptions will return the options of the BEEF generated for the format
+func (format PaymailPayloadFormat) beefOptions() *BeefOptions {
+	switch format {
+	case BeefV2PaymailPayloadFormat:
+		return &BeefOptions{Version: beefVersion2}
+	case AtomicBeefPaymailPayloadFormat:
+		return &BeefOptions{Version: beefVersion2, Atomic: true}
+	default:
+		return &BeefOptions{Version: beefVersion1}
+	}
+}
+
 // PaymailP4 paymail configuration for the p2p payments on this output
 type PaymailP4 struct {
 	Alias           string               `json:"alias" toml:"alias" yaml:"alias" bson:"alias,omitempty"`                                                       // Alias of the paymail {alias}@domain.com
@@ -113,8 +146,16 @@ const (
 
 	// ResolutionTypeP2P is the current way to resolve a Paymail (prior to P4)
 	ResolutionTypeP2P = "p2p"
+
+	// ResolutionTypePIKE is for the confirmed contacts, the outputs are derived from the PIKE templates of the receiver
+	ResolutionTypePIKE = "pike"
 )
 
+// isSubmitted will return true if the transaction is submitted to the receiver (P2P and PIKE resolution)
+func (p *PaymailP4) isSubmitted() bool {
+	return p != nil && (p.ResolutionType == ResolutionTypeP2P || p.ResolutionType == ResolutionTypePIKE)
+}
+
 // ChangeStrategy strategy to use for change
 type ChangeStrategy string
 
@@ -163,26 +204,104 @@ func (t TransactionConfig) Value() (driver.Value, error) {
 	return string(marshal), nil
 }
 
-// processOutput will inspect the output to determine how to process
-func (t *TransactionOutput) processOutput(ctx context.Context, cacheStore cachestore.ClientInterface,
-	paymailClient paymail.ClientInterface, defaultFromSender string, checkSatoshis bool,
+// validateLockTime will check that the lock time and the sequence numbers of the inputs are consistent
+func (t *TransactionConfig) validateLockTime() error {
+	hasNonFinalSequence := false
+	for _, sequence := range t.Sequences {
+		if !sequence.in(t.FromUtxos) && !sequence.in(t.IncludeUtxos) {
+			return spverrors.ErrSequenceForUnknownInput
+		}
+		if sequence.Sequence != bt.DefaultSequenceNumber {
+			hasNonFinalSequence = true
+		}
+	}
+
+	if t.LockTime == 0 {
+		if hasNonFinalSequence {
+			return spverrors.ErrSequenceWithoutLockTime
+		}
+		return nil
+	}
+
+	// The lock time is ignored if all the inputs are final
+	if len(t.Sequences) > 0 && !hasNonFinalSequence {
+		return spverrors.ErrLockTimeWithFinalSequences
+	}
+
+	return nil
+}
+
+// checkLockTimeResolution will check the output of a transaction with the lock time would not be submitted
+// to the receiver (P2P or PIKE), before any destination is requested from the receiver
+//
+// The receiver would get a transaction which can't be broadcast yet
+func (t *TransactionOutput) checkLockTimeResolution(ctx context.Context, cacheStore cachestore.ClientInterface,
+	paymailClient paymail.ClientInterface,
 ) error {
-	// Convert known handle formats ($handcash or 1relayx)
-	if strings.Contains(t.To, handleHandcashPrefix) ||
-		(len(t.To) < handleMaxLength && len(t.To) > 1 && t.To[:1] == handleRelayPrefix) {
+	t.convertHandle()
+	if !strings.Contains(t.To, "@") {
+		return nil
+	}
 
-		// Convert the handle and check if it's changed (becomes a paymail address)
-		if p := paymail.ConvertHandle(t.To, false); p != t.To {
-			t.To = p
+	_, domain, paymailAddress := paymail.SanitizePaymail(t.To)
+	if len(paymailAddress) == 0 {
+		return spverrors.ErrPaymailAddressIsInvalid
+	}
+
+	capabilities, err := getCapabilities(ctx, cacheStore, paymailClient, domain)
+	if err != nil {
+		return err
+	}
+
+	// The receivers with the P2P transactions capability (P2P or PIKE) get the transaction submitted
+	if _, _, p2pSubmitTxURL, _ := hasP2P(capabilities); p2pSubmitTxURL != "" {
+		return spverrors.ErrLockTimeWithP2POutputs
+	}
+	return nil
+}
+
+// inputSequence will get the sequence number of the input spending the given output
+func (t *TransactionConfig) inputSequence(txID string, outputIndex uint32) uint32 {
+	for _, sequence := range t.Sequences {
+		if sequence.TransactionID == txID && sequence.OutputIndex == outputIndex {
+			return sequence.Sequence
+		}
+	}
+
+	// Without explicit sequence numbers all the inputs are non-final, which enables the lock time
+	if t.LockTime > 0 && len(t.Sequences) == 0 {
+		return bt.DefaultSequenceNumber - 1
+	}
+
+	return bt.DefaultSequenceNumber
+}
+
+// in will check if the sequence is set for one of the utxos
+func (s *InputSequence) in(utxos []*UtxoPointer) bool {
+	for _, utxo := range utxos {
+		if utxo.TransactionID == s.TransactionID && utxo.OutputIndex == s.OutputIndex {
+			return true
 		}
 	}
+	return false
+}
+
+// processOutput will inspect the output to determine how to process
+//
+// sign is used to sign the sender request of the basic address resolution (nil if the sender can't sign),
+// pike is used to pay the confirmed contacts via PIKE (nil if the sender doesn't pay via PIKE)
+func (t *TransactionOutput) processOutput(ctx context.Context, cacheStore cachestore.ClientInterface,
+	paymailClient paymail.ClientInterface, defaultFromSender string, sign senderRequestSigner, pike *pikeSender,
+	checkSatoshis bool,
+) error {
+	t.convertHandle()
 
 	// Check for Paymail, Bitcoin Address or OP Return
 	if len(t.To) > 0 && strings.Contains(t.To, "@") { // Paymail output
 		if checkSatoshis && t.Satoshis <= 0 {
 			return spverrors.ErrOutputValueTooLow
 		}
-		return t.processPaymailOutput(ctx, cacheStore, paymailClient, defaultFromSender)
+		return t.processPaymailOutput(ctx, cacheStore, paymailClient, defaultFromSender, sign, pike)
 	} else if len(t.To) > 0 { // Standard Bitcoin Address
 		if checkSatoshis && t.Satoshis <= 0 {
 			return spverrors.ErrOutputValueTooLow
@@ -200,7 +319,7 @@ func (t *TransactionOutput) processOutput(ctx context.Context, cacheStore caches
 
 // processPaymailOutput will detect how to process the Paymail output given
 func (t *TransactionOutput) processPaymailOutput(ctx context.Context, cacheStore cachestore.ClientInterface,
-	paymailClient paymail.ClientInterface, fromPaymail string,
+	paymailClient paymail.ClientInterface, fromPaymail string, sign senderRequestSigner, pike *pikeSender,
 ) error {
 	// Standardize the paymail address (break into parts)
 	alias, domain, paymailAddress := paymail.SanitizePaymail(t.To)
@@ -232,15 +351,216 @@ func (t *TransactionOutput) processPaymailOutput(ctx context.Context, cacheStore
 
 	// Does the provider support P2P?
 	success, p2pDestinationURL, p2pSubmitTxURL, format := hasP2P(capabilities)
+
+	// The confirmed contacts are paid via PIKE (if the receiver supports it)
+	var contact *Contact
+	var pikeOutputsURL string
+	if contact, pikeOutputsURL, err = t.pikeContact(ctx, capabilities, pike, p2pSubmitTxURL); err != nil {
+		return err
+	} else if contact != nil {
+		return t.processPaymailViaPIKE(
+			ctx, cacheStore, paymailClient, pike, contact, pikeOutputsURL, p2pSubmitTxURL, format,
+		)
+	}
+
 	if success {
 		return t.processPaymailViaP2P(
 			paymailClient, p2pDestinationURL, p2pSubmitTxURL, fromPaymail, format,
 		)
 	}
 
+	// Fall back to the basic address resolution
+	if capabilities.Has(paymail.BRFCBasicAddressResolution, paymail.BRFCPaymentDestination) {
+		return t.processPaymailViaAddressResolution(
+			paymailClient, capabilities, fromPaymail, sign,
+		)
+	}
+
 	return spverrors.Newf("paymail provider does not support P2P")
 }
 
+// pikeContact will return the confirmed contact paid via PIKE and the PIKE outputs URL of the receiver,
+// nil if the output is not paid via PIKE (the send-all output has no amount for the PIKE outputs, it is paid via P2P)
+func (t *TransactionOutput) pikeContact(ctx context.Context, capabilities *paymail.CapabilitiesPayload,
+	pike *pikeSender, p2pSubmitTxURL string,
+) (*Contact, string, error) {
+	pikeOutputsURL := capabilities.ExtractPikeOutputsURL()
+	if pike == nil || pikeOutputsURL == "" || p2pSubmitTxURL == "" || t.Satoshis == 0 {
+		return nil, "", nil
+	}
+
+	contact, err := pike.confirmedContact(ctx, t.To)
+	if err != nil || contact == nil {
+		return nil, "", err
+	}
+	return contact, pikeOutputsURL, nil
+}
+
+// processPaymailViaAddressResolution will process the output for the basic address resolution
+//
+// Both directions are authenticated: the sender request is signed (sender validation) if possible
+// and the signature of the resolved output is verified with the PKI key of the receiver
+func (t *TransactionOutput) processPaymailViaAddressResolution(client paymail.ClientInterface,
+	capabilities *paymail.CapabilitiesPayload, fromPaymail string, sign senderRequestSigner,
+) error {
+	senderRequest := &paymail.SenderRequest{
+		Amount:       t.Satoshis,
+		Dt:           time.Now().UTC().Format(time.RFC3339),
+		SenderHandle: fromPaymail,
+	}
+	if t.PaymailP4 != nil {
+		senderRequest.Purpose = t.PaymailP4.Note
+	}
+
+	// Sign the request, the receiver may require it (the request is sent unsigned if the PKI key is not available)
+	senderValidation := capabilities.GetBool(paymail.BRFCSenderValidation, "")
+	if sign != nil {
+		if err := sign(senderRequest); isPaymailKeyUnavailable(err) {
+			if senderValidation {
+				return spverrors.ErrSenderValidationNotPossible
+			}
+		} else if err != nil {
+			return err
+		}
+	} else if senderValidation {
+		return spverrors.ErrSenderValidationNotPossible
+	}
+
+	// The receiver rejects the request if the timestamp is not valid
+	if err := paymail.ValidateTimestamp(senderRequest.Dt); err != nil {
+		return spverrors.Wrapf(err, "invalid timestamp of the sender request")
+	}
+
+	resolution, err := client.ResolveAddress(
+		capabilities.GetString(paymail.BRFCBasicAddressResolution, paymail.BRFCPaymentDestination),
+		t.PaymailP4.Alias, t.PaymailP4.Domain, senderRequest,
+	)
+	if err != nil {
+		return err //nolint:wrapcheck // we have handler for paymail errors
+	}
+
+	// Verify the receiver signed the output
+	if len(resolution.Signature) > 0 || senderValidation {
+		if len(resolution.Signature) == 0 {
+			return spverrors.ErrMissingResolutionSignature
+		}
+		if !capabilities.Has(paymail.BRFCPki, paymail.BRFCPkiAlternate) {
+			return spverrors.ErrCapabilitiesPkiUnsupported
+		}
+
+		var pki *paymail.PKIResponse
+		if pki, err = client.GetPKI(
+			capabilities.GetString(paymail.BRFCPki, paymail.BRFCPkiAlternate),
+			t.PaymailP4.Alias, t.PaymailP4.Domain,
+		); err != nil {
+			return err //nolint:wrapcheck // we have handler for paymail errors
+		}
+
+		if err = verifyPaymailSignature(pki.PubKey, resolution.Signature, resolution.Output); err != nil {
+			return err
+		}
+	}
+
+	t.Scripts = append(
+		t.Scripts,
+		&ScriptOutput{
+			Address:    resolution.Address,
+			Satoshis:   t.Satoshis,
+			Script:     resolution.Output,
+			ScriptType: utils.ScriptTypePubKeyHash,
+		},
+	)
+
+	t.PaymailP4.ResolutionType = ResolutionTypeBasic
+	t.PaymailP4.FromPaymail = fromPaymail
+
+	return nil
+}
+
+// convertHandle will convert known handle formats ($handcash or 1relayx) to a paymail address
+func (t *TransactionOutput) convertHandle() {
+	if strings.Contains(t.To, handleHandcashPrefix) ||
+		(len(t.To) < handleMaxLength && len(t.To) > 1 && t.To[:1] == handleRelayPrefix) {
+
+		// Convert the handle and check if it's changed (becomes a paymail address)
+		if p := paymail.ConvertHandle(t.To, false); p != t.To {
+			t.To = p
+		}
+	}
+}
+
+// previewOutput will process the output like processOutput, but without requesting
+// the destinations from the paymail provider (a placeholder script of the same size is used)
+func (t *TransactionOutput) previewOutput(ctx context.Context, cacheStore cachestore.ClientInterface,
+	paymailClient paymail.ClientInterface, defaultFromSender string, pike *pikeSender, checkSatoshis bool,
+) error {
+	t.convertHandle()
+
+	if !strings.Contains(t.To, "@") {
+		return t.processOutput(ctx, cacheStore, paymailClient, defaultFromSender, nil, nil, checkSatoshis)
+	}
+	if checkSatoshis && t.Satoshis <= 0 {
+		return spverrors.ErrOutputValueTooLow
+	}
+
+	alias, domain, paymailAddress := paymail.SanitizePaymail(t.To)
+	if len(paymailAddress) == 0 {
+		return spverrors.ErrPaymailAddressIsInvalid
+	}
+	t.To = paymailAddress
+	t.PaymailP4 = &PaymailP4{
+		Alias:  alias,
+		Domain: domain,
+	}
+
+	capabilities, err := getCapabilities(
+		ctx, cacheStore, paymailClient, domain,
+	)
+	if err != nil {
+		return err
+	}
+
+	success, _, p2pSubmitTxURL, format := hasP2P(capabilities)
+	basicResolution := capabilities.Has(paymail.BRFCBasicAddressResolution, paymail.BRFCPaymentDestination)
+	if !success && !basicResolution {
+		return spverrors.Newf("paymail provider does not support P2P")
+	}
+
+	satoshis := t.Satoshis
+	if satoshis <= 0 {
+		satoshis = 100
+	}
+
+	// The receiver decides the outputs, assume a single P2PKH output
+	t.Scripts = append(t.Scripts, &ScriptOutput{
+		Satoshis:   satoshis,
+		Script:     previewLockingScript,
+		ScriptType: utils.ScriptTypePubKeyHash,
+	})
+
+	t.PaymailP4.FromPaymail = defaultFromSender
+	if !success {
+		t.PaymailP4.ResolutionType = ResolutionTypeBasic
+		return nil
+	}
+
+	t.PaymailP4.ReceiveEndpoint = p2pSubmitTxURL
+	t.PaymailP4.ResolutionType = ResolutionTypeP2P
+	t.PaymailP4.Format = format
+
+	// The confirmed contacts are paid via PIKE (the outputs are not requested)
+	contact, _, err := t.pikeContact(ctx, capabilities, pike, p2pSubmitTxURL)
+	if err != nil {
+		return err
+	} else if contact != nil {
+		t.PaymailP4.PubKey = contact.PubKey
+		t.PaymailP4.ResolutionType = ResolutionTypePIKE
+		t.PaymailP4.FromPaymail = pike.paymail
+	}
+
+	return nil
+}
+
 // processPaymailViaP2P will process the output for P2P Paymail resolution
 func (t *TransactionOutput) processPaymailViaP2P(client paymail.ClientInterface, p2pDestinationURL, p2pSubmitTxURL string, fromPaymail string, format PaymailPayloadFormat) error {
 	// todo: this is a hack since paymail providers will complain if satoshis are empty (SendToAll has 0 satoshi)
@@ -287,6 +607,56 @@ func (t *TransactionOutput) processPaymailViaP2P(client paymail.ClientInterface,
 	return nil
 }
 
+// processPaymailViaPIKE will process the output for the PIKE payment to the confirmed contact,
+// the locking scripts are derived from the output templates of the receiver with the PKI keys of both parties
+func (t *TransactionOutput) processPaymailViaPIKE(ctx context.Context, cacheStore cachestore.ClientInterface,
+	client paymail.ClientInterface, sender *pikeSender, contact *Contact,
+	pikeOutputsURL, p2pSubmitTxURL string, format PaymailPayloadFormat,
+) error {
+	// The receiver derives the scripts with its current PKI key, which has to be the confirmed one
+	pmSrvnt := &PaymailServant{cs: cacheStore, pc: client}
+	pki, err := pmSrvnt.GetPkiForPaymail(ctx, &paymail.SanitisedPaymail{
+		Alias: t.PaymailP4.Alias, Domain: t.PaymailP4.Domain, Address: t.To,
+	})
+	if err != nil {
+		return err
+	} else if pki.PubKey != contact.PubKey {
+		return spverrors.ErrContactPubKeyChanged
+	}
+
+	response, err := client.GetOutputsTemplate(pikeOutputsURL, t.PaymailP4.Alias, t.PaymailP4.Domain,
+		&paymail.PikePaymentOutputsPayload{SenderPaymail: sender.paymail, Amount: t.Satoshis},
+	)
+	if err != nil {
+		return err //nolint:wrapcheck // we have handler for paymail errors
+	}
+
+	scripts, err := sender.lockingScripts(response, contact.PubKey, t.Satoshis)
+	if err != nil {
+		return err
+	}
+	for index, out := range response.Outputs {
+		t.Scripts = append(
+			t.Scripts,
+			&ScriptOutput{
+				Satoshis:   out.Satoshis,
+				Script:     scripts[index],
+				ScriptType: utils.GetDestinationType(scripts[index]),
+			},
+		)
+	}
+
+	// The transaction is submitted with the reference of the PIKE outputs
+	t.PaymailP4.PubKey = contact.PubKey
+	t.PaymailP4.ReceiveEndpoint = p2pSubmitTxURL
+	t.PaymailP4.ReferenceID = response.Reference
+	t.PaymailP4.ResolutionType = ResolutionTypePIKE
+	t.PaymailP4.FromPaymail = sender.paymail
+	t.PaymailP4.Format = format
+
+	return nil
+}
+
 // processAddressOutput will process an output for a standard Bitcoin Address Transaction
 func (t *TransactionOutput) processAddressOutput() (err error) {
 	// Create the script from the Bitcoin address
@@ -6,17 +6,20 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/bitcoin-sv/go-paymail"
 	"github.com/bitcoin-sv/spv-wallet/engine/chainstate"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/bitcoin-sv/spv-wallet/engine/utils"
 	magic "github.com/bitcoinschema/go-map"
+	"github.com/jarcoal/httpmock"
+	"github.com/libsv/go-bt/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 var (
-	emptyConfigJSON = "{\"change_destinations\":[{\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\",\"deleted_at\":null,\"id\":\"c775e7b757ede630cd0aa1113bd102661ab38829ca52a6422ab782862f268646\",\"xpub_id\":\"1a0b10d4eda0636aae1709e7e7080485a4d99af3ca2962c6e677cf5b53d8ab8c\",\"locking_script\":\"76a9147ff514e6ae3deb46e6644caac5cdd0bf2388906588ac\",\"type\":\"pubkeyhash\",\"chain\":1,\"num\":123,\"paymail_external_derivation_num\":null,\"address\":\"1CfaQw9udYNPccssFJFZ94DN8MqNZm9nGt\",\"draft_id\":\"test-reference\",\"derivation_method\":\"\",\"sender_xpub\":\"\",\"output_index\":0}],\"change_destinations_strategy\":\"\",\"change_minimum_satoshis\":0,\"change_number_of_destinations\":0,\"change_satoshis\":124,\"expires_in\":20000000000,\"fee\":12,\"fee_unit\":{\"satoshis\":1,\"bytes\":1000},\"from_utxos\":null,\"include_utxos\":null,\"inputs\":null,\"outputs\":null,\"sync\":null}"
+	emptyConfigJSON = "{\"change_destinations\":[{\"created_at\":\"0001-01-01T00:00:00Z\",\"updated_at\":\"0001-01-01T00:00:00Z\",\"deleted_at\":null,\"id\":\"c775e7b757ede630cd0aa1113bd102661ab38829ca52a6422ab782862f268646\",\"xpub_id\":\"1a0b10d4eda0636aae1709e7e7080485a4d99af3ca2962c6e677cf5b53d8ab8c\",\"locking_script\":\"76a9147ff514e6ae3deb46e6644caac5cdd0bf2388906588ac\",\"type\":\"pubkeyhash\",\"chain\":1,\"num\":123,\"paymail_external_derivation_num\":null,\"address\":\"1CfaQw9udYNPccssFJFZ94DN8MqNZm9nGt\",\"draft_id\":\"test-reference\",\"derivation_method\":\"\",\"sender_xpub\":\"\",\"output_index\":0,\"reference\":\"\"}],\"change_destinations_strategy\":\"\",\"change_minimum_satoshis\":0,\"change_number_of_destinations\":0,\"change_satoshis\":124,\"expires_in\":20000000000,\"fee\":12,\"fee_unit\":{\"satoshis\":1,\"bytes\":1000},\"from_utxos\":null,\"include_utxos\":null,\"inputs\":null,\"outputs\":null,\"sync\":null}"
 	opReturn        = "006a2231394878696756345179427633744870515663554551797131707a5a56646f417574324b65657020616e20657965206f6e207468697320706c61636520666f7220736f6d65204a616d696679206c6f76652e2e2e200d746578742f6d61726b646f776e055554462d38"
 	unsetConfigJSON = "{\"change_destinations\":null,\"change_destinations_strategy\":\"\",\"change_minimum_satoshis\":0,\"change_number_of_destinations\":0,\"change_satoshis\":0,\"expires_in\":0,\"fee\":0,\"fee_unit\":null,\"from_utxos\":null,\"include_utxos\":null,\"inputs\":null,\"outputs\":null,\"sync\":null}"
 
@@ -168,7 +171,7 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 
 		err := out.processOutput(
 			context.Background(), nil, client,
-			defaultSenderPaymail, true,
+			defaultSenderPaymail, nil, nil, true,
 		)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, spverrors.ErrOutputValueNotRecognized)
@@ -184,7 +187,7 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 
 		err := out.processOutput(
 			context.Background(), nil, client,
-			defaultSenderPaymail, true,
+			defaultSenderPaymail, nil, nil, true,
 		)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, spverrors.ErrPaymailAddressIsInvalid)
@@ -215,9 +218,14 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 
 		err = out.processOutput(
 			context.Background(), tc.Cachestore(), client,
-			defaultSenderPaymail, true,
+			defaultSenderPaymail, nil, nil, true,
 		)
-		assert.Equal(t, err.Error(), "paymail provider does not support P2P")
+		require.NoError(t, err)
+		assert.Equal(t, ResolutionTypeBasic, out.PaymailP4.ResolutionType)
+		assert.Equal(t, defaultSenderPaymail, out.PaymailP4.FromPaymail)
+		require.Len(t, out.Scripts, 1)
+		assert.Equal(t, testOutput, out.Scripts[0].Script)
+		assert.Equal(t, satoshis, out.Scripts[0].Satoshis)
 	})
 
 	t.Run("basic $handle -> paymail address resolution - valid response", func(t *testing.T) {
@@ -248,9 +256,14 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 
 		err = out.processOutput(
 			context.Background(), tc.Cachestore(), client,
-			defaultSenderPaymail, true,
+			defaultSenderPaymail, nil, nil, true,
 		)
-		assert.Equal(t, err.Error(), "paymail provider does not support P2P")
+		require.NoError(t, err)
+		assert.Equal(t, ResolutionTypeBasic, out.PaymailP4.ResolutionType)
+		assert.Equal(t, defaultSenderPaymail, out.PaymailP4.FromPaymail)
+		require.Len(t, out.Scripts, 1)
+		assert.Equal(t, testOutput, out.Scripts[0].Script)
+		assert.Equal(t, satoshis, out.Scripts[0].Satoshis)
 	})
 
 	t.Run("basic 1handle -> paymail address resolution - valid response", func(t *testing.T) {
@@ -281,9 +294,83 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 
 		err = out.processOutput(
 			context.Background(), tc.Cachestore(), client,
-			defaultSenderPaymail, true,
+			defaultSenderPaymail, nil, nil, true,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, ResolutionTypeBasic, out.PaymailP4.ResolutionType)
+		assert.Equal(t, defaultSenderPaymail, out.PaymailP4.FromPaymail)
+		require.Len(t, out.Scripts, 1)
+		assert.Equal(t, testOutput, out.Scripts[0].Script)
+		assert.Equal(t, satoshis, out.Scripts[0].Satoshis)
+	})
+
+	t.Run("basic paymail address resolution - signed response", func(t *testing.T) {
+		client := newTestPaymailClient(t, []string{testDomain})
+
+		logger := zerolog.Nop()
+		tcOpts := DefaultClientOpts(true, true)
+		tcOpts = append(tcOpts, WithLogger(&logger))
+
+		tc, err := NewClient(
+			context.Background(),
+			tcOpts...,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, tc)
+		defer CloseClient(context.Background(), t, tc)
+
+		pm, keyProvider := newTestSigningPaymail(t)
+		pubKey, err := pm.GetPubKey()
+		require.NoError(t, err)
+
+		mockSignedResolution := func(signature string) {
+			mockValidResponse(http.StatusOK, false, testDomain)
+			serverURL := "https://" + testDomain + "/api/v1/" + paymail.DefaultServiceName
+			httpmock.RegisterResponder(http.MethodPost, serverURL+"/address/"+testAlias+"@"+testDomain,
+				httpmock.NewStringResponder(
+					http.StatusOK,
+					`{"output": "`+testOutput+`","signature": "`+signature+`"}`,
+				),
+			)
+			httpmock.RegisterResponder(http.MethodGet, serverURL+"/id/"+testAlias+"@"+testDomain,
+				httpmock.NewStringResponder(
+					http.StatusOK,
+					`{"`+paymail.DefaultServiceName+`": "`+paymail.DefaultBsvAliasVersion+`","handle": "`+testAlias+"@"+testDomain+`","pubkey": "`+pubKey+`"}`,
+				),
+			)
+		}
+
+		signature, err := signWithPaymailKey(context.Background(), keyProvider, pm, testOutput)
+		require.NoError(t, err)
+		mockSignedResolution(signature)
+
+		out := &TransactionOutput{
+			Satoshis: satoshis,
+			To:       paymailAddress,
+		}
+		err = out.processOutput(
+			context.Background(), tc.Cachestore(), client,
+			defaultSenderPaymail, nil, nil, true,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, ResolutionTypeBasic, out.PaymailP4.ResolutionType)
+		require.Len(t, out.Scripts, 1)
+		assert.Equal(t, testOutput, out.Scripts[0].Script)
+
+		// Signature of a different output
+		signature, err = signWithPaymailKey(context.Background(), keyProvider, pm, "76a9147ff514e6ae3deb46e6644caac5cdd0bf2388906588ac")
+		require.NoError(t, err)
+		mockSignedResolution(signature)
+
+		out = &TransactionOutput{
+			Satoshis: satoshis,
+			To:       paymailAddress,
+		}
+		err = out.processOutput(
+			context.Background(), tc.Cachestore(), client,
+			defaultSenderPaymail, nil, nil, true,
 		)
-		assert.Equal(t, err.Error(), "paymail provider does not support P2P")
+		require.ErrorIs(t, err, spverrors.ErrInvalidPaymailSignature)
 	})
 
 	t.Run("p2p paymail address resolution - valid response", func(t *testing.T) {
@@ -311,7 +398,7 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 
 		err = out.processOutput(
 			context.Background(), tc.Cachestore(), client,
-			defaultSenderPaymail, true,
+			defaultSenderPaymail, nil, nil, true,
 		)
 		require.NoError(t, err)
 		assert.Equal(t, satoshis, out.Satoshis)
@@ -324,6 +411,40 @@ func TestTransactionConfig_processOutput(t *testing.T) {
 		assert.Equal(t, "z0bac4ec-6f15-42de-9ef4-e60bfdabf4f7", out.PaymailP4.ReferenceID)
 		assert.Equal(t, testServerURL+"/receive-transaction/{alias}@{domain.tld}", out.PaymailP4.ReceiveEndpoint)
 	})
+
+	t.Run("p2p paymail preview - no destination request", func(t *testing.T) {
+		client := newTestPaymailClient(t, []string{testDomain})
+
+		logger := zerolog.Nop()
+		tcOpts := DefaultClientOpts(true, true)
+		tcOpts = append(tcOpts, WithLogger(&logger))
+
+		tc, err := NewClient(
+			context.Background(),
+			tcOpts...,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, tc)
+		defer CloseClient(context.Background(), t, tc)
+
+		out := &TransactionOutput{
+			Satoshis: satoshis,
+			To:       paymailAddress,
+		}
+
+		mockValidResponse(http.StatusOK, true, testDomain)
+
+		err = out.previewOutput(
+			context.Background(), tc.Cachestore(), client,
+			defaultSenderPaymail, nil, true,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, ResolutionTypeP2P, out.PaymailP4.ResolutionType)
+		assert.Equal(t, "", out.PaymailP4.ReferenceID)
+		require.Len(t, out.Scripts, 1)
+		assert.Equal(t, previewLockingScript, out.Scripts[0].Script)
+		assert.Equal(t, satoshis, out.Scripts[0].Satoshis)
+	})
 }
 
 // TestTransactionConfig_processOpReturnOutput will test the method processOpReturnOutput()
@@ -473,3 +594,56 @@ func TestTransactionConfig_processScriptOutput(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// TestTransactionConfig_validateLockTime will test the method validateLockTime()
+func TestTransactionConfig_validateLockTime(t *testing.T) {
+	utxo := UtxoPointer{TransactionID: testTxID, OutputIndex: 1}
+
+	t.Run("no lock time", func(t *testing.T) {
+		config := &TransactionConfig{}
+		require.NoError(t, config.validateLockTime())
+		assert.Equal(t, bt.DefaultSequenceNumber, config.inputSequence(testTxID, 1))
+	})
+
+	t.Run("lock time - default sequences", func(t *testing.T) {
+		config := &TransactionConfig{LockTime: 800000}
+		require.NoError(t, config.validateLockTime())
+		assert.Equal(t, bt.DefaultSequenceNumber-1, config.inputSequence(testTxID, 1))
+	})
+
+	t.Run("lock time - explicit sequences", func(t *testing.T) {
+		config := &TransactionConfig{
+			LockTime:  800000,
+			FromUtxos: []*UtxoPointer{&utxo, {TransactionID: testTxID, OutputIndex: 2}},
+			Sequences: []*InputSequence{{UtxoPointer: utxo, Sequence: 10}},
+		}
+		require.NoError(t, config.validateLockTime())
+		assert.Equal(t, uint32(10), config.inputSequence(testTxID, 1))
+		assert.Equal(t, bt.DefaultSequenceNumber, config.inputSequence(testTxID, 2))
+	})
+
+	t.Run("sequence of unknown input", func(t *testing.T) {
+		config := &TransactionConfig{
+			LockTime:  800000,
+			Sequences: []*InputSequence{{UtxoPointer: utxo, Sequence: 10}},
+		}
+		require.ErrorIs(t, config.validateLockTime(), spverrors.ErrSequenceForUnknownInput)
+	})
+
+	t.Run("non-final sequence without lock time", func(t *testing.T) {
+		config := &TransactionConfig{
+			IncludeUtxos: []*UtxoPointer{&utxo},
+			Sequences:    []*InputSequence{{UtxoPointer: utxo, Sequence: 10}},
+		}
+		require.ErrorIs(t, config.validateLockTime(), spverrors.ErrSequenceWithoutLockTime)
+	})
+
+	t.Run("lock time with final sequences", func(t *testing.T) {
+		config := &TransactionConfig{
+			LockTime:     800000,
+			IncludeUtxos: []*UtxoPointer{&utxo},
+			Sequences:    []*InputSequence{{UtxoPointer: utxo, Sequence: bt.DefaultSequenceNumber}},
+		}
+		require.ErrorIs(t, config.validateLockTime(), spverrors.ErrLockTimeWithFinalSequences)
+	})
+}
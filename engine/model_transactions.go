@@ -54,6 +54,8 @@ type Transaction struct {
 	TotalValue      uint64          `json:"total_value" toml:"total_value" yaml:"total_value" gorm:"<-create;type:bigint" bson:"total_value,omitempty"`
 	XpubMetadata    XpubMetadata    `json:"-" toml:"xpub_metadata" gorm:"<-;type:json;xpub_id specific metadata" bson:"xpub_metadata,omitempty"`
 	XpubOutputValue XpubOutputValue `json:"-" toml:"xpub_output_value" gorm:"<-;type:json;xpub_id specific value" bson:"xpub_output_value,omitempty"`
+	XpubContactIDs  XpubContactIDs  `json:"-" toml:"xpub_contact_ids" gorm:"<-;type:json;xpub_id specific contact" bson:"xpub_contact_ids,omitempty"`
+	XpubContactSats XpubOutputValue `json:"-" toml:"xpub_contact_sats" gorm:"<-;type:json;xpub_id specific value exchanged with the contact" bson:"xpub_contact_sats,omitempty"`
 	BUMP            BUMP            `json:"bump" toml:"bump" yaml:"bump" gorm:"<-;type:text;comment:BSV Unified Merkle Path (BUMP) Format" bson:"bump,omitempty"`
 	TxStatus        string          `json:"txStatus" toml:"txStatus" yaml:"txStatus" gorm:"<-;type:varchar(64);comment:TxStatus retrieved from Arc API." bson:"txStatus,omitempty"`
 
@@ -61,6 +63,7 @@ type Transaction struct {
 	OutputValue int64                `json:"output_value" toml:"-" yaml:"-" gorm:"-" bson:"-,omitempty"`
 	Status      SyncStatus           `json:"status" toml:"-" yaml:"-" gorm:"-" bson:"-"`
 	Direction   TransactionDirection `json:"direction" toml:"-" yaml:"-" gorm:"-" bson:"-"`
+	ContactID   string               `json:"contact_id,omitempty" toml:"-" yaml:"-" gorm:"-" bson:"-"`
 	// Confirmations  uint64       `json:"-" toml:"-" yaml:"-" gorm:"-" bson:"-"`
 
 	// Private for internal use
@@ -70,6 +73,7 @@ type Transaction struct {
 	utxos              []Utxo               `gorm:"-" bson:"-"` // json:"destinations,omitempty"
 	XPubID             string               `gorm:"-" bson:"-"` // XPub of the user registering this transaction
 	beforeCreateCalled bool                 `gorm:"-" bson:"-"` // Private information that the transaction lifecycle method BeforeCreate was already called
+	pikeSenders        map[string]string    `gorm:"-" bson:"-"` // PKI keys of the senders of the PIKE outputs (per receiving xPub)
 }
 
 // TransactionGetter interface for getting transactions by their IDs
@@ -305,10 +309,14 @@ func (m *Transaction) Display() interface{} {
 		m.Direction = TransactionDirectionOut
 	}
 
+	m.ContactID = m.XpubContactIDs[m.XPubID]
+
 	m.XpubInIDs = nil
 	m.XpubOutIDs = nil
 	m.XpubMetadata = nil
 	m.XpubOutputValue = nil
+	m.XpubContactIDs = nil
+	m.XpubContactSats = nil
 	return m
 }
 
@@ -319,7 +327,7 @@ func (m *TransactionBase) hasOneKnownDestination(ctx context.Context, client Cli
 	// todo: this can be optimized searching X records at a time vs loop->query->loop->query
 	for _, output := range m.parsedTx.Outputs {
 		lockingScript := output.LockingScript.String()
-		destination, err := getDestinationWithCache(ctx, client, "", "", lockingScript)
+		destination, err := getDestinationWithCache(ctx, client, "", "", lockingScript, client.DefaultModelOptions()...)
 
 		if err != nil {
 			client.Logger().Error().Str("txID", m.ID).Msgf("error getting destination: %s", err.Error())
@@ -50,3 +50,42 @@ func (XpubOutputValue) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
 	}
 	return datastore.JSON
 }
+
+// XpubContactIDs Xpub specific contact (the counterparty) of the transaction
+type XpubContactIDs map[string]string
+
+// Scan scan value into Json, implements sql.Scanner interface
+func (x *XpubContactIDs) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	byteValue, err := utils.ToByteArray(value)
+	if err != nil || bytes.Equal(byteValue, []byte("")) || bytes.Equal(byteValue, []byte("\"\"")) {
+		return nil
+	}
+
+	err = json.Unmarshal(byteValue, &x)
+	return spverrors.Wrapf(err, "failed to parse XpubContactIDs from JSON")
+}
+
+// Value return json value, implement driver.Valuer interface
+func (x XpubContactIDs) Value() (driver.Value, error) {
+	if x == nil {
+		return nil, nil
+	}
+	marshal, err := json.Marshal(x)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to produce JSON from XpubContactIDs")
+	}
+
+	return string(marshal), nil
+}
+
+// GormDBDataType the gorm data type for metadata
+func (XpubContactIDs) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == datastore.Postgres {
+		return datastore.JSONB
+	}
+	return datastore.JSON
+}
@@ -144,9 +144,23 @@ func unReserveUtxos(ctx context.Context, xPubID, draftID string, opts ...ModelOp
 	return nil
 }
 
-// reserveUtxos reserve utxos for the given draft ID and amount
-func reserveUtxos(ctx context.Context, xPubID, draftID string,
+// reserveUtxos reserve utxos (of the given destination type) for the given draft ID and amount
+func reserveUtxos(ctx context.Context, xPubID, draftID, destinationType string,
 	satoshis uint64, feePerByte float64, fromUtxos []*UtxoPointer, opts ...ModelOps,
+) ([]*Utxo, error) {
+	return _selectUtxos(ctx, xPubID, draftID, destinationType, satoshis, feePerByte, fromUtxos, true, opts...)
+}
+
+// selectUtxos will select the utxos the same way as reserveUtxos, without reserving them
+func selectUtxos(ctx context.Context, xPubID, destinationType string,
+	satoshis uint64, feePerByte float64, fromUtxos []*UtxoPointer, opts ...ModelOps,
+) ([]*Utxo, error) {
+	return _selectUtxos(ctx, xPubID, "", destinationType, satoshis, feePerByte, fromUtxos, false, opts...)
+}
+
+// _selectUtxos will select utxos (of the given destination type) for the given amount and reserve them if requested
+func _selectUtxos(ctx context.Context, xPubID, draftID, destinationType string,
+	satoshis uint64, feePerByte float64, fromUtxos []*UtxoPointer, reserve bool, opts ...ModelOps,
 ) ([]*Utxo, error) {
 	// Create base model
 	m := NewBaseModel(ModelNameEmpty, opts...)
@@ -179,7 +193,7 @@ reserveUtxoLoop:
 	for {
 		var freeUtxos []*Utxo
 		if freeUtxos, err = getSpendableUtxos(
-			ctx, xPubID, utils.ScriptTypePubKeyHash, queryParams, fromUtxos, opts..., // todo: allow reservation of utxos by a different utxo destination type
+			ctx, xPubID, destinationType, queryParams, fromUtxos, opts...,
 		); err != nil {
 			return nil, err
 		}
@@ -189,24 +203,26 @@ reserveUtxoLoop:
 		}
 
 		// Set vars
-		size := utils.GetInputSizeForType(utils.ScriptTypePubKeyHash)
+		size := utils.GetInputSizeForType(destinationType)
 
 		// Loop the returned utxos
 		for _, utxo := range freeUtxos {
 
-			// Set the values on the UTXO
-			utxo.DraftID.Valid = true
-			utxo.DraftID.String = draftID
-			utxo.ReservedAt.Valid = true
-			utxo.ReservedAt.Time = time.Now().UTC()
-
 			// Accumulate the reserved satoshis
 			reservedSatoshis += utxo.Satoshis
 
-			// Save the UTXO
-			// todo: should occur in 1 DB transaction
-			if err = utxo.Save(ctx); err != nil {
-				return nil, err
+			if reserve {
+				// Set the values on the UTXO
+				utxo.DraftID.Valid = true
+				utxo.DraftID.String = draftID
+				utxo.ReservedAt.Valid = true
+				utxo.ReservedAt.Time = time.Now().UTC()
+
+				// Save the UTXO
+				// todo: should occur in 1 DB transaction
+				if err = utxo.Save(ctx); err != nil {
+					return nil, err
+				}
 			}
 
 			// Add the utxo to the final slice
@@ -222,10 +238,16 @@ reserveUtxoLoop:
 		if queryParams.PageSize == 0 {
 			// break the loop if we are not paginating
 			break reserveUtxoLoop
+		} else if !reserve {
+			// the selected utxos are still spendable, get the next page
+			queryParams.Page++
 		}
 	}
 
 	if reservedSatoshis < satoshis {
+		if !reserve {
+			return nil, spverrors.ErrNotEnoughUtxos
+		}
 		if err = unReserveUtxos(
 			ctx, xPubID, draftID, m.GetOptions(false)...,
 		); err != nil {
@@ -160,7 +160,7 @@ func TestUtxo_UnReserveUtxos(t *testing.T) {
 		require.NoError(t, err)
 
 		var utxos []*Utxo
-		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, 2000, 0.5, nil, client.DefaultModelOptions()...)
+		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 2000, 0.5, nil, client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 2)
 		for _, utxo := range utxos {
@@ -191,7 +191,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 		require.NoError(t, err)
 
 		var utxos []*Utxo
-		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, 1000, 0.5, nil, client.DefaultModelOptions()...)
+		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 1000, 0.5, nil, client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 1)
 		assert.Equal(t, testDraftID2, utxos[0].DraftID.String)
@@ -205,7 +205,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 		require.NoError(t, err)
 
 		var utxos []*Utxo
-		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, 2000, 0.5, nil, client.DefaultModelOptions()...)
+		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 2000, 0.5, nil, client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 2)
 		assert.Equal(t, testDraftID2, utxos[0].DraftID.String)
@@ -220,7 +220,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 		err := createTestUtxos(ctx, client)
 		require.NoError(t, err)
 
-		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, 20000, 0.5, nil, client.DefaultModelOptions()...)
+		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 20000, 0.5, nil, client.DefaultModelOptions()...)
 		require.Error(t, err, spverrors.ErrNotEnoughUtxos)
 	})
 
@@ -236,7 +236,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 		}}
 
 		var utxos []*Utxo
-		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, 1000, 0.5, fromUtxos, client.DefaultModelOptions()...)
+		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 1000, 0.5, fromUtxos, client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 1)
 		assert.Equal(t, testDraftID2, utxos[0].DraftID.String)
@@ -260,7 +260,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 		}}
 
 		var utxos []*Utxo
-		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, 2000, 0.5, fromUtxos, client.DefaultModelOptions()...)
+		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 2000, 0.5, fromUtxos, client.DefaultModelOptions()...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 2)
 		assert.Equal(t, testDraftID2, utxos[0].DraftID.String)
@@ -283,7 +283,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 			TransactionID: testTxID,
 			OutputIndex:   16,
 		}}
-		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, 2000, 0.5, fromUtxos, client.DefaultModelOptions()...)
+		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 2000, 0.5, fromUtxos, client.DefaultModelOptions()...)
 		require.Error(t, err, spverrors.ErrNotEnoughUtxos)
 	})
 
@@ -294,7 +294,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 		require.NoError(t, err)
 
 		var utxos []*Utxo
-		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, 4000, 0.5, nil, client.DefaultModelOptions(WithPageSize(2))...)
+		utxos, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 4000, 0.5, nil, client.DefaultModelOptions(WithPageSize(2))...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 4)
 	})
@@ -316,7 +316,7 @@ func TestUtxo_ReserveUtxos(t *testing.T) {
 			OutputIndex:   utxo.OutputIndex,
 		}}
 
-		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, 2200, 0.05, fromUtxos, client.DefaultModelOptions()...)
+		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 2200, 0.05, fromUtxos, client.DefaultModelOptions()...)
 		require.ErrorIs(t, err, spverrors.ErrDuplicateUTXOs)
 	})
 }
@@ -336,14 +336,14 @@ func TestUtxo_GetSpendableUtxos(t *testing.T) {
 		require.NoError(t, err)
 		assert.Len(t, utxos, 5)
 
-		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, 2000, 0.5, nil, opts...)
+		_, err = reserveUtxos(ctx, testXPubID, testDraftID2, utils.ScriptTypePubKeyHash, 2000, 0.5, nil, opts...)
 		require.NoError(t, err)
 
 		utxos, err = getSpendableUtxos(ctx, testXPubID, utils.ScriptTypePubKeyHash, nil, nil, opts...)
 		require.NoError(t, err)
 		assert.Len(t, utxos, 3)
 
-		_, err = reserveUtxos(ctx, testXPubID, testDraftID3, 1000, 0.5, nil, opts...)
+		_, err = reserveUtxos(ctx, testXPubID, testDraftID3, utils.ScriptTypePubKeyHash, 1000, 0.5, nil, opts...)
 		require.NoError(t, err)
 
 		utxos, err = getSpendableUtxos(ctx, testXPubID, utils.ScriptTypePubKeyHash, nil, nil, opts...)
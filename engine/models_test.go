@@ -31,7 +31,12 @@ func TestModelName_String(t *testing.T) {
 		assert.Equal(t, "xpub", ModelXPub.String())
 		assert.Equal(t, "contact", ModelContact.String())
 		assert.Equal(t, "webhook", ModelWebhook.String())
-		assert.Len(t, AllModelNames, 11)
+		assert.Equal(t, "scheduled_payment", ModelScheduledPayment.String())
+		assert.Equal(t, "multisig_account", ModelMultisigAccount.String())
+		assert.Equal(t, "paymail_domain", ModelPaymailDomain.String())
+		assert.Equal(t, "paymail_p2p_reference", ModelP2PReference.String())
+		assert.Equal(t, "contact_block", ModelContactBlock.String())
+		assert.Len(t, AllModelNames, 16)
 	})
 }
 
@@ -14,17 +14,48 @@ import (
 func getCapabilities(ctx context.Context, cs cachestore.ClientInterface, client paymail.ClientInterface,
 	domain string,
 ) (*paymail.CapabilitiesPayload, error) {
-	// Attempt to get from cachestore
-	// todo: allow user to configure the time that they want to cache the capabilities (if they want to cache or not)
-	capabilities := new(paymail.CapabilitiesPayload)
-	if err := cs.GetModel(
-		ctx, cacheKeyCapabilities+domain, capabilities,
-	); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
-		return nil, spverrors.Wrapf(err, "failed to get capabilities from cachestore")
-	} else if len(capabilities.Capabilities) > 0 {
-		return capabilities, nil
+	policy := paymailClientPolicyOf(client)
+
+	// Attempt to get from cachestore (the TTLs are set by the policy of the paymail client)
+	if policy.CapabilitiesTTL > 0 {
+		capabilities := new(paymail.CapabilitiesPayload)
+		if err := cs.GetModel(
+			ctx, cacheKeyCapabilities+domain, capabilities,
+		); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+			return nil, spverrors.Wrapf(err, "failed to get capabilities from cachestore")
+		} else if len(capabilities.Capabilities) > 0 {
+			return capabilities, nil
+		}
+	}
+
+	// The failed lookup is cached, the provider is not asked again until it expires
+	if policy.NegativeTTL > 0 {
+		if cachedErr, _ := cs.Get(ctx, cacheKeyCapabilitiesError+domain); cachedErr != "" {
+			return nil, spverrors.Wrapf(spverrors.ErrGetCapabilities, "%s", cachedErr)
+		}
+	}
+
+	response, err := requestCapabilities(client, domain)
+	if err != nil {
+		if policy.NegativeTTL > 0 && !errors.Is(err, spverrors.ErrPaymailDomainBlocked) && cs != nil && !cs.Engine().IsEmpty() {
+			_ = cs.SetTTL(context.Background(), cacheKeyCapabilitiesError+domain, err.Error(), policy.NegativeTTL)
+		}
+		return nil, err
+	}
+
+	// Save to cachestore
+	if policy.CapabilitiesTTL > 0 && cs != nil && !cs.Engine().IsEmpty() {
+		_ = cs.SetModel(
+			context.Background(), cacheKeyCapabilities+domain,
+			&response.CapabilitiesPayload, policy.CapabilitiesTTL,
+		)
 	}
 
+	return &response.CapabilitiesPayload, nil
+}
+
+// requestCapabilities will request the capabilities from the paymail provider of the domain
+func requestCapabilities(client paymail.ClientInterface, domain string) (*paymail.CapabilitiesResponse, error) {
 	// Get SRV record (domain can be different!)
 	var response *paymail.CapabilitiesResponse
 	srv, err := client.GetSRVRecord(
@@ -55,15 +86,7 @@ func getCapabilities(ctx context.Context, cs cachestore.ClientInterface, client
 		}
 	}
 
-	// Save to cachestore
-	if cs != nil && !cs.Engine().IsEmpty() {
-		_ = cs.SetModel(
-			context.Background(), cacheKeyCapabilities+domain,
-			&response.CapabilitiesPayload, cacheTTLCapabilities,
-		)
-	}
-
-	return &response.CapabilitiesPayload, nil
+	return response, nil
 }
 
 // hasP2P will return the P2P urls and true if they are both found
@@ -74,7 +97,7 @@ func hasP2P(capabilities *paymail.CapabilitiesPayload) (success bool, p2pDestina
 
 	if len(p2pBeefSubmitTxURL) > 0 {
 		p2pSubmitTxURL = p2pBeefSubmitTxURL
-		format = BeefPaymailPayloadFormat
+		format = beefFormatFromCapabilities(capabilities)
 	}
 
 	if len(p2pSubmitTxURL) > 0 && len(p2pDestinationURL) > 0 {
@@ -100,6 +123,22 @@ func startP2PTransaction(client paymail.ClientInterface,
 	return &response.PaymentDestinationPayload, nil
 }
 
+// paymailResponseError is the error of the request answered by the paymail provider (with the status code of the response)
+type paymailResponseError struct {
+	StatusCode int
+	err        error
+}
+
+// Error will return the error of the paymail client
+func (e *paymailResponseError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap will return the error of the paymail client
+func (e *paymailResponseError) Unwrap() error {
+	return e.err
+}
+
 // finalizeP2PTransaction will notify the paymail provider about the transaction
 func finalizeP2PTransaction(ctx context.Context, client paymail.ClientInterface, p4 *PaymailP4, transaction *Transaction) (*paymail.P2PTransactionPayload, error) {
 	if transaction.client != nil {
@@ -115,6 +154,9 @@ func finalizeP2PTransaction(ctx context.Context, client paymail.ClientInterface,
 
 	response, err := client.SendP2PTransaction(p4.ReceiveEndpoint, p4.Alias, p4.Domain, p2pTransaction)
 	if err != nil {
+		if response != nil && response.StatusCode != 0 {
+			err = &paymailResponseError{StatusCode: response.StatusCode, err: err}
+		}
 		if transaction.client != nil {
 			transaction.client.Logger().Info().
 				Str("txID", transaction.ID).
@@ -142,8 +184,8 @@ func buildP2pTx(ctx context.Context, p4 *PaymailP4, transaction *Transaction) (*
 
 	switch p4.Format {
 
-	case BeefPaymailPayloadFormat:
-		beef, err := ToBeef(ctx, transaction, transaction.client)
+	case BeefPaymailPayloadFormat, BeefV2PaymailPayloadFormat, AtomicBeefPaymailPayloadFormat:
+		beef, err := ToBeefWithOptions(ctx, transaction, transaction.client, p4.Format.beefOptions())
 		if err != nil {
 			return nil, err
 		}
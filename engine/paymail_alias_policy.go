@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// DefaultReservedAliases are the aliases which can be used to impersonate the operator of the paymail domain
+var DefaultReservedAliases = []string{
+	"abuse", "admin", "administrator", "billing", "help", "hostmaster", "info", "mailer-daemon",
+	"moderator", "noreply", "no-reply", "operator", "postmaster", "root", "security", "staff",
+	"support", "system", "webmaster",
+}
+
+// AliasPolicy is the policy for the aliases (and public names) of the paymail addresses
+//
+// The policies of the domains inherit the unset fields from this policy (nil lists and zero limits),
+// the ConfusableCheck can't be turned off for a domain
+type AliasPolicy struct {
+	AllowPatterns      []string                // Alias must match at least one of the patterns (if any)
+	DenyPatterns       []string                // Alias must not match any of the patterns (e.g. profanity)
+	Reserved           []string                // Reserved aliases (lookalikes are rejected as well if ConfusableCheck is on)
+	MinLength          int                     // Min length of the alias (0 = no limit)
+	MaxLength          int                     // Max length of the alias (0 = no limit)
+	ConfusableCheck    bool                    // Reject unicode lookalikes and aliases resembling the reserved ones
+	MaxPaymailsPerXpub int                     // Max (not deleted) paymails of an xPub (0 = no limit)
+	Domains            map[string]*AliasPolicy // Policies of the domains (overriding the fields of this policy for the domain)
+
+	prepareOnce sync.Once               // The patterns are compiled and the domain policies merged once
+	allowed     []*regexp.Regexp        // Compiled AllowPatterns
+	denied      []*regexp.Regexp        // Compiled DenyPatterns
+	patternsErr error                   // Error of compiling the patterns
+	domains     map[string]*AliasPolicy // Merged policies of the domains (by lowercase domain)
+}
+
+// Validate will check the alias policy (and the policies of the domains)
+func (p *AliasPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.MinLength < 0 || p.MaxLength < 0 || p.MaxPaymailsPerXpub < 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidAliasPolicy, "limits cannot be negative")
+	}
+	if p.MaxLength > 0 && p.MinLength > p.MaxLength {
+		return spverrors.Wrapf(spverrors.ErrInvalidAliasPolicy, "min length is greater than max length")
+	}
+	if _, err := compilePatterns(p.AllowPatterns); err != nil {
+		return err
+	}
+	if _, err := compilePatterns(p.DenyPatterns); err != nil {
+		return err
+	}
+
+	for domain, policy := range p.Domains {
+		if policy == nil {
+			continue
+		}
+		if len(policy.Domains) > 0 {
+			return spverrors.Wrapf(spverrors.ErrInvalidAliasPolicy, "policy of domain %s cannot have domain policies", domain)
+		}
+		if err := p.merge(policy).Validate(); err != nil {
+			return spverrors.Wrapf(err, "invalid policy of domain %s", domain)
+		}
+	}
+	return nil
+}
+
+// merge will return the policy of the domain with the unset fields taken from this policy
+func (p *AliasPolicy) merge(domainPolicy *AliasPolicy) *AliasPolicy {
+	merged := &AliasPolicy{
+		AllowPatterns:      domainPolicy.AllowPatterns,
+		DenyPatterns:       domainPolicy.DenyPatterns,
+		Reserved:           domainPolicy.Reserved,
+		MinLength:          domainPolicy.MinLength,
+		MaxLength:          domainPolicy.MaxLength,
+		ConfusableCheck:    domainPolicy.ConfusableCheck || p.ConfusableCheck,
+		MaxPaymailsPerXpub: domainPolicy.MaxPaymailsPerXpub,
+	}
+	if merged.AllowPatterns == nil {
+		merged.AllowPatterns = p.AllowPatterns
+	}
+	if merged.DenyPatterns == nil {
+		merged.DenyPatterns = p.DenyPatterns
+	}
+	if merged.Reserved == nil {
+		merged.Reserved = p.Reserved
+	}
+	if merged.MinLength == 0 {
+		merged.MinLength = p.MinLength
+	}
+	if merged.MaxLength == 0 {
+		merged.MaxLength = p.MaxLength
+	}
+	if merged.MaxPaymailsPerXpub == 0 {
+		merged.MaxPaymailsPerXpub = p.MaxPaymailsPerXpub
+	}
+	return merged
+}
+
+// prepare will compile the patterns and merge the policies of the domains (once)
+func (p *AliasPolicy) prepare() {
+	p.prepareOnce.Do(func() {
+		if p.allowed, p.patternsErr = compilePatterns(p.AllowPatterns); p.patternsErr != nil {
+			return
+		}
+		if p.denied, p.patternsErr = compilePatterns(p.DenyPatterns); p.patternsErr != nil {
+			return
+		}
+
+		p.domains = make(map[string]*AliasPolicy, len(p.Domains))
+		for name, policy := range p.Domains {
+			if policy != nil {
+				p.domains[strings.ToLower(name)] = p.merge(policy)
+			}
+		}
+	})
+}
+
+// forDomain will return the policy of the domain (this policy if the domain has no own policy)
+func (p *AliasPolicy) forDomain(domain string) *AliasPolicy {
+	if p == nil {
+		return nil
+	}
+	p.prepare()
+	if policy, ok := p.domains[strings.ToLower(domain)]; ok {
+		return policy
+	}
+	return p
+}
+
+// checkAlias will check the alias against the policy (the raw alias is the alias as provided, before sanitizing)
+func (p *AliasPolicy) checkAlias(rawAlias, alias string) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.ConfusableCheck && !isASCII(rawAlias) {
+		return spverrors.ErrPaymailAliasConfusable
+	}
+
+	if p.MinLength > 0 && len(alias) < p.MinLength {
+		return spverrors.ErrPaymailAliasTooShort
+	}
+	if p.MaxLength > 0 && len(alias) > p.MaxLength {
+		return spverrors.ErrPaymailAliasTooLong
+	}
+
+	if err := p.checkReserved(alias); err != nil {
+		return err
+	}
+
+	p.prepare()
+	if p.patternsErr != nil {
+		return p.patternsErr
+	}
+	if len(p.allowed) > 0 && !matchesAny(p.allowed, alias) {
+		return spverrors.ErrPaymailAliasNotAllowed
+	}
+	if matchesAny(p.denied, alias) {
+		return spverrors.ErrPaymailAliasDenied
+	}
+	return nil
+}
+
+// checkPublicName will check that the public name of the paymail doesn't impersonate a reserved name
+func (p *AliasPolicy) checkPublicName(publicName string) error {
+	if p == nil || publicName == "" {
+		return nil
+	}
+
+	if p.ConfusableCheck {
+		return p.checkReserved(publicName)
+	}
+
+	name := strings.ToLower(strings.Join(strings.Fields(publicName), ""))
+	return p.checkReserved(name)
+}
+
+// checkReserved will check the name against the reserved aliases (comparing the skeletons if ConfusableCheck is on)
+func (p *AliasPolicy) checkReserved(name string) error {
+	for _, reserved := range p.Reserved {
+		reserved = strings.ToLower(strings.TrimSpace(reserved))
+		if reserved == "" {
+			continue
+		}
+		if name == reserved || (p.ConfusableCheck && confusableSkeleton(name) == confusableSkeleton(reserved)) {
+			return spverrors.ErrPaymailAliasReserved
+		}
+	}
+	return nil
+}
+
+// checkPaymailAliasPolicy will check the new paymail address (and the number of paymails of the xPub) against the alias policy
+func (c *Client) checkPaymailAliasPolicy(ctx context.Context, xPubID, address, publicName string) error {
+	config := c.GetPaymailConfig()
+	if config == nil || config.AliasPolicy == nil {
+		return nil
+	}
+
+	alias, domain, _ := paymail.SanitizePaymail(address)
+	rawAlias, _, found := strings.Cut(address, "@")
+	if !found || alias == "" {
+		return spverrors.ErrPaymailAddressIsInvalid
+	}
+
+	policy := config.AliasPolicy.forDomain(domain)
+	if err := policy.checkAlias(rawAlias, alias); err != nil {
+		return err
+	}
+	if err := policy.checkPublicName(publicName); err != nil {
+		return err
+	}
+
+	if policy.MaxPaymailsPerXpub > 0 {
+		count, err := getPaymailAddressesCount(ctx, nil, map[string]interface{}{
+			xPubIDField:    xPubID,
+			deletedAtField: nil,
+		}, c.DefaultModelOptions()...)
+		if err != nil {
+			return err
+		}
+		if count >= int64(policy.MaxPaymailsPerXpub) {
+			return spverrors.ErrMaxPaymailsPerXpubReached
+		}
+	}
+	return nil
+}
+
+// checkPaymailPublicNamePolicy will check the public name of the paymail address against the alias policy
+func (c *Client) checkPaymailPublicNamePolicy(pm *PaymailAddress, publicName string) error {
+	config := c.GetPaymailConfig()
+	if config == nil || config.AliasPolicy == nil {
+		return nil
+	}
+	return config.AliasPolicy.forDomain(pm.Domain).checkPublicName(publicName)
+}
+
+// compilePatterns will compile the regex patterns of the alias policy
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, spverrors.Wrapf(spverrors.ErrInvalidAliasPolicy, "invalid pattern %s: %s", pattern, err.Error())
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// matchesAny will return true if the value matches any of the patterns
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, re := range patterns {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isASCII will return true if the value contains only ASCII characters
+func isASCII(value string) bool {
+	for _, r := range value {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// confusableRunes are the characters (unicode lookalikes and look-alike digits) mapped to the ASCII letter they resemble
+var confusableRunes = map[rune]rune{
+	'0': 'o', 'о': 'o', 'ο': 'o', 'օ': 'o',
+	'1': 'l', 'i': 'l', '|': 'l', '!': 'l', 'і': 'l', 'ı': 'l', 'ӏ': 'l', 'ι': 'l',
+	'3': 'e', 'е': 'e', 'ε': 'e',
+	'4': 'a', '@': 'a', 'а': 'a', 'α': 'a',
+	'5': 's', '$': 's', 'ѕ': 's',
+	'7': 't', 'т': 't', 'τ': 't',
+	'8': 'b', 'в': 'b', 'β': 'b',
+	'9': 'g', 'ɡ': 'g',
+	'с': 'c', 'ϲ': 'c',
+	'р': 'p', 'ρ': 'p',
+	'х': 'x', 'χ': 'x',
+	'у': 'y', 'γ': 'y',
+	'к': 'k', 'κ': 'k',
+	'м': 'm', 'н': 'h', 'ν': 'v', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+}
+
+// confusableSkeleton will return the skeleton of the name (lookalikes mapped, separators removed),
+// the names with the same skeleton look the same
+func confusableSkeleton(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if mapped, ok := confusableRunes[r]; ok {
+			r = mapped
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	skeleton := b.String()
+	skeleton = strings.ReplaceAll(skeleton, "rn", "m")
+	skeleton = strings.ReplaceAll(skeleton, "vv", "w")
+	return skeleton
+}
@@ -0,0 +1,252 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAliasPolicy_checkAlias will test the method checkAlias()
+func TestAliasPolicy_checkAlias(t *testing.T) {
+	t.Parallel()
+
+	policy := &AliasPolicy{
+		AllowPatterns:   []string{"^[a-z0-9._-]+$"},
+		DenyPatterns:    []string{"scam"},
+		Reserved:        DefaultReservedAliases,
+		MinLength:       3,
+		MaxLength:       16,
+		ConfusableCheck: true,
+	}
+
+	tests := map[string]struct {
+		rawAlias string
+		alias    string
+		expected error
+	}{
+		"valid alias":             {rawAlias: "jon.snow", alias: "jon.snow"},
+		"too short":               {rawAlias: "jo", alias: "jo", expected: spverrors.ErrPaymailAliasTooShort},
+		"too long":                {rawAlias: "jon.snow.of.winterfell", alias: "jon.snow.of.winterfell", expected: spverrors.ErrPaymailAliasTooLong},
+		"reserved":                {rawAlias: "Admin", alias: "admin", expected: spverrors.ErrPaymailAliasReserved},
+		"reserved lookalike":      {rawAlias: "adm1n", alias: "adm1n", expected: spverrors.ErrPaymailAliasReserved},
+		"reserved with separator": {rawAlias: "sup.p0rt", alias: "sup.p0rt", expected: spverrors.ErrPaymailAliasReserved},
+		"unicode lookalike":       {rawAlias: "аdmin", alias: "dmin", expected: spverrors.ErrPaymailAliasConfusable},
+		"not allowed":             {rawAlias: "jon+snow", alias: "jon+snow", expected: spverrors.ErrPaymailAliasNotAllowed},
+		"denied":                  {rawAlias: "scammer", alias: "scammer", expected: spverrors.ErrPaymailAliasDenied},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := policy.checkAlias(tc.rawAlias, tc.alias)
+			if tc.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+
+	t.Run("reserved lookalike without confusable check", func(t *testing.T) {
+		p := &AliasPolicy{Reserved: DefaultReservedAliases}
+		require.NoError(t, p.checkAlias("adm1n", "adm1n"))
+		require.ErrorIs(t, p.checkAlias("admin", "admin"), spverrors.ErrPaymailAliasReserved)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		var p *AliasPolicy
+		require.NoError(t, p.checkAlias("admin", "admin"))
+	})
+}
+
+// TestAliasPolicy_checkPublicName will test the method checkPublicName()
+func TestAliasPolicy_checkPublicName(t *testing.T) {
+	t.Parallel()
+
+	policy := &AliasPolicy{Reserved: DefaultReservedAliases, ConfusableCheck: true}
+
+	require.NoError(t, policy.checkPublicName("Jon Snow"))
+	require.NoError(t, policy.checkPublicName(""))
+	require.ErrorIs(t, policy.checkPublicName("Support"), spverrors.ErrPaymailAliasReserved)
+	require.ErrorIs(t, policy.checkPublicName("Аdmin"), spverrors.ErrPaymailAliasReserved)
+	require.ErrorIs(t, policy.checkPublicName("S u p p o r t"), spverrors.ErrPaymailAliasReserved)
+}
+
+// TestAliasPolicy_forDomain will test the method forDomain()
+func TestAliasPolicy_forDomain(t *testing.T) {
+	t.Parallel()
+
+	policy := &AliasPolicy{
+		DenyPatterns:    []string{"scam"},
+		Reserved:        DefaultReservedAliases,
+		MinLength:       3,
+		ConfusableCheck: true,
+		Domains: map[string]*AliasPolicy{
+			"Winterfell.com":   {MinLength: 5},
+			"kingslanding.com": {Reserved: []string{}},
+		},
+	}
+
+	winterfell := policy.forDomain("winterfell.com")
+	assert.Equal(t, 5, winterfell.MinLength)
+	assert.Equal(t, DefaultReservedAliases, winterfell.Reserved)
+	assert.True(t, winterfell.ConfusableCheck)
+	require.ErrorIs(t, winterfell.checkAlias("scammer", "scammer"), spverrors.ErrPaymailAliasDenied)
+
+	// the explicitly empty list is not inherited
+	kingsLanding := policy.forDomain("kingslanding.com")
+	assert.Empty(t, kingsLanding.Reserved)
+	assert.Equal(t, 3, kingsLanding.MinLength)
+	require.NoError(t, kingsLanding.checkAlias("admin", "admin"))
+
+	assert.Same(t, policy, policy.forDomain("other.com"))
+}
+
+// TestAliasPolicy_Validate will test the method Validate()
+func TestAliasPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		policy := &AliasPolicy{
+			AllowPatterns: []string{"^[a-z]+$"},
+			MinLength:     3,
+			Domains: map[string]*AliasPolicy{
+				"winterfell.com": {MaxLength: 10},
+			},
+		}
+		require.NoError(t, policy.Validate())
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		policy := &AliasPolicy{DenyPatterns: []string{"[a-z"}}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidAliasPolicy)
+	})
+
+	t.Run("invalid lengths", func(t *testing.T) {
+		policy := &AliasPolicy{MinLength: 10, MaxLength: 5}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidAliasPolicy)
+	})
+
+	t.Run("invalid lengths of merged domain policy", func(t *testing.T) {
+		policy := &AliasPolicy{
+			MinLength: 10,
+			Domains:   map[string]*AliasPolicy{"winterfell.com": {MaxLength: 5}},
+		}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidAliasPolicy)
+	})
+
+	t.Run("nested domain policies", func(t *testing.T) {
+		policy := &AliasPolicy{
+			Domains: map[string]*AliasPolicy{
+				"winterfell.com": {Domains: map[string]*AliasPolicy{"kingslanding.com": {}}},
+			},
+		}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidAliasPolicy)
+	})
+}
+
+// TestConfusableSkeleton will test the method confusableSkeleton()
+func TestConfusableSkeleton(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, confusableSkeleton("admin"), confusableSkeleton("adm1n"))
+	assert.Equal(t, confusableSkeleton("admin"), confusableSkeleton("adrnin"))
+	assert.Equal(t, confusableSkeleton("admin"), confusableSkeleton("аdmіn"))
+	assert.Equal(t, confusableSkeleton("support"), confusableSkeleton("SUPP0RT"))
+	assert.NotEqual(t, confusableSkeleton("admin"), confusableSkeleton("jon"))
+}
+
+// TestClient_NewPaymailAddress_AliasPolicy will test the alias policy of the method NewPaymailAddress()
+func TestClient_NewPaymailAddress_AliasPolicy(t *testing.T) {
+	policy := &AliasPolicy{
+		Reserved:           DefaultReservedAliases,
+		ConfusableCheck:    true,
+		MaxPaymailsPerXpub: 2,
+		Domains: map[string]*AliasPolicy{
+			"kingslanding.com": {MinLength: 6},
+		},
+	}
+
+	t.Run("reserved alias", func(t *testing.T) {
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailAliasPolicy(policy))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "Adm1n@winterfell.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasReserved)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "jon@winterfell.com", "Support", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasReserved)
+	})
+
+	t.Run("policy of domain", func(t *testing.T) {
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailAliasPolicy(policy))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "jon@kingslanding.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasTooShort)
+
+		// the reserved aliases and the confusable check are inherited by the policy of the domain
+		_, err = client.NewPaymailAddress(ctx, csXpub, "support@kingslanding.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasReserved)
+		_, err = client.NewPaymailAddress(ctx, csXpub, "adm1nistrator@kingslanding.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasReserved)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "jon.snow@kingslanding.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+	})
+
+	t.Run("max paymails per xpub", func(t *testing.T) {
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailAliasPolicy(policy))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "jon@winterfell.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		_, err = client.NewPaymailAddress(ctx, csXpub, "ghost@winterfell.com", "Ghost", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "lord.snow@winterfell.com", "Lord Snow", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrMaxPaymailsPerXpubReached)
+
+		// deleted paymails are not counted
+		require.NoError(t, client.DeletePaymailAddress(ctx, "ghost@winterfell.com", client.DefaultModelOptions()...))
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "lord.snow@winterfell.com", "Lord Snow", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+	})
+
+	t.Run("update public name", func(t *testing.T) {
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailAliasPolicy(policy))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.NewPaymailAddress(ctx, csXpub, "jon@winterfell.com", "Jon Snow", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		_, err = client.UpdatePaymailAddress(ctx, "jon@winterfell.com", "Adm1nistrator", "", client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasReserved)
+
+		updated, err := client.UpdatePaymailAddress(ctx, "jon@winterfell.com", "King in the North", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, "King in the North", updated.PublicName)
+
+		xPubID := utils.Hash(csXpub)
+		_, err = client.UpdatePaymailProfile(ctx, xPubID, "jon@winterfell.com", &PaymailProfile{PublicName: "Admin"}, client.DefaultModelOptions()...)
+		require.ErrorIs(t, err, spverrors.ErrPaymailAliasReserved)
+
+		updated, err = client.UpdatePaymailProfile(ctx, xPubID, "jon@winterfell.com", &PaymailProfile{PublicName: "Lord Snow"}, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, "Lord Snow", updated.PublicName)
+	})
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/gin-gonic/gin"
+)
+
+// BeefFormatsCapability is the capability with the BEEF formats accepted by the BEEF transaction endpoint
+const BeefFormatsCapability = "beefFormats"
+
+// Names of the BEEF formats in the BeefFormatsCapability
+const (
+	BeefV1Format     = "BEEF_V1"
+	BeefV2Format     = "BEEF_V2"
+	AtomicBeefFormat = "ATOMIC_BEEF"
+)
+
+// beefFormatsCapability will return the capability with all the BEEF formats accepted by this server
+func beefFormatsCapability() map[string]any {
+	return map[string]any{
+		BeefFormatsCapability: []string{BeefV1Format, BeefV2Format, AtomicBeefFormat},
+	}
+}
+
+// beefFormatFromCapabilities will return the preferred BEEF format accepted by the receiver,
+// the receivers without the BeefFormatsCapability accept only BEEF version 1
+func beefFormatFromCapabilities(capabilities *paymail.CapabilitiesPayload) PaymailPayloadFormat {
+	formats := make(map[string]bool)
+	switch value := capabilities.Capabilities[BeefFormatsCapability].(type) {
+	case []any:
+		for _, format := range value {
+			if name, ok := format.(string); ok {
+				formats[strings.ToUpper(name)] = true
+			}
+		}
+	case []string:
+		for _, name := range value {
+			formats[strings.ToUpper(name)] = true
+		}
+	case string:
+		for _, name := range strings.Split(value, ",") {
+			formats[strings.ToUpper(strings.TrimSpace(name))] = true
+		}
+	}
+
+	switch {
+	case formats[AtomicBeefFormat]:
+		return AtomicBeefPaymailPayloadFormat
+	case formats[BeefV2Format]:
+		return BeefV2PaymailPayloadFormat
+	default:
+		return BeefPaymailPayloadFormat
+	}
+}
+
+// RegisterRoutes will register the paymail routes to the http router,
+// the requests to the domains which are not served are rejected,
+// the incoming BEEF version 2 and Atomic BEEF are converted to BEEF version 1 (accepted by the paymail server)
+// and the verify-pubkey and P2P destination requests are marked for the service provider
+func (p *PaymailServerOptions) RegisterRoutes(engine *gin.Engine) {
+	engine.Use(p.checkPaymailDomain, p.normalizeIncomingBeef, p.markVerifyPubKeyRequest, p.markDestinationSender)
+	p.Configuration.RegisterRoutes(engine)
+}
+
+// verifyIncomingBeef will run the local SPV verification of the incoming BEEF (version 1),
+// the paymail server verifies the merkle roots of the passed BEEF again
+func (p *PaymailServerOptions) verifyIncomingBeef(ctx context.Context, beefHex string) error {
+	beef, err := decodeBeefHex(beefHex)
+	if err != nil {
+		return spverrors.Wrapf(spverrors.ErrInvalidBeef, "%s", err.Error())
+	}
+	return verifyBeef(ctx, p.verifier, beef)
+}
+
+// normalizeIncomingBeef will rewrite the BEEF of the request to the BEEF transaction endpoint to BEEF version 1,
+// the body is always written again with the checked BEEF only (the JSON keys are matched case-insensitively by the paymail server)
+func (p *PaymailServerOptions) normalizeIncomingBeef(c *gin.Context) {
+	beefRoute := fmt.Sprintf("/%s/%s/beef/:%s", p.APIVersion, p.ServiceName, server.PaymailAddressParamName)
+	if c.Request.Method != http.MethodPost || c.FullPath() != beefRoute || c.Request.Body == nil {
+		c.Next()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrCannotBindRequest, p.Logger)
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var request map[string]json.RawMessage
+	if err = json.Unmarshal(body, &request); err != nil {
+		// malformed requests are rejected by the paymail server
+		c.Next()
+		return
+	}
+	for key := range request {
+		if key != "beef" && strings.EqualFold(key, "beef") {
+			spverrors.AbortWithErrorResponse(c, spverrors.Wrapf(spverrors.ErrInvalidBeef, "unexpected key %s", key), p.Logger)
+			return
+		}
+	}
+	var beefHex string
+	if json.Unmarshal(request["beef"], &beefHex) != nil || beefHex == "" {
+		c.Next()
+		return
+	}
+
+	normalized, err := normalizeBeefHex(c.Request.Context(), beefHex, p.transactions)
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.Wrapf(spverrors.ErrInvalidBeef, "%s", err.Error()), p.Logger)
+		return
+	}
+	if p.verifier != nil {
+		if err = p.verifyIncomingBeef(c.Request.Context(), normalized); err != nil {
+			p.Logger.Warn().Str("module", "paymail").Msgf("incoming BEEF rejected: %s", err.Error())
+			spverrors.AbortWithErrorResponse(c, err, p.Logger)
+			return
+		}
+	}
+	if request["beef"], err = json.Marshal(normalized); err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrCannotBindRequest, p.Logger)
+		return
+	}
+	if body, err = json.Marshal(request); err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrCannotBindRequest, p.Logger)
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	c.Next()
+}
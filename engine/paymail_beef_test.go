@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/chainstate"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/gin-gonic/gin"
+	"github.com/jarcoal/httpmock"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_normalizeIncomingBeef(t *testing.T) {
+	beef, err := decodeBeefHex(expectedBeefHex[1])
+	require.NoError(t, err)
+	atomicBeef, err := toBeefHex(beef.bumps, beefTxs(beef), &BeefOptions{Version: beefVersion2, Atomic: true})
+	require.NoError(t, err)
+
+	send := func(t *testing.T, path, beefHex string) *httptest.ResponseRecorder {
+		logger := zerolog.Nop()
+		options := &PaymailServerOptions{
+			Configuration: &server.Configuration{APIVersion: "v1", ServiceName: paymail.DefaultServiceName, Logger: &logger},
+		}
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.Use(options.normalizeIncomingBeef)
+		engine.POST("/v1/bsvalias/beef/:"+server.PaymailAddressParamName, func(c *gin.Context) {
+			var p2pTx paymail.P2PTransaction
+			_ = c.ShouldBindJSON(&p2pTx)
+			c.String(http.StatusOK, p2pTx.Beef)
+		})
+		engine.POST("/v1/bsvalias/other", func(c *gin.Context) {
+			var p2pTx paymail.P2PTransaction
+			_ = c.ShouldBindJSON(&p2pTx)
+			c.String(http.StatusOK, p2pTx.Beef)
+		})
+
+		body, err := json.Marshal(&paymail.P2PTransaction{Beef: beefHex, Reference: "reference"})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+		return w
+	}
+
+	t.Run("atomic BEEF is converted to BEEF version 1", func(t *testing.T) {
+		w := send(t, "/v1/bsvalias/beef/alias@example.com", atomicBeef)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, expectedBeefHex[1], w.Body.String())
+	})
+
+	t.Run("BEEF version 1 is not changed", func(t *testing.T) {
+		w := send(t, "/v1/bsvalias/beef/alias@example.com", expectedBeefHex[1])
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, expectedBeefHex[1], w.Body.String())
+	})
+
+	t.Run("other routes are not changed", func(t *testing.T) {
+		w := send(t, "/v1/bsvalias/other", atomicBeef)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, atomicBeef, w.Body.String())
+	})
+
+	t.Run("BEEF under another case of the key is rejected", func(t *testing.T) {
+		body := `{"beef":"` + expectedBeefHex[1] + `","Beef":"` + atomicBeef + `","reference":"reference"}`
+		logger := zerolog.Nop()
+		options := &PaymailServerOptions{
+			Configuration: &server.Configuration{APIVersion: "v1", ServiceName: paymail.DefaultServiceName, Logger: &logger},
+		}
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.Use(options.normalizeIncomingBeef)
+		engine.POST("/v1/bsvalias/beef/:"+server.PaymailAddressParamName, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/bsvalias/beef/alias@example.com", bytes.NewReader([]byte(body))))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "error-transaction-beef-invalid")
+	})
+
+	t.Run("invalid BEEF is rejected", func(t *testing.T) {
+		w := send(t, "/v1/bsvalias/beef/alias@example.com", "0200beef00")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "error-transaction-beef-invalid")
+	})
+}
+
+func Test_normalizeIncomingBeef_verification(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	mockMerkleRootsVerification(chainstate.Confirmed)
+
+	_, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+	defer deferMe()
+
+	send := func(t *testing.T, beefHex string) *httptest.ResponseRecorder {
+		logger := zerolog.Nop()
+		options := &PaymailServerOptions{
+			Configuration: &server.Configuration{APIVersion: "v1", ServiceName: paymail.DefaultServiceName, Logger: &logger},
+			verifier:      client,
+		}
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.Use(options.normalizeIncomingBeef)
+		engine.POST("/v1/bsvalias/beef/:"+server.PaymailAddressParamName, func(c *gin.Context) {
+			var p2pTx paymail.P2PTransaction
+			_ = c.ShouldBindJSON(&p2pTx)
+			c.String(http.StatusOK, p2pTx.Beef)
+		})
+
+		body, err := json.Marshal(&paymail.P2PTransaction{Beef: beefHex, Reference: "reference"})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/bsvalias/beef/alias@example.com", bytes.NewReader(body)))
+		return w
+	}
+
+	t.Run("verified BEEF is passed to the paymail server", func(t *testing.T) {
+		w := send(t, expectedBeefHex[1])
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, expectedBeefHex[1], w.Body.String())
+	})
+
+	t.Run("BEEF with invalid script is rejected with the reason", func(t *testing.T) {
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		beef.transactions[2].tx.Inputs[0].UnlockingScript = bscript.NewFromBytes([]byte{bscript.OpTRUE})
+		tampered, err := toBeefHex(beef.bumps, beefTxs(beef), &BeefOptions{Version: beefVersion1})
+		require.NoError(t, err)
+
+		w := send(t, tampered)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), spverrors.ErrSPVInvalidScript.Code)
+	})
+
+	t.Run("BEEF with unconfirmed merkle roots is rejected with the reason", func(t *testing.T) {
+		mockMerkleRootsVerification(chainstate.Invalid)
+		defer mockMerkleRootsVerification(chainstate.Confirmed)
+
+		w := send(t, expectedBeefHex[1])
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), spverrors.ErrSPVInvalidMerkleRoots.Code)
+	})
+}
@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/mrz1836/go-cachestore"
+)
+
+// PaymailDomainCache is the cached data of a counterpart paymail domain (capabilities and PKI of the paymails)
+type PaymailDomainCache struct {
+	Domain            string                          `json:"domain"`
+	Blocked           bool                            `json:"blocked"`
+	Capabilities      *paymail.CapabilitiesPayload    `json:"capabilities,omitempty"`
+	CapabilitiesError string                          `json:"capabilities_error,omitempty"`
+	PKI               map[string]*paymail.PKIResponse `json:"pki,omitempty"`
+	PKIErrors         map[string]string               `json:"pki_errors,omitempty"`
+}
+
+// getCachedPki will get the cached PKI of the paymail (returns the error if the failed lookup is cached)
+func (s *PaymailServant) getCachedPki(ctx context.Context, sPaymail *paymail.SanitisedPaymail,
+	policy *PaymailClientPolicy,
+) (*paymail.PKIResponse, error) {
+	if s.cs == nil {
+		return nil, nil
+	}
+
+	if policy.PKITTL > 0 {
+		pki := new(paymail.PKIResponse)
+		if err := s.cs.GetModel(
+			ctx, cacheKeyPKI+sPaymail.Address, pki,
+		); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+			return nil, spverrors.Wrapf(err, "failed to get PKI from cachestore")
+		} else if pki.PubKey != "" {
+			return pki, nil
+		}
+	}
+
+	if policy.NegativeTTL > 0 {
+		if cachedErr, _ := s.cs.Get(ctx, cacheKeyPKIError+sPaymail.Address); cachedErr != "" {
+			return nil, spverrors.Wrapf(spverrors.ErrGetPaymailPki, "%s", cachedErr)
+		}
+	}
+	return nil, nil
+}
+
+// cachePki will cache the PKI of the paymail (and add the paymail into the cached paymails of the domain)
+func (s *PaymailServant) cachePki(ctx context.Context, sPaymail *paymail.SanitisedPaymail,
+	pki *paymail.PKIResponse, policy *PaymailClientPolicy,
+) {
+	if policy.PKITTL <= 0 || s.cs == nil || s.cs.Engine().IsEmpty() {
+		return
+	}
+
+	if err := s.cs.SetModel(context.Background(), cacheKeyPKI+sPaymail.Address, pki, policy.PKITTL); err == nil {
+		s.indexCachedPki(ctx, sPaymail, max(policy.PKITTL, policy.NegativeTTL))
+	}
+}
+
+// cachePkiError will cache the failed PKI lookup of the paymail
+func (s *PaymailServant) cachePkiError(ctx context.Context, sPaymail *paymail.SanitisedPaymail,
+	lookupErr error, policy *PaymailClientPolicy,
+) {
+	if policy.NegativeTTL <= 0 || s.cs == nil || s.cs.Engine().IsEmpty() {
+		return
+	}
+
+	if err := s.cs.SetTTL(
+		context.Background(), cacheKeyPKIError+sPaymail.Address, lookupErr.Error(), policy.NegativeTTL,
+	); err == nil {
+		s.indexCachedPki(ctx, sPaymail, max(policy.PKITTL, policy.NegativeTTL))
+	}
+}
+
+// evictPki will remove the cached PKI (and the cached failed lookup) of the paymail,
+// so the next lookup fetches the current public key
+func (s *PaymailServant) evictPki(ctx context.Context, sPaymail *paymail.SanitisedPaymail) {
+	if s.cs == nil || s.cs.Engine().IsEmpty() {
+		return
+	}
+	_ = s.cs.Delete(ctx, cacheKeyPKI+sPaymail.Address)
+	_ = s.cs.Delete(ctx, cacheKeyPKIError+sPaymail.Address)
+}
+
+// indexCachedPki will add the paymail into the cached paymails of the domain,
+// so the cache of the domain can be inspected and flushed
+func (s *PaymailServant) indexCachedPki(ctx context.Context, sPaymail *paymail.SanitisedPaymail, ttl time.Duration) {
+	key := cacheKeyPKIDomain + sPaymail.Domain
+	unlock, err := newWaitWriteLock(ctx, "lock-"+key, s.cs)
+	defer unlock()
+	if err != nil {
+		return
+	}
+
+	var addresses []string
+	_ = s.cs.GetModel(ctx, key, &addresses)
+	for _, address := range addresses {
+		if address == sPaymail.Address {
+			return
+		}
+	}
+	_ = s.cs.SetModel(context.Background(), key, append(addresses, sPaymail.Address), ttl)
+}
+
+// GetPaymailDomainCache will get the cached capabilities and PKI of the counterpart paymail domain
+func (c *Client) GetPaymailDomainCache(ctx context.Context, domain string) (*PaymailDomainCache, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_paymail_domain_cache")
+
+	domain, err := paymail.SanitizeDomain(domain)
+	if err != nil || paymail.ValidateDomain(domain) != nil {
+		return nil, spverrors.ErrPaymailDomainInvalid
+	}
+
+	cs := c.Cachestore()
+	domainCache := &PaymailDomainCache{
+		Domain:  domain,
+		Blocked: paymailClientPolicyOf(c.PaymailClient()).isBlocked(domain),
+	}
+
+	capabilities := new(paymail.CapabilitiesPayload)
+	if err = cs.GetModel(ctx, cacheKeyCapabilities+domain, capabilities); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+		return nil, spverrors.Wrapf(err, "failed to get capabilities from cachestore")
+	} else if len(capabilities.Capabilities) > 0 {
+		domainCache.Capabilities = capabilities
+	}
+	domainCache.CapabilitiesError, _ = cs.Get(ctx, cacheKeyCapabilitiesError+domain)
+
+	addresses, err := c.cachedPkiAddresses(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		pki := new(paymail.PKIResponse)
+		if err = cs.GetModel(ctx, cacheKeyPKI+address, pki); err == nil && pki.PubKey != "" {
+			if domainCache.PKI == nil {
+				domainCache.PKI = make(map[string]*paymail.PKIResponse)
+			}
+			domainCache.PKI[address] = pki
+		}
+		if pkiErr, _ := cs.Get(ctx, cacheKeyPKIError+address); pkiErr != "" {
+			if domainCache.PKIErrors == nil {
+				domainCache.PKIErrors = make(map[string]string)
+			}
+			domainCache.PKIErrors[address] = pkiErr
+		}
+	}
+
+	return domainCache, nil
+}
+
+// FlushPaymailDomainCache will remove the cached capabilities and PKI of the counterpart paymail domain
+func (c *Client) FlushPaymailDomainCache(ctx context.Context, domain string) error {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "flush_paymail_domain_cache")
+
+	domain, err := paymail.SanitizeDomain(domain)
+	if err != nil || paymail.ValidateDomain(domain) != nil {
+		return spverrors.ErrPaymailDomainInvalid
+	}
+
+	cs := c.Cachestore()
+	addresses, err := c.cachedPkiAddresses(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{cacheKeyCapabilities + domain, cacheKeyCapabilitiesError + domain, cacheKeyPKIDomain + domain}
+	for _, address := range addresses {
+		keys = append(keys, cacheKeyPKI+address, cacheKeyPKIError+address)
+	}
+	for _, key := range keys {
+		if err = cs.Delete(ctx, key); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+			return spverrors.Wrapf(err, "failed to delete %s from cachestore", key)
+		}
+	}
+	return nil
+}
+
+// cachedPkiAddresses will get the paymails of the domain with the cached PKI
+func (c *Client) cachedPkiAddresses(ctx context.Context, domain string) ([]string, error) {
+	var addresses []string
+	if err := c.Cachestore().GetModel(
+		ctx, cacheKeyPKIDomain+domain, &addresses,
+	); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+		return nil, spverrors.Wrapf(err, "failed to get the cached PKI of the domain from cachestore")
+	}
+	return addresses, nil
+}
@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/go-resty/resty/v2"
+)
+
+// PaymailClientPolicy is the policy of the outbound paymail requests (to the counterpart paymail providers)
+type PaymailClientPolicy struct {
+	CapabilitiesTTL      time.Duration                   // Time for which the capabilities are cached (0 = not cached)
+	PKITTL               time.Duration                   // Time for which the PKI of the paymails are cached (0 = not cached)
+	NegativeTTL          time.Duration                   // Time for which the failed lookups are cached (0 = not cached)
+	Timeout              time.Duration                   // Timeout of a request (0 = default timeout of the paymail client)
+	Retries              int                             // Retries of the failed lookups (capabilities, PKI, profiles)
+	BlockedDomains       []string                        // Domains (and their subdomains) which are never contacted
+	Domains              map[string]*PaymailDomainPolicy // Timeouts and retries of the domains (replacing the defaults)
+	NotificationAttempts int                             // Attempts of the P2P transaction submissions (0 = default attempts)
+	NotificationBackoff  time.Duration                   // Delay before the second submission, doubled by each attempt (0 = default delay)
+}
+
+// PaymailDomainPolicy is the timeout and retries of the requests to a counterpart paymail domain
+type PaymailDomainPolicy struct {
+	Timeout time.Duration // Timeout of a request (0 = timeout of the policy)
+	Retries int           // Retries of the failed lookups
+}
+
+// defaultPaymailClientPolicy is used when no policy is set (only the capabilities are cached)
+var defaultPaymailClientPolicy = &PaymailClientPolicy{CapabilitiesTTL: cacheTTLCapabilities}
+
+// paymailRetryDelay is the delay between the retries (multiplied by the attempt)
+const paymailRetryDelay = 200 * time.Millisecond
+
+// defaultPaymailRequestTimeout is the timeout of a request when the policy has none (same as the go-paymail client)
+const defaultPaymailRequestTimeout = 20 * time.Second
+
+const (
+	defaultNotificationAttempts = 10              // Attempts of the P2P transaction submissions
+	defaultNotificationBackoff  = 1 * time.Minute // Delay before the second submission of the P2P transaction
+	maxNotificationBackoffDelay = 12 * time.Hour  // Maximum delay between the submissions of the P2P transaction
+)
+
+// Validate will check the paymail client policy
+func (p *PaymailClientPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.CapabilitiesTTL < 0 || p.PKITTL < 0 || p.NegativeTTL < 0 || p.Timeout < 0 || p.Retries < 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidPaymailClientPolicy, "TTLs, timeout and retries cannot be negative")
+	}
+	if p.NotificationAttempts < 0 || p.NotificationBackoff < 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidPaymailClientPolicy, "notification attempts and backoff cannot be negative")
+	}
+	for _, domain := range p.BlockedDomains {
+		if err := paymail.ValidateDomain(domain); err != nil {
+			return spverrors.Wrapf(spverrors.ErrInvalidPaymailClientPolicy, "blocked domain %s is invalid", domain)
+		}
+	}
+	for domain, policy := range p.Domains {
+		if err := paymail.ValidateDomain(domain); err != nil {
+			return spverrors.Wrapf(spverrors.ErrInvalidPaymailClientPolicy, "domain %s is invalid", domain)
+		}
+		if policy != nil && (policy.Timeout < 0 || policy.Retries < 0) {
+			return spverrors.Wrapf(spverrors.ErrInvalidPaymailClientPolicy, "timeout and retries of domain %s cannot be negative", domain)
+		}
+	}
+	return nil
+}
+
+// isBlocked will return true if the domain (or its parent domain) is on the blocklist
+func (p *PaymailClientPolicy) isBlocked(domain string) bool {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	for _, blocked := range p.BlockedDomains {
+		blocked = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(blocked)), ".")
+		if blocked != "" && (domain == blocked || strings.HasSuffix(domain, "."+blocked)) {
+			return true
+		}
+	}
+	return false
+}
+
+// retriesOf will return the retries of the failed lookups of the domain
+func (p *PaymailClientPolicy) retriesOf(domain string) int {
+	for name, policy := range p.Domains {
+		if policy != nil && strings.EqualFold(name, domain) {
+			return policy.Retries
+		}
+	}
+	return p.Retries
+}
+
+// timeoutOf will return the timeout of the requests to the host (the policy of its domain or parent domain)
+func (p *PaymailClientPolicy) timeoutOf(host string) time.Duration {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for name, policy := range p.Domains {
+		name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+		if policy != nil && policy.Timeout > 0 && (host == name || strings.HasSuffix(host, "."+name)) {
+			return policy.Timeout
+		}
+	}
+	if p.Timeout > 0 {
+		return p.Timeout
+	}
+	return defaultPaymailRequestTimeout
+}
+
+// notificationAttempts will return the maximum attempts of the P2P transaction submissions
+func (p *PaymailClientPolicy) notificationAttempts() uint32 {
+	if p.NotificationAttempts > 0 {
+		return uint32(p.NotificationAttempts)
+	}
+	return defaultNotificationAttempts
+}
+
+// notificationBackoff will return the delay after the failed attempt of the P2P transaction submission
+func (p *PaymailClientPolicy) notificationBackoff(attempt uint32) time.Duration {
+	delay := p.NotificationBackoff
+	if delay <= 0 {
+		delay = defaultNotificationBackoff
+	}
+	for i := uint32(1); i < attempt && delay < maxNotificationBackoffDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxNotificationBackoffDelay)
+}
+
+// paymailClientPolicyOf will return the policy of the paymail client (default policy if the client has none)
+func paymailClientPolicyOf(client paymail.ClientInterface) *PaymailClientPolicy {
+	if pc, ok := client.(*policyPaymailClient); ok && pc.policy != nil {
+		return pc.policy
+	}
+	return defaultPaymailClientPolicy
+}
+
+// policyPaymailClient is the paymail client applying the policy (blocklist, timeouts and retries) to the requests
+type policyPaymailClient struct {
+	paymail.ClientInterface
+	policy *PaymailClientPolicy
+}
+
+// newPolicyPaymailClient will wrap the paymail client with the policy
+func newPolicyPaymailClient(client paymail.ClientInterface, policy *PaymailClientPolicy) paymail.ClientInterface {
+	if pc, ok := client.(*policyPaymailClient); ok {
+		client = pc.ClientInterface
+	}
+	return &policyPaymailClient{ClientInterface: client, policy: policy}
+}
+
+// doRequest will run the request to the domain, the lookups (idempotent requests) are retried
+//
+// The timeouts are applied by the HTTP client (see newPaymailHTTPClient), so a request is never abandoned
+// while it is still running, and the other requests (e.g. P2P transaction submissions) are sent only once
+func doRequest[T any](c *policyPaymailClient, domain string, lookup bool, request func() (T, error)) (T, error) {
+	var result T
+	if c.policy.isBlocked(domain) {
+		return result, spverrors.ErrPaymailDomainBlocked
+	}
+
+	retries := 0
+	if lookup {
+		retries = c.policy.retriesOf(domain)
+	}
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * paymailRetryDelay)
+		}
+		if result, err = request(); err == nil {
+			return result, nil
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return result, spverrors.ErrPaymailRequestTimeout
+	}
+	return result, err
+}
+
+// newPaymailHTTPClient will return the HTTP client of the paymail client applying the timeouts of the policy
+// (the failed requests are not retried by the HTTP client, only the lookups are retried by doRequest)
+func newPaymailHTTPClient(policy *PaymailClientPolicy) *resty.Client {
+	return resty.New().SetTransport(&paymailTimeoutTransport{policy: policy, base: http.DefaultTransport})
+}
+
+// paymailTimeoutTransport is the HTTP transport cancelling the requests running longer than the timeout of the host
+type paymailTimeoutTransport struct {
+	policy *PaymailClientPolicy
+	base   http.RoundTripper
+}
+
+// RoundTrip will run the request with the timeout of the host
+func (t *paymailTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.policy.timeoutOf(req.URL.Hostname()))
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err //nolint:wrapcheck // the error of the transport is returned to the HTTP client
+	}
+
+	// The body is read after the round trip, the timeout is released when it's closed
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody is the response body releasing the timeout of the request when it's closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close will close the body and release the timeout
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close() //nolint:wrapcheck // the error of the body is returned to the HTTP client
+}
+
+// GetSRVRecord will get the SRV record of the domain
+func (c *policyPaymailClient) GetSRVRecord(service, protocol, domainName string) (*net.SRV, error) {
+	return doRequest(c, domainName, true, func() (*net.SRV, error) {
+		return c.ClientInterface.GetSRVRecord(service, protocol, domainName) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// GetCapabilities will get the capabilities of the target host
+func (c *policyPaymailClient) GetCapabilities(target string, port int) (*paymail.CapabilitiesResponse, error) {
+	return doRequest(c, target, true, func() (*paymail.CapabilitiesResponse, error) {
+		return c.ClientInterface.GetCapabilities(target, port) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// GetPKI will get the PKI of the paymail
+func (c *policyPaymailClient) GetPKI(pkiURL, alias, domain string) (*paymail.PKIResponse, error) {
+	return doRequest(c, domain, true, func() (*paymail.PKIResponse, error) {
+		return c.ClientInterface.GetPKI(pkiURL, alias, domain) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// GetPublicProfile will get the public profile of the paymail
+func (c *policyPaymailClient) GetPublicProfile(publicProfileURL, alias, domain string) (*paymail.PublicProfileResponse, error) {
+	return doRequest(c, domain, true, func() (*paymail.PublicProfileResponse, error) {
+		return c.ClientInterface.GetPublicProfile(publicProfileURL, alias, domain) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// VerifyPubKey will verify the pub key of the paymail
+func (c *policyPaymailClient) VerifyPubKey(verifyURL, alias, domain, pubKey string) (*paymail.VerificationResponse, error) {
+	return doRequest(c, domain, true, func() (*paymail.VerificationResponse, error) {
+		return c.ClientInterface.VerifyPubKey(verifyURL, alias, domain, pubKey) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// ResolveAddress will resolve the address of the paymail (basic address resolution)
+func (c *policyPaymailClient) ResolveAddress(resolutionURL, alias, domain string,
+	senderRequest *paymail.SenderRequest,
+) (*paymail.ResolutionResponse, error) {
+	return doRequest(c, domain, false, func() (*paymail.ResolutionResponse, error) {
+		return c.ClientInterface.ResolveAddress(resolutionURL, alias, domain, senderRequest) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// GetP2PPaymentDestination will get the P2P payment destinations of the paymail
+func (c *policyPaymailClient) GetP2PPaymentDestination(p2pURL, alias, domain string,
+	paymentRequest *paymail.PaymentRequest,
+) (*paymail.PaymentDestinationResponse, error) {
+	return doRequest(c, domain, false, func() (*paymail.PaymentDestinationResponse, error) {
+		return c.ClientInterface.GetP2PPaymentDestination(p2pURL, alias, domain, paymentRequest) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// SendP2PTransaction will send the P2P transaction to the paymail
+func (c *policyPaymailClient) SendP2PTransaction(p2pURL, alias, domain string,
+	transaction *paymail.P2PTransaction,
+) (*paymail.P2PTransactionResponse, error) {
+	return doRequest(c, domain, false, func() (*paymail.P2PTransactionResponse, error) {
+		return c.ClientInterface.SendP2PTransaction(p2pURL, alias, domain, transaction) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// AddContactRequest will send the PIKE contact request to the paymail
+func (c *policyPaymailClient) AddContactRequest(url, alias, domain string,
+	request *paymail.PikeContactRequestPayload,
+) (*paymail.PikeContactRequestResponse, error) {
+	return doRequest(c, domain, false, func() (*paymail.PikeContactRequestResponse, error) {
+		return c.ClientInterface.AddContactRequest(url, alias, domain, request) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// AddInviteRequest will send the PIKE invite request to the paymail
+func (c *policyPaymailClient) AddInviteRequest(inviteURL, alias, domain string,
+	request *paymail.PikeContactRequestPayload,
+) (*paymail.PikeContactRequestResponse, error) {
+	return doRequest(c, domain, false, func() (*paymail.PikeContactRequestResponse, error) {
+		return c.ClientInterface.AddInviteRequest(inviteURL, alias, domain, request) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
+
+// GetOutputsTemplate will get the PIKE outputs template of the paymail
+func (c *policyPaymailClient) GetOutputsTemplate(pikeURL, alias, domain string,
+	payload *paymail.PikePaymentOutputsPayload,
+) (*paymail.PikePaymentOutputsResponse, error) {
+	return doRequest(c, domain, false, func() (*paymail.PikePaymentOutputsResponse, error) {
+		return c.ClientInterface.GetOutputsTemplate(pikeURL, alias, domain, payload) //nolint:wrapcheck // we have handler for paymail errors
+	})
+}
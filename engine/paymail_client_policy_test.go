@@ -0,0 +1,248 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPkiPubKey = "02ead23149a1e33df17325ec7a7ba9e0b20c674c57c630f527d69b866aa9b65b10"
+
+// countingPaymailClient is the paymail client counting the lookups (capabilities and PKI)
+type countingPaymailClient struct {
+	paymail.ClientInterface
+	fail         bool
+	timeout      bool
+	capabilities int
+	pki          int
+}
+
+func (c *countingPaymailClient) GetSRVRecord(_, _, _ string) (*net.SRV, error) {
+	return nil, errors.New("zero SRV records found")
+}
+
+func (c *countingPaymailClient) GetCapabilities(_ string, _ int) (*paymail.CapabilitiesResponse, error) {
+	c.capabilities++
+	if c.timeout {
+		return nil, &url.Error{Op: http.MethodGet, URL: "https://" + testDomain, Err: context.DeadlineExceeded}
+	}
+	if c.fail {
+		return nil, errors.New("paymail provider is not reachable")
+	}
+	return &paymail.CapabilitiesResponse{CapabilitiesPayload: paymail.CapabilitiesPayload{
+		BsvAlias:     paymail.DefaultBsvAliasVersion,
+		Capabilities: map[string]interface{}{paymail.BRFCPki: "https://" + testDomain + "/id/{alias}@{domain.tld}"},
+	}}, nil
+}
+
+func (c *countingPaymailClient) GetPKI(_, alias, domain string) (*paymail.PKIResponse, error) {
+	c.pki++
+	return &paymail.PKIResponse{PKIPayload: paymail.PKIPayload{
+		BsvAlias: paymail.DefaultBsvAliasVersion, Handle: alias + "@" + domain, PubKey: testPkiPubKey,
+	}}, nil
+}
+
+// TestPaymailClientPolicy_isBlocked will test the method isBlocked()
+func TestPaymailClientPolicy_isBlocked(t *testing.T) {
+	t.Parallel()
+
+	policy := &PaymailClientPolicy{BlockedDomains: []string{"Scam.com"}}
+
+	assert.True(t, policy.isBlocked("scam.com"))
+	assert.True(t, policy.isBlocked("pay.scam.com"))
+	assert.False(t, policy.isBlocked("notscam.com"))
+	assert.False(t, policy.isBlocked(testDomain))
+}
+
+// TestPaymailClientPolicy_forDomain will test the methods retriesOf() and timeoutOf()
+func TestPaymailClientPolicy_forDomain(t *testing.T) {
+	t.Parallel()
+
+	policy := &PaymailClientPolicy{
+		Timeout: 10 * time.Second,
+		Retries: 1,
+		Domains: map[string]*PaymailDomainPolicy{
+			"slow.com":  {Timeout: 30 * time.Second, Retries: 3},
+			"flaky.com": {Retries: 5},
+		},
+	}
+
+	assert.Equal(t, 3, policy.retriesOf("slow.com"))
+	assert.Equal(t, 5, policy.retriesOf("flaky.com"))
+	assert.Equal(t, 1, policy.retriesOf(testDomain))
+
+	assert.Equal(t, 30*time.Second, policy.timeoutOf("slow.com"))
+	assert.Equal(t, 30*time.Second, policy.timeoutOf("api.slow.com"))
+	assert.Equal(t, 10*time.Second, policy.timeoutOf("flaky.com"))
+	assert.Equal(t, 10*time.Second, policy.timeoutOf(testDomain))
+	assert.Equal(t, defaultPaymailRequestTimeout, (&PaymailClientPolicy{}).timeoutOf(testDomain))
+}
+
+// TestPaymailClientPolicy_Validate will test the method Validate()
+func TestPaymailClientPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&PaymailClientPolicy{
+		CapabilitiesTTL: time.Hour,
+		BlockedDomains:  []string{"scam.com"},
+		Domains:         map[string]*PaymailDomainPolicy{"slow.com": {Timeout: time.Second}},
+	}).Validate())
+
+	require.ErrorIs(t, (&PaymailClientPolicy{Retries: -1}).Validate(), spverrors.ErrInvalidPaymailClientPolicy)
+	require.ErrorIs(t, (&PaymailClientPolicy{BlockedDomains: []string{"not a domain"}}).Validate(), spverrors.ErrInvalidPaymailClientPolicy)
+	require.ErrorIs(t, (&PaymailClientPolicy{
+		Domains: map[string]*PaymailDomainPolicy{"slow.com": {Timeout: -time.Second}},
+	}).Validate(), spverrors.ErrInvalidPaymailClientPolicy)
+}
+
+// TestPolicyPaymailClient will test the blocklist, timeouts and retries of the policy paymail client
+func TestPolicyPaymailClient(t *testing.T) {
+	t.Run("blocked domain", func(t *testing.T) {
+		pc := &countingPaymailClient{}
+		client := newPolicyPaymailClient(pc, &PaymailClientPolicy{BlockedDomains: []string{testDomain}})
+
+		_, err := client.GetCapabilities(testDomain, paymail.DefaultPort)
+		require.ErrorIs(t, err, spverrors.ErrPaymailDomainBlocked)
+		assert.Equal(t, 0, pc.capabilities)
+	})
+
+	t.Run("lookups are retried", func(t *testing.T) {
+		pc := &countingPaymailClient{fail: true}
+		client := newPolicyPaymailClient(pc, &PaymailClientPolicy{
+			Domains: map[string]*PaymailDomainPolicy{testDomain: {Retries: 2}},
+		})
+
+		_, err := client.GetCapabilities(testDomain, paymail.DefaultPort)
+		require.Error(t, err)
+		assert.Equal(t, 3, pc.capabilities)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		pc := &countingPaymailClient{timeout: true}
+		client := newPolicyPaymailClient(pc, &PaymailClientPolicy{})
+
+		_, err := client.GetCapabilities(testDomain, paymail.DefaultPort)
+		require.ErrorIs(t, err, spverrors.ErrPaymailRequestTimeout)
+	})
+}
+
+// TestNewPaymailHTTPClient will test the timeouts applied by the HTTP client of the paymail client
+func TestNewPaymailHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+		_, _ = w.Write([]byte(`{"bsvalias":"1.0"}`))
+	}))
+	defer server.Close()
+
+	client := newPaymailHTTPClient(&PaymailClientPolicy{Timeout: 50 * time.Millisecond})
+
+	t.Run("request in time", func(t *testing.T) {
+		resp, err := client.R().Get(server.URL + "/fast")
+		require.NoError(t, err)
+		assert.Equal(t, `{"bsvalias":"1.0"}`, resp.String())
+	})
+
+	t.Run("request is cancelled after the timeout", func(t *testing.T) {
+		_, err := client.R().Post(server.URL + "/slow")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// TestGetCapabilities_ClientPolicy will test the caching of getCapabilities() set by the policy
+func TestGetCapabilities_ClientPolicy(t *testing.T) {
+	t.Run("failed lookup is cached", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		pc := &countingPaymailClient{fail: true}
+		client := newPolicyPaymailClient(pc, &PaymailClientPolicy{CapabilitiesTTL: time.Hour, NegativeTTL: time.Minute})
+
+		_, err := getCapabilities(ctx, c.Cachestore(), client, testDomain)
+		require.Error(t, err)
+
+		_, err = getCapabilities(ctx, c.Cachestore(), client, testDomain)
+		require.ErrorIs(t, err, spverrors.ErrGetCapabilities)
+		assert.Equal(t, 1, pc.capabilities)
+	})
+
+	t.Run("capabilities are not cached", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		pc := &countingPaymailClient{}
+		client := newPolicyPaymailClient(pc, &PaymailClientPolicy{})
+
+		for i := 0; i < 2; i++ {
+			_, err := getCapabilities(ctx, c.Cachestore(), client, testDomain)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, pc.capabilities)
+	})
+}
+
+// TestClient_PaymailDomainCache will test the methods GetPaymailDomainCache() and FlushPaymailDomainCache()
+func TestClient_PaymailDomainCache(t *testing.T) {
+	pc := &countingPaymailClient{}
+	policy := &PaymailClientPolicy{CapabilitiesTTL: time.Hour, PKITTL: time.Hour, BlockedDomains: []string{"scam.com"}}
+
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+		WithPaymailClient(pc), WithPaymailClientPolicy(policy))
+	defer deferMe()
+
+	servant := &PaymailServant{cs: c.Cachestore(), pc: c.PaymailClient()}
+	sPaymail, err := servant.GetSanitizedPaymail(testAlias + "@" + testDomain)
+	require.NoError(t, err)
+
+	// PKI is cached
+	for i := 0; i < 2; i++ {
+		var pki *paymail.PKIResponse
+		pki, err = servant.GetPkiForPaymail(ctx, sPaymail)
+		require.NoError(t, err)
+		assert.Equal(t, testPkiPubKey, pki.PubKey)
+	}
+	assert.Equal(t, 1, pc.capabilities)
+	assert.Equal(t, 1, pc.pki)
+
+	domainCache, err := c.GetPaymailDomainCache(ctx, testDomain)
+	require.NoError(t, err)
+	require.NotNil(t, domainCache.Capabilities)
+	require.Contains(t, domainCache.PKI, sPaymail.Address)
+	assert.Equal(t, testPkiPubKey, domainCache.PKI[sPaymail.Address].PubKey)
+	assert.False(t, domainCache.Blocked)
+
+	// Flushed cache is requested again
+	require.NoError(t, c.FlushPaymailDomainCache(ctx, testDomain))
+
+	domainCache, err = c.GetPaymailDomainCache(ctx, testDomain)
+	require.NoError(t, err)
+	assert.Nil(t, domainCache.Capabilities)
+	assert.Empty(t, domainCache.PKI)
+
+	_, err = servant.GetPkiForPaymail(ctx, sPaymail)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pc.capabilities)
+	assert.Equal(t, 2, pc.pki)
+
+	// Blocked domain
+	domainCache, err = c.GetPaymailDomainCache(ctx, "scam.com")
+	require.NoError(t, err)
+	assert.True(t, domainCache.Blocked)
+
+	_, err = servant.GetPkiForPaymail(ctx, &paymail.SanitisedPaymail{Alias: "bob", Domain: "scam.com", Address: "bob@scam.com"})
+	require.ErrorIs(t, err, spverrors.ErrPaymailDomainBlocked)
+}
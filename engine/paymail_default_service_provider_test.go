@@ -1,9 +1,18 @@
 package engine
 
 import (
+	"context"
+	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -11,8 +20,15 @@ import (
 func TestPaymailDefaultServiceProvider(t *testing.T) {
 	t.Run("PaymailDefaultServiceProvider.GetPaymailByAlias", testGetPaymailByAlias)
 	t.Run("PaymailDefaultServiceProvider.GetPaymailByAlias - multiple call", testGetPaymailByAliasMultipleRequestShouldReturnStablePubKey)
+	t.Run("PaymailDefaultServiceProvider.GetPaymailByAlias - privacy settings", testGetPaymailByAliasShouldRespectPrivacySettings)
+	t.Run("PaymailDefaultServiceProvider.GetPaymailByAlias - rotated pub key", testGetPaymailByAliasShouldVerifyRotatedPubKey)
 	t.Run("PaymailDefaultServiceProvider.CreateAddressResolutionResponse - multiple call", testCreateAddressResolutionResponseShouldReturnDifferentResponses)
+	t.Run("PaymailDefaultServiceProvider.CreateAddressResolutionResponse - key not found", testCreateAddressResolutionResponseWithoutPaymailKey)
 	t.Run("PaymailDefaultServiceProvider.CreateP2PDestinationResponse - multiple call", testCreateP2PDestinationResponseShouldReturnDifferentResponses)
+	t.Run("PaymailDefaultServiceProvider.CreateP2PDestinationResponse - split outputs", testCreateP2PDestinationResponseShouldSplitOutputs)
+	t.Run("PaymailDefaultServiceProvider.CreateP2PDestinationResponse - receive policy", testCreateP2PDestinationResponseShouldRespectReceivePolicy)
+	t.Run("PaymailDefaultServiceProvider.RecordTransaction - receive policy", testRecordTransactionShouldRespectReceivePolicy)
+	t.Run("PaymailDefaultServiceProvider.RecordTransaction - sender verified with PKI", testRecordTransactionShouldVerifySenderWithPki)
 
 }
 
@@ -70,6 +86,122 @@ func testGetPaymailByAliasMultipleRequestShouldReturnStablePubKey(t *testing.T)
 
 }
 
+func testGetPaymailByAliasShouldRespectPrivacySettings(t *testing.T) {
+	// given
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	pm.setProfile(&PaymailProfile{
+		PublicName:           "Tester",
+		Avatar:               "https://domain.sc/avatar.png",
+		PublicProfileHidden:  true,
+		VerifyPubKeyDisabled: true,
+	})
+	err := pm.Save(ctx)
+	require.NoError(t, err)
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+
+	// when
+	res, err := sut.GetPaymailByAlias(ctx, pm.Alias, pm.Domain, &server.RequestMetadata{
+		RequestURI: "/v1/bsvalias/public-profile/paymail@domain.sc",
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Empty(t, res.Name)
+	assert.Empty(t, res.Avatar)
+
+	// when
+	verifyCtx := context.WithValue(ctx, verifyPubKeyRequestKey{}, res.PubKey)
+	_, err = sut.GetPaymailByAlias(verifyCtx, pm.Alias, pm.Domain, &server.RequestMetadata{
+		RequestURI: "/v1/bsvalias/verify-pubkey/paymail@domain.sc/" + res.PubKey,
+	})
+
+	// then
+	require.ErrorIs(t, err, spverrors.ErrCouldNotFindPaymail)
+
+	// when
+	pki, err := sut.GetPaymailByAlias(ctx, pm.Alias, pm.Domain, &server.RequestMetadata{
+		RequestURI: "/v1/bsvalias/id/paymail@domain.sc",
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, res.PubKey, pki.PubKey)
+}
+
+func testGetPaymailByAliasShouldVerifyRotatedPubKey(t *testing.T) {
+	// given
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	err := pm.Save(ctx)
+	require.NoError(t, err)
+
+	previousPubKey, err := pm.GetPubKey()
+	require.NoError(t, err)
+
+	err = pm.RotatePubKey(ctx)
+	require.NoError(t, err)
+
+	currentPubKey, err := pm.GetPubKey()
+	require.NoError(t, err)
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+	verifyCtx := context.WithValue(ctx, verifyPubKeyRequestKey{}, previousPubKey)
+	verifyRequest := &server.RequestMetadata{
+		RequestURI: "/v1/bsvalias/verify-pubkey/paymail@domain.sc/" + previousPubKey,
+	}
+
+	// when
+	res, err := sut.GetPaymailByAlias(verifyCtx, pm.Alias, pm.Domain, verifyRequest)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, previousPubKey, res.PubKey)
+
+	// when
+	pki, err := sut.GetPaymailByAlias(ctx, pm.Alias, pm.Domain, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, currentPubKey, pki.PubKey)
+
+	// when - grace period is over
+	pm.PreviousPubKeys[0].ValidUntil = time.Now().Add(-time.Minute)
+	err = pm.Save(ctx)
+	require.NoError(t, err)
+
+	res, err = sut.GetPaymailByAlias(verifyCtx, pm.Alias, pm.Domain, verifyRequest)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, currentPubKey, res.PubKey)
+}
+
+func testCreateAddressResolutionResponseWithoutPaymailKey(t *testing.T) {
+	// given
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+		WithPaymailKeyProvider(&missingKeyProvider{}))
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	require.NoError(t, pm.Save(ctx))
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+
+	// when
+	res, err := sut.CreateAddressResolutionResponse(ctx, pm.Alias, pm.Domain, true, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Output)
+	assert.Empty(t, res.Signature)
+}
+
 func testCreateAddressResolutionResponseShouldReturnDifferentResponses(t *testing.T) {
 	// given
 	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
@@ -142,3 +274,225 @@ func testCreateP2PDestinationResponseShouldReturnDifferentResponses(t *testing.T
 		seen = append(seen, res)
 	}
 }
+
+func testCreateP2PDestinationResponseShouldSplitOutputs(t *testing.T) {
+	// given
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(), WithPaymailOutputSplit(&OutputSplitOptions{
+		Strategy:          OutputSplitStrategyMaxSize,
+		MaxOutputSatoshis: 40,
+	}))
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	err := pm.Save(ctx)
+	require.NoError(t, err)
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+
+	// when
+	res, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, res.Outputs, 3)
+
+	scripts := make(map[string]bool)
+	total := uint64(0)
+	for _, out := range res.Outputs {
+		assert.LessOrEqual(t, out.Satoshis, uint64(40))
+		total += out.Satoshis
+		scripts[out.Script] = true
+
+		dst, err := getDestinationByLockingScript(ctx, out.Script, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.NotNil(t, dst)
+		assert.Equal(t, res.Reference, dst.Metadata[ReferenceIDField])
+	}
+	assert.Equal(t, uint64(100), total)
+	assert.Len(t, scripts, 3)
+}
+
+func testCreateP2PDestinationResponseShouldRespectReceivePolicy(t *testing.T) {
+	// given
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	pm.setReceivePolicy(&PaymailReceivePolicy{
+		MinSatoshis:           100,
+		MaxSatoshis:           1000,
+		DailyDestinationQuota: 2,
+		DeniedSenders:         []string{"spam.com"},
+	})
+	err := pm.Save(ctx)
+	require.NoError(t, err)
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+	md := &server.RequestMetadata{IPAddress: "127.0.0.1"}
+	senderCtx := func(sender string) context.Context {
+		return context.WithValue(ctx, destinationSenderKey{}, sender)
+	}
+
+	// when
+	_, err = sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(10), md)
+
+	// then
+	require.ErrorIs(t, err, spverrors.ErrReceiveAmountTooLow)
+
+	// when
+	_, err = sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(10000), md)
+
+	// then
+	require.ErrorIs(t, err, spverrors.ErrReceiveAmountTooHigh)
+
+	// when
+	_, err = sut.CreateP2PDestinationResponse(senderCtx("bad@spam.com"), pm.Alias, pm.Domain, uint64(500), md)
+
+	// then
+	require.ErrorIs(t, err, spverrors.ErrReceiveSenderDenied)
+
+	// when - the policy with the sender rules requires the sender
+	_, err = sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(500), md)
+
+	// then
+	require.ErrorIs(t, err, spverrors.ErrReceiveSenderNotAllowed)
+
+	// when - the quota is counted for all the requests (the declared sender can be changed)
+	_, err = sut.CreateP2PDestinationResponse(senderCtx("alice@example.com"), pm.Alias, pm.Domain, uint64(500), md)
+	require.NoError(t, err)
+	_, err = sut.CreateP2PDestinationResponse(senderCtx("bob@other.com"), pm.Alias, pm.Domain, uint64(500),
+		&server.RequestMetadata{IPAddress: "127.0.0.2"})
+	require.NoError(t, err)
+	_, err = sut.CreateP2PDestinationResponse(senderCtx("carol@third.com"), pm.Alias, pm.Domain, uint64(500),
+		&server.RequestMetadata{IPAddress: "127.0.0.3"})
+
+	// then
+	require.ErrorIs(t, err, spverrors.ErrReceiveQuotaExceeded)
+
+	// when - contacts only
+	contactsOnly := newPaymail("contacts@domain.sc", 1, WithClient(c), WithXPub(testXPub))
+	contactsOnly.setReceivePolicy(&PaymailReceivePolicy{ContactsOnly: true})
+	require.NoError(t, contactsOnly.Save(ctx))
+	contact := newContact("Friend", "friend@example.com", testContactPubKey, contactsOnly.XpubID, ContactConfirmed)
+	contact.enrich(ModelContact, append(c.DefaultModelOptions(), New())...)
+	require.NoError(t, contact.Save(ctx))
+
+	// then
+	_, err = sut.CreateP2PDestinationResponse(senderCtx("stranger@example.com"), contactsOnly.Alias, contactsOnly.Domain, uint64(500), md)
+	require.ErrorIs(t, err, spverrors.ErrReceiveSenderNotContact)
+	_, err = sut.CreateP2PDestinationResponse(senderCtx("friend@example.com"), contactsOnly.Alias, contactsOnly.Domain, uint64(500), md)
+	require.NoError(t, err)
+}
+
+func testRecordTransactionShouldRespectReceivePolicy(t *testing.T) {
+	// given
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	pm.setReceivePolicy(&PaymailReceivePolicy{
+		MinSatoshis:   100,
+		DeniedSenders: []string{"spam.com"},
+		ContactsOnly:  true,
+	})
+	err := pm.Save(ctx)
+	require.NoError(t, err)
+
+	senderKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+	senderPubKey := hex.EncodeToString(senderKey.PubKey().SerialiseCompressed())
+	otherKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+
+	for address, status := range map[string]ContactStatus{
+		"friend@domain.com":   ContactConfirmed,
+		"stranger@domain.com": ContactAwaitAccept,
+		"bad@spam.com":        ContactConfirmed,
+	} {
+		contact := newContact(address, address, senderPubKey, pm.XpubID, status)
+		contact.enrich(ModelContact, append(c.DefaultModelOptions(), New())...)
+		require.NoError(t, contact.Save(ctx))
+	}
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+	md := &server.RequestMetadata{Alias: pm.Alias, Domain: pm.Domain}
+
+	destination, err := sut.CreateP2PDestinationResponse(context.WithValue(ctx, destinationSenderKey{}, "friend@domain.com"),
+		pm.Alias, pm.Domain, uint64(500), nil)
+	require.NoError(t, err)
+
+	lockingScript, err := bscript.NewFromHexString(destination.Outputs[0].Script)
+	require.NoError(t, err)
+
+	newTx := func(satoshis uint64) *bt.Tx {
+		tx := bt.NewTx()
+		tx.AddOutput(&bt.Output{Satoshis: satoshis, LockingScript: lockingScript})
+		return tx
+	}
+	signedP2PTx := func(sender string, key *bec.PrivateKey, tx *bt.Tx) *paymail.P2PTransaction {
+		signature, err := bitcoin.SignMessage(hex.EncodeToString(key.Serialise()), tx.TxID(), true)
+		require.NoError(t, err)
+		return &paymail.P2PTransaction{
+			MetaData: &paymail.P2PMetaData{
+				Sender:    sender,
+				PubKey:    hex.EncodeToString(key.PubKey().SerialiseCompressed()),
+				Signature: signature,
+			},
+			Reference: destination.Reference,
+		}
+	}
+	check := func(p2pTx *paymail.P2PTransaction, tx *bt.Tx) error {
+		return sut.checkReceivedP2PTransaction(ctx, p2pTx, tx, md)
+	}
+
+	// then
+	unsigned := &paymail.P2PTransaction{MetaData: &paymail.P2PMetaData{Sender: "friend@domain.com"}, Reference: destination.Reference}
+	require.ErrorIs(t, check(unsigned, newTx(500)), spverrors.ErrReceiveSenderNotVerified)
+	require.ErrorIs(t, check(signedP2PTx("friend@domain.com", otherKey, newTx(500)), newTx(500)), spverrors.ErrReceiveSenderNotVerified)
+	require.ErrorIs(t, check(signedP2PTx("friend@domain.com", senderKey, newTx(501)), newTx(500)), spverrors.ErrReceiveSenderNotVerified)
+
+	require.ErrorIs(t, check(signedP2PTx("bad@spam.com", senderKey, newTx(500)), newTx(500)), spverrors.ErrReceiveSenderDenied)
+	require.ErrorIs(t, check(signedP2PTx("stranger@domain.com", senderKey, newTx(500)), newTx(500)), spverrors.ErrReceiveSenderNotContact)
+	require.ErrorIs(t, check(signedP2PTx("friend@domain.com", senderKey, newTx(50)), newTx(50)), spverrors.ErrReceiveAmountTooLow)
+	require.NoError(t, check(signedP2PTx("Friend@Domain.com", senderKey, newTx(500)), newTx(500)))
+}
+
+func testRecordTransactionShouldVerifySenderWithPki(t *testing.T) {
+	// given
+	senderPaymail := "sansa_stark@winterfell.com"
+	senderKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+
+	pt := &paymailTestMock{}
+	pt.setup(t, "winterfell.com", false)
+	defer pt.cleanup()
+	pt.mockPki(senderPaymail, hex.EncodeToString(senderKey.PubKey().SerialiseCompressed()))
+
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(), WithPaymailClient(pt.paymailClient))
+	defer deferMe()
+
+	pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+	pm.setReceivePolicy(&PaymailReceivePolicy{AllowedSenders: []string{"winterfell.com"}})
+	require.NoError(t, pm.Save(ctx))
+
+	sut := &PaymailDefaultServiceProvider{client: c}
+	md := &server.RequestMetadata{Alias: pm.Alias, Domain: pm.Domain}
+
+	tx := bt.NewTx()
+	tx.AddOutput(&bt.Output{Satoshis: 500, LockingScript: &bscript.Script{}})
+	p2pTx := func(key *bec.PrivateKey) *paymail.P2PTransaction {
+		signature, err := bitcoin.SignMessage(hex.EncodeToString(key.Serialise()), tx.TxID(), true)
+		require.NoError(t, err)
+		return &paymail.P2PTransaction{MetaData: &paymail.P2PMetaData{
+			Sender:    senderPaymail,
+			PubKey:    hex.EncodeToString(key.PubKey().SerialiseCompressed()),
+			Signature: signature,
+		}}
+	}
+	otherKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+
+	// then
+	require.NoError(t, sut.checkReceivedP2PTransaction(ctx, p2pTx(senderKey), tx, md))
+	require.ErrorIs(t, sut.checkReceivedP2PTransaction(ctx, p2pTx(otherKey), tx, md), spverrors.ErrReceiveSenderNotVerified)
+}
@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/bitcoin-sv/go-paymail"
+	paymailErrors "github.com/bitcoin-sv/go-paymail/errors"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/cluster"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/gin-gonic/gin"
+)
+
+// paymailDomains are the paymail domains managed at runtime, served next to the domains from config
+//
+// The go-paymail server reads its domains without any synchronization, so its configuration is never changed at runtime:
+// the domain validation of go-paymail is disabled and the served domains are validated by the paymail routes middleware
+type paymailDomains struct {
+	sync.RWMutex
+	static             []*server.Domain          // Domains from config (never removed)
+	domains            []*server.Domain          // All the served domains (replaced, never modified)
+	settings           map[string]*PaymailDomain // Managed domains by name
+	validationDisabled bool                      // Domain validation disabled in config
+}
+
+// loadPaymailDomains will load the managed paymail domains next to the paymail server configuration
+// and subscribe to the cluster channel, so all the servers reload the domains after a change
+func (c *Client) loadPaymailDomains(ctx context.Context) error {
+	config := c.GetPaymailConfig()
+	if config == nil || config.Configuration == nil || !c.options.modelExists(ModelPaymailDomain.String(), migrateList) {
+		return nil
+	}
+
+	static := make([]*server.Domain, len(config.PaymailDomains))
+	copy(static, config.PaymailDomains)
+	config.domains = &paymailDomains{
+		static:             static,
+		domains:            static,
+		settings:           make(map[string]*PaymailDomain),
+		validationDisabled: config.PaymailDomainsValidationDisabled,
+	}
+	config.PaymailDomainsValidationDisabled = true
+
+	if err := c.reloadPaymailDomains(ctx); err != nil {
+		return err
+	}
+
+	if c.Cluster() == nil {
+		return nil
+	}
+	_, err := c.Cluster().Subscribe(cluster.PaymailDomainsReload, func(_ string) {
+		if err := c.reloadPaymailDomains(context.Background()); err != nil {
+			c.Logger().Error().Msgf("failed to reload paymail domains: %s", err.Error())
+		}
+	})
+	return spverrors.Wrapf(err, "failed to subscribe to paymail domains reload")
+}
+
+// reloadPaymailDomains will read the managed paymail domains and set them as the served domains
+func (c *Client) reloadPaymailDomains(ctx context.Context) error {
+	config := c.GetPaymailConfig()
+	if config == nil || config.domains == nil {
+		return nil
+	}
+
+	managed, err := getPaymailDomains(
+		ctx, nil, map[string]interface{}{deletedAtField: nil}, nil, c.DefaultModelOptions()...,
+	)
+	if err != nil {
+		return err
+	}
+
+	config.domains.set(managed)
+	return nil
+}
+
+// notifyPaymailDomainsChanged will reload the paymail domains on all the servers (through the cluster)
+func (c *Client) notifyPaymailDomainsChanged(ctx context.Context) error {
+	if c.Cluster() != nil {
+		if err := c.Cluster().Publish(cluster.PaymailDomainsReload, ""); err == nil {
+			return nil
+		}
+		c.Logger().Warn().Msg("failed to publish paymail domains reload, reloading only locally")
+	}
+	return c.reloadPaymailDomains(ctx)
+}
+
+// set will replace the managed domains (the list of the served domains is replaced, so it can be read after unlocking)
+func (d *paymailDomains) set(managed []*PaymailDomain) {
+	d.Lock()
+	defer d.Unlock()
+
+	domains := make([]*server.Domain, len(d.static), len(d.static)+len(managed))
+	copy(domains, d.static)
+
+	settings := make(map[string]*PaymailDomain, len(managed))
+	for _, m := range managed {
+		settings[m.ID] = m
+		if !containsDomain(domains, m.ID) {
+			domains = append(domains, &server.Domain{Name: m.ID})
+		}
+	}
+
+	d.settings = settings
+	d.domains = domains
+}
+
+// containsDomain will return true if the domain is in the list
+func containsDomain(domains []*server.Domain, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(d.Name, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// domainSettings will return the settings of the managed paymail domain (nil for the domains from config)
+func (p *PaymailServerOptions) domainSettings(domain string) *PaymailDomainSettings {
+	if p == nil || p.domains == nil {
+		return nil
+	}
+
+	sanitized, err := paymail.SanitizeDomain(domain)
+	if err != nil {
+		return nil
+	}
+
+	p.domains.RLock()
+	defer p.domains.RUnlock()
+	if m, ok := p.domains.settings[sanitized]; ok {
+		return m.Settings()
+	}
+	return nil
+}
+
+// servedDomains will return all the served paymail domains (from config and managed)
+func (p *PaymailServerOptions) servedDomains() []*server.Domain {
+	if p.domains == nil {
+		return p.PaymailDomains
+	}
+
+	p.domains.RLock()
+	defer p.domains.RUnlock()
+	return p.domains.domains
+}
+
+// DomainNames will return the names of all the served paymail domains (from config and managed)
+func (p *PaymailServerOptions) DomainNames() []string {
+	if p == nil || p.Configuration == nil {
+		return nil
+	}
+
+	domains := p.servedDomains()
+	names := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		names = append(names, domain.Name)
+	}
+	return names
+}
+
+// IsAllowedDomain will return true if the paymail domain is served (from config or managed)
+func (p *PaymailServerOptions) IsAllowedDomain(domain string) bool {
+	if p.domains == nil {
+		return p.Configuration.IsAllowedDomain(domain)
+	}
+	if p.domains.validationDisabled {
+		return true
+	}
+
+	sanitized, err := paymail.SanitizeDomain(domain)
+	if err != nil {
+		return false
+	}
+	return containsDomain(p.servedDomains(), sanitized)
+}
+
+// checkPaymailDomain will reject the paymail requests to the domains which are not served,
+// the check replaces the domain validation of go-paymail when the domains are managed at runtime
+func (p *PaymailServerOptions) checkPaymailDomain(c *gin.Context) {
+	if p.domains == nil {
+		c.Next()
+		return
+	}
+
+	var domain string
+	if address := c.Param(server.PaymailAddressParamName); address != "" {
+		_, domain, _ = paymail.SanitizePaymail(address)
+	} else if c.FullPath() == "/.well-known/"+p.ServiceName {
+		domain = c.Request.Host
+		if !c.Request.URL.IsAbs() && c.Request.URL.Host != "" {
+			domain = c.Request.URL.Host
+		}
+	} else {
+		c.Next()
+		return
+	}
+
+	if domain != "" && !p.IsAllowedDomain(domain) {
+		paymailErrors.ErrorResponse(c, paymailErrors.ErrDomainUnknown)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/libsv/go-bk/bip32"
+)
+
+// fileKeyProvider provides the xPrivs listed in a file (e.g. a mounted secret), one xPriv per line
+//
+// The file is read on every use, so the keys can be added or removed without a restart
+type fileKeyProvider struct {
+	path string
+}
+
+// NewFileKeyProvider will create the paymail key provider of the xPrivs listed in the file (one per line, # for comments)
+//
+// With the provider, SPV Wallet holds the xPrivs of the listed xPubs (the file has to be protected as a key store).
+// The file is checked when the provider is created
+func NewFileKeyProvider(path string) (PaymailKeyProvider, error) {
+	provider := &fileKeyProvider{path: path}
+	if _, err := provider.load(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// GetXPriv will get the xPriv of the xPub from the file
+func (p *fileKeyProvider) GetXPriv(_ context.Context, xPubID string) (*bip32.ExtendedKey, error) {
+	xPrivs, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	xPriv, ok := xPrivs[xPubID]
+	if !ok {
+		return nil, spverrors.ErrPaymailKeyNotFound
+	}
+	return xPriv, nil
+}
+
+// load will read the xPrivs of the file, indexed by the IDs of their xPubs
+func (p *fileKeyProvider) load() (map[string]*bip32.ExtendedKey, error) {
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to read the paymail keys file")
+	}
+
+	xPrivs := make(map[string]*bip32.ExtendedKey)
+	for index, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		xPriv, err := bip32.NewKeyFromString(line)
+		if err != nil || !xPriv.IsPrivate() {
+			return nil, spverrors.Newf("invalid xPriv on line %d of the paymail keys file", index+1)
+		}
+
+		xPub, err := xPriv.Neuter()
+		if err != nil {
+			return nil, spverrors.Wrapf(err, "failed to get xPub of the xPriv on line %d of the paymail keys file", index+1)
+		}
+		xPrivs[utils.Hash(xPub.String())] = xPriv
+	}
+
+	return xPrivs, nil
+}
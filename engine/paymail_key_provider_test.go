@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileKeyProvider(t *testing.T) {
+	writeKeys := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "xprivs")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("get the xPriv of the xPub", func(t *testing.T) {
+		provider, err := NewFileKeyProvider(writeKeys(t, "# keys of the paymails\n\n"+testXPriv+"\n"))
+		require.NoError(t, err)
+
+		xPriv, err := provider.GetXPriv(context.Background(), utils.Hash(testXPub))
+		require.NoError(t, err)
+		assert.Equal(t, testXPriv, xPriv.String())
+
+		_, err = provider.GetXPriv(context.Background(), "unknown-xpub-id")
+		require.ErrorIs(t, err, spverrors.ErrPaymailKeyNotFound)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewFileKeyProvider(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+
+	t.Run("xPub instead of xPriv", func(t *testing.T) {
+		_, err := NewFileKeyProvider(writeKeys(t, testXPub))
+		require.Error(t, err)
+	})
+}
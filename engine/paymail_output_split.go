@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sort"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// OutputSplitStrategy is the strategy for splitting the received satoshis into multiple outputs (P2P payment destinations)
+type OutputSplitStrategy string
+
+const (
+	// OutputSplitStrategyNone will return a single output for the full amount
+	OutputSplitStrategyNone OutputSplitStrategy = "none"
+
+	// OutputSplitStrategyDenominations will split the amount into fixed denominations (the remainder is a separate output)
+	OutputSplitStrategyDenominations OutputSplitStrategy = "denominations"
+
+	// OutputSplitStrategyRandom will split the amount randomly into MaxOutputs outputs
+	OutputSplitStrategyRandom OutputSplitStrategy = "random"
+
+	// OutputSplitStrategyMaxSize will split the amount evenly into outputs not bigger than MaxOutputSatoshis
+	OutputSplitStrategyMaxSize OutputSplitStrategy = "max_size"
+)
+
+// defaultMaxSplitOutputs is the limit of outputs for a single P2P payment destination, if not configured
+const defaultMaxSplitOutputs = 10
+
+// OutputSplitOptions are the options for splitting the received satoshis into multiple outputs
+type OutputSplitOptions struct {
+	Strategy          OutputSplitStrategy // Strategy for splitting the outputs
+	Denominations     []uint64            // Denominations (in satoshis) for the denominations strategy
+	MaxOutputSatoshis uint64              // Max satoshis of an output for the max_size strategy
+	MaxOutputs        int                 // Limit of outputs (the number of outputs for the random strategy)
+}
+
+// Validate will check the output split options
+func (o *OutputSplitOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.MaxOutputs < 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidOutputSplit, "max outputs cannot be negative")
+	}
+
+	switch o.Strategy {
+	case "", OutputSplitStrategyNone, OutputSplitStrategyRandom:
+		return nil
+	case OutputSplitStrategyDenominations:
+		if len(o.Denominations) == 0 {
+			return spverrors.Wrapf(spverrors.ErrInvalidOutputSplit, "denominations are required")
+		}
+		for _, denomination := range o.Denominations {
+			if denomination == 0 {
+				return spverrors.Wrapf(spverrors.ErrInvalidOutputSplit, "denomination cannot be zero")
+			}
+		}
+		return nil
+	case OutputSplitStrategyMaxSize:
+		if o.MaxOutputSatoshis == 0 {
+			return spverrors.Wrapf(spverrors.ErrInvalidOutputSplit, "max output satoshis are required")
+		}
+		return nil
+	default:
+		return spverrors.Wrapf(spverrors.ErrInvalidOutputSplit, "unknown strategy %s", o.Strategy)
+	}
+}
+
+// maxOutputs will return the limit of the outputs
+func (o *OutputSplitOptions) maxOutputs() int {
+	if o.MaxOutputs > 0 {
+		return o.MaxOutputs
+	}
+	return defaultMaxSplitOutputs
+}
+
+// split will split the satoshis into the output values according to the strategy
+func (o *OutputSplitOptions) split(satoshis uint64) ([]uint64, error) {
+	if o == nil || satoshis == 0 {
+		return []uint64{satoshis}, nil
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch o.Strategy {
+	case OutputSplitStrategyDenominations:
+		return o.splitDenominations(satoshis), nil
+	case OutputSplitStrategyRandom:
+		return o.splitRandom(satoshis)
+	case OutputSplitStrategyMaxSize:
+		return o.splitMaxSize(satoshis), nil
+	default:
+		return []uint64{satoshis}, nil
+	}
+}
+
+// splitDenominations will split the satoshis into the biggest possible denominations
+//
+// The remainder (and everything over the limit of outputs) is added as the last output
+func (o *OutputSplitOptions) splitDenominations(satoshis uint64) []uint64 {
+	denominations := make([]uint64, len(o.Denominations))
+	copy(denominations, o.Denominations)
+	sort.Slice(denominations, func(i, j int) bool { return denominations[i] > denominations[j] })
+
+	maxOutputs := o.maxOutputs()
+	values := make([]uint64, 0, maxOutputs)
+	remaining := satoshis
+	for _, denomination := range denominations {
+		for remaining >= denomination && len(values) < maxOutputs-1 {
+			values = append(values, denomination)
+			remaining -= denomination
+		}
+	}
+
+	if remaining > 0 {
+		values = append(values, remaining)
+	}
+	return values
+}
+
+// splitRandom will split the satoshis randomly (each output is 75% - 125% of an even split)
+func (o *OutputSplitOptions) splitRandom(satoshis uint64) ([]uint64, error) {
+	// Every output needs at least one satoshi
+	nrOfOutputs := o.maxOutputs()
+	if maxBySatoshis := satoshis / 2; uint64(nrOfOutputs) > maxBySatoshis {
+		nrOfOutputs = int(maxBySatoshis)
+	}
+	if nrOfOutputs <= 1 {
+		return []uint64{satoshis}, nil
+	}
+
+	weights := make([]uint64, nrOfOutputs)
+	totalWeight := uint64(0)
+	for i := range weights {
+		a, err := rand.Int(rand.Reader, big.NewInt(51))
+		if err != nil {
+			return nil, spverrors.Wrapf(err, "failed to generate random number")
+		}
+		weights[i] = uint64(a.Int64()) + 75
+		totalWeight += weights[i]
+	}
+
+	values := make([]uint64, nrOfOutputs)
+	used := uint64(0)
+	for i, weight := range weights {
+		value := new(big.Int).SetUint64(satoshis)
+		value.Mul(value, new(big.Int).SetUint64(weight))
+		value.Div(value, new(big.Int).SetUint64(totalWeight))
+
+		values[i] = value.Uint64()
+		used += values[i]
+	}
+
+	// handle remainder
+	values[len(values)-1] += satoshis - used
+	return values, nil
+}
+
+// splitMaxSize will split the satoshis evenly into the smallest number of outputs not bigger than MaxOutputSatoshis
+func (o *OutputSplitOptions) splitMaxSize(satoshis uint64) []uint64 {
+	nrOfOutputs := (satoshis + o.MaxOutputSatoshis - 1) / o.MaxOutputSatoshis
+	if maxOutputs := uint64(o.maxOutputs()); nrOfOutputs > maxOutputs {
+		nrOfOutputs = maxOutputs
+	}
+
+	values := make([]uint64, nrOfOutputs)
+	for i := range values {
+		values[i] = satoshis / nrOfOutputs
+		if uint64(i) < satoshis%nrOfOutputs {
+			values[i]++
+		}
+	}
+	return values
+}
@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOutputSplitOptions_split will test the method split()
+func TestOutputSplitOptions_split(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no options", func(t *testing.T) {
+		var options *OutputSplitOptions
+		values, err := options.split(1000)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1000}, values)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		options := &OutputSplitOptions{Strategy: OutputSplitStrategyNone}
+		values, err := options.split(1000)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1000}, values)
+	})
+
+	t.Run("denominations", func(t *testing.T) {
+		options := &OutputSplitOptions{
+			Strategy:      OutputSplitStrategyDenominations,
+			Denominations: []uint64{100, 1000, 10},
+		}
+		values, err := options.split(2125)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1000, 1000, 100, 10, 10, 5}, values)
+	})
+
+	t.Run("denominations - max outputs", func(t *testing.T) {
+		options := &OutputSplitOptions{
+			Strategy:      OutputSplitStrategyDenominations,
+			Denominations: []uint64{100},
+			MaxOutputs:    3,
+		}
+		values, err := options.split(1000)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{100, 100, 800}, values)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		options := &OutputSplitOptions{
+			Strategy:   OutputSplitStrategyRandom,
+			MaxOutputs: 4,
+		}
+		values, err := options.split(1000)
+		require.NoError(t, err)
+		require.Len(t, values, 4)
+
+		total := uint64(0)
+		for _, value := range values {
+			assert.Positive(t, value)
+			total += value
+		}
+		assert.Equal(t, uint64(1000), total)
+	})
+
+	t.Run("random - small amount", func(t *testing.T) {
+		options := &OutputSplitOptions{
+			Strategy:   OutputSplitStrategyRandom,
+			MaxOutputs: 4,
+		}
+		values, err := options.split(3)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{3}, values)
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		options := &OutputSplitOptions{
+			Strategy:          OutputSplitStrategyMaxSize,
+			MaxOutputSatoshis: 300,
+		}
+		values, err := options.split(1000)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{250, 250, 250, 250}, values)
+	})
+
+	t.Run("max size - max outputs", func(t *testing.T) {
+		options := &OutputSplitOptions{
+			Strategy:          OutputSplitStrategyMaxSize,
+			MaxOutputSatoshis: 100,
+			MaxOutputs:        3,
+		}
+		values, err := options.split(1000)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{334, 333, 333}, values)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		options := &OutputSplitOptions{Strategy: OutputSplitStrategyMaxSize}
+		_, err := options.split(1000)
+		require.ErrorIs(t, err, spverrors.ErrInvalidOutputSplit)
+
+		options = &OutputSplitOptions{Strategy: OutputSplitStrategyDenominations}
+		_, err = options.split(1000)
+		require.ErrorIs(t, err, spverrors.ErrInvalidOutputSplit)
+
+		options = &OutputSplitOptions{Strategy: "unknown"}
+		_, err = options.split(1000)
+		require.ErrorIs(t, err, spverrors.ErrInvalidOutputSplit)
+	})
+}
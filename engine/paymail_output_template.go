@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/script/template"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+)
+
+// PaymailOutputTemplate is the template of the PIKE outputs of the paymail (one of the templates registered in script/template)
+//
+// The default template is the single P2PKH output of the full amount
+type PaymailOutputTemplate template.Config
+
+// Scan will scan the value into Struct, implements sql.Scanner interface
+func (p *PaymailOutputTemplate) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	byteValue, err := utils.ToByteArray(value)
+	if err != nil || bytes.Equal(byteValue, []byte("")) || bytes.Equal(byteValue, []byte("\"\"")) {
+		return nil
+	}
+
+	err = json.Unmarshal(byteValue, &p)
+	return spverrors.Wrapf(err, "failed to parse PaymailOutputTemplate from JSON")
+}
+
+// Value return json value, implement driver.Valuer interface
+func (p *PaymailOutputTemplate) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	marshal, err := json.Marshal(p)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to convert PaymailOutputTemplate to JSON")
+	}
+
+	return string(marshal), nil
+}
+
+// Validate will check the output template
+func (p *PaymailOutputTemplate) Validate() error {
+	if err := template.Validate(p.config()); err != nil {
+		return spverrors.Wrapf(spverrors.ErrInvalidPikeOutputTemplate, "%s", err.Error())
+	}
+	return nil
+}
+
+// config will return the configuration of the registered template
+func (p *PaymailOutputTemplate) config() *template.Config {
+	return (*template.Config)(p)
+}
+
+// isDefault will return true if the template is the default single P2PKH output
+func (p *PaymailOutputTemplate) isDefault() bool {
+	return p == nil || ((p.Name == "" || p.Name == template.P2PKHTemplate) &&
+		len(p.Denominations) == 0 && p.Memo == "")
+}
+
+// setOutputTemplate will set the output template of the paymail address (the default template is removed)
+func (m *PaymailAddress) setOutputTemplate(outputTemplate *PaymailOutputTemplate) {
+	if outputTemplate.isDefault() {
+		m.OutputTemplate = nil
+		return
+	}
+	m.OutputTemplate = outputTemplate
+}
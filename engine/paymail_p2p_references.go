@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// p2pReferenceExpiry will return the time for which the P2P payment destinations wait for the transaction
+func (p *PaymailServerOptions) p2pReferenceExpiry() time.Duration {
+	if p != nil && p.P2PReferenceExpiry > 0 {
+		return p.P2PReferenceExpiry
+	}
+	return defaultP2PReferenceExpiry
+}
+
+// acceptExpiredP2P will return true if the transactions of the expired P2P references are accepted (and flagged)
+func (p *PaymailServerOptions) acceptExpiredP2P() bool {
+	return p != nil && p.AcceptExpiredP2P
+}
+
+// p2pCleanupDelay will return the time for which the unused destinations of the expired P2P references are kept,
+// the late transactions are accepted only while their destinations exist
+func (p *PaymailServerOptions) p2pCleanupDelay() time.Duration {
+	if !p.acceptExpiredP2P() {
+		return 0
+	}
+	if p.P2PExpiredRetention > 0 {
+		return p.P2PExpiredRetention
+	}
+	return defaultP2PCleanupDelay
+}
+
+// saveP2PReference will track the reference of the created P2P payment destinations (with the expiration)
+func (p *PaymailDefaultServiceProvider) saveP2PReference(ctx context.Context, referenceID string, pm *PaymailAddress,
+	satoshis uint64, destinationIDs []string,
+) error {
+	reference := newP2PReference(
+		referenceID, pm, satoshis, destinationIDs,
+		p.client.GetPaymailConfig().p2pReferenceExpiry(),
+		append(p.client.DefaultModelOptions(), New())...,
+	)
+	return reference.Save(ctx)
+}
+
+// checkP2PReference will check the reference of the received P2P transaction,
+// the transaction of an expired reference is rejected or flagged in the metadata (depends on the configuration)
+func (p *PaymailDefaultServiceProvider) checkP2PReference(ctx context.Context, referenceID string,
+	metadata Metadata,
+) (*P2PReference, error) {
+	if referenceID == "" {
+		return nil, nil
+	}
+
+	// References created before the tracking was introduced are not found
+	reference, err := getP2PReference(ctx, referenceID, p.client.DefaultModelOptions()...)
+	if err != nil || reference == nil {
+		return nil, err
+	}
+
+	if reference.isExpired() {
+		if !p.client.GetPaymailConfig().acceptExpiredP2P() {
+			return nil, spverrors.ErrP2PReferenceExpired
+		}
+		metadata[p2pReferenceExpiredField] = true
+	}
+	return reference, nil
+}
+
+// markP2PReferenceReceived will mark the reference as received (the destinations are not removed anymore)
+func (p *PaymailDefaultServiceProvider) markP2PReferenceReceived(ctx context.Context, reference *P2PReference, txID string) {
+	if reference == nil {
+		return
+	}
+
+	reference.Status = P2PReferenceReceived
+	reference.TxID = txID
+	if err := reference.Save(ctx); err != nil {
+		p.client.Logger().Warn().
+			Str("p2pReferenceID", reference.ID).
+			Msgf("failed to mark the P2P reference as received: %s", err.Error())
+	}
+}
+
+// processExpiredP2PReferences will expire the pending P2P references (expired for longer than the delay)
+// and soft-delete their unused destinations, returns the number of the expired references and the deleted destinations
+func processExpiredP2PReferences(ctx context.Context, maxReferences int, delay time.Duration, opts ...ModelOps) (int, int, error) {
+	queryParams := &datastore.QueryParams{
+		Page:          1,
+		PageSize:      maxReferences,
+		OrderByField:  expiresAtField,
+		SortDirection: datastore.SortAsc,
+	}
+
+	references, err := getExpiredP2PReferences(ctx, time.Now().UTC().Add(-delay), queryParams, opts...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var expired, deleted int
+	for _, reference := range references {
+		var count int
+		if count, err = reference.expire(ctx); err != nil {
+			reference.Client().Logger().Error().
+				Str("p2pReferenceID", reference.ID).
+				Msgf("error expiring P2P reference: %s", err.Error())
+			continue
+		}
+		expired++
+		deleted += count
+	}
+
+	return expired, deleted, nil
+}
+
+// expire will soft-delete the unused destinations of the reference and mark it as expired
+func (m *P2PReference) expire(ctx context.Context) (int, error) {
+	var deleted int
+	for _, destinationID := range m.DestinationIDs {
+		destination, err := getDestinationByID(ctx, destinationID, m.GetOptions(false)...)
+		if err != nil {
+			return deleted, err
+		} else if destination == nil || destination.DeletedAt.Valid {
+			continue
+		}
+
+		// Skip the destinations which received an output (e.g. transaction not sent through P2P)
+		var utxos int64
+		if utxos, err = getUtxosCount(
+			ctx, nil, map[string]interface{}{scriptPubKeyField: destination.LockingScript}, m.GetOptions(false)...,
+		); err != nil {
+			return deleted, err
+		} else if utxos > 0 {
+			continue
+		}
+
+		destination.DeletedAt.Valid = true
+		destination.DeletedAt.Time = time.Now().UTC()
+		if err = destination.Save(ctx); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	m.Status = P2PReferenceExpired
+	return deleted, m.Save(ctx)
+}
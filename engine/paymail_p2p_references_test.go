@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaymailDefaultServiceProvider_checkP2PReference will test the method checkP2PReference()
+func TestPaymailDefaultServiceProvider_checkP2PReference(t *testing.T) {
+	t.Run("reference is tracked", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, pm.Save(ctx))
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		res, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+		require.NoError(t, err)
+
+		reference, err := getP2PReference(ctx, res.Reference, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.NotNil(t, reference)
+		assert.Equal(t, P2PReferencePending, reference.Status)
+		assert.Equal(t, pm.XpubID, reference.XpubID)
+		assert.Len(t, reference.DestinationIDs, 1)
+		assert.WithinDuration(t, time.Now().UTC().Add(defaultP2PReferenceExpiry), reference.ExpiresAt, time.Minute)
+
+		metadata := Metadata{}
+		checked, err := sut.checkP2PReference(ctx, res.Reference, metadata)
+		require.NoError(t, err)
+		require.NotNil(t, checked)
+		assert.NotContains(t, metadata, p2pReferenceExpiredField)
+	})
+
+	t.Run("unknown reference", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		reference, err := sut.checkP2PReference(ctx, "unknown-reference", Metadata{})
+		require.NoError(t, err)
+		assert.Nil(t, reference)
+	})
+
+	t.Run("expired reference is rejected", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+			WithPaymailP2PDestinationExpiry(time.Millisecond, false))
+		defer deferMe()
+
+		pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, pm.Save(ctx))
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		res, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = sut.checkP2PReference(ctx, res.Reference, Metadata{})
+		require.ErrorIs(t, err, spverrors.ErrP2PReferenceExpired)
+	})
+
+	t.Run("expired reference is accepted and flagged", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+			WithPaymailP2PDestinationExpiry(time.Millisecond, true))
+		defer deferMe()
+
+		pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, pm.Save(ctx))
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		res, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		metadata := Metadata{}
+		reference, err := sut.checkP2PReference(ctx, res.Reference, metadata)
+		require.NoError(t, err)
+		require.NotNil(t, reference)
+		assert.Equal(t, true, metadata[p2pReferenceExpiredField])
+	})
+}
+
+// TestProcessExpiredP2PReferences will test the method processExpiredP2PReferences()
+func TestProcessExpiredP2PReferences(t *testing.T) {
+	t.Run("unused destinations are removed", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+			WithPaymailP2PDestinationExpiry(time.Millisecond, false),
+			WithPaymailOutputSplit(&OutputSplitOptions{
+				Strategy:          OutputSplitStrategyMaxSize,
+				MaxOutputSatoshis: 50,
+			}))
+		defer deferMe()
+
+		pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, pm.Save(ctx))
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		res, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+		require.NoError(t, err)
+		require.Len(t, res.Outputs, 2)
+		time.Sleep(10 * time.Millisecond)
+
+		expired, deleted, err := processExpiredP2PReferences(ctx, 10, 0, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		assert.Equal(t, 2, deleted)
+
+		for _, out := range res.Outputs {
+			var dst *Destination
+			dst, err = getDestinationByLockingScript(ctx, out.Script, c.DefaultModelOptions()...)
+			require.NoError(t, err)
+			require.NotNil(t, dst)
+			assert.True(t, dst.DeletedAt.Valid)
+		}
+
+		reference, err := getP2PReference(ctx, res.Reference, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, P2PReferenceExpired, reference.Status)
+
+		// the reference is processed only once
+		expired, deleted, err = processExpiredP2PReferences(ctx, 10, 0, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, 0, expired)
+		assert.Equal(t, 0, deleted)
+	})
+
+	t.Run("pending references are not processed", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, pm.Save(ctx))
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		_, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+		require.NoError(t, err)
+
+		expired, deleted, err := processExpiredP2PReferences(ctx, 10, 0, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, 0, expired)
+		assert.Equal(t, 0, deleted)
+	})
+
+	t.Run("cleanup is delayed when the late transactions are accepted", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+			WithPaymailP2PDestinationExpiry(time.Millisecond, true),
+			WithPaymailP2PExpiredRetention(time.Hour))
+		defer deferMe()
+
+		pm := newPaymail("paymail@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, pm.Save(ctx))
+
+		sut := &PaymailDefaultServiceProvider{client: c}
+		res, err := sut.CreateP2PDestinationResponse(ctx, pm.Alias, pm.Domain, uint64(100), nil)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		delay := c.GetPaymailConfig().p2pCleanupDelay()
+		require.Equal(t, time.Hour, delay)
+
+		// the destinations are kept for the late transaction
+		expired, deleted, err := processExpiredP2PReferences(ctx, 10, delay, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, 0, expired)
+		assert.Equal(t, 0, deleted)
+
+		dst, err := getDestinationByLockingScript(ctx, res.Outputs[0].Script, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.NotNil(t, dst)
+		assert.False(t, dst.DeletedAt.Valid)
+
+		// the destinations are removed after the retention
+		expired, deleted, err = processExpiredP2PReferences(ctx, 10, 0, c.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		assert.Equal(t, 1, deleted)
+	})
+
+	t.Run("cleanup is not delayed when the late transactions are rejected", func(t *testing.T) {
+		_, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+			WithPaymailP2PDestinationExpiry(time.Millisecond, false),
+			WithPaymailP2PExpiredRetention(time.Hour))
+		defer deferMe()
+
+		assert.Equal(t, time.Duration(0), c.GetPaymailConfig().p2pCleanupDelay())
+	})
+}
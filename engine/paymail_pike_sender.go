@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/pike"
+	"github.com/bitcoin-sv/spv-wallet/engine/script/template"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// pikeSender is the sender of the PIKE payments to the confirmed contacts of its xPub
+type pikeSender struct {
+	client  ClientInterface // (pointer) to the Client for accessing the contacts
+	xPubID  string          // xPub of the sender (owner of the contacts)
+	paymail string          // Paymail of the sender, the receiver derives the outputs with its PKI key
+	pubKey  string          // PKI key of the sender's paymail
+}
+
+// newPikeSender will create the sender of the PIKE payments, nil if the PIKE payments are disabled
+func newPikeSender(c ClientInterface, pm *PaymailAddress) *pikeSender {
+	if pm == nil || !c.GetPaymailConfig().PikePayments {
+		return nil
+	}
+	if err := checkPikeEnabled(c, pm.Domain); err != nil {
+		return nil
+	}
+
+	pubKey, err := pm.GetPubKey()
+	if err != nil {
+		return nil
+	}
+
+	return &pikeSender{
+		client:  c,
+		xPubID:  pm.XpubID,
+		paymail: fmt.Sprintf("%s@%s", pm.Alias, pm.Domain),
+		pubKey:  pubKey,
+	}
+}
+
+// confirmedContact will get the contact of the receiver, nil if the receiver is not a confirmed contact
+func (s *pikeSender) confirmedContact(ctx context.Context, paymailAddress string) (*Contact, error) {
+	contact, err := getContact(ctx, paymailAddress, s.xPubID, s.client.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	} else if contact == nil || contact.Status != ContactConfirmed {
+		return nil, nil
+	}
+	return contact, nil
+}
+
+// lockingScripts will derive the locking scripts of the PIKE outputs (the same way the receiver derives its destinations)
+func (s *pikeSender) lockingScripts(response *paymail.PikePaymentOutputsResponse, receiverPubKeyHex string,
+	satoshis uint64,
+) ([]string, error) {
+	if response == nil || len(response.Outputs) == 0 || response.Reference == "" {
+		return nil, spverrors.ErrPikeOutputsInvalid
+	}
+
+	var total uint64
+	templates := make([]*template.OutputTemplate, 0, len(response.Outputs))
+	for _, output := range response.Outputs {
+		if output == nil || output.Satoshis == 0 {
+			return nil, spverrors.ErrPikeOutputsInvalid
+		}
+		total += output.Satoshis
+		templates = append(templates, &template.OutputTemplate{Script: output.Script, Satoshis: output.Satoshis})
+	}
+	if total != satoshis {
+		return nil, spverrors.Wrapf(spverrors.ErrPikeOutputsInvalid, "outputs of %d satoshis for the payment of %d satoshis", total, satoshis)
+	}
+
+	receiverPubKey, senderPubKey, err := getPublicKeys(receiverPubKeyHex, s.pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, err := pike.GenerateLockingScriptsFromTemplates(templates, senderPubKey, receiverPubKey, response.Reference)
+	if err != nil {
+		return nil, spverrors.Wrapf(spverrors.ErrPikeOutputsInvalid, "failed to generate locking scripts: %s", err.Error())
+	}
+	return scripts, nil
+}
@@ -0,0 +1,269 @@
+package engine
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/script/template"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPikeSender_lockingScripts will test the method lockingScripts()
+func TestPikeSender_lockingScripts(t *testing.T) {
+	t.Run("scripts match the destinations of the receiver", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		receiver := newPaymail("receiver@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, receiver.Save(ctx))
+		receiverPubKey, err := receiver.GetPubKey()
+		require.NoError(t, err)
+
+		sut := &pikeSender{client: c, paymail: "sender@" + testDomain, pubKey: testPkiPubKey}
+
+		provider := &PikePaymentServiceProvider{client: c}
+		response, err := provider.CreatePikeOutputResponse(ctx, receiver.Alias, receiver.Domain, sut.pubKey, 100, nil)
+		require.NoError(t, err)
+
+		scripts, err := sut.lockingScripts(response, receiverPubKey, 100)
+		require.NoError(t, err)
+		require.Len(t, scripts, len(response.Outputs))
+
+		for _, script := range scripts {
+			var dst *Destination
+			dst, err = getDestinationByLockingScript(ctx, script, c.DefaultModelOptions()...)
+			require.NoError(t, err)
+			require.NotNil(t, dst)
+			assert.Equal(t, receiver.XpubID, dst.XpubID)
+			assert.Equal(t, PIKEDerivationMethod, dst.DerivationMethod)
+		}
+	})
+
+	t.Run("scripts match the destinations of the configured templates", func(t *testing.T) {
+		ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		receiver := newPaymail("receiver@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		require.NoError(t, receiver.Save(ctx))
+		receiverPubKey, err := receiver.GetPubKey()
+		require.NoError(t, err)
+
+		sut := &pikeSender{client: c, paymail: "sender@" + testDomain, pubKey: testPkiPubKey}
+		provider := &PikePaymentServiceProvider{client: c}
+
+		outputTemplates := []*PaymailOutputTemplate{
+			{Name: template.P2PKHTemplate},
+			{Name: template.SplitP2PKHTemplate, Denominations: []uint64{1000, 100}},
+			{Name: template.MemoP2PKHTemplate, Memo: "thank you"},
+		}
+		for _, outputTemplate := range outputTemplates {
+			_, err = c.UpdatePaymailOutputTemplate(ctx, receiver.XpubID, receiver.String(), outputTemplate)
+			require.NoError(t, err)
+
+			response, err := provider.CreatePikeOutputResponse(ctx, receiver.Alias, receiver.Domain, sut.pubKey, 2150, nil)
+			require.NoError(t, err)
+
+			scripts, err := sut.lockingScripts(response, receiverPubKey, 2150)
+			require.NoError(t, err)
+			require.Len(t, scripts, len(response.Outputs))
+
+			for _, script := range scripts {
+				dst, err := getDestinationByLockingScript(ctx, script, c.DefaultModelOptions()...)
+				require.NoError(t, err)
+				require.NotNil(t, dst, outputTemplate.Name)
+				assert.Equal(t, receiver.XpubID, dst.XpubID)
+				// the outputs are spent as P2PKH (the memo follows OP_RETURN)
+				assert.Equal(t, utils.ScriptTypePubKeyHash, dst.Type)
+			}
+		}
+
+		// the outputs the wallet cannot sign are not supported
+		_, err = c.UpdatePaymailOutputTemplate(ctx, receiver.XpubID, receiver.String(), &PaymailOutputTemplate{Name: "custom"})
+		require.ErrorIs(t, err, spverrors.ErrInvalidPikeOutputTemplate)
+	})
+
+	t.Run("invalid outputs", func(t *testing.T) {
+		sut := &pikeSender{paymail: "sender@" + testDomain, pubKey: testPkiPubKey}
+		output := &paymail.OutputTemplate{Script: "76a914000000000000000000000000000000000000000088ac", Satoshis: 100}
+
+		_, err := sut.lockingScripts(nil, testPkiPubKey, 100)
+		require.ErrorIs(t, err, spverrors.ErrPikeOutputsInvalid)
+
+		_, err = sut.lockingScripts(&paymail.PikePaymentOutputsResponse{
+			Outputs: []*paymail.OutputTemplate{output},
+		}, testPkiPubKey, 100)
+		require.ErrorIs(t, err, spverrors.ErrPikeOutputsInvalid)
+
+		_, err = sut.lockingScripts(&paymail.PikePaymentOutputsResponse{
+			Outputs:   []*paymail.OutputTemplate{output},
+			Reference: "reference",
+		}, testPkiPubKey, 200)
+		require.ErrorIs(t, err, spverrors.ErrPikeOutputsInvalid)
+	})
+}
+
+// TestNewPikeSender will test the method newPikeSender()
+func TestNewPikeSender(t *testing.T) {
+	t.Run("pike payments disabled", func(t *testing.T) {
+		_, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		pm := newPaymail("sender@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		assert.Nil(t, newPikeSender(c, pm))
+	})
+
+	t.Run("pike payments enabled", func(t *testing.T) {
+		_, c, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(),
+			WithPaymailSupport([]string{"domain.sc"}, defaultSenderPaymail, true, false),
+			WithPaymailPikePaymentSupport())
+		defer deferMe()
+
+		pm := newPaymail("sender@domain.sc", 0, WithClient(c), WithXPub(testXPub))
+		sut := newPikeSender(c, pm)
+		require.NotNil(t, sut)
+		assert.Equal(t, "sender@domain.sc", sut.paymail)
+		assert.Equal(t, pm.XpubID, sut.xPubID)
+		assert.NotEmpty(t, sut.pubKey)
+	})
+}
+
+// pikePaymailClient is the paymail client of the receiver serving the PIKE outputs
+type pikePaymailClient struct {
+	paymail.ClientInterface
+	pubKey  string
+	outputs *paymail.PikePaymentOutputsResponse
+}
+
+func (c *pikePaymailClient) GetSRVRecord(_, _, _ string) (*net.SRV, error) {
+	return nil, errors.New("zero SRV records found")
+}
+
+func (c *pikePaymailClient) GetCapabilities(target string, _ int) (*paymail.CapabilitiesResponse, error) {
+	serverURL := "https://" + target + "/api/v1/" + paymail.DefaultServiceName
+	outputsURL := serverURL + "/pike/outputs/{alias}@{domain.tld}"
+	return &paymail.CapabilitiesResponse{CapabilitiesPayload: paymail.CapabilitiesPayload{
+		BsvAlias: paymail.DefaultBsvAliasVersion,
+		Capabilities: map[string]interface{}{
+			paymail.BRFCPki:                   serverURL + "/id/{alias}@{domain.tld}",
+			paymail.BRFCP2PPaymentDestination: serverURL + "/p2p-payment-destination/{alias}@{domain.tld}",
+			paymail.BRFCP2PTransactions:       serverURL + "/receive-transaction/{alias}@{domain.tld}",
+			paymail.BRFCPike: map[string]interface{}{
+				paymail.BRFCPikeOutputs: outputsURL,
+			},
+		},
+		Pike: &paymail.PikeCapability{Outputs: &outputsURL},
+	}}, nil
+}
+
+func (c *pikePaymailClient) GetPKI(_, alias, domain string) (*paymail.PKIResponse, error) {
+	return &paymail.PKIResponse{PKIPayload: paymail.PKIPayload{
+		BsvAlias: paymail.DefaultBsvAliasVersion, Handle: alias + "@" + domain, PubKey: c.pubKey,
+	}}, nil
+}
+
+func (c *pikePaymailClient) GetP2PPaymentDestination(_, _, _ string, request *paymail.PaymentRequest,
+) (*paymail.PaymentDestinationResponse, error) {
+	return &paymail.PaymentDestinationResponse{PaymentDestinationPayload: paymail.PaymentDestinationPayload{
+		Outputs:   []*paymail.PaymentOutput{{Script: "76a914000000000000000000000000000000000000000088ac", Satoshis: request.Satoshis}},
+		Reference: "p2p-reference",
+	}}, nil
+}
+
+func (c *pikePaymailClient) GetOutputsTemplate(_, _, _ string, _ *paymail.PikePaymentOutputsPayload,
+) (*paymail.PikePaymentOutputsResponse, error) {
+	return c.outputs, nil
+}
+
+// TestTransactionOutput_processPaymailViaPIKE will test the draft paying the confirmed contact via PIKE
+func TestTransactionOutput_processPaymailViaPIKE(t *testing.T) {
+	receiverPaymail := "receiver@" + testDomain
+	pc := &pikePaymailClient{
+		pubKey: testPkiPubKey,
+		outputs: &paymail.PikePaymentOutputsResponse{
+			Outputs: []*paymail.OutputTemplate{
+				{Script: "76a9fd88ac", Satoshis: 500},         // P2PKH
+				{Script: "feac", Satoshis: 400},               // P2PK
+				{Script: "76a9fd88ac6a026869", Satoshis: 100}, // P2PKH with memo
+			},
+			Reference: "pike-reference",
+		},
+	}
+
+	ctx, c, deferMe := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithFreeCache(),
+		WithPaymailSupport([]string{testDomain}, defaultSenderPaymail, false, false),
+		WithPaymailPikePaymentSupport(), WithPaymailClient(pc))
+	defer deferMe()
+
+	xPub := newXpub(testXPub, append(c.DefaultModelOptions(), New())...)
+	require.NoError(t, xPub.Save(ctx))
+	sender := newPaymail("sender@"+testDomain, 0, WithClient(c), WithXPub(testXPub))
+	require.NoError(t, sender.Save(ctx))
+
+	destination, err := xPub.getNewDestination(ctx, utils.ChainExternal, utils.ScriptTypePubKeyHash, c.DefaultModelOptions(New())...)
+	require.NoError(t, err)
+	require.NoError(t, destination.Save(ctx))
+	// each draft reserves its own utxo
+	addUtxo := func(t *testing.T, index uint32) {
+		utxo := newUtxo(xPub.ID, testTxID, destination.LockingScript, index, 100000, c.DefaultModelOptions(New())...)
+		require.NoError(t, utxo.Save(ctx))
+	}
+
+	contact := newContact("Receiver", receiverPaymail, testPkiPubKey, xPub.ID, ContactConfirmed, c.DefaultModelOptions(New())...)
+	require.NoError(t, contact.Save(ctx))
+
+	t.Run("outputs of the templates", func(t *testing.T) {
+		addUtxo(t, 0)
+		draft, err := c.NewTransaction(ctx, testXPub, &TransactionConfig{
+			Outputs: []*TransactionOutput{{To: receiverPaymail, Satoshis: 1000}},
+		}, c.DefaultModelOptions()...)
+
+		require.NoError(t, err)
+		output := draft.Configuration.Outputs[0]
+		require.NotNil(t, output.PaymailP4)
+		assert.Equal(t, ResolutionTypePIKE, output.PaymailP4.ResolutionType)
+		assert.Equal(t, "pike-reference", output.PaymailP4.ReferenceID)
+
+		require.Len(t, output.Scripts, 3)
+		assert.Equal(t, utils.ScriptTypePubKeyHash, output.Scripts[0].ScriptType)
+		assert.Equal(t, utils.ScriptTypePubKey, output.Scripts[1].ScriptType)
+		assert.Equal(t, uint64(400), output.Scripts[1].Satoshis)
+		assert.Equal(t, utils.ScriptTypePubKeyHash, output.Scripts[2].ScriptType)
+		assert.True(t, strings.HasSuffix(output.Scripts[2].Script, "6a026869"))
+		assert.NotEmpty(t, draft.Hex)
+	})
+
+	t.Run("send all is paid via P2P", func(t *testing.T) {
+		addUtxo(t, 1)
+		draft, err := c.NewTransaction(ctx, testXPub, &TransactionConfig{
+			SendAllTo: &TransactionOutput{To: receiverPaymail},
+		}, c.DefaultModelOptions()...)
+
+		require.NoError(t, err)
+		output := draft.Configuration.Outputs[0]
+		require.NotNil(t, output.PaymailP4)
+		assert.Equal(t, ResolutionTypeP2P, output.PaymailP4.ResolutionType)
+		assert.Equal(t, "p2p-reference", output.PaymailP4.ReferenceID)
+		require.Len(t, output.Scripts, 1)
+		assert.Equal(t, draft.Configuration.Fee+output.Scripts[0].Satoshis, uint64(100000))
+	})
+
+	t.Run("simulated draft reports the PIKE payment", func(t *testing.T) {
+		addUtxo(t, 2)
+		draft, err := c.SimulateTransaction(ctx, testXPub, &TransactionConfig{
+			Outputs: []*TransactionOutput{{To: receiverPaymail, Satoshis: 1000}},
+		})
+
+		require.NoError(t, err)
+		output := draft.Configuration.Outputs[0]
+		require.NotNil(t, output.PaymailP4)
+		assert.Equal(t, ResolutionTypePIKE, output.PaymailP4.ResolutionType)
+		assert.Equal(t, testPkiPubKey, output.PaymailP4.PubKey)
+		assert.Equal(t, "sender@"+testDomain, output.PaymailP4.FromPaymail)
+	})
+}
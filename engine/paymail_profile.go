@@ -0,0 +1,50 @@
+package engine
+
+// PaymailProfile is the public profile of the paymail address (public-profile capability) with its privacy settings
+type PaymailProfile struct {
+	PublicName           string // Name served by the public-profile capability
+	Avatar               string // Avatar URL served by the public-profile capability
+	PublicProfileHidden  bool   // Hide the name and avatar from the public-profile capability
+	VerifyPubKeyDisabled bool   // Do not answer the verify-pubkey capability requests
+}
+
+// Profile will return the public profile of the paymail address with its privacy settings
+func (m *PaymailAddress) Profile() *PaymailProfile {
+	return &PaymailProfile{
+		PublicName:           m.PublicName,
+		Avatar:               m.Avatar,
+		PublicProfileHidden:  m.isPublicProfileHidden(),
+		VerifyPubKeyDisabled: m.isVerifyPubKeyDisabled(),
+	}
+}
+
+// setProfile will set the public profile and the privacy settings (stored in metadata)
+func (m *PaymailAddress) setProfile(profile *PaymailProfile) {
+	m.PublicName = profile.PublicName
+	m.Avatar = profile.Avatar
+
+	m.UpdateMetadata(Metadata{
+		publicProfileHiddenField:  metadataFlag(profile.PublicProfileHidden),
+		verifyPubKeyDisabledField: metadataFlag(profile.VerifyPubKeyDisabled),
+	})
+}
+
+// isPublicProfileHidden will return true if the public profile should not be served
+func (m *PaymailAddress) isPublicProfileHidden() bool {
+	hidden, _ := m.Metadata[publicProfileHiddenField].(bool)
+	return hidden
+}
+
+// isVerifyPubKeyDisabled will return true if the verify-pubkey requests should not be answered
+func (m *PaymailAddress) isVerifyPubKeyDisabled() bool {
+	disabled, _ := m.Metadata[verifyPubKeyDisabledField].(bool)
+	return disabled
+}
+
+// metadataFlag will return the metadata value of the flag (unset flags are removed from the metadata)
+func metadataFlag(flag bool) interface{} {
+	if flag {
+		return true
+	}
+	return nil
+}
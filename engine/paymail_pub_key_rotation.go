@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PaymailPreviousPubKey is a rotated PKI public key of the paymail which is still verifiable
+type PaymailPreviousPubKey struct {
+	PubKey     string    `json:"pub_key"`     // Rotated public key (hex, compressed)
+	PubKeyNum  uint32    `json:"pubkey_num"`  // Derivation number of the rotated public key
+	ValidUntil time.Time `json:"valid_until"` // End of the grace period
+}
+
+// PaymailPreviousPubKeys is the list of the rotated PKI public keys of the paymail
+type PaymailPreviousPubKeys []*PaymailPreviousPubKey
+
+// Scan will scan the value into Struct, implements sql.Scanner interface
+func (p *PaymailPreviousPubKeys) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	byteValue, err := utils.ToByteArray(value)
+	if err != nil || bytes.Equal(byteValue, []byte("")) || bytes.Equal(byteValue, []byte("\"\"")) {
+		return nil
+	}
+
+	err = json.Unmarshal(byteValue, &p)
+	return spverrors.Wrapf(err, "failed to parse PaymailPreviousPubKeys from JSON")
+}
+
+// Value return json value, implement driver.Valuer interface
+func (p PaymailPreviousPubKeys) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	marshal, err := json.Marshal(p)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to convert PaymailPreviousPubKeys to JSON")
+	}
+
+	return string(marshal), nil
+}
+
+// pubKeyGracePeriod will return the time for which the rotated public keys stay verifiable
+func (m *PaymailAddress) pubKeyGracePeriod() time.Duration {
+	if c := m.Client(); c != nil {
+		if config := c.GetPaymailConfig(); config != nil && config.PubKeyGracePeriod > 0 {
+			return config.PubKeyGracePeriod
+		}
+	}
+	return defaultPubKeyGracePeriod
+}
+
+// addPreviousPubKey will keep the rotated public key for the grace period (expired keys are removed)
+func (m *PaymailAddress) addPreviousPubKey(pubKey string, gracePeriod time.Duration) {
+	previousPubKeys := m.ValidPreviousPubKeys()
+	m.PreviousPubKeys = append(previousPubKeys, &PaymailPreviousPubKey{
+		PubKey:     pubKey,
+		PubKeyNum:  m.PubKeyNum,
+		ValidUntil: time.Now().UTC().Add(gracePeriod),
+	})
+}
+
+// ValidPreviousPubKeys will return the rotated public keys which are still in the grace period
+func (m *PaymailAddress) ValidPreviousPubKeys() PaymailPreviousPubKeys {
+	now := time.Now()
+	previousPubKeys := make(PaymailPreviousPubKeys, 0, len(m.PreviousPubKeys))
+	for _, previous := range m.PreviousPubKeys {
+		if previous.ValidUntil.After(now) {
+			previousPubKeys = append(previousPubKeys, previous)
+		}
+	}
+	return previousPubKeys
+}
+
+// isPreviousPubKey will return true if the public key is a rotated key still in the grace period
+func (m *PaymailAddress) isPreviousPubKey(pubKey string) bool {
+	for _, previous := range m.ValidPreviousPubKeys() {
+		if previous.PubKey == pubKey {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyPubKeyRequestKey is the key of the request context holding the public key of the verify-pubkey capability request
+type verifyPubKeyRequestKey struct{}
+
+// verifyPubKeyRequest will return the requested public key if the request is the verify-pubkey capability request
+func verifyPubKeyRequest(ctx context.Context) (pubKey string, ok bool) {
+	pubKey, ok = ctx.Value(verifyPubKeyRequestKey{}).(string)
+	return
+}
+
+// markVerifyPubKeyRequest will mark the request matched by the verify-pubkey capability route with the requested public key
+func (p *PaymailServerOptions) markVerifyPubKeyRequest(c *gin.Context) {
+	verifyRoute := fmt.Sprintf(
+		"/%s/%s/verify-pubkey/:%s/:%s", p.APIVersion, p.ServiceName, server.PaymailAddressParamName, server.PubKeyParamName,
+	)
+	if c.FullPath() == verifyRoute {
+		c.Request = c.Request.WithContext(
+			context.WithValue(c.Request.Context(), verifyPubKeyRequestKey{}, c.Param(server.PubKeyParamName)),
+		)
+	}
+	c.Next()
+}
+
+// notifyContactsAboutPubKeyRotation will send the contact request (PIKE) to the confirmed contacts of the rotated paymail,
+// so they can fetch the new public key of the paymail
+//
+// The contacts which know other paymail of the xPub are not notified (it would link the paymails),
+// the contacts without the known paymail are notified only if the rotated paymail is the only paymail of the xPub
+func (c *Client) notifyContactsAboutPubKeyRotation(ctx context.Context, pm *PaymailAddress) {
+	ownerPaymails := []map[string]interface{}{{ownerPaymailField: pm.String()}}
+	count, err := getPaymailAddressesCount(
+		ctx, nil, map[string]interface{}{xPubIDField: pm.XpubID, deletedAtField: nil}, c.DefaultModelOptions()...,
+	)
+	if err != nil {
+		c.Logger().Warn().Str("paymail", pm.String()).Msgf("getting paymails to notify about pub key rotation failed: %s", err.Error())
+		return
+	}
+	if count <= 1 {
+		ownerPaymails = append(ownerPaymails, map[string]interface{}{ownerPaymailField: nil}, map[string]interface{}{ownerPaymailField: ""})
+	}
+
+	contacts, err := getContactsByXpubID(
+		ctx, pm.XpubID, nil, map[string]interface{}{
+			contactStatusField: ContactConfirmed,
+			"$or":              ownerPaymails,
+		}, nil, c.DefaultModelOptions()...,
+	)
+	if err != nil {
+		c.Logger().Warn().Str("paymail", pm.String()).Msgf("getting contacts to notify about pub key rotation failed: %s", err.Error())
+		return
+	}
+
+	pmSrvnt := &PaymailServant{
+		cs: c.Cachestore(),
+		pc: c.PaymailClient(),
+	}
+	contactRequest := paymail.PikeContactRequestPayload{
+		FullName: pm.PublicName,
+		Paymail:  pm.String(),
+	}
+
+	for _, contact := range contacts {
+		contactPm, err := pmSrvnt.GetSanitizedPaymail(contact.Paymail)
+		if err == nil {
+			_, err = pmSrvnt.AddContactRequest(ctx, contactPm, &contactRequest)
+		}
+		if err != nil {
+			c.logContactWarining(pm.XpubID, contact.Paymail, "notifying contact about pub key rotation failed: "+err.Error())
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/gin-gonic/gin"
+	"github.com/jarcoal/httpmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_RotatePaymailPubKey will test the method RotatePaymailPubKey()
+func TestClient_RotatePaymailPubKey(t *testing.T) {
+	t.Run("rotate pub key - confirmed contacts are notified", func(t *testing.T) {
+		// given
+		confirmedPaymail := "sansa_stark@winterfell.com"
+		unconfirmedPaymail := "arya_stark@winterfell.com"
+
+		pt := &paymailTestMock{}
+		pt.setup(t, "winterfell.com", true)
+		defer pt.cleanup()
+
+		pt.mockPike(confirmedPaymail)
+		pt.mockPike(unconfirmedPaymail)
+
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailClient(pt.paymailClient))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		pm, err := client.NewPaymailAddress(ctx, csXpub, "lady_stoneheart@winterfell.com", "Catelyn Stark", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		previousPubKey, err := pm.GetPubKey()
+		require.NoError(t, err)
+
+		confirmed := newContact("Sansa Stark", confirmedPaymail, pubKey, csXpubHash, ContactConfirmed)
+		confirmed.enrich(ModelContact, append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, confirmed.Save(ctx))
+
+		unconfirmed := newContact("Arya Stark", unconfirmedPaymail, pubKey, csXpubHash, ContactNotConfirmed)
+		unconfirmed.enrich(ModelContact, append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, unconfirmed.Save(ctx))
+
+		// when
+		rotated, err := client.RotatePaymailPubKey(ctx, pm.String())
+
+		// then
+		require.NoError(t, err)
+
+		currentPubKey, err := rotated.GetPubKey()
+		require.NoError(t, err)
+		require.NotEqual(t, previousPubKey, currentPubKey)
+		require.True(t, rotated.isPreviousPubKey(previousPubKey))
+
+		calls := httpmock.GetCallCountInfo()
+		require.Equal(t, 1, calls[fmt.Sprintf("%s %s/contact/invite/%s", http.MethodPost, pt.serverURL, confirmedPaymail)])
+		require.Equal(t, 0, calls[fmt.Sprintf("%s %s/contact/invite/%s", http.MethodPost, pt.serverURL, unconfirmedPaymail)])
+	})
+
+	t.Run("rotate pub key - only contacts of the rotated paymail are notified", func(t *testing.T) {
+		// given
+		rotatedContact := "sansa_stark@winterfell.com"
+		otherContact := "arya_stark@winterfell.com"
+		unknownContact := "bran_stark@winterfell.com"
+
+		pt := &paymailTestMock{}
+		pt.setup(t, "winterfell.com", true)
+		defer pt.cleanup()
+
+		pt.mockPike(rotatedContact)
+		pt.mockPike(otherContact)
+		pt.mockPike(unknownContact)
+
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup(), WithPaymailClient(pt.paymailClient))
+		defer cleanup()
+
+		_, err := client.NewXpub(ctx, csXpub, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		pm, err := client.NewPaymailAddress(ctx, csXpub, "lady_stoneheart@winterfell.com", "Catelyn Stark", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		_, err = client.NewPaymailAddress(ctx, csXpub, "catelyn_stark@winterfell.com", "Catelyn Stark", "", client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		for contactPaymail, ownerPaymail := range map[string]string{
+			rotatedContact: pm.String(),
+			otherContact:   "catelyn_stark@winterfell.com",
+			unknownContact: "",
+		} {
+			contact := newContact(contactPaymail, contactPaymail, pubKey, csXpubHash, ContactConfirmed)
+			contact.setOwnerPaymail(ownerPaymail)
+			contact.enrich(ModelContact, append(client.DefaultModelOptions(), New())...)
+			require.NoError(t, contact.Save(ctx))
+		}
+
+		// when
+		_, err = client.RotatePaymailPubKey(ctx, pm.String())
+
+		// then
+		require.NoError(t, err)
+
+		calls := httpmock.GetCallCountInfo()
+		require.Equal(t, 1, calls[fmt.Sprintf("%s %s/contact/invite/%s", http.MethodPost, pt.serverURL, rotatedContact)])
+		require.Equal(t, 0, calls[fmt.Sprintf("%s %s/contact/invite/%s", http.MethodPost, pt.serverURL, otherContact)])
+		require.Equal(t, 0, calls[fmt.Sprintf("%s %s/contact/invite/%s", http.MethodPost, pt.serverURL, unknownContact)])
+	})
+
+	t.Run("rotate pub key - paymail not found", func(t *testing.T) {
+		// given
+		ctx, client, cleanup := CreateTestSQLiteClient(t, false, false, withTaskManagerMockup())
+		defer cleanup()
+
+		// when
+		rotated, err := client.RotatePaymailPubKey(ctx, "lady_stoneheart@winterfell.com")
+
+		// then
+		require.ErrorIs(t, err, spverrors.ErrCouldNotFindPaymail)
+		require.Nil(t, rotated)
+	})
+}
+
+func Test_markVerifyPubKeyRequest(t *testing.T) {
+	send := func(t *testing.T, path string) (pubKey string, ok bool) {
+		logger := zerolog.Nop()
+		options := &PaymailServerOptions{
+			Configuration: &server.Configuration{APIVersion: "v1", ServiceName: paymail.DefaultServiceName, Logger: &logger},
+		}
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.Use(options.markVerifyPubKeyRequest)
+		handler := func(c *gin.Context) {
+			pubKey, ok = verifyPubKeyRequest(c.Request.Context())
+			c.Status(http.StatusOK)
+		}
+		engine.GET("/v1/bsvalias/verify-pubkey/:"+server.PaymailAddressParamName+"/:"+server.PubKeyParamName, handler)
+		engine.GET("/v1/bsvalias/id/:"+server.PaymailAddressParamName, handler)
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		return
+	}
+
+	t.Run("verify-pubkey request is marked with the requested pub key", func(t *testing.T) {
+		pubKey, ok := send(t, "/v1/bsvalias/verify-pubkey/alias@example.com/"+testPkiPubKey)
+
+		require.True(t, ok)
+		require.Equal(t, testPkiPubKey, pubKey)
+	})
+
+	t.Run("other requests are not marked", func(t *testing.T) {
+		_, ok := send(t, "/v1/bsvalias/id/alias@example.com?next=/verify-pubkey/")
+
+		require.False(t, ok)
+	})
+}
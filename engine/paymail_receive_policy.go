@@ -0,0 +1,368 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/libsv/go-bt/v2"
+)
+
+// PaymailReceivePolicy is the policy of the paymail for receiving the P2P payments
+//
+// The senders are paymails (alice@example.com) or domains (example.com) of the senders
+type PaymailReceivePolicy struct {
+	MinSatoshis           uint64   `json:"min_satoshis,omitempty"`            // Min amount of a payment (0 = no limit)
+	MaxSatoshis           uint64   `json:"max_satoshis,omitempty"`            // Max amount of a payment (0 = no limit)
+	DailyDestinationQuota int      `json:"daily_destination_quota,omitempty"` // Max P2P destination requests per day (0 = no limit)
+	AllowedSenders        []string `json:"allowed_senders,omitempty"`         // Only these senders are accepted (if any)
+	DeniedSenders         []string `json:"denied_senders,omitempty"`          // These senders are rejected
+	ContactsOnly          bool     `json:"contacts_only,omitempty"`           // Only the contacts of the paymail are accepted
+}
+
+// Scan will scan the value into Struct, implements sql.Scanner interface
+func (p *PaymailReceivePolicy) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	byteValue, err := utils.ToByteArray(value)
+	if err != nil || bytes.Equal(byteValue, []byte("")) || bytes.Equal(byteValue, []byte("\"\"")) {
+		return nil
+	}
+
+	err = json.Unmarshal(byteValue, &p)
+	return spverrors.Wrapf(err, "failed to parse PaymailReceivePolicy from JSON")
+}
+
+// Value return json value, implement driver.Valuer interface
+func (p *PaymailReceivePolicy) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	marshal, err := json.Marshal(p)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to convert PaymailReceivePolicy to JSON")
+	}
+
+	return string(marshal), nil
+}
+
+// Validate will check the receive policy
+func (p *PaymailReceivePolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.MaxSatoshis > 0 && p.MinSatoshis > p.MaxSatoshis {
+		return spverrors.Wrapf(spverrors.ErrInvalidReceivePolicy, "min satoshis are greater than max satoshis")
+	}
+	if p.DailyDestinationQuota < 0 {
+		return spverrors.Wrapf(spverrors.ErrInvalidReceivePolicy, "daily destination quota cannot be negative")
+	}
+	for _, sender := range append(append([]string{}, p.AllowedSenders...), p.DeniedSenders...) {
+		if !isValidSenderEntry(sender) {
+			return spverrors.Wrapf(spverrors.ErrInvalidReceivePolicy, "sender %s is not a paymail or domain", sender)
+		}
+	}
+	return nil
+}
+
+// isEmpty will return true if the policy doesn't restrict the payments
+func (p *PaymailReceivePolicy) isEmpty() bool {
+	return p == nil || (p.MinSatoshis == 0 && p.MaxSatoshis == 0 && p.DailyDestinationQuota == 0 &&
+		len(p.AllowedSenders) == 0 && len(p.DeniedSenders) == 0 && !p.ContactsOnly)
+}
+
+// checkAmount will check the amount of the payment against the min/max satoshis
+func (p *PaymailReceivePolicy) checkAmount(satoshis uint64) error {
+	if p.MinSatoshis > 0 && satoshis < p.MinSatoshis {
+		return spverrors.ErrReceiveAmountTooLow
+	}
+	if p.MaxSatoshis > 0 && satoshis > p.MaxSatoshis {
+		return spverrors.ErrReceiveAmountTooHigh
+	}
+	return nil
+}
+
+// hasSenderRules will return true if the policy accepts the payments depending on the sender
+func (p *PaymailReceivePolicy) hasSenderRules() bool {
+	return len(p.AllowedSenders) > 0 || len(p.DeniedSenders) > 0 || p.ContactsOnly
+}
+
+// checkSender will check the sender paymail against the allow/deny lists
+func (p *PaymailReceivePolicy) checkSender(sender string) error {
+	if sender != "" && matchesSender(p.DeniedSenders, sender) {
+		return spverrors.ErrReceiveSenderDenied
+	}
+	if len(p.AllowedSenders) > 0 && (sender == "" || !matchesSender(p.AllowedSenders, sender)) {
+		return spverrors.ErrReceiveSenderNotAllowed
+	}
+	return nil
+}
+
+// setReceivePolicy will set the receive policy of the paymail address (empty policy is removed)
+func (m *PaymailAddress) setReceivePolicy(policy *PaymailReceivePolicy) {
+	if policy.isEmpty() {
+		m.ReceivePolicy = nil
+		return
+	}
+	m.ReceivePolicy = policy
+}
+
+// destinationSenderKey is the key of the request context holding the sender paymail declared in the P2P destination request
+type destinationSenderKey struct{}
+
+// destinationSender will return the sender paymail declared in the P2P destination request (empty if not declared)
+func destinationSender(ctx context.Context) string {
+	sender, _ := ctx.Value(destinationSenderKey{}).(string)
+	return sender
+}
+
+// markDestinationSender will mark the P2P destination request with the declared sender paymail (senderPaymail field),
+// the P2P destination requests of the bsvalias specification don't identify the sender
+func (p *PaymailServerOptions) markDestinationSender(c *gin.Context) {
+	destinationRoute := fmt.Sprintf("/%s/%s/p2p-payment-destination/:%s", p.APIVersion, p.ServiceName, server.PaymailAddressParamName)
+	if c.Request.Method != http.MethodPost || c.FullPath() != destinationRoute || c.Request.Body == nil {
+		c.Next()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrCannotBindRequest, p.Logger)
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var request struct {
+		SenderPaymail string `json:"senderPaymail"`
+	}
+	if json.Unmarshal(body, &request) == nil {
+		if sender, ok := sanitizeSender(request.SenderPaymail); ok {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), destinationSenderKey{}, sender))
+		}
+	}
+	c.Next()
+}
+
+// checkP2PDestinationRequest will check the P2P destination request against the receive policy of the paymail
+//
+// The sender declared in the P2P destination request is not authenticated (the received transaction is verified),
+// the policy with the sender rules requires the sender, which is checked against the allow/deny lists and the contacts.
+// The daily quota is counted for all the requests of the paymail (the declared sender can be changed by the requester)
+func (p *PaymailDefaultServiceProvider) checkP2PDestinationRequest(ctx context.Context, pm *PaymailAddress,
+	satoshis uint64, _ *server.RequestMetadata,
+) error {
+	policy := pm.ReceivePolicy
+	if policy.isEmpty() {
+		return nil
+	}
+
+	if satoshis > 0 {
+		if err := policy.checkAmount(satoshis); err != nil {
+			return err
+		}
+	}
+
+	if policy.hasSenderRules() {
+		sender := destinationSender(ctx)
+		if sender == "" {
+			return spverrors.ErrReceiveSenderNotAllowed
+		}
+		if err := policy.checkSender(sender); err != nil {
+			return err
+		}
+		if policy.ContactsOnly {
+			if err := p.checkSenderIsContact(ctx, pm, sender); err != nil {
+				return err
+			}
+		}
+	}
+
+	if policy.DailyDestinationQuota > 0 {
+		return p.useDestinationQuota(ctx, pm, policy.DailyDestinationQuota)
+	}
+	return nil
+}
+
+// useDestinationQuota will count the P2P destination request of the paymail (fails if the quota is exceeded)
+func (p *PaymailDefaultServiceProvider) useDestinationQuota(ctx context.Context, pm *PaymailAddress, quota int) error {
+	cs := p.client.Cachestore()
+	key := fmt.Sprintf(cacheKeyDestinationQuota, pm.ID, time.Now().UTC().Format(time.DateOnly))
+
+	unlock, err := newWaitWriteLock(ctx, "lock-"+key, cs)
+	defer unlock()
+	if err != nil {
+		return err
+	}
+
+	var used int
+	if value, _ := cs.Get(ctx, key); value != "" {
+		used, _ = strconv.Atoi(value)
+	}
+	if used >= quota {
+		return spverrors.ErrReceiveQuotaExceeded
+	}
+
+	err = cs.SetTTL(ctx, key, strconv.Itoa(used+1), 24*time.Hour)
+	return spverrors.Wrapf(err, "failed to count the payment destination")
+}
+
+// checkReceivedP2PTransaction will check the received P2P transaction against the receive policy of the paymail
+func (p *PaymailDefaultServiceProvider) checkReceivedP2PTransaction(ctx context.Context, p2pTx *paymail.P2PTransaction,
+	btTx *bt.Tx, requestMetadata *server.RequestMetadata,
+) error {
+	if requestMetadata == nil || requestMetadata.Alias == "" {
+		return nil
+	}
+
+	pm, err := p.getPaymailForDestination(ctx, requestMetadata.Alias, requestMetadata.Domain)
+	if err != nil {
+		return err
+	}
+	policy := pm.ReceivePolicy
+	if policy.isEmpty() {
+		return nil
+	}
+
+	if policy.hasSenderRules() {
+		var sender string
+		if sender, err = p.verifyP2PSender(ctx, pm, p2pTx.MetaData, btTx.TxID()); err != nil {
+			return err
+		}
+		if err = policy.checkSender(sender); err != nil {
+			return err
+		}
+		if policy.ContactsOnly {
+			if err = p.checkSenderIsContact(ctx, pm, sender); err != nil {
+				return err
+			}
+		}
+	}
+
+	if policy.MinSatoshis > 0 || policy.MaxSatoshis > 0 {
+		var received uint64
+		if received, err = p.receivedSatoshis(ctx, pm, btTx); err != nil {
+			return err
+		}
+		return policy.checkAmount(received)
+	}
+	return nil
+}
+
+// verifyP2PSender will verify the sender of the P2P transaction, the transaction ID must be signed with the public key
+// of the sender paymail (the public key of the sender contact or the PKI of the sender paymail)
+func (p *PaymailDefaultServiceProvider) verifyP2PSender(ctx context.Context, pm *PaymailAddress,
+	metaData *paymail.P2PMetaData, txID string,
+) (string, error) {
+	if metaData == nil || metaData.Signature == "" || metaData.PubKey == "" {
+		return "", spverrors.ErrReceiveSenderNotVerified
+	}
+	sender, ok := sanitizeSender(metaData.Sender)
+	if !ok {
+		return "", spverrors.ErrReceiveSenderNotVerified
+	}
+	if err := verifyPaymailSignature(metaData.PubKey, metaData.Signature, txID); err != nil {
+		return "", spverrors.ErrReceiveSenderNotVerified
+	}
+
+	contact, err := getContact(ctx, sender, pm.XpubID, p.client.DefaultModelOptions()...)
+	if err != nil {
+		return "", err
+	}
+	if contact != nil && contact.PubKey == metaData.PubKey {
+		return sender, nil
+	}
+
+	pmSrvnt := &PaymailServant{cs: p.client.Cachestore(), pc: p.client.PaymailClient()}
+	sPaymail, err := pmSrvnt.GetSanitizedPaymail(sender)
+	if err != nil {
+		return "", spverrors.ErrReceiveSenderNotVerified
+	}
+	pki, err := pmSrvnt.GetPkiForPaymail(ctx, sPaymail)
+	if err != nil {
+		return "", spverrors.Wrapf(spverrors.ErrReceiveSenderNotVerified, "%s", err.Error())
+	}
+	if pki.PubKey != metaData.PubKey {
+		return "", spverrors.ErrReceiveSenderNotVerified
+	}
+	return sender, nil
+}
+
+// checkSenderIsContact will check that the sender is a contact of the paymail owner (not rejected or awaiting acceptance)
+func (p *PaymailDefaultServiceProvider) checkSenderIsContact(ctx context.Context, pm *PaymailAddress, sender string) error {
+	if sender == "" {
+		return spverrors.ErrReceiveSenderNotContact
+	}
+
+	contact, err := getContact(ctx, sender, pm.XpubID, p.client.DefaultModelOptions()...)
+	if err != nil {
+		return err
+	}
+	if contact == nil || (contact.Status != ContactConfirmed && contact.Status != ContactNotConfirmed) {
+		return spverrors.ErrReceiveSenderNotContact
+	}
+	return nil
+}
+
+// receivedSatoshis will sum the outputs of the transaction paying to the destinations of the paymail owner
+func (p *PaymailDefaultServiceProvider) receivedSatoshis(ctx context.Context, pm *PaymailAddress, btTx *bt.Tx) (uint64, error) {
+	var received uint64
+	for _, output := range btTx.Outputs {
+		destination, err := getDestinationByLockingScript(ctx, output.LockingScript.String(), p.client.DefaultModelOptions()...)
+		if err != nil {
+			return 0, err
+		}
+		if destination != nil && destination.XpubID == pm.XpubID {
+			received += output.Satoshis
+		}
+	}
+	return received, nil
+}
+
+// sanitizeSender will standardize the sender paymail (returns false if it's not a paymail)
+func sanitizeSender(sender string) (string, bool) {
+	alias, domain, address := paymail.SanitizePaymail(sender)
+	if alias == "" || domain == "" {
+		return "", false
+	}
+	return address, true
+}
+
+// isValidSenderEntry will return true if the entry of the allow/deny list is a paymail or domain
+func isValidSenderEntry(entry string) bool {
+	if strings.Contains(entry, "@") && !strings.HasPrefix(entry, "@") {
+		return paymail.ValidatePaymail(entry) == nil
+	}
+	return paymail.ValidateDomain(strings.TrimPrefix(entry, "@")) == nil
+}
+
+// matchesSender will return true if the sender paymail (or its domain) is in the list
+func matchesSender(entries []string, sender string) bool {
+	_, senderDomain, _ := paymail.SanitizePaymail(sender)
+	for _, entry := range entries {
+		if strings.Contains(entry, "@") && !strings.HasPrefix(entry, "@") {
+			if address, ok := sanitizeSender(entry); ok && address == sender {
+				return true
+			}
+			continue
+		}
+		if domain, err := paymail.SanitizeDomain(strings.TrimPrefix(entry, "@")); err == nil && domain == senderDomain {
+			return true
+		}
+	}
+	return false
+}
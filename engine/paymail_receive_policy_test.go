@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/go-paymail/server"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaymailReceivePolicy_Validate will test the method Validate()
+func TestPaymailReceivePolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no policy", func(t *testing.T) {
+		var policy *PaymailReceivePolicy
+		require.NoError(t, policy.Validate())
+	})
+
+	t.Run("valid policy", func(t *testing.T) {
+		policy := &PaymailReceivePolicy{
+			MinSatoshis:           100,
+			MaxSatoshis:           1000,
+			DailyDestinationQuota: 10,
+			AllowedSenders:        []string{"friend@domain.com", "trusted.com", "@example.com"},
+			DeniedSenders:         []string{"spam.com"},
+		}
+		require.NoError(t, policy.Validate())
+	})
+
+	t.Run("min greater than max", func(t *testing.T) {
+		policy := &PaymailReceivePolicy{MinSatoshis: 1000, MaxSatoshis: 100}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidReceivePolicy)
+	})
+
+	t.Run("negative quota", func(t *testing.T) {
+		policy := &PaymailReceivePolicy{DailyDestinationQuota: -1}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidReceivePolicy)
+	})
+
+	t.Run("invalid sender", func(t *testing.T) {
+		policy := &PaymailReceivePolicy{DeniedSenders: []string{"not a sender"}}
+		require.ErrorIs(t, policy.Validate(), spverrors.ErrInvalidReceivePolicy)
+	})
+}
+
+// TestPaymailReceivePolicy_checkSender will test the method checkSender()
+func TestPaymailReceivePolicy_checkSender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deny list", func(t *testing.T) {
+		policy := &PaymailReceivePolicy{DeniedSenders: []string{"spam.com", "bad@domain.com"}}
+		require.ErrorIs(t, policy.checkSender("anyone@spam.com"), spverrors.ErrReceiveSenderDenied)
+		require.ErrorIs(t, policy.checkSender("bad@domain.com"), spverrors.ErrReceiveSenderDenied)
+		require.NoError(t, policy.checkSender("good@domain.com"))
+		require.NoError(t, policy.checkSender(""))
+	})
+
+	t.Run("allow list", func(t *testing.T) {
+		policy := &PaymailReceivePolicy{AllowedSenders: []string{"@trusted.com", "friend@domain.com"}}
+		require.NoError(t, policy.checkSender("anyone@trusted.com"))
+		require.NoError(t, policy.checkSender("friend@domain.com"))
+		require.ErrorIs(t, policy.checkSender("stranger@domain.com"), spverrors.ErrReceiveSenderNotAllowed)
+		require.ErrorIs(t, policy.checkSender(""), spverrors.ErrReceiveSenderNotAllowed)
+	})
+}
+
+// TestPaymailAddress_setReceivePolicy will test the method setReceivePolicy()
+func TestPaymailAddress_setReceivePolicy(t *testing.T) {
+	t.Parallel()
+
+	pm := &PaymailAddress{}
+	pm.setReceivePolicy(&PaymailReceivePolicy{ContactsOnly: true})
+	require.NotNil(t, pm.ReceivePolicy)
+	assert.True(t, pm.ReceivePolicy.ContactsOnly)
+
+	pm.setReceivePolicy(&PaymailReceivePolicy{})
+	assert.Nil(t, pm.ReceivePolicy)
+}
+
+func Test_markDestinationSender(t *testing.T) {
+	send := func(t *testing.T, body string) (sender string) {
+		logger := zerolog.Nop()
+		options := &PaymailServerOptions{
+			Configuration: &server.Configuration{APIVersion: "v1", ServiceName: paymail.DefaultServiceName, Logger: &logger},
+		}
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.Use(options.markDestinationSender)
+		engine.POST("/v1/bsvalias/p2p-payment-destination/:"+server.PaymailAddressParamName, func(c *gin.Context) {
+			var request struct {
+				Satoshis uint64 `json:"satoshis"`
+			}
+			require.NoError(t, c.ShouldBindJSON(&request))
+			require.Equal(t, uint64(1000), request.Satoshis)
+
+			sender = destinationSender(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/bsvalias/p2p-payment-destination/alias@example.com", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		return
+	}
+
+	t.Run("declared sender is marked", func(t *testing.T) {
+		assert.Equal(t, "alice@example.com", send(t, `{"satoshis":1000,"senderPaymail":"Alice@Example.com"}`))
+	})
+
+	t.Run("request without the sender is not marked", func(t *testing.T) {
+		assert.Empty(t, send(t, `{"satoshis":1000}`))
+	})
+
+	t.Run("invalid sender is not marked", func(t *testing.T) {
+		assert.Empty(t, send(t, `{"satoshis":1000,"senderPaymail":"not a paymail"}`))
+	})
+}
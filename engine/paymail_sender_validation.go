@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/mrz1836/go-cachestore"
+)
+
+// PaymailKeyProvider gives access to the xPriv of an xPub, which is needed to sign with the PKI key of its paymails
+//
+// SPV Wallet Engine holds only the xPubs, so the basic address resolution can be signed (in both directions)
+// only if the deployment provides the keys (e.g. from a key vault, or a file with NewFileKeyProvider)
+type PaymailKeyProvider interface {
+	GetXPriv(ctx context.Context, xPubID string) (*bip32.ExtendedKey, error)
+}
+
+// senderRequestSigner will sign the sender request of the basic address resolution
+type senderRequestSigner func(request *paymail.SenderRequest) error
+
+// newSenderRequestSigner will create the signer of the sender requests of the paymail, nil if the keys are not available
+func newSenderRequestSigner(ctx context.Context, keyProvider PaymailKeyProvider, pm *PaymailAddress) senderRequestSigner {
+	if keyProvider == nil || pm == nil {
+		return nil
+	}
+
+	return func(request *paymail.SenderRequest) error {
+		signature, err := signWithPaymailKey(ctx, keyProvider, pm, senderRequestMessage(request))
+		if err != nil {
+			return err
+		}
+
+		// Check the outgoing signature before sending it
+		pubKey, err := pm.GetPubKey()
+		if err != nil {
+			return err
+		}
+		if err = verifyPaymailSignature(pubKey, signature, senderRequestMessage(request)); err != nil {
+			return err
+		}
+
+		request.Signature = signature
+		return nil
+	}
+}
+
+// senderRequestMessage is the signed message of the sender request (http://bsvalias.org/04-02-sender-validation.html)
+func senderRequestMessage(request *paymail.SenderRequest) string {
+	return fmt.Sprintf("%s%d%s%s", request.SenderHandle, request.Amount, request.Dt, request.Purpose)
+}
+
+// getPKIPrivateKey will derive the private key of the PKI public key of the paymail (xPriv/0/external_xpub_num/pubkey_num)
+func (m *PaymailAddress) getPKIPrivateKey(xPriv *bip32.ExtendedKey) (*bec.PrivateKey, error) {
+	externalXPriv, err := bitcoin.GetHDKeyByPath(xPriv, utils.ChainExternal, m.ExternalXpubKeyNum)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to derive external xPriv")
+	}
+
+	child, err := externalXPriv.Child(m.PubKeyNum)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to derive PKI key")
+	}
+
+	privateKey, err := bitcoin.GetPrivateKeyFromHDKey(child)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to get PKI private key")
+	}
+
+	// The key has to be the one published by the PKI
+	pubKey, err := m.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(privateKey.PubKey().SerialiseCompressed()) != pubKey {
+		return nil, spverrors.ErrPaymailKeyMismatch
+	}
+
+	return privateKey, nil
+}
+
+// signWithPaymailKey will sign the message (Bitcoin Signed Message) with the PKI key of the paymail
+func signWithPaymailKey(ctx context.Context, keyProvider PaymailKeyProvider, pm *PaymailAddress,
+	message string,
+) (string, error) {
+	if keyProvider == nil {
+		return "", spverrors.ErrPaymailKeyProviderMissing
+	}
+
+	xPriv, err := keyProvider.GetXPriv(ctx, pm.XpubID)
+	if err != nil {
+		return "", spverrors.Wrapf(err, "failed to get xPriv of the paymail")
+	}
+
+	privateKey, err := pm.getPKIPrivateKey(xPriv)
+	if err != nil {
+		return "", err
+	}
+
+	// The PKI key is compressed
+	signature, err := bitcoin.SignMessage(hex.EncodeToString(privateKey.Serialise()), message, true)
+	if err != nil {
+		return "", spverrors.Wrapf(err, "failed to sign message")
+	}
+	return signature, nil
+}
+
+// isPaymailKeyUnavailable will return true if the PKI key of the paymail can't be used for signing
+// (no key provider, or the key provider has no xPriv for the xPub of the paymail)
+func isPaymailKeyUnavailable(err error) bool {
+	return errors.Is(err, spverrors.ErrPaymailKeyProviderMissing) || errors.Is(err, spverrors.ErrPaymailKeyNotFound)
+}
+
+// verifyPaymailSignature will verify the message (Bitcoin Signed Message) was signed with the PKI key
+func verifyPaymailSignature(pubKey, signature, message string) error {
+	publicKey, err := bitcoin.PubKeyFromString(pubKey)
+	if err != nil {
+		return spverrors.ErrInvalidPaymailSignature
+	}
+
+	address, err := bitcoin.GetAddressFromPubKey(publicKey, true)
+	if err != nil {
+		return spverrors.ErrInvalidPaymailSignature
+	}
+
+	if err = bitcoin.VerifyMessage(address.AddressString, signature, message); err != nil {
+		return spverrors.ErrInvalidPaymailSignature
+	}
+	return nil
+}
+
+// verifySenderRequest will verify the signature of the sender request with the PKI key of the sender paymail
+func verifySenderRequest(ctx context.Context, cs cachestore.ClientInterface, pc paymail.ClientInterface,
+	request *paymail.SenderRequest,
+) error {
+	if request == nil || request.Signature == "" {
+		return spverrors.ErrSenderValidationRequired
+	}
+
+	pmSrvnt := &PaymailServant{cs: cs, pc: pc}
+	sPaymail, err := pmSrvnt.GetSanitizedPaymail(request.SenderHandle)
+	if err != nil {
+		return err
+	}
+
+	pki, err := pmSrvnt.GetPkiForPaymail(ctx, sPaymail)
+	if err != nil {
+		return err
+	}
+
+	return verifyPaymailSignature(pki.PubKey, request.Signature, senderRequestMessage(request))
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyProvider is a PaymailKeyProvider with a single xPriv
+type testKeyProvider struct {
+	xPriv *bip32.ExtendedKey
+}
+
+func (p *testKeyProvider) GetXPriv(_ context.Context, _ string) (*bip32.ExtendedKey, error) {
+	return p.xPriv, nil
+}
+
+// missingKeyProvider is a PaymailKeyProvider without any xPriv
+type missingKeyProvider struct{}
+
+func (p *missingKeyProvider) GetXPriv(_ context.Context, _ string) (*bip32.ExtendedKey, error) {
+	return nil, spverrors.ErrPaymailKeyNotFound
+}
+
+func newTestSigningPaymail(t *testing.T) (*PaymailAddress, *testKeyProvider) {
+	xPrivs := generateTestXPrivs(t, 1)
+	xPubs := neuterTestXPrivs(t, xPrivs)
+
+	pm := newPaymail("tester@example.com", 3, WithXPub(xPubs[0]))
+	pm.PubKeyNum = 2
+	return pm, &testKeyProvider{xPriv: xPrivs[0]}
+}
+
+// Test_signWithPaymailKey will test the method signWithPaymailKey()
+func Test_signWithPaymailKey(t *testing.T) {
+	message := "76a914a503165988d079e0bde3e1d0f5d1fd9ecc8c6c9888ac"
+
+	t.Run("valid signature", func(t *testing.T) {
+		pm, keyProvider := newTestSigningPaymail(t)
+
+		signature, err := signWithPaymailKey(context.Background(), keyProvider, pm, message)
+		require.NoError(t, err)
+		require.NotEmpty(t, signature)
+
+		pubKey, err := pm.GetPubKey()
+		require.NoError(t, err)
+		require.NoError(t, verifyPaymailSignature(pubKey, signature, message))
+	})
+
+	t.Run("different message", func(t *testing.T) {
+		pm, keyProvider := newTestSigningPaymail(t)
+
+		signature, err := signWithPaymailKey(context.Background(), keyProvider, pm, message)
+		require.NoError(t, err)
+
+		pubKey, err := pm.GetPubKey()
+		require.NoError(t, err)
+		err = verifyPaymailSignature(pubKey, signature, message+"00")
+		assert.ErrorIs(t, err, spverrors.ErrInvalidPaymailSignature)
+	})
+
+	t.Run("wrong xPriv", func(t *testing.T) {
+		pm, _ := newTestSigningPaymail(t)
+		_, keyProvider := newTestSigningPaymail(t)
+
+		_, err := signWithPaymailKey(context.Background(), keyProvider, pm, message)
+		assert.ErrorIs(t, err, spverrors.ErrPaymailKeyMismatch)
+	})
+
+	t.Run("no key provider", func(t *testing.T) {
+		pm, _ := newTestSigningPaymail(t)
+
+		_, err := signWithPaymailKey(context.Background(), nil, pm, message)
+		assert.ErrorIs(t, err, spverrors.ErrPaymailKeyProviderMissing)
+	})
+}
+
+// Test_newSenderRequestSigner will test the method newSenderRequestSigner()
+func Test_newSenderRequestSigner(t *testing.T) {
+	t.Run("no key provider", func(t *testing.T) {
+		pm, _ := newTestSigningPaymail(t)
+		assert.Nil(t, newSenderRequestSigner(context.Background(), nil, pm))
+	})
+
+	t.Run("signed sender request", func(t *testing.T) {
+		pm, keyProvider := newTestSigningPaymail(t)
+		sign := newSenderRequestSigner(context.Background(), keyProvider, pm)
+		require.NotNil(t, sign)
+
+		request := &paymail.SenderRequest{
+			Amount:       1000,
+			Dt:           "2024-03-01T10:00:00Z",
+			Purpose:      "test payment",
+			SenderHandle: pm.String(),
+		}
+		require.NoError(t, sign(request))
+		require.NotEmpty(t, request.Signature)
+
+		pubKey, err := pm.GetPubKey()
+		require.NoError(t, err)
+		require.NoError(t, verifyPaymailSignature(pubKey, request.Signature, senderRequestMessage(request)))
+
+		request.Amount = 2000
+		err = verifyPaymailSignature(pubKey, request.Signature, senderRequestMessage(request))
+		assert.ErrorIs(t, err, spverrors.ErrInvalidPaymailSignature)
+	})
+}
+
+// resolvingPaymailClient is the paymail client of the receiver answering the basic address resolution
+type resolvingPaymailClient struct {
+	paymail.ClientInterface
+	request *paymail.SenderRequest
+}
+
+func (c *resolvingPaymailClient) ResolveAddress(_, _, _ string, request *paymail.SenderRequest) (*paymail.ResolutionResponse, error) {
+	c.request = request
+	return &paymail.ResolutionResponse{ResolutionPayload: paymail.ResolutionPayload{
+		Address: "1Dpzga3KJjebtH7P6RBa7JAjpUJ3pubNQ5",
+		Output:  "76a9148ca2b6a4da0a1ab39e7da3c3e9e3e1c4b5f7d4a888ac",
+	}}, nil
+}
+
+// TestTransactionOutput_processPaymailViaAddressResolution_keyNotFound will test the sender without its xPriv in the key provider
+func TestTransactionOutput_processPaymailViaAddressResolution_keyNotFound(t *testing.T) {
+	pm, _ := newTestSigningPaymail(t)
+	sign := newSenderRequestSigner(context.Background(), &missingKeyProvider{}, pm)
+	require.NotNil(t, sign)
+
+	resolve := func(senderValidation bool) (*TransactionOutput, *resolvingPaymailClient, error) {
+		client := &resolvingPaymailClient{}
+		capabilities := &paymail.CapabilitiesPayload{Capabilities: map[string]interface{}{
+			paymail.BRFCBasicAddressResolution: "https://example.com/address/{alias}@{domain.tld}",
+			paymail.BRFCSenderValidation:       senderValidation,
+		}}
+		output := &TransactionOutput{
+			To:        "receiver@example.com",
+			Satoshis:  1000,
+			PaymailP4: &PaymailP4{Alias: "receiver", Domain: "example.com"},
+		}
+		return output, client, output.processPaymailViaAddressResolution(client, capabilities, pm.String(), sign)
+	}
+
+	t.Run("unsigned request", func(t *testing.T) {
+		output, client, err := resolve(false)
+
+		require.NoError(t, err)
+		require.NotNil(t, client.request)
+		assert.Empty(t, client.request.Signature)
+		assert.Equal(t, ResolutionTypeBasic, output.PaymailP4.ResolutionType)
+		require.Len(t, output.Scripts, 1)
+	})
+
+	t.Run("receiver requires sender validation", func(t *testing.T) {
+		_, client, err := resolve(true)
+
+		require.ErrorIs(t, err, spverrors.ErrSenderValidationNotPossible)
+		assert.Nil(t, client.request)
+	})
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"errors"
 
 	"github.com/bitcoin-sv/go-paymail"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
@@ -28,9 +29,14 @@ func (s *PaymailServant) GetSanitizedPaymail(addr string) (*paymail.SanitisedPay
 
 // GetPkiForPaymail retrieves the PKI for a paymail address
 func (s *PaymailServant) GetPkiForPaymail(ctx context.Context, sPaymail *paymail.SanitisedPaymail) (*paymail.PKIResponse, error) {
+	policy := paymailClientPolicyOf(s.pc)
+	if pki, err := s.getCachedPki(ctx, sPaymail, policy); pki != nil || err != nil {
+		return pki, err
+	}
+
 	capabilities, err := getCapabilities(ctx, s.cs, s.pc, sPaymail.Domain)
 	if err != nil {
-		return nil, spverrors.ErrGetCapabilities
+		return nil, capabilitiesError(err)
 	}
 
 	if !capabilities.Has(paymail.BRFCPki, paymail.BRFCPkiAlternate) {
@@ -40,17 +46,56 @@ func (s *PaymailServant) GetPkiForPaymail(ctx context.Context, sPaymail *paymail
 	url := capabilities.GetString(paymail.BRFCPki, paymail.BRFCPkiAlternate)
 	pki, err := s.pc.GetPKI(url, sPaymail.Alias, sPaymail.Domain)
 	if err != nil {
+		s.cachePkiError(ctx, sPaymail, err, policy)
 		return nil, err //nolint:wrapcheck // we have handler for paymail errors
 	}
 
+	s.cachePki(ctx, sPaymail, pki, policy)
 	return pki, nil
 }
 
+// GetPublicProfile retrieves the public profile of a paymail address (cached)
+func (s *PaymailServant) GetPublicProfile(ctx context.Context, sPaymail *paymail.SanitisedPaymail) (*paymail.PublicProfilePayload, error) {
+	profile := new(paymail.PublicProfilePayload)
+	if err := s.cs.GetModel(
+		ctx, cacheKeyPublicProfile+sPaymail.Address, profile,
+	); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+		return nil, spverrors.Wrapf(err, "failed to get public profile from cachestore")
+	} else if profile.Name != "" || profile.Avatar != "" {
+		return profile, nil
+	}
+
+	capabilities, err := getCapabilities(ctx, s.cs, s.pc, sPaymail.Domain)
+	if err != nil {
+		return nil, capabilitiesError(err)
+	}
+
+	if !capabilities.Has(paymail.BRFCPublicProfile, "") {
+		return nil, spverrors.ErrCapabilitiesPublicProfileUnsupported
+	}
+
+	url := capabilities.GetString(paymail.BRFCPublicProfile, "")
+	response, err := s.pc.GetPublicProfile(url, sPaymail.Alias, sPaymail.Domain)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // we have handler for paymail errors
+	}
+
+	// Save to cachestore
+	if s.cs != nil && !s.cs.Engine().IsEmpty() {
+		_ = s.cs.SetModel(
+			context.Background(), cacheKeyPublicProfile+sPaymail.Address,
+			&response.PublicProfilePayload, cacheTTLPublicProfile,
+		)
+	}
+
+	return &response.PublicProfilePayload, nil
+}
+
 // AddContactRequest sends a contact invitation via PIKE capability
 func (s *PaymailServant) AddContactRequest(ctx context.Context, receiverPaymail *paymail.SanitisedPaymail, contactData *paymail.PikeContactRequestPayload) (*paymail.PikeContactRequestResponse, error) {
 	capabilities, err := getCapabilities(ctx, s.cs, s.pc, receiverPaymail.Domain)
 	if err != nil {
-		return nil, spverrors.ErrGetCapabilities
+		return nil, capabilitiesError(err)
 	}
 
 	if !capabilities.Has(paymail.BRFCPike, "") {
@@ -65,3 +110,11 @@ func (s *PaymailServant) AddContactRequest(ctx context.Context, receiverPaymail
 
 	return response, nil
 }
+
+// capabilitiesError will return the error of the failed capabilities lookup (the blocked domains are reported as such)
+func capabilitiesError(err error) error {
+	if errors.Is(err, spverrors.ErrPaymailDomainBlocked) {
+		return spverrors.ErrPaymailDomainBlocked
+	}
+	return spverrors.ErrGetCapabilities
+}
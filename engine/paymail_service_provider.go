@@ -49,7 +49,7 @@ func createMetadata(serverMetaData *server.RequestMetadata, request string) (met
 func (p *PaymailDefaultServiceProvider) GetPaymailByAlias(
 	ctx context.Context,
 	alias, domain string,
-	_ *server.RequestMetadata,
+	requestMetadata *server.RequestMetadata,
 ) (*paymail.AddressInformation, error) {
 
 	pm, err := getPaymailAddress(ctx, alias+"@"+domain, p.client.DefaultModelOptions()...)
@@ -60,39 +60,82 @@ func (p *PaymailDefaultServiceProvider) GetPaymailByAlias(
 		return nil, spverrors.ErrCouldNotFindPaymail
 	}
 
+	requested, isVerifyRequest := verifyPubKeyRequest(ctx)
+
+	// Respect the privacy settings of the paymail
+	if pm.isVerifyPubKeyDisabled() && isVerifyRequest {
+		return nil, spverrors.ErrCouldNotFindPaymail
+	}
+
 	pk, err := pm.GetPubKey()
 	if err != nil {
 		return nil, err
 	}
 
-	return &paymail.AddressInformation{
+	// Rotated keys are still verifiable during the grace period (in-flight payments)
+	if isVerifyRequest && requested != pk && pm.isPreviousPubKey(requested) {
+		pk = requested
+	}
+
+	info := &paymail.AddressInformation{
 		Alias:  pm.Alias,
 		Avatar: pm.Avatar,
 		Domain: pm.Domain,
 		ID:     pm.ID,
 		Name:   pm.PublicName,
 		PubKey: pk,
-	}, nil
+	}
+
+	// Name and avatar are served only by the public-profile capability
+	if pm.isPublicProfileHidden() {
+		info.Name = ""
+		info.Avatar = ""
+	}
+
+	return info, nil
 }
 
 // CreateAddressResolutionResponse will create the address resolution response
 func (p *PaymailDefaultServiceProvider) CreateAddressResolutionResponse(
 	ctx context.Context,
 	alias, domain string,
-	_ bool,
+	senderValidation bool,
 	requestMetadata *server.RequestMetadata,
 ) (*paymail.ResolutionPayload, error) {
 	metadata := createMetadata(requestMetadata, "CreateAddressResolutionResponse")
 
-	dst, err := p.getDestinationForPaymail(ctx, alias, domain, metadata)
+	// The paymail domain can require sender validation (even if it is disabled for the server)
+	if settings := p.client.GetPaymailConfig().domainSettings(domain); settings != nil && settings.SenderValidationEnabled && !senderValidation {
+		var senderRequest *paymail.SenderRequest
+		if requestMetadata != nil {
+			senderRequest = requestMetadata.ResolveAddress
+		}
+		if err := verifySenderRequest(ctx, p.client.Cachestore(), p.client.PaymailClient(), senderRequest); err != nil {
+			return nil, err
+		}
+		senderValidation = true
+	}
+
+	dst, pm, err := p.getDestinationForPaymail(ctx, alias, domain, metadata)
 	if err != nil {
 		return nil, err
 	}
 
+	// Sign the output with the PKI key, so the sender can verify the response
+	var signature string
+	if senderValidation {
+		signature, err = signWithPaymailKey(ctx, p.client.GetPaymailConfig().KeyProvider, pm, dst.LockingScript)
+		if isPaymailKeyUnavailable(err) {
+			p.client.Logger().Warn().Str("paymail", pm.String()).Msgf("address resolution response is not signed: %s", err.Error())
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
 	return &paymail.ResolutionPayload{
 		Address:   dst.Address,
 		Output:    dst.LockingScript,
-		Signature: "", // todo: add the signature if senderValidation is enabled
+		Signature: signature,
 	}, nil
 }
 
@@ -112,19 +155,45 @@ func (p *PaymailDefaultServiceProvider) CreateP2PDestinationResponse(
 	metadata[ReferenceIDField] = referenceID
 	metadata[satoshisField] = satoshis
 
-	// todo: strategy to break apart outputs based on satoshis (return x Outputs)
-	dst, err := p.getDestinationForPaymail(ctx, alias, domain, metadata)
+	pm, err := p.getPaymailForDestination(ctx, alias, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the receive policy of the paymail (amount limits and daily quota)
+	if err = p.checkP2PDestinationRequest(ctx, pm, satoshis, requestMetadata); err != nil {
+		return nil, err
+	}
+
+	// Break apart the satoshis, each output gets its own destination (recorded with the reference ID)
+	values, err := p.client.GetPaymailConfig().OutputSplit.split(satoshis)
 	if err != nil {
 		return nil, err
 	}
 
 	// Append the output(s)
-	var outputs []*paymail.PaymentOutput
-	outputs = append(outputs, &paymail.PaymentOutput{
-		Address:  dst.Address,
-		Satoshis: satoshis,
-		Script:   dst.LockingScript,
-	})
+	outputs := make([]*paymail.PaymentOutput, 0, len(values))
+	destinationIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		var dst *Destination
+		if dst, err = createDestination(
+			ctx, pm, append(p.client.DefaultModelOptions(), WithMetadatas(metadata))...,
+		); err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, &paymail.PaymentOutput{
+			Address:  dst.Address,
+			Satoshis: value,
+			Script:   dst.LockingScript,
+		})
+		destinationIDs = append(destinationIDs, dst.ID)
+	}
+
+	// Track the reference, the destinations are removed if no transaction arrives before the expiration
+	if err = p.saveP2PReference(ctx, referenceID, pm, satoshis, destinationIDs); err != nil {
+		return nil, err
+	}
 
 	return &paymail.PaymentDestinationPayload{
 		Outputs:   outputs,
@@ -142,13 +211,27 @@ func (p *PaymailDefaultServiceProvider) RecordTransaction(ctx context.Context,
 	metadata[p2pMetadataField] = p2pTx.MetaData
 	metadata[ReferenceIDField] = p2pTx.Reference
 
+	if err := p.checkDomainSettings(p2pTx, requestMetadata); err != nil {
+		return nil, err
+	}
+
 	// Record the transaction
 	btTx := buildBtTx(p2pTx)
+	if err := p.checkReceivedP2PTransaction(ctx, p2pTx, btTx, requestMetadata); err != nil {
+		return nil, err
+	}
+
+	// Check the expiration of the payment destinations (late transactions are rejected or flagged)
+	reference, err := p.checkP2PReference(ctx, p2pTx.Reference, metadata)
+	if err != nil {
+		return nil, err
+	}
+
 	rts, err := getIncomingTxRecordStrategy(ctx, p.client, btTx)
 	if err != nil {
 		return nil, err
 	}
-	if err := rts.Validate(); err != nil {
+	if err = rts.Validate(); err != nil {
 		return nil, err //nolint:wrapcheck // returns our internal errors
 	}
 
@@ -163,6 +246,8 @@ func (p *PaymailDefaultServiceProvider) RecordTransaction(ctx context.Context,
 		return nil, err
 	}
 
+	p.markP2PReferenceReceived(ctx, reference, transaction.ID)
+
 	if p2pTx.DecodedBeef != nil {
 		if reflect.TypeOf(rts) == reflect.TypeOf(&externalIncomingTx{}) {
 			go saveBEEFTxInputs(ctx, p.client, p2pTx.DecodedBeef)
@@ -223,11 +308,11 @@ func (p *PaymailDefaultServiceProvider) AddContact(
 		return
 	}
 
-	_, err = p.client.AddContactRequest(ctx, contact.FullName, contact.Paymail, reqPaymail.XpubID)
+	_, err = p.client.AddContactRequest(ctx, contact.FullName, contact.Paymail, reqPaymail.XpubID, reqPaymail.String())
 	return
 }
 
-func (p *PaymailDefaultServiceProvider) getDestinationForPaymail(ctx context.Context, alias, domain string, metadata Metadata) (*Destination, error) {
+func (p *PaymailDefaultServiceProvider) getPaymailForDestination(ctx context.Context, alias, domain string) (*PaymailAddress, error) {
 	pm, err := getPaymailAddress(ctx, alias+"@"+domain, p.client.DefaultModelOptions()...)
 	if err != nil {
 		return nil, err
@@ -235,15 +320,23 @@ func (p *PaymailDefaultServiceProvider) getDestinationForPaymail(ctx context.Con
 	if pm == nil {
 		return nil, spverrors.ErrCouldNotFindPaymail
 	}
+	return pm, nil
+}
+
+func (p *PaymailDefaultServiceProvider) getDestinationForPaymail(ctx context.Context, alias, domain string, metadata Metadata) (*Destination, *PaymailAddress, error) {
+	pm, err := p.getPaymailForDestination(ctx, alias, domain)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	dst, err := createDestination(
 		ctx, pm, append(p.client.DefaultModelOptions(), WithMetadatas(metadata))...,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return dst, nil
+	return dst, pm, nil
 }
 
 func createDestination(ctx context.Context, pm *PaymailAddress, opts ...ModelOps) (*Destination, error) {
@@ -322,7 +415,7 @@ func saveBEEFTxInputs(ctx context.Context, c ClientInterface, dBeef *beef.Decode
 
 		}
 
-		err = saveBeefTransactionInput(ctx, c, input, bump)
+		err = saveBeefTransactionInput(ctx, c, input.Transaction, bump)
 		if err != nil {
 			c.Logger().Error().Msgf("error in saveBEEFTxInputs: %v for beef: %v", err, dBeef)
 		}
@@ -389,9 +482,9 @@ func getBump(bumpIndex int, bumps beef.BUMPs) (*BUMP, error) {
 	}, nil
 }
 
-func saveBeefTransactionInput(ctx context.Context, c ClientInterface, input *beef.TxData, bump *BUMP) error {
+func saveBeefTransactionInput(ctx context.Context, c ClientInterface, input *bt.Tx, bump *BUMP) error {
 	newOpts := c.DefaultModelOptions(New())
-	inputTx, _ := txFromHex(input.Transaction.String(), newOpts...) // we can ignore error here
+	inputTx, _ := txFromHex(input.String(), newOpts...) // we can ignore error here
 
 	sync := newSyncTransaction(
 		inputTx.GetID(),
@@ -412,3 +505,27 @@ func saveBeefTransactionInput(ctx context.Context, c ClientInterface, input *bee
 	err := inputTx.Save(ctx)
 	return spverrors.Wrapf(err, "error in saveBeefTransactionInput during saving tx")
 }
+
+// checkDomainSettings will check the received P2P transaction against the settings of the paymail domain
+//
+// A present signature is verified by the paymail server, a missing one is rejected if the domain requires sender validation
+func (p *PaymailDefaultServiceProvider) checkDomainSettings(p2pTx *paymail.P2PTransaction,
+	requestMetadata *server.RequestMetadata,
+) error {
+	if requestMetadata == nil {
+		return nil
+	}
+
+	settings := p.client.GetPaymailConfig().domainSettings(requestMetadata.Domain)
+	if settings == nil {
+		return nil
+	}
+
+	if settings.SenderValidationEnabled && (p2pTx.MetaData == nil || p2pTx.MetaData.Signature == "") {
+		return spverrors.ErrSenderValidationRequired
+	}
+	if !settings.BeefEnabled && p2pTx.Beef != "" {
+		return spverrors.ErrPaymailDomainBeefDisabled
+	}
+	return nil
+}
@@ -175,6 +175,35 @@ func Test_hasP2P_beefCapabilities(t *testing.T) {
 		assert.Equal(t, capabilities.Capabilities[paymail.BRFCP2PPaymentDestination], p2pDestinationURL)
 		assert.Equal(t, capabilities.Capabilities[paymail.BRFCBeefTransaction], p2pSubmitTxURL)
 	})
+
+	t.Run("beef formats capability", func(t *testing.T) {
+		testCases := []struct {
+			formats  any
+			expected PaymailPayloadFormat
+		}{
+			{formats: []any{BeefV1Format}, expected: BeefPaymailPayloadFormat},
+			{formats: []any{BeefV1Format, BeefV2Format}, expected: BeefV2PaymailPayloadFormat},
+			{formats: []any{BeefV1Format, BeefV2Format, AtomicBeefFormat}, expected: AtomicBeefPaymailPayloadFormat},
+			{formats: "beef_v1,beef_v2", expected: BeefV2PaymailPayloadFormat},
+			{formats: true, expected: BeefPaymailPayloadFormat},
+		}
+		for _, tc := range testCases {
+			capabilities := mockCapabilities(t, true, true)
+			capabilities.Capabilities[BeefFormatsCapability] = tc.formats
+
+			success, _, _, format := hasP2P(capabilities)
+			assert.Equal(t, true, success)
+			assert.Equal(t, tc.expected, format)
+		}
+	})
+
+	t.Run("beef formats capability without beef capabilities", func(t *testing.T) {
+		capabilities := mockCapabilities(t, true, false)
+		capabilities.Capabilities[BeefFormatsCapability] = []any{AtomicBeefFormat}
+
+		_, _, _, format := hasP2P(capabilities)
+		assert.Equal(t, BasicPaymailPayloadFormat, format)
+	})
 }
 
 // Test_startP2PTransaction will test the method startP2PTransaction()
@@ -26,11 +26,17 @@ import (
 
 // GenerateOutputsTemplate creates a Pike output template
 func GenerateOutputsTemplate(satoshis uint64) ([]*template.OutputTemplate, error) {
-	p2pkhTemplate, err := template.P2PKH(satoshis)
+	return GenerateOutputsTemplateFromConfig(satoshis, nil)
+}
+
+// GenerateOutputsTemplateFromConfig creates the Pike output templates using the configured template
+// (registered in the template package), nil config is a single P2PKH template
+func GenerateOutputsTemplateFromConfig(satoshis uint64, config *template.Config) ([]*template.OutputTemplate, error) {
+	outputs, err := template.Outputs(satoshis, config)
 	if err != nil {
-		return nil, spverrors.Wrapf(err, "error creating P2PKH template")
+		return nil, spverrors.Wrapf(err, "error creating output templates")
 	}
-	return []*template.OutputTemplate{p2pkhTemplate}, nil
+	return outputs, nil
 }
 
 // GenerateLockingScriptsFromTemplates converts Pike outputs templates to scripts
@@ -50,8 +50,11 @@ func (p *PikeContactServiceProvider) AddContact(
 		err = spverrors.ErrInvalidRequesterXpub
 		return
 	}
+	if err = checkPikeEnabled(p.client, reqPaymail.Domain); err != nil {
+		return
+	}
 
-	_, err = p.client.AddContactRequest(ctx, contact.FullName, contact.Paymail, reqPaymail.XpubID)
+	_, err = p.client.AddContactRequest(ctx, contact.FullName, contact.Paymail, reqPaymail.XpubID, reqPaymail.String())
 	return
 }
 
@@ -64,12 +67,24 @@ func (p *PikePaymentServiceProvider) CreatePikeOutputResponse(
 	satoshis uint64,
 	requestMetadata *server.RequestMetadata,
 ) (*paymail.PikePaymentOutputsResponse, error) {
+	if err := checkPikeEnabled(p.client, domain); err != nil {
+		return nil, err
+	}
+
 	referenceID, err := generateReferenceID()
 	if err != nil {
 		return nil, err
 	}
 
-	outputs, err := pike.GenerateOutputsTemplate(satoshis)
+	pAddress, err := getPaymailAddress(ctx, alias+"@"+domain, p.client.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	} else if pAddress == nil {
+		return nil, spverrors.ErrCouldNotFindPaymail
+	}
+
+	// The outputs are created by the template configured for the paymail (single P2PKH output by default)
+	outputs, err := pike.GenerateOutputsTemplateFromConfig(satoshis, pAddress.OutputTemplate.config())
 	if err != nil {
 		return nil, spverrors.Wrapf(err, "failed to generate output templates")
 	}
@@ -77,7 +92,7 @@ func (p *PikePaymentServiceProvider) CreatePikeOutputResponse(
 	metadata := createMetadata(requestMetadata, "CreatePikeDestinationResponse")
 	opts := WithMetadatas(metadata)
 
-	if err = p.createPikeDestinations(ctx, outputs, alias, domain, senderPubKey, referenceID, opts); err != nil {
+	if err = p.createPikeDestinations(ctx, outputs, pAddress, senderPubKey, referenceID, opts); err != nil {
 		return nil, err
 	}
 
@@ -87,12 +102,7 @@ func (p *PikePaymentServiceProvider) CreatePikeOutputResponse(
 	}, nil
 }
 
-func (p *PikePaymentServiceProvider) createPikeDestinations(ctx context.Context, outputsTemplate []*template.OutputTemplate, alias, domain, senderPubKeyHex, reference string, opts ...ModelOps) error {
-	pAddress, err := getPaymailAddress(ctx, alias+"@"+domain, p.client.DefaultModelOptions()...)
-	if err != nil {
-		return err
-	}
-
+func (p *PikePaymentServiceProvider) createPikeDestinations(ctx context.Context, outputsTemplate []*template.OutputTemplate, pAddress *PaymailAddress, senderPubKeyHex, reference string, opts ...ModelOps) error {
 	receiverPublicKeyHex, err := pAddress.GetPubKey()
 	if err != nil {
 		return err
@@ -108,21 +118,27 @@ func (p *PikePaymentServiceProvider) createPikeDestinations(ctx context.Context,
 		return spverrors.Wrapf(err, "failed to generate locking scripts")
 	}
 
-	return p.saveDestinations(ctx, pAddress, scripts, senderPubKeyHex, opts...)
+	return p.saveDestinations(ctx, pAddress, scripts, senderPubKeyHex, reference, opts...)
 }
 
 func (p *PikePaymentServiceProvider) saveDestinations(
 	ctx context.Context,
 	pAddress *PaymailAddress,
 	scripts []string,
-	senderPubKeyHex string,
+	senderPubKeyHex, reference string,
 	opts ...ModelOps,
 ) error {
+	// The scripts are linked to the paymail public key: chain/num/(pub_key_num) of the xPub
+	pubKeyNum := pAddress.PubKeyNum
 	for index, script := range scripts {
 		dst := newDestination(pAddress.XpubID, script, append(p.client.DefaultModelOptions(), opts...)...)
 		dst.DerivationMethod = PIKEDerivationMethod
+		dst.Chain = utils.ChainExternal
+		dst.Num = pAddress.ExternalXpubKeyNum
+		dst.PaymailExternalDerivationNum = &pubKeyNum
 		dst.SenderXpub = senderPubKeyHex
 		dst.OutputIndex = uint32(index)
+		dst.Reference = reference
 
 		if err := dst.Save(ctx); err != nil {
 			return err
@@ -169,3 +185,11 @@ func convertToPaymailOutputTemplates(outputTemplates []*template.OutputTemplate)
 	}
 	return outputs
 }
+
+// checkPikeEnabled will return an error if PIKE is disabled for the paymail domain
+func checkPikeEnabled(client ClientInterface, domain string) error {
+	if settings := client.GetPaymailConfig().domainSettings(domain); settings != nil && !settings.PikeEnabled {
+		return spverrors.ErrPaymailDomainPikeDisabled
+	}
+	return nil
+}
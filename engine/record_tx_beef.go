@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/libsv/go-bt/v2"
+)
+
+// isVerifiableTxHex will return true if the hex is BEEF (any version) or the transaction in the Extended Format
+func isVerifiableTxHex(txHex string) bool {
+	return isBeefHex(txHex) || isExtendedFormatHex(txHex)
+}
+
+// recordVerifiedTransaction will verify the transaction given as BEEF or EF and record it with the external incoming strategy,
+// the ancestors from BEEF are saved with their BUMPs (as the ancestors of BEEF received via paymail)
+//
+// xPubID (optional) requires the transaction to pay at least to one destination of the xPub
+func recordVerifiedTransaction(ctx context.Context, c ClientInterface, xPubID, txHex string, opts ...ModelOps) (*Transaction, error) {
+	btTx, beef, err := verifyTransactionHex(ctx, c, txHex)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(xPubID) > 0 && !paysToXpub(ctx, c, xPubID, btTx) {
+		return nil, spverrors.ErrNoMatchingOutputs
+	}
+
+	rts, err := getIncomingTxRecordStrategy(ctx, c, btTx)
+	if err != nil {
+		return nil, err
+	}
+	rts.ForceBroadcast(true)
+	rts.FailOnBroadcastError(true)
+
+	transaction, err := recordTransaction(ctx, c, rts, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if beef != nil {
+		saveVerifiedBeefAncestors(ctx, c, beef)
+	}
+	return transaction, nil
+}
+
+// verifyTransactionHex will decode and verify the transaction given as BEEF or EF,
+// returns the processed transaction (with the previous outputs of the inputs) and the decoded BEEF (nil for EF)
+func verifyTransactionHex(ctx context.Context, c ClientInterface, txHex string) (*bt.Tx, *decodedBeef, error) {
+	if !isBeefHex(txHex) {
+		btTx, err := bt.NewTxFromString(txHex)
+		if err != nil {
+			return nil, nil, spverrors.ErrInvalidHex
+		}
+		if err = verifyExtendedFormat(c, btTx); err != nil {
+			return nil, nil, err
+		}
+		return btTx, nil, nil
+	}
+
+	beef, err := decodeBeefHex(txHex)
+	if err == nil {
+		err = beef.resolveTxIDOnly(ctx, c)
+	}
+	if err != nil {
+		return nil, nil, spverrors.Wrapf(spverrors.ErrInvalidBeef, "%s", err.Error())
+	}
+
+	if err = verifyBeef(ctx, c, beef); err != nil {
+		return nil, nil, err
+	}
+
+	btTx, err := beef.subjectTx()
+	if err != nil {
+		return nil, nil, spverrors.Wrapf(spverrors.ErrInvalidBeef, "%s", err.Error())
+	}
+	return btTx, beef, nil
+}
+
+// paysToXpub will check if at least one output of the transaction is a destination of the xPub
+func paysToXpub(ctx context.Context, c ClientInterface, xPubID string, btTx *bt.Tx) bool {
+	for _, output := range btTx.Outputs {
+		destination, err := getDestinationWithCache(ctx, c, "", "", output.LockingScript.String(), c.DefaultModelOptions()...)
+		if err != nil {
+			c.Logger().Error().Str("txID", btTx.TxID()).Msgf("error getting destination: %s", err.Error())
+			continue
+		}
+		if destination != nil && destination.XpubID == xPubID {
+			return true
+		}
+	}
+	return false
+}
+
+// saveVerifiedBeefAncestors will save the ancestors of the verified BEEF which are not in the datastore (with their BUMPs)
+func saveVerifiedBeefAncestors(ctx context.Context, c ClientInterface, beef *decodedBeef) {
+	ancestors := beef.transactions[:len(beef.transactions)-1]
+	if len(ancestors) == 0 {
+		return
+	}
+
+	txIDs := make([]string, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		txIDs = append(txIDs, ancestor.txID)
+	}
+	stored, err := c.GetTransactionsByIDs(ctx, txIDs)
+	if err != nil {
+		c.Logger().Error().Msgf("error in saveVerifiedBeefAncestors: %v", err)
+		return
+	}
+	storedIDs := make(map[string]bool, len(stored))
+	for _, tx := range stored {
+		storedIDs[tx.ID] = true
+	}
+
+	for _, ancestor := range ancestors {
+		if storedIDs[ancestor.txID] {
+			continue
+		}
+
+		var bump *BUMP
+		if ancestor.bumpIndex > -1 {
+			bump = beef.bumps[ancestor.bumpIndex]
+		}
+		if err = saveBeefTransactionInput(ctx, c, ancestor.tx, bump); err != nil {
+			c.Logger().Error().Msgf("error in saveVerifiedBeefAncestors: %v (tx.ID: %s)", err, ancestor.txID)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/chainstate"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/jarcoal/httpmock"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBlockHeadersServiceURL = "http://block-headers-service.test/api/v1/chain/merkleroot/verify"
+
+// withBlockHeadersServiceMockup will connect the chainstate to the mocked Block Headers Service
+func withBlockHeadersServiceMockup() ClientOps {
+	return func(c *clientOptions) {
+		c.chainstate.options = append(c.chainstate.options, chainstate.WithConnectionToBlockHeaderService(testBlockHeadersServiceURL, ""))
+	}
+}
+
+func mockMerkleRootsVerification(state chainstate.MerkleRootConfirmationState) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", testBlockHeadersServiceURL,
+		httpmock.NewJsonResponderOrPanic(200, chainstate.MerkleRootsConfirmationsResponse{
+			ConfirmationState: state,
+			Confirmations:     []chainstate.MerkleRootConfirmation{},
+		}),
+	)
+}
+
+// extendedFormatHex will return the processed transaction of the BEEF fixture in the Extended Format
+func extendedFormatHex(t *testing.T) string {
+	beef, err := decodeBeefHex(expectedBeefHex[1])
+	require.NoError(t, err)
+	subject, err := beef.subjectTx()
+	require.NoError(t, err)
+
+	for _, input := range subject.Inputs {
+		for _, tx := range beef.transactions {
+			if tx.txID == input.PreviousTxIDStr() {
+				input.PreviousTxSatoshis = tx.tx.Outputs[input.PreviousTxOutIndex].Satoshis
+				input.PreviousTxScript = tx.tx.Outputs[input.PreviousTxOutIndex].LockingScript
+			}
+		}
+	}
+	return hex.EncodeToString(subject.ExtendedBytes())
+}
+
+func Test_verifyTransactionHex(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+	defer deferMe()
+
+	t.Run("valid BEEF", func(t *testing.T) {
+		mockMerkleRootsVerification(chainstate.Confirmed)
+
+		btTx, beef, err := verifyTransactionHex(ctx, client, expectedBeefHex[1])
+
+		require.NoError(t, err)
+		require.NotNil(t, beef)
+		assert.Equal(t, beef.transactions[2].txID, btTx.TxID())
+		assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("BEEF with invalid merkle roots", func(t *testing.T) {
+		mockMerkleRootsVerification(chainstate.Invalid)
+
+		_, _, err := verifyTransactionHex(ctx, client, expectedBeefHex[1])
+
+		require.ErrorIs(t, err, spverrors.ErrSPVInvalidMerkleRoots)
+	})
+
+	t.Run("BEEF with invalid script", func(t *testing.T) {
+		mockMerkleRootsVerification(chainstate.Confirmed)
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		beef.transactions[2].tx.Inputs[0].UnlockingScript = bscript.NewFromBytes([]byte{bscript.OpTRUE})
+		tampered, err := toBeefHex(beef.bumps, beefTxs(beef), &BeefOptions{Version: beefVersion1})
+		require.NoError(t, err)
+
+		_, _, err = verifyTransactionHex(ctx, client, tampered)
+
+		require.ErrorIs(t, err, spverrors.ErrSPVInvalidScript)
+		assert.ErrorContains(t, err, "script of input 0")
+		assert.Equal(t, 0, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("BEEF without parent of unmined transaction", func(t *testing.T) {
+		beef, err := decodeBeefHex(expectedBeefHex[1])
+		require.NoError(t, err)
+		txs := []*bt.Tx{beef.transactions[0].tx, beef.transactions[2].tx}
+		incomplete, err := toBeefHex(beef.bumps, txs, &BeefOptions{Version: beefVersion1})
+		require.NoError(t, err)
+
+		_, _, err = verifyTransactionHex(ctx, client, incomplete)
+
+		require.ErrorIs(t, err, spverrors.ErrSPVMissingParent)
+		assert.ErrorContains(t, err, "has no parent in BEEF")
+	})
+
+	t.Run("valid EF", func(t *testing.T) {
+		efHex := extendedFormatHex(t)
+		require.True(t, isExtendedFormatHex(efHex))
+
+		btTx, beef, err := verifyTransactionHex(ctx, client, efHex)
+
+		require.NoError(t, err)
+		assert.Nil(t, beef)
+		assert.Len(t, btTx.Inputs, 2)
+	})
+
+	t.Run("EF with invalid script", func(t *testing.T) {
+		btTx, err := bt.NewTxFromString(extendedFormatHex(t))
+		require.NoError(t, err)
+		btTx.Inputs[1].PreviousTxSatoshis++
+
+		_, _, err = verifyTransactionHex(ctx, client, hex.EncodeToString(btTx.ExtendedBytes()))
+
+		require.ErrorIs(t, err, spverrors.ErrSPVInvalidScript)
+		assert.ErrorContains(t, err, "script of input 1")
+	})
+
+	t.Run("raw transaction is not verifiable", func(t *testing.T) {
+		btTx, err := bt.NewTxFromString(extendedFormatHex(t))
+		require.NoError(t, err)
+
+		assert.False(t, isVerifiableTxHex(btTx.String()))
+		assert.True(t, isVerifiableTxHex(expectedBeefHex[1]))
+	})
+}
+
+func Test_saveVerifiedBeefAncestors(t *testing.T) {
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+	defer deferMe()
+
+	beef, err := decodeBeefHex(expectedBeefHex[1])
+	require.NoError(t, err)
+
+	// when
+	saveVerifiedBeefAncestors(ctx, client, beef)
+
+	// then
+	txs, err := client.GetTransactionsByIDs(ctx, []string{beef.transactions[0].txID, beef.transactions[1].txID, beef.transactions[2].txID})
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	for _, tx := range txs {
+		assert.NotEqual(t, beef.transactions[2].txID, tx.ID)
+		assert.NotEmpty(t, tx.BUMP.Path)
+	}
+}
+
+func Test_RecordRawTransaction_beef(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	mockMerkleRootsVerification(chainstate.Confirmed)
+
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+	defer deferMe()
+
+	beef, err := decodeBeefHex(expectedBeefHex[1])
+	require.NoError(t, err)
+	xPub := newXpub(testXPub, append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, xPub.Save(ctx))
+	lockingScript := beef.transactions[2].tx.Outputs[0].LockingScript.String()
+	destination := newDestination(xPub.ID, lockingScript, append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, destination.Save(ctx))
+
+	// when
+	transaction, err := client.RecordRawTransaction(ctx, expectedBeefHex[1])
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, beef.transactions[2].txID, transaction.ID)
+	assert.Equal(t, beef.transactions[2].tx.String(), transaction.Hex)
+
+	txs, err := client.GetTransactionsByIDs(ctx, []string{beef.transactions[0].txID, beef.transactions[1].txID})
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+}
@@ -133,6 +133,13 @@ func _hydrateOutgoingWithDraft(ctx context.Context, tx *Transaction) error {
 		return err
 	}
 
+	if draft == nil {
+		// the draft could have been created for a multisig account of the xPub
+		if draft, err = _getMultisigDraftOfMember(ctx, tx); err != nil {
+			return err
+		}
+	}
+
 	if draft == nil {
 		return spverrors.ErrCouldNotFindDraftTx
 	}
@@ -150,6 +157,24 @@ func _hydrateOutgoingWithDraft(ctx context.Context, tx *Transaction) error {
 	return nil // success
 }
 
+func _getMultisigDraftOfMember(ctx context.Context, tx *Transaction) (*DraftTransaction, error) {
+	draft, err := getDraftTransactionID(ctx, "", tx.DraftID, tx.GetOptions(false)...)
+	if err != nil || draft == nil {
+		return nil, err
+	}
+
+	account, err := getMultisigAccount(ctx, draft.XpubID, tx.GetOptions(false)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil || !account.IsMember(tx.XPubID) {
+		return nil, nil
+	}
+
+	return draft, nil
+}
+
 func _hydrateOutgoingWithSync(tx *Transaction) {
 	sync := newSyncTransaction(tx.ID, tx.draftTransaction.Configuration.Sync, tx.GetOptions(true)...)
 
@@ -172,7 +197,7 @@ func _getBroadcastSyncStatus(tx *Transaction) SyncStatus {
 
 	for _, o := range outputs {
 		if o.PaymailP4 != nil {
-			if o.PaymailP4.Format == BeefPaymailPayloadFormat {
+			if o.PaymailP4.Format.isBeef() {
 				broadcast = SyncStatusSkipped // postpone broadcasting if tx contains outputs in BEEF
 
 				break
@@ -188,7 +213,7 @@ func _getP2pSyncStatus(tx *Transaction) SyncStatus {
 
 	outputs := tx.draftTransaction.Configuration.Outputs
 	for _, o := range outputs {
-		if o.PaymailP4 != nil && o.PaymailP4.ResolutionType == ResolutionTypeP2P {
+		if o.PaymailP4.isSubmitted() {
 			p2pStatus = SyncStatusReady // notify p2p immediately
 
 			break
@@ -204,6 +229,14 @@ func _outgoingNotifyP2p(ctx context.Context, logger *zerolog.Logger, tx *Transac
 		Msg("start p2p")
 
 	if err := processP2PTransaction(ctx, tx); err != nil {
+		if tx.syncTransaction.P2PStatus == SyncStatusReady && tx.syncTransaction.P2PNextAttemptAt.Valid {
+			// ignore error, the receiver was not reached and the next try will be handled by task manager
+			logger.Warn().
+				Str("txID", tx.ID).
+				Msgf("p2p notification failed, next try will be handled by task manager. Reason: %s", err)
+			return nil
+		}
+
 		logger.Error().
 			Str("txID", tx.ID).
 			Msgf("processP2PTransaction failed. Reason: %s", err)
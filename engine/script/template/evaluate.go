@@ -9,8 +9,8 @@ import (
 )
 
 // Evaluate processes a given Bitcoin script by parsing it, replacing certain opcodes
-// with the public key hash, and returning the resulting script as a byte array.
-// Will replace any OP_PUBKEYHASH or OP_PUBKEY
+// with the public key hash (or the public key), and returning the resulting script as a byte array.
+// Will replace any OP_PUBKEYHASH or OP_PUBKEY (the data after OP_RETURN is not evaluated)
 //
 // Parameters:
 // - script: A byte array representing the input script.
@@ -19,19 +19,10 @@ import (
 // Returns:
 // - A byte array representing the evaluated script, or nil if an error occurs.
 func Evaluate(script []byte, pubKey *bec.PublicKey) ([]byte, error) {
-	s := bscript.Script(script)
-
 	parser := interpreter.DefaultOpcodeParser{}
-	parsedScript, err := parser.Parse(&s)
+	parsedScript, err := parseTemplate(script)
 	if err != nil {
-		return nil, spverrors.Wrapf(err, "failed to parse script template")
-	}
-
-	// Validate parsed opcodes
-	for _, op := range parsedScript {
-		if op.Value() == 0xFF {
-			return nil, spverrors.Newf("invalid opcode")
-		}
+		return nil, err
 	}
 
 	// Serialize the public key to compressed format
@@ -40,26 +31,25 @@ func Evaluate(script []byte, pubKey *bec.PublicKey) ([]byte, error) {
 	// Apply Hash160 (SHA-256 followed by RIPEMD-160) to the compressed public key
 	dPKHash := crypto.Hash160(dPKBytes)
 
-	// Create a new script with the public key hash
-	newScript := new(bscript.Script)
-	if err = newScript.AppendPushData(dPKHash); err != nil {
+	pkhParsed, err := parsePushData(dPKHash)
+	if err != nil {
 		return nil, spverrors.Wrapf(err, "failed to convert pubkeyhash value into opcodes")
 	}
-
-	// Parse the public key hash script
-	pkhParsed, err := parser.Parse(newScript)
+	pkParsed, err := parsePushData(dPKBytes)
 	if err != nil {
-		return nil, spverrors.Wrapf(err, "failed to convert pubkeyhash value into opcodes")
+		return nil, spverrors.Wrapf(err, "failed to convert pubkey value into opcodes")
 	}
 
-	// Replace OP_PUBKEYHASH with the actual public key hash
+	// Replace OP_PUBKEYHASH and OP_PUBKEY with the actual public key hash and public key
 	evaluated := make([]interpreter.ParsedOpcode, 0, len(parsedScript))
-	for _, op := range parsedScript {
-		switch op.Value() {
-		case bscript.OpPUBKEYHASH:
+	for index, op := range parsedScript {
+		switch {
+		case isReturnData(parsedScript, index):
+			evaluated = append(evaluated, op)
+		case op.Value() == bscript.OpPUBKEYHASH:
 			evaluated = append(evaluated, pkhParsed...)
-		case bscript.OpPUBKEY:
-			return nil, spverrors.Newf("OP_PUBKEY not supported yet")
+		case op.Value() == bscript.OpPUBKEY:
+			evaluated = append(evaluated, pkParsed...)
 		default:
 			evaluated = append(evaluated, op)
 		}
@@ -74,3 +64,53 @@ func Evaluate(script []byte, pubKey *bec.PublicKey) ([]byte, error) {
 	// Cast *bscript.Script back to []byte
 	return []byte(*finalScript), nil
 }
+
+// parseTemplate will parse the script template and validate its opcodes
+func parseTemplate(script []byte) (interpreter.ParsedScript, error) {
+	s := bscript.Script(script)
+
+	parser := interpreter.DefaultOpcodeParser{}
+	parsedScript, err := parser.Parse(&s)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to parse script template")
+	}
+
+	// Validate parsed opcodes
+	for index, op := range parsedScript {
+		if op.Value() == 0xFF && !isReturnData(parsedScript, index) {
+			return nil, spverrors.Newf("invalid opcode")
+		}
+	}
+	return parsedScript, nil
+}
+
+// parsePushData will create the opcodes pushing the data
+func parsePushData(data []byte) (interpreter.ParsedScript, error) {
+	script := new(bscript.Script)
+	if err := script.AppendPushData(data); err != nil {
+		return nil, spverrors.Wrapf(err, "failed to append push data")
+	}
+
+	parser := interpreter.DefaultOpcodeParser{}
+	parsed, err := parser.Parse(script)
+	return parsed, spverrors.Wrapf(err, "failed to parse push data")
+}
+
+// isReturnData will return true if the opcode is the unformatted data after OP_RETURN (outside of conditional blocks),
+// the parser puts the remaining data into a single opcode which is the last one
+func isReturnData(parsedScript interpreter.ParsedScript, index int) bool {
+	if index == 0 || index != len(parsedScript)-1 || parsedScript[index-1].Value() != bscript.OpRETURN {
+		return false
+	}
+
+	conditionalBlock := 0
+	for _, op := range parsedScript[:index-1] {
+		switch op.Value() {
+		case bscript.OpIF, bscript.OpNOTIF, bscript.OpVERIF, bscript.OpVERNOTIF:
+			conditionalBlock++
+		case bscript.OpENDIF:
+			conditionalBlock--
+		}
+	}
+	return conditionalBlock == 0
+}
@@ -102,6 +102,18 @@ func TestEvaluate(t *testing.T) {
 				publicKey: mockPublicKey,
 				expected:  append([]byte{bscript.OpDUP, bscript.OpHASH160, bscript.OpDATA20}, append(mockPubKeyHash, bscript.OpEQUALVERIFY, bscript.OpCHECKSIG)...),
 			},
+			{
+				name:      "valid script with OP_PUBKEY",
+				script:    []byte{bscript.OpPUBKEY, bscript.OpCHECKSIG},
+				publicKey: mockPublicKey,
+				expected:  append(append([]byte{bscript.OpDATA33}, mockPublicKey.SerialiseCompressed()...), bscript.OpCHECKSIG),
+			},
+			{
+				name:      "OP_RETURN data is not evaluated",
+				script:    []byte{bscript.OpPUBKEYHASH, bscript.OpRETURN, bscript.OpPUBKEYHASH, bscript.OpPUBKEY, 0xFF},
+				publicKey: mockPublicKey,
+				expected:  append(append([]byte{bscript.OpDATA20}, mockPubKeyHash...), bscript.OpRETURN, bscript.OpPUBKEYHASH, bscript.OpPUBKEY, 0xFF),
+			},
 			{
 				name:      "valid script without OP_PUBKEYHASH or OP_PUBKEY",
 				script:    []byte{bscript.OpDUP, bscript.OpHASH160, bscript.OpEQUALVERIFY, bscript.OpCHECKSIG},
@@ -150,11 +162,6 @@ func TestEvaluate(t *testing.T) {
 				script:    []byte{0xFF}, // Invalid opcode
 				publicKey: mockPublicKey,
 			},
-			{
-				name:      "valid script with OP_PUBKEY",
-				script:    []byte{bscript.OpDUP, bscript.OpHASH160, bscript.OpPUBKEY, bscript.OpEQUALVERIFY, bscript.OpCHECKSIG},
-				publicKey: mockPublicKey,
-			},
 		}
 
 		for _, tt := range invalidTests {
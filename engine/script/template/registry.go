@@ -0,0 +1,213 @@
+package template
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/libsv/go-bt/v2/bscript"
+)
+
+// Names of the registered output templates
+const (
+	// P2PKHTemplate is the single P2PKH output of the full amount (default)
+	P2PKHTemplate = "p2pkh"
+
+	// SplitP2PKHTemplate is the amount split into multiple P2PKH outputs of the configured denominations
+	SplitP2PKHTemplate = "p2pkh_split"
+
+	// MemoP2PKHTemplate is the single P2PKH output with the memo appended after OP_RETURN
+	MemoP2PKHTemplate = "p2pkh_memo"
+)
+
+const (
+	// MaxSplitOutputs is the max number of the outputs of the split template (the last output takes the rest of the amount)
+	MaxSplitOutputs = 100
+
+	// MaxMemoLength is the max length of the memo of the memo template (in bytes)
+	MaxMemoLength = 512
+)
+
+// Config is the configuration of the output templates (the name of the registered template and its parameters)
+type Config struct {
+	Name          string   `json:"name"`                    // Name of the registered template (empty = p2pkh)
+	Denominations []uint64 `json:"denominations,omitempty"` // Denominations of the outputs (p2pkh_split)
+	Memo          string   `json:"memo,omitempty"`          // Memo appended after OP_RETURN (p2pkh_memo)
+}
+
+// Template creates the output templates of the payment,
+// the outputs must be spendable by the wallet (the signer supports only P2PKH inputs, optionally followed by OP_RETURN data)
+type Template interface {
+	// Validate will check the parameters of the configuration
+	Validate(config *Config) error
+
+	// Outputs will create the output templates of the given satoshis
+	Outputs(satoshis uint64, config *Config) ([]*OutputTemplate, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	templates map[string]Template
+}{
+	templates: map[string]Template{
+		P2PKHTemplate:      p2pkhTemplate{},
+		SplitP2PKHTemplate: splitP2PKHTemplate{},
+		MemoP2PKHTemplate:  memoP2PKHTemplate{},
+	},
+}
+
+// Register will register the output template with the given name (the registered template with the same name is replaced)
+func Register(name string, template Template) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.templates[name] = template
+}
+
+// Names will return the names of the registered output templates
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.templates))
+	for name := range registry.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate will check the configuration of the output templates (nil configuration is the default p2pkh template)
+func Validate(config *Config) error {
+	template, err := lookup(config)
+	if err != nil {
+		return err
+	}
+	return template.Validate(config)
+}
+
+// Outputs will create the output templates of the given satoshis using the configured template
+func Outputs(satoshis uint64, config *Config) ([]*OutputTemplate, error) {
+	template, err := lookup(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = template.Validate(config); err != nil {
+		return nil, err
+	}
+	return template.Outputs(satoshis, config)
+}
+
+// lookup will find the registered template of the configuration
+func lookup(config *Config) (Template, error) {
+	name := P2PKHTemplate
+	if config != nil && config.Name != "" {
+		name = config.Name
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	template, ok := registry.templates[name]
+	if !ok {
+		return nil, spverrors.Newf("unknown output template: %s", name)
+	}
+	return template, nil
+}
+
+// p2pkhTemplate is the single P2PKH output of the full amount
+type p2pkhTemplate struct{}
+
+func (p2pkhTemplate) Validate(*Config) error {
+	return nil
+}
+
+func (p2pkhTemplate) Outputs(satoshis uint64, _ *Config) ([]*OutputTemplate, error) {
+	output, err := P2PKH(satoshis)
+	if err != nil {
+		return nil, err
+	}
+	return []*OutputTemplate{output}, nil
+}
+
+// splitP2PKHTemplate is the amount split into P2PKH outputs of the denominations (the biggest first),
+// the rest of the amount (less than the smallest denomination) is the last output
+type splitP2PKHTemplate struct{}
+
+func (splitP2PKHTemplate) Validate(config *Config) error {
+	if len(config.Denominations) == 0 {
+		return spverrors.Newf("denominations are required for %s template", SplitP2PKHTemplate)
+	}
+	for _, denomination := range config.Denominations {
+		if denomination == 0 {
+			return spverrors.Newf("denomination cannot be zero")
+		}
+	}
+	return nil
+}
+
+func (splitP2PKHTemplate) Outputs(satoshis uint64, config *Config) ([]*OutputTemplate, error) {
+	if satoshis == 0 {
+		return nil, spverrors.Newf("satoshis cannot be zero")
+	}
+
+	denominations := append([]uint64{}, config.Denominations...)
+	sort.Slice(denominations, func(i, j int) bool { return denominations[i] > denominations[j] })
+
+	amounts := make([]uint64, 0)
+	remaining := satoshis
+	for _, denomination := range denominations {
+		for remaining >= denomination && len(amounts) < MaxSplitOutputs-1 {
+			amounts = append(amounts, denomination)
+			remaining -= denomination
+		}
+	}
+	if remaining > 0 {
+		amounts = append(amounts, remaining)
+	}
+
+	outputs := make([]*OutputTemplate, 0, len(amounts))
+	for _, amount := range amounts {
+		output, err := P2PKH(amount)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// memoP2PKHTemplate is the single P2PKH output with the memo (OP_RETURN ends the script after the signature check)
+type memoP2PKHTemplate struct{}
+
+func (memoP2PKHTemplate) Validate(config *Config) error {
+	if config.Memo == "" {
+		return spverrors.Newf("memo is required for %s template", MemoP2PKHTemplate)
+	}
+	if len(config.Memo) > MaxMemoLength {
+		return spverrors.Newf("memo cannot be longer than %d bytes", MaxMemoLength)
+	}
+	return nil
+}
+
+func (memoP2PKHTemplate) Outputs(satoshis uint64, config *Config) ([]*OutputTemplate, error) {
+	output, err := P2PKH(satoshis)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := bscript.NewFromHexString(output.Script)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to create script from hex string")
+	}
+	if err = script.AppendOpcodes(bscript.OpRETURN); err != nil {
+		return nil, spverrors.Wrapf(err, "failed to append OP_RETURN")
+	}
+	if err = script.AppendPushData([]byte(config.Memo)); err != nil {
+		return nil, spverrors.Wrapf(err, "failed to append memo")
+	}
+
+	return []*OutputTemplate{{
+		Script:   script.String(),
+		Satoshis: satoshis,
+	}}, nil
+}
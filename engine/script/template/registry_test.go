@@ -0,0 +1,102 @@
+package template
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bt/v2/bscript"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestOutputs(t *testing.T) {
+	p2pkhScript := "76a9fd88ac"
+
+	t.Run("default template", func(t *testing.T) {
+		outputs, err := Outputs(1000, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []*OutputTemplate{{Script: p2pkhScript, Satoshis: 1000}}, outputs)
+	})
+
+	t.Run("split template", func(t *testing.T) {
+		outputs, err := Outputs(2750, &Config{Name: SplitP2PKHTemplate, Denominations: []uint64{100, 1000}})
+		assert.NoError(t, err)
+
+		amounts := make([]uint64, 0, len(outputs))
+		for _, output := range outputs {
+			assert.Equal(t, p2pkhScript, output.Script)
+			amounts = append(amounts, output.Satoshis)
+		}
+		assert.Equal(t, []uint64{1000, 1000, 100, 100, 100, 100, 100, 100, 100, 50}, amounts)
+	})
+
+	t.Run("split template limits the outputs", func(t *testing.T) {
+		outputs, err := Outputs(1000, &Config{Name: SplitP2PKHTemplate, Denominations: []uint64{1}})
+		assert.NoError(t, err)
+		assert.Len(t, outputs, MaxSplitOutputs)
+		assert.Equal(t, uint64(1000-MaxSplitOutputs+1), outputs[MaxSplitOutputs-1].Satoshis)
+	})
+
+	t.Run("memo template", func(t *testing.T) {
+		outputs, err := Outputs(1000, &Config{Name: MemoP2PKHTemplate, Memo: "hi"})
+		assert.NoError(t, err)
+		assert.Equal(t, []*OutputTemplate{{Script: p2pkhScript + "6a026869", Satoshis: 1000}}, outputs)
+	})
+
+	t.Run("registered template", func(t *testing.T) {
+		Register("test_double", testTemplate{})
+		assert.Contains(t, Names(), "test_double")
+
+		outputs, err := Outputs(1000, &Config{Name: "test_double"})
+		assert.NoError(t, err)
+		assert.Len(t, outputs, 2)
+	})
+
+	t.Run("invalid configurations", func(t *testing.T) {
+		invalidConfigs := map[string]*Config{
+			"unknown template":             {Name: "unknown"},
+			"custom template":              {Name: "custom"},
+			"split without denominations":  {Name: SplitP2PKHTemplate},
+			"split with zero denomination": {Name: SplitP2PKHTemplate, Denominations: []uint64{0}},
+			"memo without memo":            {Name: MemoP2PKHTemplate},
+			"memo too long":                {Name: MemoP2PKHTemplate, Memo: string(make([]byte, MaxMemoLength+1))},
+		}
+		for name, config := range invalidConfigs {
+			t.Run(name, func(t *testing.T) {
+				assert.Error(t, Validate(config))
+				_, err := Outputs(1000, config)
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("evaluated memo script", func(t *testing.T) {
+		outputs, err := Outputs(1000, &Config{Name: MemoP2PKHTemplate, Memo: string([]byte{bscript.OpPUBKEYHASH})})
+		assert.NoError(t, err)
+
+		privateKey, err := bec.NewPrivateKey(bec.S256())
+		assert.NoError(t, err)
+		script, err := bscript.NewFromHexString(outputs[0].Script)
+		assert.NoError(t, err)
+
+		evaluated, err := Evaluate(*script, privateKey.PubKey())
+		assert.NoError(t, err)
+		expected, err := bscript.NewP2PKHFromPubKeyBytes(privateKey.PubKey().SerialiseCompressed())
+		assert.NoError(t, err)
+		assert.Equal(t, expected.String()+"6a01fd", hex.EncodeToString(evaluated))
+	})
+}
+
+type testTemplate struct{}
+
+func (testTemplate) Validate(*Config) error {
+	return nil
+}
+
+func (testTemplate) Outputs(satoshis uint64, _ *Config) ([]*OutputTemplate, error) {
+	output, err := P2PKH(satoshis)
+	if err != nil {
+		return nil, err
+	}
+	return []*OutputTemplate{output, output}, nil
+}
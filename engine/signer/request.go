@@ -0,0 +1,75 @@
+// Package signer signs draft transactions offline (e.g. on an air-gapped machine).
+//
+// The wallet exports a draft transaction as a signing Request: the unsigned transaction in Extended Format
+// (which carries the previous outputs needed for the signature hashes) and, for every input,
+// the derivation path of the key which can unlock it. The xPriv never leaves the machine running the signer,
+// the signed transaction hex is imported back into the wallet which validates it against the draft.
+package signer
+
+import "time"
+
+// RequestVersion is the current version of the signing request format
+const RequestVersion = 1
+
+// Derivation methods of the input keys
+const (
+	// DerivationBIP32 is the chain/num derivation from the xPub
+	DerivationBIP32 = "BIP32"
+	// DerivationPIKE is the derivation of the PIKE (contact) outputs
+	DerivationPIKE = "PIKE"
+)
+
+// Request is a portable request to sign a draft transaction
+type Request struct {
+	// Version of the signing request format
+	Version int `json:"version" example:"1"`
+	// DraftID is the id of the draft transaction the request was exported from
+	DraftID string `json:"draftId" example:"b356f7fa00cd3f20cce6c21d704cd13e871d28d714a5ebd0532f5a0e0cde63f7"`
+	// XpubID is the id of the xpub whose keys unlock the inputs
+	XpubID string `json:"xpubId" example:"bb8593f85ef8056a77026ad415f02128f3768906de53e9e8bf8749fe2d66cf50"`
+	// EF is the unsigned transaction in Extended Format (with the previous outputs of the inputs), in hex
+	EF string `json:"ef" example:"010000000000000000ef01..."`
+	// Inputs are the signing instructions of every input (in the order of the transaction inputs)
+	Inputs []*Input `json:"inputs"`
+	// ExpiresAt is the time after which the draft transaction can't be recorded anymore
+	ExpiresAt time.Time `json:"expiresAt" example:"2024-02-26T11:00:28.069911Z"`
+}
+
+// Input is the signing instruction of a single input
+type Input struct {
+	// Index of the input in the transaction
+	Index uint32 `json:"index" example:"0"`
+	// TxID of the spent output
+	TxID string `json:"txId" example:"d2b7ed2e8e5e7e8c63e7d8a5b6c9bdf2d1f5c6e4b3a2a1f0e9d8c7b6a5f4e3d2"`
+	// OutputIndex (vout) of the spent output
+	OutputIndex uint32 `json:"outputIndex" example:"0"`
+	// Satoshis of the spent output
+	Satoshis uint64 `json:"satoshis" example:"1000"`
+	// LockingScript of the spent output, in hex
+	LockingScript string `json:"lockingScript" example:"76a9147b05764a97f3b4b981471492aa703b188e45979b88ac"`
+	// ScriptType of the spent output
+	ScriptType string `json:"scriptType" example:"pubkeyhash"`
+	// DerivationMethod of the key unlocking the input (BIP32 or PIKE)
+	DerivationMethod string `json:"derivationMethod" example:"BIP32"`
+	// Chain is the (chain)/num derivation of the key
+	Chain uint32 `json:"chain" example:"0"`
+	// Num is the chain/(num) derivation of the key
+	Num uint32 `json:"num" example:"0"`
+	// Pike is the reference of the PIKE output (set for the PIKE derivation method)
+	Pike *PikeReference `json:"pike,omitempty"`
+}
+
+// PikeReference identifies the PIKE output template the locking script was derived from
+//
+// The key of the output is linked (type42) to the paymail key chain/num/(PubKeyNum) of the xPub
+// with the public key of the sender and the invoice number "<Reference>-<OutputIndex>"
+type PikeReference struct {
+	// SenderPubKey is the public key of the sender (contact) of the output
+	SenderPubKey string `json:"senderPubKey" example:"03a1b2c3..."`
+	// Reference is the reference of the PIKE payment the output was derived with
+	Reference string `json:"reference" example:"a1b2c3d4e5f60718"`
+	// OutputIndex is the index of the script in the output templates
+	OutputIndex uint32 `json:"outputIndex" example:"0"`
+	// PubKeyNum is the chain/num/(pub_key_num) derivation of the paymail key the output is linked to
+	PubKeyNum uint32 `json:"pubKeyNum" example:"0"`
+}
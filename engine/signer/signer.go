@@ -0,0 +1,211 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/types/type42"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/libsv/go-bt/v2/bscript/interpreter"
+)
+
+// SignWithKey will sign all the inputs of the request using the given xPriv key and return the signed transaction hex
+func SignWithKey(request *Request, xPrivKey string) (string, error) {
+	xPriv, err := bip32.NewKeyFromString(xPrivKey)
+	if err != nil {
+		return "", spverrors.Wrapf(err, "failed to parse xPriv")
+	}
+
+	return Sign(request, xPriv)
+}
+
+// Sign will sign all the inputs of the request using the given xPriv and return the signed transaction hex
+//
+// Every input is checked before signing: it has to be the spent output of the transaction
+// and its locking script has to be the one of the derived key (P2PKH, optionally followed by the data after OP_RETURN)
+func Sign(request *Request, xPriv *bip32.ExtendedKey) (string, error) {
+	tx, err := parseRequest(request)
+	if err != nil {
+		return "", err
+	}
+
+	xPub, err := xPriv.Neuter()
+	if err != nil {
+		return "", spverrors.Wrapf(err, "failed to get xPub from xPriv")
+	}
+	if utils.Hash(xPub.String()) != request.XpubID {
+		return "", spverrors.ErrSigningRequestWrongKey
+	}
+
+	for _, input := range request.Inputs {
+		if input.ScriptType != utils.ScriptTypePubKeyHash {
+			return "", spverrors.ErrSigningRequestUnsupportedInput
+		}
+
+		var privateKey *bec.PrivateKey
+		if privateKey, err = inputPrivateKey(xPriv, input); err != nil {
+			return "", err
+		}
+
+		// Never sign an input which is not locked by the derived key
+		var lockingScript *bscript.Script
+		if lockingScript, err = bscript.NewP2PKHFromPubKeyBytes(
+			privateKey.PubKey().SerialiseCompressed(),
+		); err != nil {
+			return "", spverrors.Wrapf(err, "failed to create locking script of input %d", input.Index)
+		}
+		if !isLockedBy(tx.Inputs[input.Index].PreviousTxScript, lockingScript) {
+			return "", spverrors.ErrSigningRequestWrongKey
+		}
+
+		var s *bscript.Script
+		if s, err = utils.GetUnlockingScript(tx, input.Index, privateKey); err != nil {
+			return "", err
+		}
+
+		if err = tx.InsertInputUnlockingScript(input.Index, s); err != nil {
+			return "", spverrors.Wrapf(err, "failed to insert unlocking script of input %d", input.Index)
+		}
+	}
+
+	return tx.String(), nil
+}
+
+// inputPrivateKey will derive the private key of the input (BIP32 chain/num or PIKE linked key)
+func inputPrivateKey(xPriv *bip32.ExtendedKey, input *Input) (*bec.PrivateKey, error) {
+	// Derive the child key (chain/num)
+	numKey, err := bitcoin.GetHDKeyByPath(xPriv, input.Chain, input.Num)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to derive key of input %d", input.Index)
+	}
+
+	switch input.DerivationMethod {
+	case DerivationBIP32:
+		privateKey, err := bitcoin.GetPrivateKeyFromHDKey(numKey)
+		return privateKey, spverrors.Wrapf(err, "failed to get private key of input %d", input.Index)
+	case DerivationPIKE:
+		if input.Pike == nil || input.Pike.Reference == "" {
+			return nil, spverrors.ErrSigningRequestUnsupportedInput
+		}
+		return pikePrivateKey(numKey, input)
+	default:
+		return nil, spverrors.ErrSigningRequestUnsupportedInput
+	}
+}
+
+// pikePrivateKey will derive the private key of the PIKE output linked to the paymail key chain/num/(pub_key_num)
+func pikePrivateKey(numKey *bip32.ExtendedKey, input *Input) (*bec.PrivateKey, error) {
+	pubKeyNumKey, err := numKey.Child(input.Pike.PubKeyNum)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to derive paymail key of input %d", input.Index)
+	}
+
+	receiverKey, err := bitcoin.GetPrivateKeyFromHDKey(pubKeyNumKey)
+	if err != nil {
+		return nil, spverrors.Wrapf(err, "failed to get paymail private key of input %d", input.Index)
+	}
+
+	senderPubKeyBytes, err := hex.DecodeString(input.Pike.SenderPubKey)
+	if err != nil {
+		return nil, spverrors.ErrSigningRequestInvalid
+	}
+	senderPubKey, err := bec.ParsePubKey(senderPubKeyBytes, bec.S256())
+	if err != nil {
+		return nil, spverrors.ErrSigningRequestInvalid
+	}
+
+	privateKey, err := type42.DeriveLinkedPrivateKey(
+		senderPubKey, receiverKey, fmt.Sprintf("%s-%d", input.Pike.Reference, input.Pike.OutputIndex),
+	)
+	return privateKey, spverrors.Wrapf(err, "failed to derive linked key of input %d", input.Index)
+}
+
+// isLockedBy will check that the spent output is locked by the P2PKH locking script of the key,
+// the script can be followed by the data after OP_RETURN (e.g. the memo of the PIKE output)
+func isLockedBy(previousTxScript, lockingScript *bscript.Script) bool {
+	if previousTxScript == nil {
+		return false
+	}
+	if lockingScript.Equals(previousTxScript) {
+		return true
+	}
+	return len(*previousTxScript) > len(*lockingScript) &&
+		bytes.HasPrefix(*previousTxScript, *lockingScript) &&
+		(*previousTxScript)[len(*lockingScript)] == bscript.OpRETURN
+}
+
+// Verify will check that the signed transaction is the transaction of the request and all its inputs are correctly signed
+func Verify(request *Request, signedHex string) error {
+	unsignedTx, err := parseRequest(request)
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := bt.NewTxFromString(signedHex)
+	if err != nil {
+		return spverrors.ErrSignedTxDoesNotMatchDraft
+	}
+
+	// The signed transaction can only differ in the unlocking scripts
+	if len(signedTx.Inputs) != len(unsignedTx.Inputs) {
+		return spverrors.ErrSignedTxDoesNotMatchDraft
+	}
+	stripped := signedTx.Clone()
+	for index := range stripped.Inputs {
+		stripped.Inputs[index].UnlockingScript = unsignedTx.Inputs[index].UnlockingScript
+	}
+	if !bytes.Equal(stripped.Bytes(), unsignedTx.Bytes()) {
+		return spverrors.ErrSignedTxDoesNotMatchDraft
+	}
+
+	for index, input := range unsignedTx.Inputs {
+		if err = interpreter.NewEngine().Execute(
+			interpreter.WithTx(signedTx, index, &bt.Output{
+				LockingScript: input.PreviousTxScript,
+				Satoshis:      input.PreviousTxSatoshis,
+			}),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		); err != nil {
+			return spverrors.ErrSignedTxInvalidSignature
+		}
+	}
+
+	return nil
+}
+
+// parseRequest will parse the transaction of the request and check it matches the inputs
+func parseRequest(request *Request) (*bt.Tx, error) {
+	if request == nil {
+		return nil, spverrors.ErrSigningRequestInvalid
+	}
+	if request.Version != RequestVersion {
+		return nil, spverrors.ErrSigningRequestUnsupportedVersion
+	}
+
+	tx, err := bt.NewTxFromString(request.EF)
+	if err != nil || len(tx.Inputs) != len(request.Inputs) {
+		return nil, spverrors.ErrSigningRequestInvalid
+	}
+
+	for index, input := range request.Inputs {
+		txInput := tx.Inputs[index]
+		if input.Index != uint32(index) ||
+			input.TxID != txInput.PreviousTxIDStr() ||
+			input.OutputIndex != txInput.PreviousTxOutIndex ||
+			input.Satoshis != txInput.PreviousTxSatoshis ||
+			txInput.PreviousTxScript == nil ||
+			input.LockingScript != hex.EncodeToString(*txInput.PreviousTxScript) {
+			return nil, spverrors.ErrSigningRequestInvalid
+		}
+	}
+
+	return tx, nil
+}
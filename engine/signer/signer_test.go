@@ -0,0 +1,219 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/types/type42"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bt/v2"
+	"github.com/libsv/go-bt/v2/bscript"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testXPriv  = "xprv9s21ZrQH143K31pvNoYNcRZjtdJXnNVEc5NmBbgJmEg27YWbZVL7jTLQhPELqAR7tcJTnF9AJLwVN5w3ABZvrfeDLm4vnBDw76bkx8a2NxK"
+	testTxID   = "65bb8d2733298b2d3b441a871868d6323c5392facf0d3eced3a6c6a17dc84c10"
+	testTo     = "1AqYEDUf16CHaD2guBLHHhosfV2AyYJLz"
+	testAmount = uint64(12229)
+)
+
+func Test_SignAndVerify(t *testing.T) {
+	xPriv, err := bip32.NewKeyFromString(testXPriv)
+	require.NoError(t, err)
+
+	t.Run("sign and verify", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+
+		signedHex, err := SignWithKey(request, testXPriv)
+		require.NoError(t, err)
+		require.NoError(t, Verify(request, signedHex))
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+
+		other, err := bitcoin.GenerateHDKey(bitcoin.RecommendedSeedLength)
+		require.NoError(t, err)
+		_, err = Sign(request, other)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestWrongKey)
+	})
+
+	t.Run("input not locked by the derived key", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+		request.Inputs[0].Num = 2
+
+		_, err = Sign(request, xPriv)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestWrongKey)
+	})
+
+	t.Run("sign and verify PIKE input", func(t *testing.T) {
+		request := newTestPikeRequest(t, xPriv, 0, 1, 2)
+
+		signedHex, err := Sign(request, xPriv)
+		require.NoError(t, err)
+		require.NoError(t, Verify(request, signedHex))
+	})
+
+	t.Run("sign and verify P2PKH input with memo", func(t *testing.T) {
+		request := newTestMemoRequest(t, xPriv, 0, 1, "thank you")
+
+		signedHex, err := Sign(request, xPriv)
+		require.NoError(t, err)
+		require.NoError(t, Verify(request, signedHex))
+	})
+
+	t.Run("input locked by the derived key followed by other opcodes", func(t *testing.T) {
+		numKey, err := bitcoin.GetHDKeyByPath(xPriv, 0, 1)
+		require.NoError(t, err)
+		pubKey, err := numKey.ECPubKey()
+		require.NoError(t, err)
+		lockingScript, err := bscript.NewP2PKHFromPubKeyBytes(pubKey.SerialiseCompressed())
+		require.NoError(t, err)
+		require.NoError(t, lockingScript.AppendOpcodes(bscript.OpDROP))
+
+		_, err = Sign(newTestRequestForScript(t, xPriv, lockingScript, 0, 1), xPriv)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestWrongKey)
+	})
+
+	t.Run("PIKE input with wrong reference", func(t *testing.T) {
+		request := newTestPikeRequest(t, xPriv, 0, 1, 2)
+		request.Inputs[0].Pike.Reference = "other-reference"
+
+		_, err = Sign(request, xPriv)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestWrongKey)
+	})
+
+	t.Run("PIKE input without reference", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+		request.Inputs[0].DerivationMethod = DerivationPIKE
+
+		_, err = Sign(request, xPriv)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestUnsupportedInput)
+	})
+
+	t.Run("inputs do not match the transaction", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+		request.Inputs[0].Satoshis++
+
+		_, err = Sign(request, xPriv)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestInvalid)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+		request.Version = RequestVersion + 1
+
+		_, err = Sign(request, xPriv)
+		require.ErrorIs(t, err, spverrors.ErrSigningRequestUnsupportedVersion)
+	})
+
+	t.Run("verify modified transaction", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+		signedHex, err := Sign(request, xPriv)
+		require.NoError(t, err)
+
+		tx, err := bt.NewTxFromString(signedHex)
+		require.NoError(t, err)
+		tx.Outputs[0].Satoshis--
+		require.ErrorIs(t, Verify(request, tx.String()), spverrors.ErrSignedTxDoesNotMatchDraft)
+	})
+
+	t.Run("verify unsigned transaction", func(t *testing.T) {
+		request := newTestRequest(t, xPriv, 0, 1)
+		tx, err := bt.NewTxFromString(request.EF)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, Verify(request, tx.String()), spverrors.ErrSignedTxInvalidSignature)
+	})
+}
+
+// newTestRequest will create a signing request spending a P2PKH output of the xPriv (chain/num)
+func newTestRequest(t *testing.T, xPriv *bip32.ExtendedKey, chain, num uint32) *Request {
+	numKey, err := bitcoin.GetHDKeyByPath(xPriv, chain, num)
+	require.NoError(t, err)
+	pubKey, err := numKey.ECPubKey()
+	require.NoError(t, err)
+
+	return newTestRequestForKey(t, xPriv, pubKey, chain, num)
+}
+
+// newTestPikeRequest will create a signing request spending a PIKE output linked to the paymail key of the xPriv (chain/num/pubKeyNum)
+func newTestPikeRequest(t *testing.T, xPriv *bip32.ExtendedKey, chain, num, pubKeyNum uint32) *Request {
+	numKey, err := bitcoin.GetHDKeyByPath(xPriv, chain, num)
+	require.NoError(t, err)
+	paymailKey, err := numKey.Child(pubKeyNum)
+	require.NoError(t, err)
+	paymailPubKey, err := paymailKey.ECPubKey()
+	require.NoError(t, err)
+
+	senderKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+	pubKey, err := type42.DeriveLinkedKey(senderKey.PubKey(), paymailPubKey, "test-reference-0")
+	require.NoError(t, err)
+
+	request := newTestRequestForKey(t, xPriv, pubKey, chain, num)
+	request.Inputs[0].DerivationMethod = DerivationPIKE
+	request.Inputs[0].Pike = &PikeReference{
+		SenderPubKey: hex.EncodeToString(senderKey.PubKey().SerialiseCompressed()),
+		Reference:    "test-reference",
+		OutputIndex:  0,
+		PubKeyNum:    pubKeyNum,
+	}
+	return request
+}
+
+// newTestMemoRequest will create a signing request spending a P2PKH output of the xPriv (chain/num) with the memo after OP_RETURN
+func newTestMemoRequest(t *testing.T, xPriv *bip32.ExtendedKey, chain, num uint32, memo string) *Request {
+	numKey, err := bitcoin.GetHDKeyByPath(xPriv, chain, num)
+	require.NoError(t, err)
+	pubKey, err := numKey.ECPubKey()
+	require.NoError(t, err)
+
+	lockingScript, err := bscript.NewP2PKHFromPubKeyBytes(pubKey.SerialiseCompressed())
+	require.NoError(t, err)
+	require.NoError(t, lockingScript.AppendOpcodes(bscript.OpRETURN))
+	require.NoError(t, lockingScript.AppendPushData([]byte(memo)))
+
+	return newTestRequestForScript(t, xPriv, lockingScript, chain, num)
+}
+
+// newTestRequestForKey will create a signing request spending a P2PKH output of the public key
+func newTestRequestForKey(t *testing.T, xPriv *bip32.ExtendedKey, pubKey *bec.PublicKey, chain, num uint32) *Request {
+	lockingScript, err := bscript.NewP2PKHFromPubKeyBytes(pubKey.SerialiseCompressed())
+	require.NoError(t, err)
+
+	return newTestRequestForScript(t, xPriv, lockingScript, chain, num)
+}
+
+// newTestRequestForScript will create a signing request spending an output with the locking script
+func newTestRequestForScript(t *testing.T, xPriv *bip32.ExtendedKey, lockingScript *bscript.Script, chain, num uint32) *Request {
+	tx := bt.NewTx()
+	require.NoError(t, tx.From(testTxID, 0, lockingScript.String(), testAmount))
+	require.NoError(t, tx.PayToAddress(testTo, testAmount-100))
+
+	xPub, err := xPriv.Neuter()
+	require.NoError(t, err)
+
+	return &Request{
+		Version: RequestVersion,
+		DraftID: "draft-id",
+		XpubID:  utils.Hash(xPub.String()),
+		EF:      hex.EncodeToString(tx.ExtendedBytes()),
+		Inputs: []*Input{{
+			Index:            0,
+			TxID:             testTxID,
+			OutputIndex:      0,
+			Satoshis:         testAmount,
+			LockingScript:    lockingScript.String(),
+			ScriptType:       utils.ScriptTypePubKeyHash,
+			DerivationMethod: DerivationBIP32,
+			Chain:            chain,
+			Num:              num,
+		}},
+	}
+}
@@ -91,7 +91,8 @@ func CreateTestSQLiteClient(t *testing.T, debug, shared bool, clientOpts ...Clie
 
 	// Set the default options, add migrate models
 	opts := DefaultClientOpts(debug, shared)
-	opts = append(opts, WithAutoMigrate(append(BaseModels, newPaymail("", 0))...))
+	opts = append(opts, WithAutoMigrate(append(BaseModels, newPaymail("", 0), &P2PReference{Model: *NewBaseModel(ModelP2PReference)},
+		&ContactBlock{Model: *NewBaseModel(ModelContactBlock)})...))
 	opts = append(opts, WithLogger(&logger))
 	opts = append(opts, clientOpts...)
 
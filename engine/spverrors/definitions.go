@@ -129,6 +129,48 @@ var ErrGettingPKIFailed = models.SPVError{Message: "getting PKI for contact fail
 // ErrSaveContact is when saving new contact failed
 var ErrSaveContact = models.SPVError{Message: "adding contact failed", StatusCode: 400, Code: "error-contact-adding-contact-failed"}
 
+// ErrContactVerificationFailed is when the verification code of the contact is not valid
+var ErrContactVerificationFailed = models.SPVError{Message: "contact verification code is not valid", StatusCode: 400, Code: "error-contact-verification-failed"}
+
+// ErrContactVerificationLocked is when the verification of the contact is locked after too many failed attempts
+var ErrContactVerificationLocked = models.SPVError{Message: "too many failed verification attempts, try again later", StatusCode: 429, Code: "error-contact-verification-locked"}
+
+// ErrMissingContactPasscode is when the verification code is missing
+var ErrMissingContactPasscode = models.SPVError{Message: "missing contact verification code", StatusCode: 400, Code: "error-contact-passcode-missing"}
+
+// ErrContactVerificationUnavailable is when the codes can't be generated or validated without the PKI keys of the paymails
+var ErrContactVerificationUnavailable = models.SPVError{Message: "contact verification requires the paymail key provider", StatusCode: 501, Code: "error-contact-verification-unavailable"}
+
+// ErrContactConfirmationDeprecated is when the contact is confirmed via the deprecated endpoint (without the verification code) while the codes are available
+var ErrContactConfirmationDeprecated = models.SPVError{Message: "confirmation without a verification code is not supported with the paymail key provider, use (POST) /api/v1/contacts/{paymail}/confirmation", StatusCode: 410, Code: "error-contact-confirmation-deprecated"}
+
+// ErrInvalidContactBlock is when the blocked paymail (or domain) or the action of the block is not valid
+var ErrInvalidContactBlock = models.SPVError{Message: "invalid paymail or domain to block", StatusCode: 400, Code: "error-contact-block-invalid"}
+
+// ErrContactBlockNotFound is when the paymail (or domain) is not blocked
+var ErrContactBlockNotFound = models.SPVError{Message: "contact block not found", StatusCode: 404, Code: "error-contact-block-not-found"}
+
+// ErrContactRequesterBlocked is when the contact invitation comes from a blocked paymail (or domain)
+var ErrContactRequesterBlocked = models.SPVError{Message: "contact invitations from this paymail are blocked", StatusCode: 403, Code: "error-contact-requester-blocked"}
+
+// ErrContactInvitationRateLimited is when too many contact invitations came from the domain of the requester
+var ErrContactInvitationRateLimited = models.SPVError{Message: "too many contact invitations from this domain, try again later", StatusCode: 429, Code: "error-contact-invitation-rate-limited"}
+
+// ErrInvalidContactGroup is when the group (tag) of the contact is empty or too long
+var ErrInvalidContactGroup = models.SPVError{Message: "invalid contact group", StatusCode: 400, Code: "error-contact-group-invalid"}
+
+// ErrUnsupportedContactsFormat is when the format of the imported (or exported) contacts is not supported
+var ErrUnsupportedContactsFormat = models.SPVError{Message: "unsupported contacts format, expected csv or vcard", StatusCode: 400, Code: "error-contacts-format-unsupported"}
+
+// ErrInvalidContactsFile is when the imported contacts cannot be parsed
+var ErrInvalidContactsFile = models.SPVError{Message: "invalid contacts file", StatusCode: 400, Code: "error-contacts-file-invalid"}
+
+// ErrTooManyContactsToImport is when the imported file contains more contacts than allowed in a single import
+var ErrTooManyContactsToImport = models.SPVError{Message: "too many contacts to import", StatusCode: 400, Code: "error-contacts-import-too-large"}
+
+// ErrInvalidContactVerificationOptions is when the options of the contact verification are not valid
+var ErrInvalidContactVerificationOptions = models.SPVError{Message: "invalid contact verification options", StatusCode: 500, Code: "error-contact-verification-options-invalid"}
+
 // ////////////////////////////////// PAYMAIL ERRORS
 
 // ErrCouldNotFindPaymail is when paymail could not be found
@@ -155,6 +197,117 @@ var ErrMissingPaymailXPubID = models.SPVError{Message: "missing xpub_id in payma
 // ErrPaymailAlreadyExists is when paymail with given data already exists in db
 var ErrPaymailAlreadyExists = models.SPVError{Message: "paymail already exists", StatusCode: 409, Code: "error-paymail-already-exists"}
 
+// ErrPaymailKeyProviderMissing is when the paymail key provider is not configured, so the PKI key can't be used for signing
+var ErrPaymailKeyProviderMissing = models.SPVError{Message: "paymail key provider is not configured", StatusCode: 500, Code: "error-paymail-key-provider-missing"}
+
+// ErrPaymailKeyNotFound is when the paymail key provider has no xPriv for the xPub of the paymail
+var ErrPaymailKeyNotFound = models.SPVError{Message: "paymail key provider has no key for the xpub", StatusCode: 500, Code: "error-paymail-key-not-found"}
+
+// ErrPaymailKeyMismatch is when the key derived from the provided xPriv is not the PKI key of the paymail
+var ErrPaymailKeyMismatch = models.SPVError{Message: "derived key does not match the PKI key of the paymail", StatusCode: 500, Code: "error-paymail-key-mismatch"}
+
+// ErrInvalidPaymailSignature is when the signature is not made by the PKI key of the paymail
+var ErrInvalidPaymailSignature = models.SPVError{Message: "invalid signature of the paymail", StatusCode: 400, Code: "error-paymail-signature-invalid"}
+
+// ErrMissingResolutionSignature is when the receiver requires sender validation, but its address resolution response is not signed
+var ErrMissingResolutionSignature = models.SPVError{Message: "address resolution response is not signed", StatusCode: 400, Code: "error-paymail-resolution-signature-missing"}
+
+// ErrSenderValidationNotPossible is when the receiver requires sender validation, but the sender request can't be signed
+var ErrSenderValidationNotPossible = models.SPVError{Message: "receiver requires sender validation, but the sender paymail can't sign the request", StatusCode: 400, Code: "error-paymail-sender-validation-not-possible"}
+
+// ErrInvalidOutputSplit is when the output split options of the received payments are invalid
+var ErrInvalidOutputSplit = models.SPVError{Message: "invalid output split options", StatusCode: 500, Code: "error-paymail-output-split-invalid"}
+
+// ErrPaymailDomainNotFound is when the paymail domain is not managed by the admin API
+var ErrPaymailDomainNotFound = models.SPVError{Message: "paymail domain not found", StatusCode: 404, Code: "error-paymail-domain-not-found"}
+
+// ErrPaymailDomainAlreadyExists is when the paymail domain already exists in db
+var ErrPaymailDomainAlreadyExists = models.SPVError{Message: "paymail domain already exists", StatusCode: 409, Code: "error-paymail-domain-already-exists"}
+
+// ErrPaymailDomainInvalid is when the paymail domain name is not a valid domain
+var ErrPaymailDomainInvalid = models.SPVError{Message: "paymail domain is invalid", StatusCode: 400, Code: "error-paymail-domain-invalid"}
+
+// ErrSenderValidationRequired is when the paymail domain requires sender validation, but the sender request is not signed
+var ErrSenderValidationRequired = models.SPVError{Message: "paymail domain requires sender validation", StatusCode: 400, Code: "error-paymail-domain-sender-validation-required"}
+
+// ErrPaymailDomainBeefDisabled is when BEEF transaction is received for the paymail domain with BEEF disabled
+var ErrPaymailDomainBeefDisabled = models.SPVError{Message: "BEEF is disabled for the paymail domain", StatusCode: 400, Code: "error-paymail-domain-beef-disabled"}
+
+// ErrPaymailDomainPikeDisabled is when PIKE request is received for the paymail domain with PIKE disabled
+var ErrPaymailDomainPikeDisabled = models.SPVError{Message: "PIKE is disabled for the paymail domain", StatusCode: 400, Code: "error-paymail-domain-pike-disabled"}
+
+// ErrPaymailAliasTooShort is when the paymail alias is shorter than the alias policy allows
+var ErrPaymailAliasTooShort = models.SPVError{Message: "paymail alias is too short", StatusCode: 400, Code: "error-paymail-alias-too-short"}
+
+// ErrPaymailAliasTooLong is when the paymail alias is longer than the alias policy allows
+var ErrPaymailAliasTooLong = models.SPVError{Message: "paymail alias is too long", StatusCode: 400, Code: "error-paymail-alias-too-long"}
+
+// ErrPaymailAliasReserved is when the paymail alias (or public name) is a reserved name or looks like one
+var ErrPaymailAliasReserved = models.SPVError{Message: "paymail alias is reserved", StatusCode: 400, Code: "error-paymail-alias-reserved"}
+
+// ErrPaymailAliasNotAllowed is when the paymail alias doesn't match any of the allowed patterns of the alias policy
+var ErrPaymailAliasNotAllowed = models.SPVError{Message: "paymail alias does not match the allowed patterns", StatusCode: 400, Code: "error-paymail-alias-not-allowed"}
+
+// ErrPaymailAliasDenied is when the paymail alias matches a denied pattern of the alias policy
+var ErrPaymailAliasDenied = models.SPVError{Message: "paymail alias is not permitted", StatusCode: 400, Code: "error-paymail-alias-denied"}
+
+// ErrPaymailAliasConfusable is when the paymail alias contains characters which look like other characters (e.g. unicode lookalikes)
+var ErrPaymailAliasConfusable = models.SPVError{Message: "paymail alias contains confusable characters", StatusCode: 400, Code: "error-paymail-alias-confusable"}
+
+// ErrMaxPaymailsPerXpubReached is when the xPub already has the maximum number of paymails allowed by the alias policy
+var ErrMaxPaymailsPerXpubReached = models.SPVError{Message: "maximum number of paymails for the xPub reached", StatusCode: 400, Code: "error-paymail-max-per-xpub-reached"}
+
+// ErrInvalidAliasPolicy is when the paymail alias policy is misconfigured (e.g. invalid regex pattern)
+var ErrInvalidAliasPolicy = models.SPVError{Message: "invalid paymail alias policy", StatusCode: 500, Code: "error-paymail-alias-policy-invalid"}
+
+// ErrReceiveAmountTooLow is when the P2P payment is below the minimum accepted by the receive policy of the paymail
+var ErrReceiveAmountTooLow = models.SPVError{Message: "amount is below the minimum accepted by the paymail", StatusCode: 400, Code: "error-paymail-receive-amount-too-low"}
+
+// ErrReceiveAmountTooHigh is when the P2P payment is above the maximum accepted by the receive policy of the paymail
+var ErrReceiveAmountTooHigh = models.SPVError{Message: "amount is above the maximum accepted by the paymail", StatusCode: 400, Code: "error-paymail-receive-amount-too-high"}
+
+// ErrReceiveQuotaExceeded is when the daily quota of the P2P destinations of the paymail is exceeded by the sender
+var ErrReceiveQuotaExceeded = models.SPVError{Message: "daily quota of payment destinations exceeded", StatusCode: 429, Code: "error-paymail-receive-quota-exceeded"}
+
+// ErrReceiveSenderDenied is when the sender of the P2P payment is on the deny list of the paymail
+var ErrReceiveSenderDenied = models.SPVError{Message: "sender is not accepted by the paymail", StatusCode: 403, Code: "error-paymail-receive-sender-denied"}
+
+// ErrReceiveSenderNotAllowed is when the sender of the P2P payment is not on the allow list of the paymail
+var ErrReceiveSenderNotAllowed = models.SPVError{Message: "sender is not on the allow list of the paymail", StatusCode: 403, Code: "error-paymail-receive-sender-not-allowed"}
+
+// ErrReceiveSenderNotVerified is when the sender of the P2P payment is not verified (the transaction is not signed with the PKI of the sender)
+var ErrReceiveSenderNotVerified = models.SPVError{Message: "sender of the payment is not verified", StatusCode: 403, Code: "error-paymail-receive-sender-not-verified"}
+
+// ErrReceiveSenderNotContact is when the paymail accepts payments only from its contacts
+var ErrReceiveSenderNotContact = models.SPVError{Message: "paymail accepts payments only from its contacts", StatusCode: 403, Code: "error-paymail-receive-sender-not-contact"}
+
+// ErrInvalidReceivePolicy is when the receive policy of the paymail is invalid
+var ErrInvalidReceivePolicy = models.SPVError{Message: "invalid receive policy", StatusCode: 400, Code: "error-paymail-receive-policy-invalid"}
+
+// ErrInvalidPikeOutputTemplate is when the PIKE output template of the paymail is invalid
+var ErrInvalidPikeOutputTemplate = models.SPVError{Message: "invalid PIKE output template", StatusCode: 400, Code: "error-paymail-pike-output-template-invalid"}
+
+// ErrP2PReferenceExpired is when the P2P transaction arrives for the expired reference of the payment destinations
+var ErrP2PReferenceExpired = models.SPVError{Message: "payment destination reference expired", StatusCode: 400, Code: "error-paymail-p2p-reference-expired"}
+
+// ErrPaymailDomainBlocked is when the counterpart paymail domain is on the blocklist of the outbound paymail requests
+var ErrPaymailDomainBlocked = models.SPVError{Message: "paymail domain is blocked", StatusCode: 403, Code: "error-paymail-domain-blocked"}
+
+// ErrPaymailRequestTimeout is when the request to the counterpart paymail provider timed out
+var ErrPaymailRequestTimeout = models.SPVError{Message: "paymail request timed out", StatusCode: 504, Code: "error-paymail-request-timeout"}
+
+// ErrGetPaymailPki is when getting the PKI of the counterpart paymail failed (the failure is cached)
+var ErrGetPaymailPki = models.SPVError{Message: "failed to get paymail PKI", StatusCode: 400, Code: "error-paymail-pki-failed-to-get"}
+
+// ErrInvalidPaymailClientPolicy is when the policy of the outbound paymail requests is invalid
+var ErrInvalidPaymailClientPolicy = models.SPVError{Message: "invalid paymail client policy", StatusCode: 500, Code: "error-paymail-client-policy-invalid"}
+
+// ErrPikeOutputsInvalid is when the PIKE output templates of the receiver are empty or don't match the amount
+var ErrPikeOutputsInvalid = models.SPVError{Message: "invalid PIKE outputs of the receiver", StatusCode: 400, Code: "error-paymail-pike-outputs-invalid"}
+
+// ErrContactPubKeyChanged is when the PKI key of the confirmed contact differs from the key published by its paymail
+var ErrContactPubKeyChanged = models.SPVError{Message: "PKI key of the contact has changed, the contact has to be confirmed again", StatusCode: 409, Code: "error-contact-pub-key-changed"}
+
 // ////////////////////////////////// CAPABILITIES ERRORS
 
 // ErrCapabilitiesPkiUnsupported is when PKI is not supported for given paymail domain
@@ -163,6 +316,9 @@ var ErrCapabilitiesPkiUnsupported = models.SPVError{Message: "server doesn't sup
 // ErrCapabilitiesPikeUnsupported is when PIKE is not supported for given paymail domain
 var ErrCapabilitiesPikeUnsupported = models.SPVError{Message: "server doesn't support PIKE", StatusCode: 400, Code: "error-capabilities-pike-unsupported"}
 
+// ErrCapabilitiesPublicProfileUnsupported is when public profile is not supported for given paymail domain
+var ErrCapabilitiesPublicProfileUnsupported = models.SPVError{Message: "server doesn't support public profile", StatusCode: 400, Code: "error-capabilities-public-profile-unsupported"}
+
 // ErrGetCapabilities is when getting capabilities failed
 var ErrGetCapabilities = models.SPVError{Message: "failed to get paymail capabilities", StatusCode: 400, Code: "error-capabilities-failed-to-get"}
 
@@ -228,12 +384,63 @@ var ErrTransactionRejectedByP2PProvider = models.SPVError{Message: "transaction
 // ErrDraftTxHasNoOutputs is when draft transaction has no outputs
 var ErrDraftTxHasNoOutputs = models.SPVError{Message: "corresponding draft transaction has no outputs", StatusCode: 400, Code: "error-transaction-draft-has-no-outputs"}
 
+// ErrSequenceForUnknownInput is when a sequence number is set for an utxo which is not selected with from_utxos or include_utxos
+var ErrSequenceForUnknownInput = models.SPVError{Message: "sequence number is set for an utxo which is not in from_utxos or include_utxos", StatusCode: 400, Code: "error-transaction-sequence-unknown-input"}
+
+// ErrSequenceWithoutLockTime is when a non-final sequence number is set without a lock time
+var ErrSequenceWithoutLockTime = models.SPVError{Message: "non-final sequence number requires a lock time", StatusCode: 400, Code: "error-transaction-sequence-without-lock-time"}
+
+// ErrLockTimeWithFinalSequences is when a lock time is set, but all the sequence numbers are final (the lock time would be ignored)
+var ErrLockTimeWithFinalSequences = models.SPVError{Message: "lock time requires at least one non-final sequence number", StatusCode: 400, Code: "error-transaction-lock-time-final-sequences"}
+
+// ErrLockTimeWithP2POutputs is when a lock time is set for a transaction sent to a paymail via P2P (the receiver can't broadcast it)
+var ErrLockTimeWithP2POutputs = models.SPVError{Message: "lock time is not supported for paymail P2P outputs", StatusCode: 400, Code: "error-transaction-lock-time-p2p-outputs"}
+
+// ErrTransactionNotFinal is when the lock time of the transaction has not been reached yet
+var ErrTransactionNotFinal = models.SPVError{Message: "transaction is not final yet, lock time has not been reached", StatusCode: 400, Code: "error-transaction-not-final"}
+
 // ErrProcessP2PTx is when error occurred during processing p2p tx
 var ErrProcessP2PTx = models.SPVError{Message: "error during processing p2p transaction", StatusCode: 500, Code: "error-transaction-process-p2p"}
 
 // ErrInvalidHex is when cannot create tx from hex
 var ErrInvalidHex = models.SPVError{Message: "invalid hex", StatusCode: 400, Code: "error-transaction-hex-invalid"}
 
+// ErrInvalidBeef is when cannot decode the transaction from BEEF (or Atomic BEEF)
+var ErrInvalidBeef = models.SPVError{Message: "invalid BEEF", StatusCode: 400, Code: "error-transaction-beef-invalid"}
+
+// ErrSPVNoInputs is when the verified transaction has no inputs
+var ErrSPVNoInputs = models.SPVError{Message: "transaction has no inputs", StatusCode: 400, Code: "error-spv-transaction-inputs-missing"}
+
+// ErrSPVNoOutputs is when the verified transaction has no outputs
+var ErrSPVNoOutputs = models.SPVError{Message: "transaction has no outputs", StatusCode: 400, Code: "error-spv-transaction-outputs-missing"}
+
+// ErrSPVMissingParent is when the output spent by the input of the unmined transaction is not given (in BEEF or EF)
+var ErrSPVMissingParent = models.SPVError{Message: "output spent by the input is missing", StatusCode: 400, Code: "error-spv-parent-output-missing"}
+
+// ErrSPVInvalidScript is when the unlocking script of the input does not unlock the spent output
+var ErrSPVInvalidScript = models.SPVError{Message: "script of the input is invalid", StatusCode: 400, Code: "error-spv-script-invalid"}
+
+// ErrSPVOutputValueTooHigh is when the transaction spends more satoshis than its inputs hold
+var ErrSPVOutputValueTooHigh = models.SPVError{Message: "value of the outputs is higher than value of the inputs", StatusCode: 400, Code: "error-spv-output-value-too-high"}
+
+// ErrSPVFeeTooLow is when the fee of the unmined transaction is lower than required by the fee policy
+var ErrSPVFeeTooLow = models.SPVError{Message: "fee of the transaction is too low", StatusCode: 400, Code: "error-spv-fee-too-low"}
+
+// ErrSPVAncestryTooDeep is when BEEF has more generations of the unmined ancestors than allowed
+var ErrSPVAncestryTooDeep = models.SPVError{Message: "too many unmined ancestors of the transaction", StatusCode: 400, Code: "error-spv-ancestry-too-deep"}
+
+// ErrSPVInvalidBump is when the BUMP of the transaction does not contain the transaction
+var ErrSPVInvalidBump = models.SPVError{Message: "BUMP does not prove the transaction", StatusCode: 400, Code: "error-spv-bump-invalid"}
+
+// ErrSPVInvalidMerkleRoots is when the merkle roots of the BUMPs are not confirmed by the Block Headers Service
+var ErrSPVInvalidMerkleRoots = models.SPVError{Message: "merkle roots of the BUMPs are not confirmed", StatusCode: 400, Code: "error-spv-merkle-roots-invalid"}
+
+// ErrTransactionExportFormat is when the transaction is requested in an unsupported format
+var ErrTransactionExportFormat = models.SPVError{Message: "unsupported export format, must be beef, ef or raw (atomic only with beef)", StatusCode: 400, Code: "error-transaction-export-format-invalid"}
+
+// ErrTransactionExport is when the BEEF or EF of the transaction cannot be built (e.g. its ancestors are missing)
+var ErrTransactionExport = models.SPVError{Message: "transaction cannot be exported in the requested format", StatusCode: 500, Code: "error-transaction-export-failed"}
+
 // ErrEmptyRelatedDraftID is when related draft id is empty
 var ErrEmptyRelatedDraftID = models.SPVError{Message: "empty RelatedDraftID", StatusCode: 400, Code: "error-transaction-related-draft-id-empty"}
 
@@ -255,6 +462,81 @@ var ErrTxRevertNotFoundOnChain = models.SPVError{Message: "transaction was found
 // ErrTxRevertUtxoAlreadySpent is when utxo from tx was already spent
 var ErrTxRevertUtxoAlreadySpent = models.SPVError{Message: "utxo of this transaction has been spent, cannot revert", StatusCode: 400, Code: "error-transaction-revert-utxo-already-spent"}
 
+// ////////////////////////////////// MULTISIG ERRORS
+
+// ErrCouldNotFindMultisigAccount is when multisig account could not be found
+var ErrCouldNotFindMultisigAccount = models.SPVError{Message: "multisig account not found", StatusCode: 404, Code: "error-multisig-account-not-found"}
+
+// ErrMultisigAccountAlreadyExists is when multisig account with the same keys and threshold already exists
+var ErrMultisigAccountAlreadyExists = models.SPVError{Message: "multisig account already exists", StatusCode: 409, Code: "error-multisig-account-already-exists"}
+
+// ErrMultisigInvalidThreshold is when the threshold of multisig account is not between 1 and the number of keys
+var ErrMultisigInvalidThreshold = models.SPVError{Message: "multisig threshold must be between 1 and the number of keys", StatusCode: 400, Code: "error-multisig-threshold-invalid"}
+
+// ErrMultisigInvalidNumberOfKeys is when multisig account has too few or too many keys
+var ErrMultisigInvalidNumberOfKeys = models.SPVError{Message: "multisig account requires from 2 to 16 distinct keys", StatusCode: 400, Code: "error-multisig-keys-number-invalid"}
+
+// ErrMultisigNotAMember is when xPub is not a member of the multisig account
+var ErrMultisigNotAMember = models.SPVError{Message: "xpub is not a member of the multisig account", StatusCode: 403, Code: "error-multisig-not-a-member"}
+
+// ErrMultisigDraftNotMultisig is when signatures are added to a draft which does not spend multisig outputs
+var ErrMultisigDraftNotMultisig = models.SPVError{Message: "draft transaction does not belong to a multisig account", StatusCode: 400, Code: "error-multisig-draft-not-multisig"}
+
+// ErrMultisigDraftIncorrectStatus is when signatures are added to a draft which is not in draft status anymore
+var ErrMultisigDraftIncorrectStatus = models.SPVError{Message: "draft transaction is not accepting signatures", StatusCode: 400, Code: "error-multisig-draft-status-incorrect"}
+
+// ErrMultisigInvalidInputIndex is when signature refers to an input which does not exist
+var ErrMultisigInvalidInputIndex = models.SPVError{Message: "invalid input index in multisig signature", StatusCode: 400, Code: "error-multisig-input-index-invalid"}
+
+// ErrMultisigInvalidSignature is when signature cannot be parsed or does not match the input
+var ErrMultisigInvalidSignature = models.SPVError{Message: "invalid multisig signature", StatusCode: 400, Code: "error-multisig-signature-invalid"}
+
+// ErrMultisigThresholdNotMet is when the transaction is requested before enough signatures are collected
+var ErrMultisigThresholdNotMet = models.SPVError{Message: "not enough signatures collected to complete the transaction", StatusCode: 400, Code: "error-multisig-threshold-not-met"}
+
+// ////////////////////////////////// OFFLINE SIGNING ERRORS
+
+// ErrSigningRequestUnsupportedVersion is when the version of the signing request is not supported
+var ErrSigningRequestUnsupportedVersion = models.SPVError{Message: "unsupported signing request version", StatusCode: 400, Code: "error-signing-request-unsupported-version"}
+
+// ErrSigningRequestInvalid is when the signing request is malformed or its inputs don't match the transaction
+var ErrSigningRequestInvalid = models.SPVError{Message: "signing request is invalid", StatusCode: 400, Code: "error-signing-request-invalid"}
+
+// ErrSigningRequestWrongKey is when the key used to sign doesn't belong to the xpub of the signing request
+var ErrSigningRequestWrongKey = models.SPVError{Message: "key does not match the xpub of the signing request", StatusCode: 400, Code: "error-signing-request-wrong-key"}
+
+// ErrSigningRequestUnsupportedInput is when an input of the signing request can't be signed offline
+var ErrSigningRequestUnsupportedInput = models.SPVError{Message: "input of the signing request cannot be signed offline", StatusCode: 400, Code: "error-signing-request-unsupported-input"}
+
+// ErrSigningRequestDraftIncorrectStatus is when the signing request is exported or imported for a draft which is not awaiting signatures
+var ErrSigningRequestDraftIncorrectStatus = models.SPVError{Message: "draft transaction is not awaiting signatures", StatusCode: 400, Code: "error-signing-request-draft-incorrect-status"}
+
+// ErrSignedTxDoesNotMatchDraft is when the signed transaction is not the transaction of the draft
+var ErrSignedTxDoesNotMatchDraft = models.SPVError{Message: "signed transaction does not match the draft transaction", StatusCode: 400, Code: "error-signed-tx-does-not-match-draft"}
+
+// ErrSignedTxInvalidSignature is when an input of the signed transaction is not correctly signed
+var ErrSignedTxInvalidSignature = models.SPVError{Message: "signed transaction has an invalid signature", StatusCode: 400, Code: "error-signed-tx-invalid-signature"}
+
+// ////////////////////////////////// SCHEDULED PAYMENT ERRORS
+
+// ErrCouldNotFindScheduledPayment is when scheduled payment could not be found
+var ErrCouldNotFindScheduledPayment = models.SPVError{Message: "scheduled payment not found", StatusCode: 404, Code: "error-scheduled-payment-not-found"}
+
+// ErrScheduledPaymentMissingRecipient is when scheduled payment has no recipient
+var ErrScheduledPaymentMissingRecipient = models.SPVError{Message: "missing recipient in scheduled payment", StatusCode: 400, Code: "error-scheduled-payment-recipient-missing"}
+
+// ErrScheduledPaymentInvalidCron is when scheduled payment cron expression cannot be parsed
+var ErrScheduledPaymentInvalidCron = models.SPVError{Message: "invalid cron expression in scheduled payment", StatusCode: 400, Code: "error-scheduled-payment-cron-invalid"}
+
+// ErrScheduledPaymentInvalidInterval is when scheduled payment has neither cron expression nor a valid interval
+var ErrScheduledPaymentInvalidInterval = models.SPVError{Message: "scheduled payment requires a cron expression or an interval of at least one minute", StatusCode: 400, Code: "error-scheduled-payment-interval-invalid"}
+
+// ErrScheduledPaymentEndBeforeStart is when scheduled payment ends before its first run
+var ErrScheduledPaymentEndBeforeStart = models.SPVError{Message: "scheduled payment end date is before its start", StatusCode: 400, Code: "error-scheduled-payment-end-before-start"}
+
+// ErrScheduledPaymentIncorrectStatus is when scheduled payment is in incorrect status to make a change
+var ErrScheduledPaymentIncorrectStatus = models.SPVError{Message: "scheduled payment is in incorrect status to proceed", StatusCode: 400, Code: "error-scheduled-payment-status-incorrect"}
+
 // ////////////////////////////////// UTXO ERRORS
 
 // ErrCouldNotFindUtxo is an error when a given utxo could not be found
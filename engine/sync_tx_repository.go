@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"time"
 
 	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
@@ -96,6 +97,16 @@ func getTransactionsToBroadcast(ctx context.Context, queryParams *datastore.Quer
 			continue
 		}
 
+		final, err := _isTransactionFinal(ctx, sTx.transaction, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if !final {
+			// the lock time has not been reached yet, the tx would be rejected
+			continue
+		}
+
 		res = append(res, sTx)
 	}
 
@@ -120,6 +131,27 @@ func getTransactionsToSync(ctx context.Context, queryParams *datastore.QueryPara
 	return txs, nil
 }
 
+// getTransactionsToNotifyP2P will get the sync transactions with a failed P2P notification which is due to retry
+func getTransactionsToNotifyP2P(ctx context.Context, queryParams *datastore.QueryParams,
+	opts ...ModelOps,
+) ([]*SyncTransaction, error) {
+	// Get the records by status (the records without the next attempt are being processed by the record action)
+	txs, err := _getSyncTransactionsByConditions(
+		ctx,
+		map[string]interface{}{
+			p2pStatusField: SyncStatusReady.String(),
+			p2pNextAttemptField: map[string]interface{}{
+				"$lte": time.Now().UTC(),
+			},
+		},
+		queryParams, opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
 /*** /public unexported funcs ***/
 
 // getTransactionsToSync will get the sync transactions to sync
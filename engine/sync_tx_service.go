@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -119,6 +122,14 @@ func broadcastSyncTransaction(ctx context.Context, syncTx *SyncTransaction) erro
 		}
 	}
 
+	// Keep the transaction in the queue until its lock time is reached
+	final, err := _isTransactionFinal(ctx, tx, syncTx.GetOptions(false)...)
+	if err != nil {
+		return err
+	} else if !final {
+		return spverrors.ErrTransactionNotFinal
+	}
+
 	// Broadcast
 	txHex, hexFormat := _getTxHexInFormat(ctx, tx, chainstateSrv.SupportedBroadcastFormats(), client)
 	br := chainstateSrv.Broadcast(ctx, syncTx.ID, txHex, hexFormat, defaultBroadcastTimeout)
@@ -269,12 +280,12 @@ func processP2PTransaction(ctx context.Context, tx *Transaction) error {
 
 	syncTx := tx.syncTransaction
 	// Create the lock and set the release for after the function completes
-	unlock, err := newWriteLock(
+	unlock, lockErr := newWriteLock(
 		ctx, fmt.Sprintf(lockKeyProcessP2PTx, syncTx.GetID()), syncTx.Client().Cachestore(),
 	)
 	defer unlock()
-	if err != nil {
-		return err
+	if lockErr != nil {
+		return lockErr
 	}
 
 	// No draft?
@@ -286,20 +297,23 @@ func processP2PTransaction(ctx context.Context, tx *Transaction) error {
 	}
 
 	// Notify any P2P paymail providers associated to the transaction
-	var results []*SyncResult
-	if results, err = _notifyPaymailProviders(ctx, tx); err != nil {
-		syncTx.P2PStatus = SyncStatusReady
-		_addSyncResult(ctx, syncTx, syncActionP2P, "", err.Error())
-		return err
-	}
+	results, err := _notifyPaymailProviders(ctx, tx)
 
-	// Update if we have some results
+	// Update if we have some results (the receivers notified before a failure are not notified again)
 	if len(results) > 0 {
 		syncTx.Results.Results = append(syncTx.Results.Results, results...)
 	}
 
+	syncTx.P2PAttempts++
+	if err != nil {
+		_scheduleP2PRetry(syncTx, err)
+		_addSyncResult(ctx, syncTx, syncActionP2P, "", err.Error())
+		return err
+	}
+
 	// Save the record
 	syncTx.P2PStatus = SyncStatusComplete
+	syncTx.P2PNextAttemptAt.Valid = false
 
 	// Update sync status to be ready now
 	if syncTx.SyncStatus == SyncStatusPending {
@@ -316,13 +330,15 @@ func processP2PTransaction(ctx context.Context, tx *Transaction) error {
 	return nil
 }
 
-// _notifyPaymailProviders will notify any associated Paymail providers
+// _notifyPaymailProviders will notify any associated Paymail providers,
+// the results of the notified receivers are returned even if notifying the next receiver failed
 func _notifyPaymailProviders(ctx context.Context, transaction *Transaction) ([]*SyncResult, error) {
 	pm := transaction.Client().PaymailClient()
 	outputs := transaction.draftTransaction.Configuration.Outputs
 
-	notifiedReceivers := make([]string, 0)
-	results := make([]*SyncResult, len(outputs))
+	// Receivers notified by the previous attempts
+	notifiedReceivers := _notifiedP2PReceivers(transaction.syncTransaction)
+	results := make([]*SyncResult, 0, len(outputs))
 
 	var payload *paymail.P2PTransactionPayload
 	var err error
@@ -330,7 +346,7 @@ func _notifyPaymailProviders(ctx context.Context, transaction *Transaction) ([]*
 	for _, out := range outputs {
 		p4 := out.PaymailP4
 
-		if p4 == nil || p4.ResolutionType != ResolutionTypeP2P {
+		if !p4.isSubmitted() {
 			continue
 		}
 
@@ -345,21 +361,152 @@ func _notifyPaymailProviders(ctx context.Context, transaction *Transaction) ([]*
 			p4,
 			transaction,
 		); err != nil {
-			return nil, err
+			return results, spverrors.Wrapf(err, "failed to notify %s", receiver)
 		}
 
 		notifiedReceivers = append(notifiedReceivers, receiver)
 		results = append(results, &SyncResult{
 			Action:        syncActionP2P,
 			ExecutedAt:    time.Now().UTC(),
-			Provider:      p4.ReceiveEndpoint,
-			StatusMessage: "success: " + payload.TxID,
+			Provider:      receiver,
+			StatusMessage: p2pSuccessPrefix + payload.TxID,
 		})
 
 	}
 	return results, nil
 }
 
+// processP2PNotifications will retry the failed P2P notifications which are due,
+// returns the number of the notified transactions
+func processP2PNotifications(ctx context.Context, maxTransactions int, opts ...ModelOps) (int, error) {
+	queryParams := &datastore.QueryParams{
+		Page:          1,
+		PageSize:      maxTransactions,
+		OrderByField:  p2pNextAttemptField,
+		SortDirection: datastore.SortAsc,
+	}
+
+	syncTxs, err := getTransactionsToNotifyP2P(ctx, queryParams, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	var notified int
+	for _, syncTx := range syncTxs {
+		if err = _retryP2PNotification(ctx, syncTx); err != nil {
+			syncTx.Client().Logger().Warn().
+				Str("txID", syncTx.ID).
+				Uint32("attempts", syncTx.P2PAttempts).
+				Str("p2pStatus", syncTx.P2PStatus.String()).
+				Msgf("error running P2P notification: %s", err.Error())
+			continue
+		}
+		notified++
+	}
+
+	return notified, nil
+}
+
+// _retryP2PNotification will hydrate the transaction (with the draft holding the paymail P4 data) and notify the receivers
+func _retryP2PNotification(ctx context.Context, syncTx *SyncTransaction) error {
+	transaction, err := _getTransaction(ctx, syncTx.ID, syncTx.GetOptions(false))
+	if err != nil {
+		return err
+	}
+
+	if len(transaction.DraftID) > 0 {
+		if transaction.draftTransaction, err = getDraftTransactionID(
+			ctx, "", transaction.DraftID, syncTx.GetOptions(false)...,
+		); err != nil {
+			return err
+		} else if transaction.draftTransaction == nil {
+			transaction.DraftID = "" // the P2P notification cannot be completed
+		}
+	}
+
+	syncTx.transaction = transaction
+	transaction.syncTransaction = syncTx
+
+	if err = processP2PTransaction(ctx, transaction); err != nil && syncTx.P2PStatus == SyncStatusError {
+		_abandonP2PTransaction(ctx, transaction)
+	}
+	return err
+}
+
+// _abandonP2PTransaction will handle the transaction which cannot be delivered (rejected by the receiver or out of attempts),
+// the transaction already delivered to some receivers is broadcast, otherwise it's reverted (the inputs are released)
+func _abandonP2PTransaction(ctx context.Context, transaction *Transaction) {
+	syncTx := transaction.syncTransaction
+	logger := syncTx.Client().Logger()
+
+	if len(_notifiedP2PReceivers(syncTx)) > 0 {
+		if syncTx.BroadcastStatus == SyncStatusSkipped {
+			syncTx.BroadcastStatus = SyncStatusReady
+			if err := syncTx.Save(ctx); err != nil {
+				logger.Error().
+					Str("txID", syncTx.ID).
+					Msgf("scheduling broadcast after failed P2P notification failed. Reason: %s", err)
+			}
+		}
+		return
+	}
+
+	if err := syncTx.Client().RevertTransaction(ctx, syncTx.ID); err != nil {
+		logger.Error().
+			Str("txID", syncTx.ID).
+			Msgf("FATAL! Reverting transaction after failed P2P notification failed. Reason: %s", err)
+	}
+}
+
+// _scheduleP2PRetry will schedule the next attempt of the failed P2P notification,
+// the transactions rejected by the receiver (or out of attempts) are marked as failed
+func _scheduleP2PRetry(syncTx *SyncTransaction, err error) {
+	policy := paymailClientPolicyOf(syncTx.Client().PaymailClient())
+
+	if !isP2PDeliveryFailure(err) || syncTx.P2PAttempts >= policy.notificationAttempts() {
+		syncTx.P2PStatus = SyncStatusError
+		syncTx.P2PNextAttemptAt.Valid = false
+		return
+	}
+
+	syncTx.P2PStatus = SyncStatusReady
+	syncTx.P2PNextAttemptAt.Valid = true
+	syncTx.P2PNextAttemptAt.Time = time.Now().UTC().Add(policy.notificationBackoff(syncTx.P2PAttempts))
+}
+
+// isP2PDeliveryFailure will return true if the P2P transaction was not delivered to the receiver
+// (provider not reachable, timeout, server error or unreadable response), false if the receiver rejected the transaction
+func isP2PDeliveryFailure(err error) bool {
+	var netErr net.Error
+	var respErr *paymailResponseError
+
+	switch {
+	case errors.Is(err, spverrors.ErrPaymailRequestTimeout),
+		errors.As(err, &netErr):
+		return true
+	case errors.As(err, &respErr):
+		return respErr.StatusCode < http.StatusBadRequest || respErr.StatusCode >= http.StatusInternalServerError ||
+			respErr.StatusCode == http.StatusRequestTimeout || respErr.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
+// _notifiedP2PReceivers will return the receivers successfully notified by the previous attempts
+func _notifiedP2PReceivers(syncTx *SyncTransaction) []string {
+	receivers := make([]string, 0)
+	if syncTx == nil {
+		return receivers
+	}
+
+	for _, result := range syncTx.Results.Results {
+		if result != nil && result.Action == syncActionP2P && strings.HasPrefix(result.StatusMessage, p2pSuccessPrefix) {
+			receivers = append(receivers, result.Provider)
+		}
+	}
+	return receivers
+}
+
 // utils
 
 func _groupByXpub(scTxs []*SyncTransaction) map[string][]*SyncTransaction {
@@ -2,9 +2,15 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/url"
 	"testing"
+	"time"
 
 	broadcast_client "github.com/bitcoin-sv/go-broadcast-client/broadcast"
+	broadcast_client_mock "github.com/bitcoin-sv/go-broadcast-client/broadcast/broadcast-client-mock"
+	"github.com/bitcoin-sv/go-paymail"
 	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
 	"github.com/bitcoin-sv/spv-wallet/engine/tester"
 	"github.com/rs/zerolog"
@@ -145,3 +151,257 @@ func (mq *broadcastClientMq) SubmitTransaction(_ context.Context, _ *broadcast_c
 	r := mq.responses["SubmitTransaction"]
 	return r.r.(*broadcast_client.SubmitTxResponse), r.f
 }
+
+// p2pPaymailClient is the paymail client counting the P2P transaction submissions
+type p2pPaymailClient struct {
+	paymail.ClientInterface
+	err        error
+	statusCode int
+	calls      int
+}
+
+func (c *p2pPaymailClient) SendP2PTransaction(_, _, _ string, transaction *paymail.P2PTransaction,
+) (*paymail.P2PTransactionResponse, error) {
+	c.calls++
+	if c.err != nil {
+		if c.statusCode != 0 {
+			return &paymail.P2PTransactionResponse{StandardResponse: paymail.StandardResponse{StatusCode: c.statusCode}}, c.err
+		}
+		return nil, c.err
+	}
+	return &paymail.P2PTransactionResponse{P2PTransactionPayload: paymail.P2PTransactionPayload{
+		TxID: transaction.Reference,
+	}}, nil
+}
+
+// newTestP2PTransaction will save a transaction with the draft notifying a P2P receiver
+func newTestP2PTransaction(ctx context.Context, t *testing.T, client ClientInterface) *Transaction {
+	draft := &DraftTransaction{
+		Model: *NewBaseModel(ModelDraftTransaction, WithClient(client), New()),
+		TransactionBase: TransactionBase{
+			ID: "draft-p2p-transaction",
+		},
+		XpubID: testXPubID,
+		Configuration: TransactionConfig{
+			Outputs: []*TransactionOutput{{
+				To:       "alice@" + testDomain,
+				Satoshis: 1000,
+				PaymailP4: &PaymailP4{
+					Alias:           "alice",
+					Domain:          testDomain,
+					Format:          BasicPaymailPayloadFormat,
+					ReceiveEndpoint: "https://" + testDomain + "/receive-transaction/{alias}@{domain.tld}",
+					ReferenceID:     "reference-id",
+					ResolutionType:  ResolutionTypeP2P,
+				},
+			}},
+		},
+		Status: DraftStatusDraft,
+	}
+	require.NoError(t, draft.Save(ctx))
+
+	tx, err := txFromHex(testTx2Hex, WithXPub(testXPub), WithClient(client))
+	require.NoError(t, err)
+	tx.DraftID = draft.ID
+	tx.draftTransaction = draft
+
+	syncTx := newSyncTransaction(tx.ID, &SyncConfig{PaymailP2P: true}, WithClient(client))
+	syncTx.P2PStatus = SyncStatusReady
+	syncTx.SyncStatus = SyncStatusPending
+	syncTx.transaction = tx
+	tx.syncTransaction = syncTx
+	require.NoError(t, tx.Save(ctx))
+
+	return tx
+}
+
+func Test_processP2PNotifications(t *testing.T) {
+	unreachable := &url.Error{Op: "Post", URL: "https://" + testDomain, Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	t.Run("unreachable receiver is notified by the next attempt", func(t *testing.T) {
+		// given
+		pc := &p2pPaymailClient{err: unreachable}
+		ctx, spvengine, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(), WithPaymailClient(pc),
+			WithPaymailClientPolicy(&PaymailClientPolicy{NotificationBackoff: time.Millisecond}))
+		defer deferMe()
+
+		tx := newTestP2PTransaction(ctx, t, spvengine)
+
+		// when
+		err := processP2PTransaction(ctx, tx)
+		require.Error(t, err)
+
+		// then
+		syncTx, err := GetSyncTransactionByID(ctx, tx.ID, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusReady, syncTx.P2PStatus)
+		require.Equal(t, uint32(1), syncTx.P2PAttempts)
+		require.True(t, syncTx.P2PNextAttemptAt.Valid)
+
+		// when
+		pc.err = nil
+		time.Sleep(10 * time.Millisecond)
+		notified, err := processP2PNotifications(ctx, 10, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, 1, notified)
+
+		// then
+		syncTx, err = GetSyncTransactionByID(ctx, tx.ID, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusComplete, syncTx.P2PStatus)
+		require.Equal(t, SyncStatusReady, syncTx.SyncStatus)
+		require.Equal(t, uint32(2), syncTx.P2PAttempts)
+		require.False(t, syncTx.P2PNextAttemptAt.Valid)
+		require.Equal(t, 2, pc.calls)
+
+		delivery, err := spvengine.GetTransactionP2PDelivery(ctx, tx.ID)
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusComplete, delivery.Status)
+		require.Nil(t, delivery.NextAttemptAt)
+		require.Len(t, delivery.Results, 2)
+		require.Equal(t, "alice@"+testDomain, delivery.Results[1].Provider)
+
+		// the notified transaction is not retried
+		notified, err = processP2PNotifications(ctx, 10, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, 0, notified)
+	})
+
+	t.Run("attempt is not retried before its time", func(t *testing.T) {
+		// given
+		pc := &p2pPaymailClient{err: unreachable}
+		ctx, spvengine, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(), WithPaymailClient(pc))
+		defer deferMe()
+
+		tx := newTestP2PTransaction(ctx, t, spvengine)
+		require.Error(t, processP2PTransaction(ctx, tx))
+
+		// when
+		notified, err := processP2PNotifications(ctx, 10, WithClient(spvengine))
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, 0, notified)
+		require.Equal(t, 1, pc.calls)
+
+		delivery, err := spvengine.GetTransactionP2PDelivery(ctx, tx.ID)
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusReady, delivery.Status)
+		require.NotNil(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("rejected transaction is not retried", func(t *testing.T) {
+		// given
+		pc := &p2pPaymailClient{err: errors.New("bad response from paymail provider: code 400, message: invalid transaction"), statusCode: 400}
+		ctx, spvengine, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(), WithPaymailClient(pc))
+		defer deferMe()
+
+		tx := newTestP2PTransaction(ctx, t, spvengine)
+
+		// when
+		err := processP2PTransaction(ctx, tx)
+
+		// then
+		require.Error(t, err)
+		syncTx, err := GetSyncTransactionByID(ctx, tx.ID, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusError, syncTx.P2PStatus)
+		require.False(t, syncTx.P2PNextAttemptAt.Valid)
+	})
+
+	t.Run("transaction is not retried after the last attempt", func(t *testing.T) {
+		// given
+		pc := &p2pPaymailClient{err: unreachable}
+		ctx, spvengine, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache(), WithPaymailClient(pc),
+			WithPaymailClientPolicy(&PaymailClientPolicy{NotificationAttempts: 1}))
+		defer deferMe()
+
+		tx := newTestP2PTransaction(ctx, t, spvengine)
+
+		// when
+		err := processP2PTransaction(ctx, tx)
+
+		// then
+		require.Error(t, err)
+		syncTx, err := GetSyncTransactionByID(ctx, tx.ID, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusError, syncTx.P2PStatus)
+		require.Equal(t, uint32(1), syncTx.P2PAttempts)
+	})
+}
+
+func Test_isP2PDeliveryFailure(t *testing.T) {
+	responseError := func(statusCode int) error {
+		return spverrors.Wrapf(&paymailResponseError{StatusCode: statusCode, err: errors.New("bad response from paymail provider")}, "failed")
+	}
+
+	require.True(t, isP2PDeliveryFailure(spverrors.Wrapf(&url.Error{Op: "Post", Err: errors.New("connection refused")}, "failed")))
+	require.True(t, isP2PDeliveryFailure(spverrors.ErrPaymailRequestTimeout))
+	require.True(t, isP2PDeliveryFailure(responseError(503)))
+	require.True(t, isP2PDeliveryFailure(responseError(429)))
+	require.True(t, isP2PDeliveryFailure(responseError(200))) // unreadable response
+	require.False(t, isP2PDeliveryFailure(responseError(400)))
+	require.False(t, isP2PDeliveryFailure(responseError(404)))
+	require.False(t, isP2PDeliveryFailure(errors.New("bad response from paymail provider: code 503, message: unavailable")))
+}
+
+func Test_abandonP2PTransaction(t *testing.T) {
+	t.Run("undelivered transaction is reverted", func(t *testing.T) {
+		// given
+		bc := broadcast_client_mock.Builder().
+			WithMockArc(broadcast_client_mock.MockNilQueryTxResp).
+			Build()
+		ctx, client, transaction, _, deferMe := initRevertTransactionData(t, WithBroadcastClient(bc))
+		defer deferMe()
+
+		// when
+		_abandonP2PTransaction(ctx, transaction)
+
+		// then
+		tx, err := client.GetTransaction(ctx, testXPubID, transaction.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"reverted"}, []string(tx.XpubInIDs))
+
+		syncTx, err := GetSyncTransactionByID(ctx, transaction.ID, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusCanceled, syncTx.BroadcastStatus)
+	})
+
+	t.Run("transaction delivered to some receivers is broadcast", func(t *testing.T) {
+		// given
+		ctx, spvengine, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+		defer deferMe()
+
+		tx := newTestP2PTransaction(ctx, t, spvengine)
+		tx.syncTransaction.BroadcastStatus = SyncStatusSkipped
+		tx.syncTransaction.P2PStatus = SyncStatusError
+		tx.syncTransaction.Results.Results = append(tx.syncTransaction.Results.Results, &SyncResult{
+			Action:        syncActionP2P,
+			Provider:      "bob@" + testDomain,
+			StatusMessage: p2pSuccessPrefix + tx.ID,
+		})
+		require.NoError(t, tx.syncTransaction.Save(ctx))
+
+		// when
+		_abandonP2PTransaction(ctx, tx)
+
+		// then
+		syncTx, err := GetSyncTransactionByID(ctx, tx.ID, WithClient(spvengine))
+		require.NoError(t, err)
+		require.Equal(t, SyncStatusReady, syncTx.BroadcastStatus)
+
+		stored, err := spvengine.GetTransaction(ctx, "", tx.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, []string{"reverted"}, []string(stored.XpubInIDs))
+	})
+}
+
+func TestPaymailClientPolicy_notificationBackoff(t *testing.T) {
+	policy := &PaymailClientPolicy{NotificationBackoff: time.Minute}
+
+	require.Equal(t, time.Minute, policy.notificationBackoff(1))
+	require.Equal(t, 2*time.Minute, policy.notificationBackoff(2))
+	require.Equal(t, 8*time.Minute, policy.notificationBackoff(4))
+	require.Equal(t, maxNotificationBackoffDelay, policy.notificationBackoff(100))
+	require.Equal(t, defaultNotificationBackoff, (&PaymailClientPolicy{}).notificationBackoff(1))
+}
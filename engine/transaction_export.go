@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/mrz1836/go-cachestore"
+)
+
+// TransactionExportFormat is the format of the exported transaction
+type TransactionExportFormat string
+
+// Formats of the exported transaction
+const (
+	TransactionExportBeef TransactionExportFormat = "beef" // BEEF version 1 (or Atomic BEEF version 2)
+	TransactionExportEF   TransactionExportFormat = "ef"   // Extended Format
+	TransactionExportRaw  TransactionExportFormat = "raw"  // Raw transaction hex
+)
+
+// TransactionExport is the transaction exported for the third parties (with the proofs of its inputs for BEEF)
+type TransactionExport struct {
+	ID     string                  `json:"id"`
+	Format TransactionExportFormat `json:"format"`
+	Atomic bool                    `json:"atomic"`
+	Hex    string                  `json:"hex"`
+}
+
+// cachedTransactionBeef is the computed BEEF of the transaction,
+// valid until the BUMP of the transaction or of any of its written ancestors changes
+type cachedTransactionBeef struct {
+	Ancestors []string `json:"ancestors"` // IDs of the ancestors written into the BEEF
+	BUMPs     string   `json:"bumps"`     // Hash of the BUMPs of the transaction and its written ancestors
+	Hex       string   `json:"hex"`
+}
+
+// ExportTransaction will export the transaction as BEEF (optionally Atomic BEEF), EF or raw hex
+//
+// xPubID (optional) requires the transaction to be associated with the xPub (empty for admin)
+func (c *Client) ExportTransaction(ctx context.Context, xPubID, txID string, format TransactionExportFormat,
+	atomic bool,
+) (*TransactionExport, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "export_transaction")
+
+	if atomic && format != TransactionExportBeef {
+		return nil, spverrors.ErrTransactionExportFormat
+	}
+
+	transaction, err := c.GetTransaction(ctx, "", txID)
+	if err != nil {
+		return nil, err
+	}
+	if len(xPubID) > 0 && !transaction.IsXpubIDAssociated(xPubID) {
+		return nil, spverrors.ErrAuthorization
+	}
+
+	export := &TransactionExport{ID: transaction.ID, Format: format, Atomic: atomic}
+	switch format {
+	case TransactionExportRaw:
+		export.Hex = transaction.Hex
+	case TransactionExportEF:
+		efHex, ok := ToEfHex(ctx, transaction, c)
+		if !ok {
+			return nil, spverrors.Wrapf(spverrors.ErrTransactionExport, "cannot build EF of transaction %s", transaction.ID)
+		}
+		export.Hex = efHex
+	case TransactionExportBeef:
+		if export.Hex, err = c.transactionBeef(ctx, transaction, atomic); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, spverrors.ErrTransactionExportFormat
+	}
+	return export, nil
+}
+
+// transactionBeef will return the BEEF of the transaction from the cache
+// (computed again when the BUMP of the transaction or of any of its written ancestors changes)
+func (c *Client) transactionBeef(ctx context.Context, transaction *Transaction, atomic bool) (string, error) {
+	key := fmt.Sprintf(cacheKeyTransactionBeef, transaction.ID, atomic)
+
+	var cached cachedTransactionBeef
+	if err := c.Cachestore().GetModel(ctx, key, &cached); err != nil && !errors.Is(err, cachestore.ErrKeyNotFound) {
+		c.Logger().Warn().Str("txID", transaction.ID).Msgf("failed to get the cached BEEF: %s", err.Error())
+	} else if err == nil && len(cached.Hex) > 0 && c.isCachedBeefValid(ctx, transaction, &cached) {
+		return cached.Hex, nil
+	}
+
+	options := &BeefOptions{Version: beefVersion1}
+	if atomic {
+		options = &BeefOptions{Version: beefVersion2, Atomic: true}
+	}
+	beefHex, included, err := toBeefFromInputs(ctx, transaction, c, options)
+	if err != nil {
+		return "", spverrors.Wrapf(spverrors.ErrTransactionExport, "cannot build BEEF of transaction %s: %s", transaction.ID, err.Error())
+	}
+
+	cached = cachedTransactionBeef{BUMPs: bumpsHash(included), Hex: beefHex}
+	for _, tx := range included {
+		if tx.ID != transaction.ID {
+			cached.Ancestors = append(cached.Ancestors, tx.ID)
+		}
+	}
+	if err = c.Cachestore().SetModel(ctx, key, &cached, cacheTTLTransactionBeef); err != nil {
+		c.Logger().Warn().Str("txID", transaction.ID).Msgf("failed to cache the BEEF: %s", err.Error())
+	}
+	return beefHex, nil
+}
+
+// isCachedBeefValid will return true if the BUMPs of the transaction and its written ancestors did not change
+func (c *Client) isCachedBeefValid(ctx context.Context, transaction *Transaction, cached *cachedTransactionBeef) bool {
+	included := []*Transaction{transaction}
+	if len(cached.Ancestors) > 0 {
+		ancestors, err := c.GetTransactionsByIDs(ctx, cached.Ancestors)
+		if err != nil {
+			c.Logger().Warn().Str("txID", transaction.ID).Msgf("failed to get the ancestors of the cached BEEF: %s", err.Error())
+			return false
+		}
+		if len(ancestors) != len(cached.Ancestors) {
+			return false
+		}
+		included = append(included, ancestors...)
+	}
+	return bumpsHash(included) == cached.BUMPs
+}
+
+// bumpsHash will return the hash of the BUMPs of the transactions (independent of their order)
+func bumpsHash(transactions []*Transaction) string {
+	bumps := make([]string, 0, len(transactions))
+	for _, tx := range transactions {
+		bumps = append(bumps, tx.ID+":"+tx.BUMP.Hex())
+	}
+	sort.Strings(bumps)
+	return utils.Hash(strings.Join(bumps, ","))
+}
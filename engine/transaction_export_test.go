@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ExportTransaction(t *testing.T) {
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+	defer deferMe()
+
+	beef, err := decodeBeefHex(expectedBeefHex[1])
+	require.NoError(t, err)
+	saveVerifiedBeefAncestors(ctx, client, beef)
+
+	subject := beef.transactions[2]
+	transaction, err := txFromHex(subject.tx.String(), append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, err)
+	transaction.XpubOutIDs = []string{testXPubID}
+	require.NoError(t, transaction.Save(ctx))
+
+	t.Run("raw hex", func(t *testing.T) {
+		export, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportRaw, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, subject.txID, export.ID)
+		assert.Equal(t, subject.tx.String(), export.Hex)
+	})
+
+	t.Run("extended format", func(t *testing.T) {
+		export, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportEF, false)
+
+		require.NoError(t, err)
+		assert.True(t, isExtendedFormatHex(export.Hex))
+	})
+
+	t.Run("BEEF of the unmined transaction with its ancestors", func(t *testing.T) {
+		export, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+
+		require.NoError(t, err)
+		decoded, err := decodeBeefHex(export.Hex)
+		require.NoError(t, err)
+		assert.Equal(t, beefVersion1, decoded.version)
+		require.Len(t, decoded.transactions, 3)
+		assert.Equal(t, subject.txID, decoded.transactions[2].txID)
+	})
+
+	t.Run("atomic BEEF", func(t *testing.T) {
+		export, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, true)
+
+		require.NoError(t, err)
+		assert.True(t, export.Atomic)
+		decoded, err := decodeBeefHex(export.Hex)
+		require.NoError(t, err)
+		assert.Equal(t, subject.txID, decoded.subjectTxID)
+	})
+
+	t.Run("admin exports any transaction", func(t *testing.T) {
+		export, err := client.ExportTransaction(ctx, "", subject.txID, TransactionExportRaw, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, subject.tx.String(), export.Hex)
+	})
+
+	t.Run("ancestors are missing", func(t *testing.T) {
+		_, err := client.ExportTransaction(ctx, "", beef.transactions[0].txID, TransactionExportBeef, false)
+
+		require.ErrorIs(t, err, spverrors.ErrTransactionExport)
+	})
+
+	t.Run("transaction of another xPub", func(t *testing.T) {
+		_, err := client.ExportTransaction(ctx, testXPubID, beef.transactions[0].txID, TransactionExportRaw, false)
+
+		require.ErrorIs(t, err, spverrors.ErrAuthorization)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportFormat("json"), false)
+		require.ErrorIs(t, err, spverrors.ErrTransactionExportFormat)
+
+		_, err = client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportEF, true)
+		require.ErrorIs(t, err, spverrors.ErrTransactionExportFormat)
+	})
+
+	// cacheBeef will replace the cached BEEF of the subject (keeping the cached state of the BUMPs)
+	cacheBeef := func(t *testing.T, beefHex string) {
+		key := "transaction-beef-" + subject.txID + "-false"
+		var cached cachedTransactionBeef
+		require.NoError(t, client.Cachestore().GetModel(ctx, key, &cached))
+		cached.Hex = beefHex
+		require.NoError(t, client.Cachestore().SetModel(ctx, key, &cached, cacheTTLTransactionBeef))
+	}
+
+	t.Run("cached BEEF is computed again when the BUMP of an ancestor changes", func(t *testing.T) {
+		before, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+		require.NoError(t, err)
+
+		cacheBeef(t, "cached")
+		cached, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", cached.Hex)
+
+		// when the ancestor is mined in another block
+		ancestor, err := client.GetTransaction(ctx, "", beef.transactions[1].txID)
+		require.NoError(t, err)
+		ancestorBUMP := ancestor.BUMP
+		ancestor.BlockHeight = beef.bumps[len(beef.bumps)-1].BlockHeight + 1
+		ancestor.BUMP = BUMP{BlockHeight: ancestor.BlockHeight, Path: [][]BUMPLeaf{{
+			{Offset: 0, Hash: ancestor.ID, TxID: true},
+			{Offset: 1, Hash: beef.transactions[0].txID},
+		}}}
+		require.NoError(t, ancestor.Save(ctx))
+
+		after, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, "cached", after.Hex)
+		assert.NotEqual(t, before.Hex, after.Hex)
+
+		// restore the ancestor for the other tests
+		ancestor.BlockHeight = ancestorBUMP.BlockHeight
+		ancestor.BUMP = ancestorBUMP
+		require.NoError(t, ancestor.Save(ctx))
+	})
+
+	t.Run("cached BEEF is computed again when the BUMP changes", func(t *testing.T) {
+		before, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+		require.NoError(t, err)
+
+		// the cached BEEF is returned until the BUMPs change
+		cacheBeef(t, "cached")
+		cached, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", cached.Hex)
+
+		// when the transaction is mined
+		mined, err := client.GetTransaction(ctx, "", subject.txID)
+		require.NoError(t, err)
+		mined.BlockHeight = beef.bumps[len(beef.bumps)-1].BlockHeight + 1
+		mined.BUMP = BUMP{BlockHeight: mined.BlockHeight, Path: [][]BUMPLeaf{{
+			{Offset: 0, Hash: subject.txID, TxID: true},
+			{Offset: 1, Hash: beef.transactions[0].txID},
+		}}}
+		require.NoError(t, mined.Save(ctx))
+
+		after, err := client.ExportTransaction(ctx, testXPubID, subject.txID, TransactionExportBeef, false)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, before.Hex, after.Hex)
+		decoded, err := decodeBeefHex(after.Hex)
+		require.NoError(t, err)
+		require.Len(t, decoded.transactions, 3)
+		assert.NotEqual(t, -1, decoded.transactions[2].bumpIndex)
+	})
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+)
+
+// ContactTransactions are the transactions exchanged with the contact
+type ContactTransactions struct {
+	Contact       *Contact       // Contact (the counterparty of the transactions)
+	Transactions  []*Transaction // Page of the transactions exchanged with the contact
+	Count         int64          // Number of all the transactions exchanged with the contact
+	TotalSent     uint64         // Satoshis sent to the contact (the outputs to the other receivers and the fees excluded)
+	TotalReceived uint64         // Satoshis received from the contact
+}
+
+// GetContactTransactions will get the transactions exchanged with the contact and the totals sent and received
+func (c *Client) GetContactTransactions(ctx context.Context, xPubID, paymailAddress string,
+	queryParams *datastore.QueryParams,
+) (*ContactTransactions, error) {
+	// Check for existing NewRelic transaction
+	ctx = c.GetOrStartTxn(ctx, "get_contact_transactions")
+
+	contact, err := getContact(ctx, paymailAddress, xPubID, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if contact == nil {
+		return nil, spverrors.ErrContactNotFound
+	}
+
+	conditions := map[string]interface{}{
+		xPubContactIDsField: XpubContactIDs{xPubID: contact.ID},
+	}
+
+	// The totals are computed from all the transactions (not only the requested page)
+	result := &ContactTransactions{Contact: contact}
+	if result.Count, err = getTransactionsCountByXpubID(
+		ctx, xPubID, nil, conditions, c.DefaultModelOptions()...,
+	); err != nil {
+		return nil, err
+	}
+
+	received, sent, err := getContactSatsByXpubID(ctx, xPubID, conditions, c.DefaultModelOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	result.TotalReceived, result.TotalSent = uint64(received), uint64(-sent)
+
+	if result.Transactions, err = getTransactionsByXpubID(
+		ctx, xPubID, nil, conditions, queryParams, c.DefaultModelOptions()...,
+	); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// linkContacts will link the transaction to the contacts (the counterparties) of its xPubs,
+// the contacts are matched by the paymails of the draft outputs, the verified sender of the P2P transaction
+// and the PKI keys of the senders of the PIKE outputs
+func (m *Transaction) linkContacts(ctx context.Context) {
+	// The paymail receivers of the draft are the contacts of the sender,
+	// the sender is the contact of the receivers (if they are in the same wallet)
+	if m.draftTransaction != nil {
+		for _, output := range m.draftTransaction.Configuration.Outputs {
+			p4 := output.PaymailP4
+			if p4 == nil || p4.Alias == "" || p4.Domain == "" {
+				continue
+			}
+			receiver := p4.Alias + "@" + p4.Domain
+			m.linkContactByPaymail(ctx, m.draftTransaction.XpubID, receiver, -int64(m.sentToPaymail(receiver)))
+
+			if p4.FromPaymail == "" {
+				continue
+			}
+			for _, xPubID := range m.XpubOutIDs {
+				if xPubID != m.draftTransaction.XpubID {
+					m.linkContactByPaymail(ctx, xPubID, p4.FromPaymail, m.XpubOutputValue[xPubID])
+				}
+			}
+		}
+	}
+
+	// The sender of the received P2P transaction is the contact of the receivers (if the sender signed the transaction)
+	if sender, pubKey := p2pVerifiedSender(m.Metadata, m.ID); sender != "" {
+		for _, xPubID := range m.XpubOutIDs {
+			m.linkContact(ctx, xPubID, map[string]interface{}{
+				paymailField: sender,
+				pubKeyField:  pubKey,
+			}, m.XpubOutputValue[xPubID])
+		}
+	}
+
+	// The PIKE outputs are derived from the PKI key of the sender (the pub key of the contact)
+	for xPubID, senderPubKey := range m.pikeSenders {
+		m.linkContact(ctx, xPubID, map[string]interface{}{
+			pubKeyField: senderPubKey,
+		}, m.XpubOutputValue[xPubID])
+	}
+}
+
+// sentToPaymail will return the satoshis of the draft outputs sent to the paymail
+func (m *Transaction) sentToPaymail(paymailAddress string) uint64 {
+	var satoshis uint64
+	for _, output := range m.draftTransaction.Configuration.Outputs {
+		if p4 := output.PaymailP4; p4 != nil && strings.EqualFold(p4.Alias+"@"+p4.Domain, paymailAddress) {
+			satoshis += output.Satoshis
+		}
+	}
+	return satoshis
+}
+
+// linkContactByPaymail will link the transaction to the contact of the xPub with the given paymail
+func (m *Transaction) linkContactByPaymail(ctx context.Context, xPubID, paymailAddress string, satoshis int64) {
+	m.linkContact(ctx, xPubID, map[string]interface{}{
+		paymailField: paymail.SanitizeEmail(paymailAddress),
+	}, satoshis)
+}
+
+// linkContact will link the transaction to the contact of the xPub matching the conditions (the first link is kept),
+// the satoshis are the value exchanged with the contact (received from the contact or, if negative, sent to the contact)
+func (m *Transaction) linkContact(ctx context.Context, xPubID string, conditions map[string]interface{}, satoshis int64) {
+	if _, linked := m.XpubContactIDs[xPubID]; linked {
+		return
+	}
+
+	conditions[xPubIDField] = xPubID
+	conditions[deletedAtField] = nil
+
+	contact := &Contact{}
+	contact.enrich(ModelContact, m.GetOptions(false)...)
+	if err := Get(ctx, contact, conditions, false, defaultDatabaseReadTimeout, false); err != nil {
+		if !errors.Is(err, datastore.ErrNoResults) {
+			m.Client().Logger().Warn().
+				Str("txID", m.ID).
+				Str("xPubID", xPubID).
+				Msgf("failed to link transaction to contact: %s", err.Error())
+		}
+		return
+	}
+
+	if m.XpubContactIDs == nil {
+		m.XpubContactIDs = make(XpubContactIDs)
+	}
+	m.XpubContactIDs[xPubID] = contact.ID
+
+	if m.XpubContactSats == nil {
+		m.XpubContactSats = make(XpubOutputValue)
+	}
+	m.XpubContactSats[xPubID] = satoshis
+}
+
+// p2pVerifiedSender will get the paymail and the pub key of the sender from the P2P metadata of the received transaction,
+// the sender is returned only if the transaction is signed with the pub key (the sender is not trusted otherwise)
+func p2pVerifiedSender(metadata Metadata, txID string) (sender, pubKey string) {
+	var signature string
+	switch p2pMetadata := metadata[p2pMetadataField].(type) {
+	case *paymail.P2PMetaData:
+		if p2pMetadata != nil {
+			sender, pubKey, signature = p2pMetadata.Sender, p2pMetadata.PubKey, p2pMetadata.Signature
+		}
+	case map[string]interface{}:
+		sender, _ = p2pMetadata["sender"].(string)
+		pubKey, _ = p2pMetadata["pubkey"].(string)
+		signature, _ = p2pMetadata["signature"].(string)
+	}
+
+	if sender == "" || pubKey == "" || signature == "" {
+		return "", ""
+	}
+	if err := verifyPaymailSignature(pubKey, signature, txID); err != nil {
+		return "", ""
+	}
+	return paymail.SanitizeEmail(sender), pubKey
+}
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/engine/utils"
+	"github.com/bitcoinschema/go-bitcoin/v2"
+	"github.com/libsv/go-bk/bec"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedP2PMetadata will return the P2P metadata of the transaction signed by the sender
+func signedP2PMetadata(t *testing.T, sender string, key *bec.PrivateKey, txHex string) *paymail.P2PMetaData {
+	tx, err := bt.NewTxFromString(txHex)
+	require.NoError(t, err)
+	signature, err := bitcoin.SignMessage(hex.EncodeToString(key.Serialise()), tx.TxID(), true)
+	require.NoError(t, err)
+
+	return &paymail.P2PMetaData{
+		Sender:    sender,
+		PubKey:    hex.EncodeToString(key.PubKey().SerialiseCompressed()),
+		Signature: signature,
+	}
+}
+
+// TestClient_GetContactTransactions will test the linking of the received transactions to the contacts
+func TestClient_GetContactTransactions(t *testing.T) {
+	ctx, client, deferMe := CreateTestSQLiteClient(t, false, false, WithFreeCache())
+	defer deferMe()
+
+	masterKey, xPub, rawXPub := CreateNewXPub(ctx, t, client)
+
+	senderKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+	sender := newContact("Sender", "sender@example.com", hex.EncodeToString(senderKey.PubKey().SerialiseCompressed()),
+		xPub.ID, ContactConfirmed, append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, sender.Save(ctx))
+	pikeSender := newContact("PIKE Sender", "pike_sender@example.com", testContactPubKey, xPub.ID, ContactConfirmed,
+		append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, pikeSender.Save(ctx))
+
+	// P2P transaction signed by the sender
+	destination, err := client.NewDestination(ctx, rawXPub, utils.ChainExternal, utils.ScriptTypePubKeyHash)
+	require.NoError(t, err)
+	txHex := CreateFakeFundingTransaction(t, masterKey, []*Destination{destination}, 1000)
+	tx, err := saveRawTransaction(ctx, client, true, txHex, WithMetadatas(Metadata{
+		p2pMetadataField: signedP2PMetadata(t, "Sender@Example.com", senderKey, txHex),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, sender.ID, tx.XpubContactIDs[xPub.ID])
+	assert.Equal(t, int64(1000), tx.XpubContactSats[xPub.ID])
+
+	// P2P transaction claiming the sender without the signature
+	txHex = CreateFakeFundingTransaction(t, masterKey, []*Destination{destination}, 1500)
+	tx, err = saveRawTransaction(ctx, client, true, txHex, WithMetadatas(Metadata{
+		p2pMetadataField: &paymail.P2PMetaData{Sender: "sender@example.com"},
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, tx.XpubContactIDs)
+
+	// P2P transaction claiming the sender, signed with another key
+	otherKey, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+	txHex = CreateFakeFundingTransaction(t, masterKey, []*Destination{destination}, 1700)
+	tx, err = saveRawTransaction(ctx, client, true, txHex, WithMetadatas(Metadata{
+		p2pMetadataField: signedP2PMetadata(t, "sender@example.com", otherKey, txHex),
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, tx.XpubContactIDs)
+
+	// PIKE output derived from the PKI key of the sender
+	pikeDestination := newDestination(xPub.ID, testLockingScript, append(client.DefaultModelOptions(), New())...)
+	pikeDestination.DerivationMethod = PIKEDerivationMethod
+	pikeDestination.SenderXpub = testContactPubKey
+	require.NoError(t, pikeDestination.Save(ctx))
+	txHex = CreateFakeFundingTransaction(t, masterKey, []*Destination{pikeDestination}, 2000)
+	_, err = saveRawTransaction(ctx, client, true, txHex)
+	require.NoError(t, err)
+
+	// unknown sender
+	txHex = CreateFakeFundingTransaction(t, masterKey, []*Destination{destination}, 3000)
+	tx, err = saveRawTransaction(ctx, client, true, txHex, WithMetadatas(Metadata{
+		p2pMetadataField: signedP2PMetadata(t, "stranger@example.com", otherKey, txHex),
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, tx.XpubContactIDs)
+
+	result, err := client.GetContactTransactions(ctx, xPub.ID, sender.Paymail, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Transactions, 1)
+	assert.Equal(t, int64(1), result.Count)
+	assert.Equal(t, uint64(1000), result.TotalReceived)
+	assert.Equal(t, uint64(0), result.TotalSent)
+	assert.Equal(t, sender.ID, result.Transactions[0].Display().(*Transaction).ContactID)
+
+	result, err = client.GetContactTransactions(ctx, xPub.ID, pikeSender.Paymail, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Transactions, 1)
+	assert.Equal(t, uint64(2000), result.TotalReceived)
+
+	_, err = client.GetContactTransactions(ctx, xPub.ID, "stranger@example.com", nil)
+	require.ErrorIs(t, err, spverrors.ErrContactNotFound)
+}
+
+// TestClient_GetContactTransactions_sent will test the totals of the transactions sent to the contact
+func TestClient_GetContactTransactions_sent(t *testing.T) {
+	ctx, client, deferMe := initSimpleTestCase(t)
+	defer deferMe()
+
+	receiver := newContact("Receiver", "receiver@example.com", testContactPubKey, testXPubID, ContactConfirmed,
+		append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, receiver.Save(ctx))
+
+	// the transaction pays the contact and another receiver
+	draft, err := newDraftTransaction(testXPub, &TransactionConfig{
+		Outputs: []*TransactionOutput{
+			{To: "18VWHjMt4ixHddPPbs6righWTs3Sg2QNcn", Satoshis: 1000},
+			{To: "1A1PjKqjWMNBzTVdcBru27EV1PHcXWc63W", Satoshis: 2000},
+		},
+		ChangeNumberOfDestinations: 1,
+		Sync:                       &SyncConfig{Broadcast: true},
+	}, append(client.DefaultModelOptions(), New())...)
+	require.NoError(t, err)
+	require.NoError(t, draft.Save(ctx))
+	draft.Configuration.Outputs[0].PaymailP4 = &PaymailP4{Alias: "receiver", Domain: "example.com"}
+
+	xPriv, err := bip32.NewKeyFromString(testXPriv)
+	require.NoError(t, err)
+	txHex, err := draft.SignInputs(xPriv)
+	require.NoError(t, err)
+
+	transaction, err := newTransactionWithDraftID(txHex, draft.ID, client.DefaultModelOptions(WithXPub(testXPub), New())...)
+	require.NoError(t, err)
+	transaction.draftTransaction = draft
+	_hydrateOutgoingWithSync(transaction)
+	require.NoError(t, transaction.processUtxos(ctx))
+	require.NoError(t, transaction.Save(ctx))
+
+	// when
+	result, err := client.GetContactTransactions(ctx, testXPubID, receiver.Paymail, nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Count)
+	assert.Equal(t, uint64(1000), result.TotalSent)
+	assert.Equal(t, uint64(0), result.TotalReceived)
+}
+
+// TestP2PVerifiedSender will test the method p2pVerifiedSender()
+func TestP2PVerifiedSender(t *testing.T) {
+	t.Parallel()
+
+	key, err := bec.NewPrivateKey(bec.S256())
+	require.NoError(t, err)
+	tx, err := bt.NewTxFromString(testTx2Hex)
+	require.NoError(t, err)
+	txID := tx.TxID()
+	signed := signedP2PMetadata(t, "Alice@Example.com", key, testTx2Hex)
+
+	sender, pubKey := p2pVerifiedSender(Metadata{p2pMetadataField: signed}, txID)
+	assert.Equal(t, "alice@example.com", sender)
+	assert.Equal(t, signed.PubKey, pubKey)
+
+	sender, _ = p2pVerifiedSender(Metadata{p2pMetadataField: map[string]interface{}{
+		"sender": signed.Sender, "pubkey": signed.PubKey, "signature": signed.Signature,
+	}}, txID)
+	assert.Equal(t, "alice@example.com", sender)
+
+	sender, _ = p2pVerifiedSender(Metadata{p2pMetadataField: signed}, "another-tx-id")
+	assert.Empty(t, sender)
+	sender, _ = p2pVerifiedSender(Metadata{p2pMetadataField: &paymail.P2PMetaData{Sender: "alice@example.com"}}, txID)
+	assert.Empty(t, sender)
+	sender, _ = p2pVerifiedSender(Metadata{p2pMetadataField: (*paymail.P2PMetaData)(nil)}, txID)
+	assert.Empty(t, sender)
+	sender, _ = p2pVerifiedSender(nil, txID)
+	assert.Empty(t, sender)
+}
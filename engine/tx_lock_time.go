@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/datastore"
+	"github.com/bitcoin-sv/spv-wallet/engine/spverrors"
+	"github.com/libsv/go-bt/v2"
+)
+
+// _isTransactionFinal will check if the transaction can be included in the next block
+//
+// Non-final transactions are rejected by the broadcasters, so they are kept in the queue until the lock time is reached
+func _isTransactionFinal(ctx context.Context, tx *Transaction, opts ...ModelOps) (bool, error) {
+	btTx, err := bt.NewTxFromString(tx.Hex)
+	if err != nil {
+		return false, spverrors.Wrapf(err, "could not parse transaction hex")
+	}
+
+	if !hasLockTime(btTx) {
+		return true, nil
+	}
+
+	var blockHeight uint32
+	if btTx.LockTime < lockTimeThreshold {
+		if blockHeight, err = _nextBlockHeight(ctx, tx.Client(), opts...); err != nil {
+			return false, err
+		} else if blockHeight == 0 {
+			// Without any known block, the lock time can't be checked: keep the transaction until it is
+			return false, nil
+		}
+	}
+
+	return isLockTimeReached(btTx.LockTime, blockHeight, time.Now().UTC()), nil
+}
+
+// _nextBlockHeight will return the height of the next block from the tip of the longest chain (Block Headers Service)
+//
+// If the tip is not available, the height is the lower bound given by the last mined transaction known to the wallet.
+// Returns 0 if the height is not known
+func _nextBlockHeight(ctx context.Context, client ClientInterface, opts ...ModelOps) (uint32, error) {
+	if client != nil && client.Chainstate() != nil {
+		tipHeight, err := client.Chainstate().GetTipHeight(ctx)
+		if err == nil && tipHeight > 0 {
+			return tipHeight + 1, nil
+		}
+		client.Logger().Warn().Err(err).Msg("could not get the tip of the longest chain, using the last mined transaction")
+	}
+
+	txs, err := getTransactionsInternal(
+		ctx,
+		map[string]interface{}{
+			blockHeightField: map[string]interface{}{
+				"$gt": 0,
+			},
+		},
+		"",
+		&datastore.QueryParams{
+			Page:          1,
+			PageSize:      1,
+			OrderByField:  blockHeightField,
+			SortDirection: datastore.SortDesc,
+		},
+		opts...,
+	)
+	if err != nil {
+		return 0, err
+	} else if len(txs) == 0 {
+		return 0, nil
+	}
+
+	return uint32(txs[0].BlockHeight) + 1, nil
+}
+
+// hasLockTime will check if the lock time of the transaction is enabled (lock time is set and at least one input is non-final)
+func hasLockTime(tx *bt.Tx) bool {
+	if tx.LockTime == 0 {
+		return false
+	}
+	for _, input := range tx.Inputs {
+		if input.SequenceNumber != bt.DefaultSequenceNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// isLockTimeReached will check if a transaction with the lock time can be included in the block
+// with the given height, or (for time based lock times) at the given time
+func isLockTimeReached(lockTime, blockHeight uint32, now time.Time) bool {
+	if lockTime < lockTimeThreshold {
+		return lockTime < blockHeight
+	}
+	return int64(lockTime) < now.Add(-medianTimePastLag).Unix()
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine/chainstate"
+	"github.com/jarcoal/httpmock"
+	"github.com/libsv/go-bt/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hasLockTime(t *testing.T) {
+	tx := bt.NewTx()
+	require.NoError(t, tx.From(testTxID, 0, testLockingScript, 1000))
+
+	t.Run("no lock time", func(t *testing.T) {
+		assert.False(t, hasLockTime(tx))
+	})
+
+	t.Run("lock time with final sequence", func(t *testing.T) {
+		tx.LockTime = 800000
+		assert.False(t, hasLockTime(tx))
+	})
+
+	t.Run("lock time with non-final sequence", func(t *testing.T) {
+		tx.LockTime = 800000
+		tx.Inputs[0].SequenceNumber = bt.DefaultSequenceNumber - 1
+		assert.True(t, hasLockTime(tx))
+	})
+}
+
+func Test_isLockTimeReached(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("block height", func(t *testing.T) {
+		assert.False(t, isLockTimeReached(800000, 799999, now))
+		assert.False(t, isLockTimeReached(800000, 800000, now))
+		assert.True(t, isLockTimeReached(800000, 800001, now))
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		assert.False(t, isLockTimeReached(uint32(now.Unix()), 0, now))
+		assert.False(t, isLockTimeReached(uint32(now.Add(-30*time.Minute).Unix()), 0, now))
+		assert.True(t, isLockTimeReached(uint32(now.Add(-2*time.Hour).Unix()), 0, now))
+	})
+}
+
+func Test_isTransactionFinal(t *testing.T) {
+	const testTipURL = "http://block-headers-service.test/api/v1/chain/header/state/tip"
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	lockedTx := bt.NewTx()
+	require.NoError(t, lockedTx.From(testTxID, 0, testLockingScript, 1000))
+	require.NoError(t, lockedTx.PayToAddress(testExternalAddress, 900))
+	lockedTx.LockTime = 800000
+	lockedTx.Inputs[0].SequenceNumber = bt.DefaultSequenceNumber - 1
+
+	mockTip := func(height uint32) {
+		httpmock.Reset()
+		httpmock.RegisterResponder("GET", testTipURL,
+			httpmock.NewJsonResponderOrPanic(200, chainstate.BlockHeaderState{State: "LONGEST_CHAIN", Height: height}),
+		)
+	}
+
+	t.Run("tip of the longest chain", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup(), withBlockHeadersServiceMockup())
+		defer deferMe()
+
+		tx, err := txFromHex(lockedTx.String(), client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		mockTip(799999)
+		final, err := _isTransactionFinal(ctx, tx, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.False(t, final)
+
+		mockTip(800000)
+		final, err = _isTransactionFinal(ctx, tx, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.True(t, final)
+	})
+
+	t.Run("unknown block height", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+		defer deferMe()
+
+		tx, err := txFromHex(lockedTx.String(), client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		final, err := _isTransactionFinal(ctx, tx, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.False(t, final)
+	})
+
+	t.Run("last mined transaction without the tip", func(t *testing.T) {
+		ctx, client, deferMe := CreateTestSQLiteClient(t, false, true, withTaskManagerMockup())
+		defer deferMe()
+
+		mined, err := txFromHex(testTxHex, append(client.DefaultModelOptions(), New())...)
+		require.NoError(t, err)
+		mined.BlockHeight = 800000
+		require.NoError(t, mined.Save(ctx))
+
+		tx, err := txFromHex(lockedTx.String(), client.DefaultModelOptions()...)
+		require.NoError(t, err)
+
+		final, err := _isTransactionFinal(ctx, tx, client.DefaultModelOptions()...)
+		require.NoError(t, err)
+		assert.True(t, final)
+	})
+}
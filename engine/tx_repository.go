@@ -70,6 +70,18 @@ func getTransactionsCountByXpubID(ctx context.Context, xPubID string, metadata *
 	return getTransactionsCountInternal(ctx, dbConditions, opts...)
 }
 
+// getContactSatsByXpubID will get the satoshis received from (positive) and sent to (negative) the contacts
+// of the xPub in the transactions with the given conditions
+func getContactSatsByXpubID(ctx context.Context, xPubID string, conditions map[string]interface{},
+	opts ...ModelOps,
+) (received, sent int64, err error) {
+	dbConditions := processDBConditions(xPubID, conditions, nil)
+
+	return NewBaseModel(ModelNameEmpty, opts...).Client().Datastore().GetModelJSONValueSums(
+		ctx, &Transaction{}, dbConditions, xPubContactSatsField, xPubID, defaultDatabaseReadTimeout,
+	)
+}
+
 // getTransactionsByXpubID will get all the models for a given xpub ID
 func getTransactionsByXpubID(ctx context.Context, xPubID string,
 	metadata *Metadata, conditions map[string]interface{},
@@ -68,6 +68,8 @@ func (m *Transaction) processUtxos(ctx context.Context) error {
 		return err
 	}
 
+	m.linkContacts(ctx)
+
 	m.TotalValue, m.Fee = m.getValues()
 	m.NumberOfInputs = uint32(len(m.parsedTx.Inputs))
 	m.NumberOfOutputs = uint32(len(m.parsedTx.Outputs))
@@ -186,6 +188,14 @@ func (m *Transaction) _processOutputs(ctx context.Context) (err error) {
 					m.XpubOutIDs = append(m.XpubOutIDs, destination.XpubID)
 				}
 
+				// The PIKE destinations are derived from the PKI key of the sender
+				if destination.SenderXpub != "" {
+					if m.pikeSenders == nil {
+						m.pikeSenders = make(map[string]string)
+					}
+					m.pikeSenders[destination.XpubID] = destination.SenderXpub
+				}
+
 				numberOfOutputsProcessed++
 			}
 		}
@@ -98,3 +98,32 @@ func DeriveLinkedKey(source *bec.PublicKey, linkPubKey *bec.PublicKey, invoiceNu
 
 	return linkedPK, nil
 }
+
+// DeriveLinkedPrivateKey derives the private key of the linked public key (see DeriveLinkedKey)
+// from the private key of the link public key, the source public key and the same invoiceNumber.
+func DeriveLinkedPrivateKey(source *bec.PublicKey, linkPrivKey *bec.PrivateKey, invoiceNumber string) (*bec.PrivateKey, error) {
+	if source == nil || source.X == nil || source.Y == nil {
+		return nil, spverrors.Newf("source public key is nil")
+	}
+	if linkPrivKey == nil || linkPrivKey.D == nil {
+		return nil, spverrors.Newf("receiver private key is nil")
+	}
+
+	// Compute the HMAC result (the same as for the linked public key)
+	hmacResult, err := calculateHMAC(source.SerialiseCompressed(), invoiceNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// The linked private key is (receiverPrivKey + hn) mod N
+	curve := bec.S256()
+	hn := new(big.Int).SetBytes(hmacResult)
+	d := new(big.Int).Add(linkPrivKey.D, hn)
+	d.Mod(d, curve.Params().N)
+	if d.Sign() == 0 {
+		return nil, spverrors.Newf("linked private key is zero")
+	}
+
+	linkedPrivKey, _ := bec.PrivKeyFromBytes(curve, d.Bytes())
+	return linkedPrivKey, nil
+}
@@ -177,3 +177,35 @@ func TestDeriveLinkedKeyCases(t *testing.T) {
 		}
 	})
 }
+
+func TestDeriveLinkedPrivateKey(t *testing.T) {
+	sourcePubKeyHex := "027c1404c3ecb034053e6dd90bc68f7933284559c7d0763367584195a8796d9b0e"
+	sourcePubKeyBytes, err := hex.DecodeString(sourcePubKeyHex)
+	assert.NoError(t, err)
+	sourcePubKey, err := bec.ParsePubKey(sourcePubKeyBytes, bec.S256())
+	assert.NoError(t, err)
+
+	linkPrivKey, err := bec.NewPrivateKey(bec.S256())
+	assert.NoError(t, err)
+
+	t.Run("matches the linked public key", func(t *testing.T) {
+		linkedPubKey, err := DeriveLinkedKey(sourcePubKey, linkPrivKey.PubKey(), "valid-invoice")
+		assert.NoError(t, err)
+
+		linkedPrivKey, err := DeriveLinkedPrivateKey(sourcePubKey, linkPrivKey, "valid-invoice")
+		assert.NoError(t, err)
+		assert.Equal(t, linkedPubKey.SerialiseCompressed(), linkedPrivKey.PubKey().SerialiseCompressed())
+	})
+
+	t.Run("empty invoice number", func(t *testing.T) {
+		linkedPrivKey, err := DeriveLinkedPrivateKey(sourcePubKey, linkPrivKey, "")
+		assert.Error(t, err)
+		assert.Nil(t, linkedPrivKey)
+	})
+
+	t.Run("nil private key", func(t *testing.T) {
+		linkedPrivKey, err := DeriveLinkedPrivateKey(sourcePubKey, nil, "valid-invoice")
+		assert.Error(t, err)
+		assert.Nil(t, linkedPrivKey)
+	})
+}
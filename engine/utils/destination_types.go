@@ -59,6 +59,9 @@ var (
 	// P2PKHSubstringRegexp substring of OP_DUP OP_HASH160 [pubkey hash] OP_EQUALVERIFY OP_CHECKSIG
 	P2PKHSubstringRegexp, _ = regexp.Compile(P2PKHRegexpString)
 
+	// P2PKHDataRegexp OP_DUP OP_HASH160 [pubkey hash] OP_EQUALVERIFY OP_CHECKSIG OP_RETURN [data]
+	P2PKHDataRegexp, _ = regexp.Compile(`^` + P2PKHRegexpString + `6a`)
+
 	// P2SHRegexp OP_HASH160 [hash] OP_EQUAL
 	P2SHRegexp, _ = regexp.Compile(`^a914[\da-f]{40}87$`)
 
@@ -106,6 +109,12 @@ func IsP2PKH(lockingScript string) bool {
 	return P2PKHRegexp.MatchString(lockingScript)
 }
 
+// IsP2PKHData Check whether the given string is a p2pkh output followed by the data after OP_RETURN
+// (OP_RETURN ends the script after the signature check, so it's spent as p2pkh)
+func IsP2PKHData(lockingScript string) bool {
+	return P2PKHDataRegexp.MatchString(lockingScript)
+}
+
 // IsP2SH Check whether the given string is a p2shHex output
 func IsP2SH(lockingScript string) bool {
 	return P2SHRegexp.MatchString(lockingScript)
@@ -147,7 +156,7 @@ func IsMultiSig(lockingScript string) bool {
 
 // GetDestinationType Get the type of output script destination
 func GetDestinationType(lockingScript string) string {
-	if IsP2PKH(lockingScript) {
+	if IsP2PKH(lockingScript) || IsP2PKHData(lockingScript) {
 		return ScriptTypePubKeyHash
 	} else if IsMetanet(lockingScript) {
 		// metanet is a special op_return - needs to be checked first
@@ -188,7 +197,8 @@ func GetDestinationTypeRegex(destType string) *regexp.Regexp {
 func GetAddressFromScript(lockingScript string) (address string) {
 	scriptType := GetDestinationType(lockingScript)
 	if scriptType == ScriptTypePubKeyHash {
-		address, _ = bitcoin.GetAddressFromScript(lockingScript)
+		// the data after OP_RETURN is not part of the address
+		address, _ = bitcoin.GetAddressFromScript(lockingScript[:50])
 	} else if scriptType == ScriptTypePubKey {
 		s, err := bscript2.NewFromHexString(lockingScript) // no need for error check, if type is set, it should be valid
 		if err != nil {
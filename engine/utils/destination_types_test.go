@@ -10,6 +10,7 @@ import (
 var (
 	p2pkHex     = "410444e56eab3d6f4aca5e71f51b3fe389951af2a030e14cc33dc8f665c5af28f65875898b4dc59ab1bb2071e625d8140b4fead2706fd43ad907339aaf0e090315dcac"
 	p2pkhHex    = "76a91413473d21dc9e1fb392f05a028b447b165a052d4d88ac"
+	p2pkhMemo   = "76a91413473d21dc9e1fb392f05a028b447b165a052d4d88ac6a026869"
 	p2shHex     = "a9149bc6f9caddaaab28c2bc0a8bf8531f91109bdd5887"
 	metanetHex  = "006a046d65746142303237383763323464643466..."
 	opReturnHex = "006a067477657463684d9501424945"
@@ -57,6 +58,21 @@ func TestIsP2PKH(t *testing.T) {
 	})
 }
 
+// TestIsP2PKHData will test the method IsP2PKHData()
+func TestIsP2PKHData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Equal(t, false, IsP2PKHData(p2pkhHex))
+		assert.Equal(t, false, IsP2PKHData(p2pkhHex+"06"))
+		assert.Equal(t, false, IsP2PKHData(stasHex))
+	})
+
+	t.Run("match", func(t *testing.T) {
+		assert.Equal(t, true, IsP2PKHData(p2pkhMemo))
+	})
+}
+
 // TestIsP2SH will test the method IsP2SH()
 func TestIsP2SH(t *testing.T) {
 	t.Parallel()
@@ -183,6 +199,10 @@ func TestGetDestinationType(t *testing.T) {
 		assert.Equal(t, bscript2.ScriptTypePubKeyHash, GetDestinationType(p2pkhHex))
 	})
 
+	t.Run("p2pkh with data - ScriptTypePubKeyHash", func(t *testing.T) {
+		assert.Equal(t, bscript2.ScriptTypePubKeyHash, GetDestinationType(p2pkhMemo))
+	})
+
 	t.Run("ScriptHashType", func(t *testing.T) {
 		assert.Equal(t, ScriptHashType, GetDestinationType(p2shHex))
 	})
@@ -216,6 +236,10 @@ func TestGetAddressFromScript(t *testing.T) {
 		assert.Equal(t, "12kwBQPUnAMouxBBWRa5wsA6vC29soEdXT", GetAddressFromScript(p2pkhHex))
 	})
 
+	t.Run("p2pkh with data", func(t *testing.T) {
+		assert.Equal(t, "12kwBQPUnAMouxBBWRa5wsA6vC29soEdXT", GetAddressFromScript(p2pkhMemo))
+	})
+
 	t.Run("stas 1", func(t *testing.T) {
 		assert.Equal(t, "1AxScC72W9tyk1Enej6dBsVZNkkgAonk4H", GetAddressFromScript(stasHex))
 	})
@@ -1,6 +1,8 @@
 package mappings
 
 import (
+	"time"
+
 	"github.com/bitcoin-sv/spv-wallet/engine"
 	"github.com/bitcoin-sv/spv-wallet/mappings/common"
 	"github.com/bitcoin-sv/spv-wallet/models"
@@ -36,6 +38,7 @@ func MapToContactContract(src *engine.Contact) *response.Contact {
 		Paymail:  src.Paymail,
 		PubKey:   src.PubKey,
 		Status:   mapContactStatus(src.Status),
+		Groups:   src.Groups,
 	}
 }
 
@@ -75,3 +78,56 @@ func mapContactStatus(s engine.ContactStatus) response.ContactStatus {
 		return "unknown"
 	}
 }
+
+// MapToContactTotpContract will map the contact verification code to the spv-wallet-models contract
+func MapToContactTotpContract(src *engine.ContactTotp) *response.ContactTotp {
+	if src == nil {
+		return nil
+	}
+
+	return &response.ContactTotp{
+		Passcode:  src.Passcode,
+		Period:    uint(src.Period / time.Second),
+		ExpiresAt: src.ExpiresAt,
+	}
+}
+
+// MapToContactsImportContract will map the result of the contacts import to the spv-wallet-models contract
+func MapToContactsImportContract(src *engine.ContactsImportResult) *response.ContactsImport {
+	if src == nil {
+		return nil
+	}
+
+	failed := make([]*response.ContactImportFailure, 0, len(src.Failed))
+	for _, failure := range src.Failed {
+		failed = append(failed, &response.ContactImportFailure{
+			Entry:   failure.Entry,
+			Paymail: failure.Paymail,
+			Reason:  failure.Reason,
+		})
+	}
+
+	return &response.ContactsImport{
+		Imported: MapToContactContracts(src.Imported),
+		Failed:   failed,
+	}
+}
+
+// MapToContactTransactionsContract will map the transactions exchanged with the contact to the spv-wallet-models contract
+func MapToContactTransactionsContract(src *engine.ContactTransactions) *response.ContactTransactions {
+	if src == nil {
+		return nil
+	}
+
+	transactions := make([]*response.Transaction, 0, len(src.Transactions))
+	for _, transaction := range src.Transactions {
+		transactions = append(transactions, MapToTransactionContract(transaction))
+	}
+
+	return &response.ContactTransactions{
+		Contact:       MapToContactContract(src.Contact),
+		TotalSent:     src.TotalSent,
+		TotalReceived: src.TotalReceived,
+		Content:       transactions,
+	}
+}
@@ -0,0 +1,30 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/mappings/common"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToContactBlockContract will map the contact block to the spv-wallet-models contract
+func MapToContactBlockContract(src *engine.ContactBlock) *response.ContactBlock {
+	if src == nil {
+		return nil
+	}
+
+	return &response.ContactBlock{
+		Model:   *common.MapToContract(&src.Model),
+		Paymail: src.Paymail,
+		Domain:  src.Domain,
+		Action:  string(src.Action),
+	}
+}
+
+// MapToContactBlockContracts will map the contact blocks to the spv-wallet-models contracts
+func MapToContactBlockContracts(blocks []*engine.ContactBlock) []*response.ContactBlock {
+	contracts := make([]*response.ContactBlock, 0, len(blocks))
+	for _, block := range blocks {
+		contracts = append(contracts, MapToContactBlockContract(block))
+	}
+	return contracts
+}
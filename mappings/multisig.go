@@ -0,0 +1,51 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/mappings/common"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToMultisigAccountContract will map the multisig account to the spv-wallet-models contract
+func MapToMultisigAccountContract(src *engine.MultisigAccount) *response.MultisigAccount {
+	if src == nil {
+		return nil
+	}
+
+	return &response.MultisigAccount{
+		Model:           *common.MapToContract(&src.Model),
+		ID:              src.ID,
+		Threshold:       src.Threshold,
+		XpubIDs:         src.XpubIDs,
+		CurrentBalance:  src.CurrentBalance,
+		NextInternalNum: src.NextInternalNum,
+		NextExternalNum: src.NextExternalNum,
+	}
+}
+
+// MapToMultisigDraftTransactionContract will map the multisig draft with its collected signatures to the spv-wallet-models contract
+func MapToMultisigDraftTransactionContract(draft *engine.DraftTransaction, account *engine.MultisigAccount,
+	signedHex string,
+) *response.MultisigDraftTransaction {
+	if draft == nil || account == nil {
+		return nil
+	}
+
+	contract := &response.MultisigDraftTransaction{
+		DraftTransaction: MapToDraftTransactionContract(draft),
+		AccountID:        account.ID,
+		Signatures:       make([]*response.MultisigSignature, 0, len(draft.Signatures)),
+		Complete:         draft.IsMultisigComplete(account),
+		Hex:              signedHex,
+	}
+
+	for _, s := range draft.Signatures {
+		contract.Signatures = append(contract.Signatures, &response.MultisigSignature{
+			InputIndex: s.InputIndex,
+			XpubID:     s.XpubID,
+			Signature:  s.Signature,
+		})
+	}
+
+	return contract
+}
@@ -0,0 +1,32 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/mappings/common"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToPaymailDomainContract will map the spv-wallet paymail-domain model to the spv-wallet-models contract
+func MapToPaymailDomainContract(pd *engine.PaymailDomain) *response.PaymailDomain {
+	if pd == nil {
+		return nil
+	}
+
+	return &response.PaymailDomain{
+		Model:                   *common.MapToContract(&pd.Model),
+		Domain:                  pd.ID,
+		SenderValidationEnabled: pd.SenderValidationEnabled,
+		BeefEnabled:             pd.BeefEnabled,
+		PikeEnabled:             pd.PikeEnabled,
+		DefaultFromPaymail:      pd.DefaultFromPaymail,
+	}
+}
+
+// MapToPaymailDomainContracts will map the spv-wallet paymail-domain models to the spv-wallet-models contracts
+func MapToPaymailDomainContracts(domains []*engine.PaymailDomain) []*response.PaymailDomain {
+	contracts := make([]*response.PaymailDomain, 0, len(domains))
+	for _, domain := range domains {
+		contracts = append(contracts, MapToPaymailDomainContract(domain))
+	}
+	return contracts
+}
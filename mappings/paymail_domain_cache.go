@@ -0,0 +1,45 @@
+package mappings
+
+import (
+	"sort"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToPaymailDomainCacheContract will map the spv-wallet paymail domain cache to the spv-wallet-models contract
+func MapToPaymailDomainCacheContract(dc *engine.PaymailDomainCache) *response.PaymailDomainCache {
+	if dc == nil {
+		return nil
+	}
+
+	contract := &response.PaymailDomainCache{
+		Domain:            dc.Domain,
+		Blocked:           dc.Blocked,
+		CapabilitiesError: dc.CapabilitiesError,
+		PKI:               make([]*response.PaymailPKI, 0, len(dc.PKI)+len(dc.PKIErrors)),
+	}
+	if dc.Capabilities != nil {
+		contract.Capabilities = dc.Capabilities.Capabilities
+	}
+
+	addresses := make(map[string]*response.PaymailPKI)
+	for address, pki := range dc.PKI {
+		addresses[address] = &response.PaymailPKI{Paymail: address, PubKey: pki.PubKey}
+	}
+	for address, pkiErr := range dc.PKIErrors {
+		if pki, ok := addresses[address]; ok {
+			pki.Error = pkiErr
+			continue
+		}
+		addresses[address] = &response.PaymailPKI{Paymail: address, Error: pkiErr}
+	}
+	for _, pki := range addresses {
+		contract.PKI = append(contract.PKI, pki)
+	}
+	sort.Slice(contract.PKI, func(i, j int) bool {
+		return contract.PKI[i].Paymail < contract.PKI[j].Paymail
+	})
+
+	return contract
+}
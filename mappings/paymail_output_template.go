@@ -0,0 +1,30 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/engine/script/template"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToPaymailOutputTemplateContract will map the PIKE output template of the spv-wallet paymail-address model to the spv-wallet-models contract
+func MapToPaymailOutputTemplateContract(pa *engine.PaymailAddress) *response.PaymailOutputTemplate {
+	if pa == nil {
+		return nil
+	}
+
+	contract := &response.PaymailOutputTemplate{
+		Paymail:       pa.String(),
+		Name:          template.P2PKHTemplate,
+		Denominations: []uint64{},
+	}
+	if outputTemplate := pa.OutputTemplate; outputTemplate != nil {
+		if outputTemplate.Name != "" {
+			contract.Name = outputTemplate.Name
+		}
+		if outputTemplate.Denominations != nil {
+			contract.Denominations = outputTemplate.Denominations
+		}
+		contract.Memo = outputTemplate.Memo
+	}
+	return contract
+}
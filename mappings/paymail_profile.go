@@ -0,0 +1,36 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/go-paymail"
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToPaymailProfileContract will map the public profile of the spv-wallet paymail-address model to the spv-wallet-models contract
+func MapToPaymailProfileContract(pa *engine.PaymailAddress) *response.PaymailProfile {
+	if pa == nil {
+		return nil
+	}
+
+	profile := pa.Profile()
+	return &response.PaymailProfile{
+		Paymail:              pa.String(),
+		PublicName:           profile.PublicName,
+		Avatar:               profile.Avatar,
+		PublicProfileHidden:  profile.PublicProfileHidden,
+		VerifyPubKeyDisabled: profile.VerifyPubKeyDisabled,
+	}
+}
+
+// MapToContactProfileContract will map the public profile of the contact to the spv-wallet-models contract
+func MapToContactProfileContract(contactPaymail string, profile *paymail.PublicProfilePayload) *response.ContactProfile {
+	if profile == nil {
+		return nil
+	}
+
+	return &response.ContactProfile{
+		Paymail: contactPaymail,
+		Name:    profile.Name,
+		Avatar:  profile.Avatar,
+	}
+}
@@ -0,0 +1,27 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToPaymailPubKeyContract will map the PKI public keys of the spv-wallet paymail-address model to the spv-wallet-models contract
+func MapToPaymailPubKeyContract(pa *engine.PaymailAddress, pubKey string) *response.PaymailPubKey {
+	if pa == nil {
+		return nil
+	}
+
+	previousPubKeys := make([]*response.PaymailPreviousPubKey, 0)
+	for _, previous := range pa.ValidPreviousPubKeys() {
+		previousPubKeys = append(previousPubKeys, &response.PaymailPreviousPubKey{
+			PubKey:     previous.PubKey,
+			ValidUntil: previous.ValidUntil,
+		})
+	}
+
+	return &response.PaymailPubKey{
+		Paymail:         pa.String(),
+		PubKey:          pubKey,
+		PreviousPubKeys: previousPubKeys,
+	}
+}
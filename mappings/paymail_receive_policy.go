@@ -0,0 +1,32 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToPaymailReceivePolicyContract will map the receive policy of the spv-wallet paymail-address model to the spv-wallet-models contract
+func MapToPaymailReceivePolicyContract(pa *engine.PaymailAddress) *response.PaymailReceivePolicy {
+	if pa == nil {
+		return nil
+	}
+
+	contract := &response.PaymailReceivePolicy{
+		Paymail:        pa.String(),
+		AllowedSenders: []string{},
+		DeniedSenders:  []string{},
+	}
+	if policy := pa.ReceivePolicy; policy != nil {
+		contract.MinSatoshis = policy.MinSatoshis
+		contract.MaxSatoshis = policy.MaxSatoshis
+		contract.DailyDestinationQuota = policy.DailyDestinationQuota
+		contract.ContactsOnly = policy.ContactsOnly
+		if policy.AllowedSenders != nil {
+			contract.AllowedSenders = policy.AllowedSenders
+		}
+		if policy.DeniedSenders != nil {
+			contract.DeniedSenders = policy.DeniedSenders
+		}
+	}
+	return contract
+}
@@ -0,0 +1,65 @@
+package mappings
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	customtypes "github.com/bitcoin-sv/spv-wallet/engine/datastore/customtypes"
+	"github.com/bitcoin-sv/spv-wallet/mappings/common"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToScheduledPaymentContract will map the scheduled payment to the spv-wallet-models contract
+func MapToScheduledPaymentContract(src *engine.ScheduledPayment) *response.ScheduledPayment {
+	if src == nil {
+		return nil
+	}
+
+	contract := &response.ScheduledPayment{
+		Model:          *common.MapToContract(&src.Model),
+		ID:             src.ID,
+		XpubID:         src.XpubID,
+		Recipient:      src.Recipient,
+		Satoshis:       src.Satoshis,
+		CronExpression: src.CronExpression,
+		EndAt:          mapNullTime(src.EndAt),
+		MaxRuns:        src.MaxRuns,
+		RunCount:       src.RunCount,
+		FailureCount:   src.FailureCount,
+		NextRunAt:      mapNullTime(src.NextRunAt),
+		LastRunAt:      mapNullTime(src.LastRunAt),
+		Status:         src.Status.String(),
+		Runs:           make([]*response.ScheduledPaymentRun, 0, len(src.Runs.Results)),
+	}
+	if src.Interval > 0 {
+		contract.Interval = src.Interval.String()
+	}
+
+	for _, run := range src.Runs.Results {
+		contract.Runs = append(contract.Runs, &response.ScheduledPaymentRun{
+			ExecutedAt: run.ExecutedAt,
+			DraftID:    run.DraftID,
+			Error:      run.Error,
+		})
+	}
+
+	return contract
+}
+
+// MapToScheduledPaymentContracts will map the scheduled payments collection to the spv-wallet-models contracts collection
+func MapToScheduledPaymentContracts(src []*engine.ScheduledPayment) []*response.ScheduledPayment {
+	res := make([]*response.ScheduledPayment, 0, len(src))
+
+	for _, s := range src {
+		res = append(res, MapToScheduledPaymentContract(s))
+	}
+
+	return res
+}
+
+func mapNullTime(t customtypes.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -28,6 +28,7 @@ func MapToTransactionContract(t *engine.Transaction) *response.Transaction {
 		TotalValue:           t.TotalValue,
 		Status:               string(t.Status),
 		TransactionDirection: string(t.Direction),
+		ContactID:            t.XpubContactIDs[t.XPubID],
 	}
 
 	processMetadata(t, t.XPubID, &model)
@@ -64,6 +65,29 @@ func MapToTransactionContractForAdmin(t *engine.Transaction) *response.Transacti
 	return &model
 }
 
+// MapToP2PDeliveryContract will map the P2P delivery state of the transaction to the spv-wallet-models contract
+func MapToP2PDeliveryContract(d *engine.P2PDelivery) *response.P2PDelivery {
+	if d == nil {
+		return nil
+	}
+
+	results := make([]*response.P2PDeliveryResult, 0, len(d.Results))
+	for _, result := range d.Results {
+		results = append(results, &response.P2PDeliveryResult{
+			Receiver:   result.Provider,
+			ExecutedAt: result.ExecutedAt,
+			Message:    result.StatusMessage,
+		})
+	}
+
+	return &response.P2PDelivery{
+		Status:        d.Status.String(),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		Results:       results,
+	}
+}
+
 func processMetadata(t *engine.Transaction, xpubID string, model *response.Transaction) {
 	if len(t.XpubMetadata) > 0 && len(t.XpubMetadata[xpubID]) > 0 {
 		if t.Model.Metadata == nil {
@@ -130,8 +154,10 @@ func MapTransactionConfigEngineToModel(tx *response.TransactionConfig) *engine.T
 		FromUtxos:                  mapToEngineFromUtxos(tx),
 		IncludeUtxos:               mapIncludeUtxosModelToEngine(tx),
 		Inputs:                     mapToEngineInputs(tx),
+		LockTime:                   tx.LockTime,
 		Outputs:                    mapToEngineOutputs(tx),
 		SendAllTo:                  MapTransactionOutputModelToEngine(tx.SendAllTo),
+		Sequences:                  mapToEngineSequences(tx),
 		Sync:                       MapSyncConfigModelToEngine(tx.Sync),
 	}
 }
@@ -172,6 +198,21 @@ func mapIncludeUtxosModelToEngine(tx *response.TransactionConfig) []*engine.Utxo
 	return includeUtxos
 }
 
+func mapToEngineSequences(tx *response.TransactionConfig) []*engine.InputSequence {
+	if tx.Sequences == nil {
+		return nil
+	}
+
+	sequences := make([]*engine.InputSequence, 0)
+	for _, sequence := range tx.Sequences {
+		sequences = append(sequences, &engine.InputSequence{
+			UtxoPointer: *MapUtxoPointerModelToEngine(&sequence.UtxoPointer),
+			Sequence:    sequence.Sequence,
+		})
+	}
+	return sequences
+}
+
 func mapToEngineFromUtxos(tx *response.TransactionConfig) []*engine.UtxoPointer {
 	if tx.FromUtxos == nil {
 		return nil
@@ -213,8 +254,10 @@ func MapToTransactionConfigContract(tx *engine.TransactionConfig) *response.Tran
 		FromUtxos:                  mapToContractFromUtxos(tx),
 		IncludeUtxos:               mapToContractIncludeUtxos(tx),
 		Inputs:                     mapToContractInputs(tx),
+		LockTime:                   tx.LockTime,
 		Outputs:                    mapToContractOutputs(tx),
 		SendAllTo:                  MapToTransactionOutputContract(tx.SendAllTo),
+		Sequences:                  mapToContractSequences(tx),
 		Sync:                       MapToSyncConfigContract(tx.Sync),
 	}
 }
@@ -267,6 +310,21 @@ func mapToContractFromUtxos(tx *engine.TransactionConfig) []*response.UtxoPointe
 	return fromUtxos
 }
 
+func mapToContractSequences(tx *engine.TransactionConfig) []*response.InputSequence {
+	if tx.Sequences == nil {
+		return nil
+	}
+
+	sequences := make([]*response.InputSequence, 0)
+	for _, sequence := range tx.Sequences {
+		sequences = append(sequences, &response.InputSequence{
+			UtxoPointer: *MapToUtxoPointer(&sequence.UtxoPointer),
+			Sequence:    sequence.Sequence,
+		})
+	}
+	return sequences
+}
+
 func mapToContractDestinations(tx *engine.TransactionConfig) []*response.Destination {
 	if tx.ChangeDestinations == nil {
 		return nil
@@ -0,0 +1,20 @@
+package mappings
+
+import (
+	"github.com/bitcoin-sv/spv-wallet/engine"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
+
+// MapToTransactionExportContract will map the exported transaction from the engine to the spv-wallet-models contract
+func MapToTransactionExportContract(export *engine.TransactionExport) *response.TransactionExport {
+	if export == nil {
+		return nil
+	}
+
+	return &response.TransactionExport{
+		ID:     export.ID,
+		Format: string(export.Format),
+		Atomic: export.Atomic,
+		Hex:    export.Hex,
+	}
+}
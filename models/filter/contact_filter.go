@@ -10,6 +10,7 @@ type ContactFilter struct {
 	Paymail     *string `json:"paymail" example:"alice@example.com"`
 	PubKey      *string `json:"pubKey" example:"0334f01ecb971e93db179e6fb320cd1466beb0c1ec6c1c6a37aa6cb02e53d5dd1a"`
 	Status      *string `json:"status,omitempty" enums:"unconfirmed,awaiting,confirmed,rejected"`
+	Group       *string `json:"group,omitempty" example:"family"`
 }
 
 var validContactStatuses = getEnumValues[ContactFilter]("Status")
@@ -26,6 +27,7 @@ func (d *ContactFilter) ToDbConditions() (map[string]interface{}, error) {
 	applyIfNotNil(conditions, "full_name", d.FullName)
 	applyIfNotNil(conditions, "paymail", d.Paymail)
 	applyIfNotNil(conditions, "pub_key", d.PubKey)
+	applyIfNotNil(conditions, "groups", d.Group) // the contacts having the group (tag) among their groups
 	if err := checkAndApplyStrOption(conditions, "status", d.Status, validContactStatuses...); err != nil {
 		return nil, err
 	}
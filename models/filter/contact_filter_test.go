@@ -0,0 +1,41 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default filter", func(t *testing.T) {
+		filter := ContactFilter{}
+		dbConditions, err := filter.ToDbConditions()
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(dbConditions))
+		assert.Nil(t, dbConditions["deleted_at"])
+	})
+
+	t.Run("with group", func(t *testing.T) {
+		filter := fromJSON[ContactFilter](`{
+			"group": "family",
+			"includeDeleted": true
+		}`)
+		dbConditions, err := filter.ToDbConditions()
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(dbConditions))
+		assert.Equal(t, "family", dbConditions["groups"])
+	})
+
+	t.Run("with invalid status", func(t *testing.T) {
+		filter := fromJSON[ContactFilter](`{
+			"status": "unknown"
+		}`)
+		_, err := filter.ToDbConditions()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package filter
+
+// ScheduledPaymentFilter is a struct for handling request parameters for scheduled payment search requests
+type ScheduledPaymentFilter struct {
+	// ModelFilter is a struct for handling typical request parameters for search requests
+	//lint:ignore SA5008 We want to reuse json tags also to mapstructure.
+	ModelFilter `json:",inline,squash"`
+	Recipient   *string `json:"recipient,omitempty" example:"alice@example.com"`
+	Status      *string `json:"status,omitempty" enums:"active,completed,canceled,failed"`
+}
+
+var validScheduledPaymentStatuses = getEnumValues[ScheduledPaymentFilter]("Status")
+
+// ToDbConditions converts filter fields to the datastore conditions using gorm naming strategy
+func (d *ScheduledPaymentFilter) ToDbConditions() (map[string]interface{}, error) {
+	if d == nil {
+		return nil, nil
+	}
+	conditions := d.ModelFilter.ToDbConditions()
+
+	// Column names come from the database model, see: /engine/model_scheduled_payments.go
+	applyIfNotNil(conditions, "recipient", d.Recipient)
+	if err := checkAndApplyStrOption(conditions, "status", d.Status, validScheduledPaymentStatuses...); err != nil {
+		return nil, err
+	}
+
+	return conditions, nil
+}
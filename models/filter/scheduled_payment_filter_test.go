@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledPaymentFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default filter", func(t *testing.T) {
+		filter := ScheduledPaymentFilter{}
+		dbConditions, err := filter.ToDbConditions()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(dbConditions))
+		assert.Nil(t, dbConditions["deleted_at"])
+	})
+
+	t.Run("with recipient and status", func(t *testing.T) {
+		filter := fromJSON[ScheduledPaymentFilter](`{
+			"recipient": "alice@example.com",
+			"status": "active",
+			"includeDeleted": true
+		}`)
+		dbConditions, err := filter.ToDbConditions()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(dbConditions))
+		assert.Equal(t, "alice@example.com", dbConditions["recipient"])
+		assert.Equal(t, "active", dbConditions["status"])
+	})
+
+	t.Run("with wrong status", func(t *testing.T) {
+		filter := fromJSON[ScheduledPaymentFilter](`{
+			"status": "paused"
+		}`)
+		dbConditions, err := filter.ToDbConditions()
+
+		assert.Error(t, err)
+		assert.Nil(t, dbConditions)
+	})
+}
@@ -11,3 +11,6 @@ type SearchUtxosQuery = SearchParams[UtxoFilter]
 
 // SearchAccessKeysQuery is a model for handling searching with filters and metadata passed in the query string
 type SearchAccessKeysQuery = SearchParams[AccessKeyFilter]
+
+// SearchScheduledPaymentsQuery is a model for handling searching with filters and metadata passed in the query string
+type SearchScheduledPaymentsQuery = SearchParams[ScheduledPaymentFilter]
@@ -39,9 +39,19 @@ type TransactionEvent struct {
 	XpubOutputValue map[string]int64 `json:"xpubOutputValue"`
 }
 
+// ScheduledPaymentEvent - event for scheduled payment runs; when a draft is created it waits for the user's signature
+type ScheduledPaymentEvent struct {
+	UserEvent `json:",inline"`
+
+	ScheduledPaymentID string `json:"scheduledPaymentId"`
+	DraftID            string `json:"draftId,omitempty"`
+	Status             string `json:"status"`
+	Error              string `json:"error,omitempty"`
+}
+
 // NOTICE: If you add a new event type, you must also update the Events interface
 
 // Events - interface for all supported events
 type Events interface {
-	StringEvent | TransactionEvent
+	StringEvent | TransactionEvent | ScheduledPaymentEvent
 }
@@ -1,5 +1,7 @@
 package response
 
+import "time"
+
 type CreateContactResponse struct {
 	Contact        *Contact          `json:"contact"`
 	AdditionalInfo map[string]string `json:"additionalInfo"`
@@ -18,6 +20,50 @@ type Contact struct {
 	PubKey string `json:"pubKey" example:"xpub661MyMwAqRbcGpZVrSHU..."`
 	// Status is a contact's current status.
 	Status ContactStatus `json:"status" example:"unconfirmed"`
+	// Groups are the groups (tags) of the contact.
+	Groups []string `json:"groups" example:"family,friends"`
+}
+
+// ContactsImport is the result of the import of the contacts.
+type ContactsImport struct {
+	// Imported are the added (or updated) contacts.
+	Imported []*Contact `json:"imported"`
+	// Failed are the entries of the file which were not imported (or not invited).
+	Failed []*ContactImportFailure `json:"failed"`
+}
+
+// ContactImportFailure is the entry of the imported file which failed.
+type ContactImportFailure struct {
+	// Entry is the position of the entry in the file (starting from 1).
+	Entry int `json:"entry" example:"1"`
+	// Paymail is the paymail address of the entry.
+	Paymail string `json:"paymail" example:"test@spv-wallet.com"`
+	// Reason is the reason of the failure.
+	Reason string `json:"reason" example:"invalid contact paymail"`
+}
+
+// ContactTransactions are the transactions exchanged with the contact.
+type ContactTransactions struct {
+	// Contact is the counterparty of the transactions.
+	Contact *Contact `json:"contact"`
+	// TotalSent is the total of the satoshis sent to the contact (the outputs to other receivers and the fees excluded).
+	TotalSent uint64 `json:"totalSent" example:"1000"`
+	// TotalReceived is the total of the satoshis received from the contact.
+	TotalReceived uint64 `json:"totalReceived" example:"500"`
+	// Content is the page of the transactions exchanged with the contact.
+	Content []*Transaction `json:"content"`
+	// Page is the description of the page.
+	Page PageDescription `json:"page"`
+}
+
+// ContactTotp is the verification code which the user shares with the contact, so the contact can confirm the user.
+type ContactTotp struct {
+	// Passcode is the code to share with the contact.
+	Passcode string `json:"passcode" example:"123456"`
+	// Period is the validity of the code in seconds.
+	Period uint `json:"period" example:"300"`
+	// ExpiresAt is the time after which the contact does not accept the code.
+	ExpiresAt time.Time `json:"expiresAt" example:"2024-02-26T11:05:00.000Z"`
 }
 
 type ContactStatus string
@@ -0,0 +1,14 @@
+package response
+
+// ContactBlock is a model that represents a paymail (or a whole domain) whose contact invitations are rejected.
+type ContactBlock struct {
+	// Model is a common model that contains common fields for all models.
+	Model
+
+	// Paymail is the blocked paymail address (empty if the whole domain is blocked).
+	Paymail string `json:"paymail,omitempty" example:"spammer@example.com"`
+	// Domain is the blocked domain (or the domain of the blocked paymail), its subdomains are blocked too.
+	Domain string `json:"domain" example:"example.com"`
+	// Action is the action applied to the invitations: block (rejected with an error) or mute (dropped silently).
+	Action string `json:"action" example:"block"`
+}
@@ -0,0 +1,44 @@
+package response
+
+// MultisigAccount is a model that represents an m-of-n account composed of several xpubs.
+type MultisigAccount struct {
+	// Model is a common model that contains common fields for all models.
+	Model
+
+	// ID is a multisig account id.
+	ID string `json:"id" example:"4bd5a1f4fb5d3d3d6d0f0bba8c10d2c6bc1d7f0a30e3b7c0d64f6c86e0df9f3d"`
+	// Threshold is the number of signatures required to spend the funds of the account.
+	Threshold uint16 `json:"threshold" example:"2"`
+	// XpubIDs are the xpub ids of the members (in key order).
+	XpubIDs []string `json:"xpubIds" example:"[\"bb8593f85ef8056a77026ad415f02128f3768906de53e9e8bf8749fe2d66cf50\"]"`
+	// CurrentBalance is the current balance of the account.
+	CurrentBalance uint64 `json:"currentBalance" example:"1000"`
+	// NextInternalNum is the next internal (change) derivation number.
+	NextInternalNum uint32 `json:"nextInternalNum" example:"0"`
+	// NextExternalNum is the next external derivation number.
+	NextExternalNum uint32 `json:"nextExternalNum" example:"0"`
+}
+
+// MultisigSignature is a model that represents a partial signature of a multisig input.
+type MultisigSignature struct {
+	// InputIndex is the index of the signed input.
+	InputIndex uint32 `json:"inputIndex" example:"0"`
+	// XpubID is the xpub id of the member who made the signature.
+	XpubID string `json:"xpubId" example:"bb8593f85ef8056a77026ad415f02128f3768906de53e9e8bf8749fe2d66cf50"`
+	// Signature is the DER signature with the sighash flag appended, in hex.
+	Signature string `json:"signature" example:"3044022017...41"`
+}
+
+// MultisigDraftTransaction is a model that represents a draft transaction of a multisig account with the collected signatures.
+type MultisigDraftTransaction struct {
+	// DraftTransaction is the draft transaction to sign.
+	DraftTransaction *DraftTransaction `json:"draftTransaction"`
+	// AccountID is the id of the multisig account.
+	AccountID string `json:"accountId" example:"4bd5a1f4fb5d3d3d6d0f0bba8c10d2c6bc1d7f0a30e3b7c0d64f6c86e0df9f3d"`
+	// Signatures are the partial signatures collected so far.
+	Signatures []*MultisigSignature `json:"signatures"`
+	// Complete is true when enough signatures have been collected for all the inputs.
+	Complete bool `json:"complete" example:"false"`
+	// Hex is the signed transaction hex (set when complete), ready to be recorded.
+	Hex string `json:"hex,omitempty" example:"0100000002..."`
+}
@@ -0,0 +1,18 @@
+package response
+
+// PaymailDomain is a model that represents a paymail domain managed at runtime with its settings.
+type PaymailDomain struct {
+	// Model is a common model that contains common fields for all models.
+	Model
+
+	// Domain is the name of the paymail domain.
+	Domain string `json:"domain" example:"spvwallet.com"`
+	// SenderValidationEnabled requires signed sender requests for the paymails of the domain.
+	SenderValidationEnabled bool `json:"senderValidationEnabled" example:"true"`
+	// BeefEnabled accepts BEEF transactions for the paymails of the domain.
+	BeefEnabled bool `json:"beefEnabled" example:"true"`
+	// PikeEnabled accepts PIKE requests for the paymails of the domain.
+	PikeEnabled bool `json:"pikeEnabled" example:"false"`
+	// DefaultFromPaymail is the sender paymail used for the xpubs without paymail sending from the domain.
+	DefaultFromPaymail string `json:"defaultFromPaymail" example:"from@spvwallet.com"`
+}
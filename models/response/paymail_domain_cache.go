@@ -0,0 +1,25 @@
+package response
+
+// PaymailDomainCache is a model that represents the cached data of a counterpart paymail domain.
+type PaymailDomainCache struct {
+	// Domain is the name of the counterpart paymail domain.
+	Domain string `json:"domain" example:"handcash.io"`
+	// Blocked is true if the domain is on the blocklist of the outbound paymail requests.
+	Blocked bool `json:"blocked" example:"false"`
+	// Capabilities are the cached capabilities of the domain (BRFC ID or alias to URL or flag).
+	Capabilities map[string]interface{} `json:"capabilities,omitempty"`
+	// CapabilitiesError is the cached error of the failed capabilities lookup.
+	CapabilitiesError string `json:"capabilitiesError,omitempty" example:"paymail provider is not reachable"`
+	// PKI are the cached PKI of the paymails of the domain.
+	PKI []*PaymailPKI `json:"pki"`
+}
+
+// PaymailPKI is a model that represents the cached PKI of a counterpart paymail.
+type PaymailPKI struct {
+	// Paymail is the paymail address.
+	Paymail string `json:"paymail" example:"alice@handcash.io"`
+	// PubKey is the cached PKI public key of the paymail.
+	PubKey string `json:"pubKey,omitempty" example:"03f0ba5e5e3b9b3c1d4c2e8a6e5fbb2b6d5ec6b6e3b2b1e7f6e2a5d5c4b3a2f1e0"`
+	// Error is the cached error of the failed PKI lookup.
+	Error string `json:"error,omitempty" example:"paymail not found"`
+}
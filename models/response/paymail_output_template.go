@@ -0,0 +1,13 @@
+package response
+
+// PaymailOutputTemplate is a model that represents the template of the PIKE outputs of a paymail address.
+type PaymailOutputTemplate struct {
+	// Paymail is the paymail address.
+	Paymail string `json:"paymail" example:"test@spvwallet.com"`
+	// Name is the name of the template (p2pkh, p2pkh_split, p2pkh_memo).
+	Name string `json:"name" example:"p2pkh_split"`
+	// Denominations are the amounts of the outputs of the p2pkh_split template.
+	Denominations []uint64 `json:"denominations" example:"10000,1000,100"`
+	// Memo is the memo appended after OP_RETURN to the output of the p2pkh_memo template.
+	Memo string `json:"memo,omitempty" example:"thank you"`
+}
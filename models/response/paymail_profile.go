@@ -0,0 +1,25 @@
+package response
+
+// PaymailProfile is a model that represents the public profile of a paymail address with its privacy settings.
+type PaymailProfile struct {
+	// Paymail is the paymail address.
+	Paymail string `json:"paymail" example:"test@spvwallet.com"`
+	// PublicName is the name served by the public-profile capability.
+	PublicName string `json:"publicName" example:"Test User"`
+	// Avatar is the avatar URL served by the public-profile capability.
+	Avatar string `json:"avatar" example:"https://spvwallet.com/avatar.png"`
+	// PublicProfileHidden hides the name and avatar from the public-profile capability.
+	PublicProfileHidden bool `json:"publicProfileHidden" example:"false"`
+	// VerifyPubKeyDisabled disables the answers to the verify-pubkey capability requests.
+	VerifyPubKeyDisabled bool `json:"verifyPubKeyDisabled" example:"false"`
+}
+
+// ContactProfile is a model that represents the public profile of a contact (served by its paymail provider).
+type ContactProfile struct {
+	// Paymail is the paymail address of the contact.
+	Paymail string `json:"paymail" example:"test@spv-wallet.com"`
+	// Name is the public name of the contact.
+	Name string `json:"name" example:"Test User"`
+	// Avatar is the avatar URL of the contact.
+	Avatar string `json:"avatar" example:"https://spv-wallet.com/avatar.png"`
+}
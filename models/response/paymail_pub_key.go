@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+// PaymailPubKey is a model that represents the PKI public key of a paymail address with its rotated keys.
+type PaymailPubKey struct {
+	// Paymail is the paymail address.
+	Paymail string `json:"paymail" example:"test@spvwallet.com"`
+	// PubKey is the current PKI public key of the paymail address.
+	PubKey string `json:"pubKey" example:"02c3bd7eafa5ae1fd7be7f3d2e8d5d06e2a8fd5a4bbfa1fe0dbe50a28f9c6a9f5c"`
+	// PreviousPubKeys are the rotated public keys which are still verifiable.
+	PreviousPubKeys []*PaymailPreviousPubKey `json:"previousPubKeys"`
+}
+
+// PaymailPreviousPubKey is a model that represents a rotated PKI public key of a paymail address.
+type PaymailPreviousPubKey struct {
+	// PubKey is the rotated public key.
+	PubKey string `json:"pubKey" example:"03a48e13dc598dce5fda9b14ee80d5e0ab0d61d6a8c84c8a8c1e0e4e0d8ef5b6f4"`
+	// ValidUntil is the end of the grace period, the key is not verifiable after it.
+	ValidUntil time.Time `json:"validUntil" example:"2024-02-26T11:00:28.069911Z"`
+}
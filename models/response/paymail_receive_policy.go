@@ -0,0 +1,19 @@
+package response
+
+// PaymailReceivePolicy is a model that represents the policy of a paymail address for receiving the P2P payments.
+type PaymailReceivePolicy struct {
+	// Paymail is the paymail address.
+	Paymail string `json:"paymail" example:"test@spvwallet.com"`
+	// MinSatoshis is the minimum amount of a payment (0 = no limit).
+	MinSatoshis uint64 `json:"minSatoshis" example:"1000"`
+	// MaxSatoshis is the maximum amount of a payment (0 = no limit).
+	MaxSatoshis uint64 `json:"maxSatoshis" example:"0"`
+	// DailyDestinationQuota is the maximum number of payment destination requests per day (0 = no limit).
+	DailyDestinationQuota int `json:"dailyDestinationQuota" example:"100"`
+	// AllowedSenders are the paymails or domains of the only accepted senders (empty = all senders).
+	AllowedSenders []string `json:"allowedSenders" example:"friend@example.com,trusted.com"`
+	// DeniedSenders are the paymails or domains of the rejected senders.
+	DeniedSenders []string `json:"deniedSenders" example:"spammer@example.com"`
+	// ContactsOnly accepts the payments only from the contacts of the paymail owner.
+	ContactsOnly bool `json:"contactsOnly" example:"false"`
+}
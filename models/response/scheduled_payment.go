@@ -0,0 +1,48 @@
+package response
+
+import "time"
+
+// ScheduledPayment is a model that represents a recurring payment definition.
+type ScheduledPayment struct {
+	// Model is a common model that contains common fields for all models.
+	Model
+
+	// ID is a scheduled payment id.
+	ID string `json:"id" example:"c0ba4e1a64616ae1e8d5acc3a9e1a3d3a0d1da2e0b3ba3a3c5b2b7e0e7f7e7a1"`
+	// XpubID is the xpub id of the owner of the scheduled payment.
+	XpubID string `json:"xpubId" example:"bb8593f85ef8056a77026ad415f02128f3768906de53e9e8bf8749fe2d66cf50"`
+	// Recipient is a paymail, address or handle of the recipient.
+	Recipient string `json:"recipient" example:"alice@example.com"`
+	// Satoshis is the amount sent on every run.
+	Satoshis uint64 `json:"satoshis" example:"1000"`
+	// CronExpression is the standard cron expression of the schedule.
+	CronExpression string `json:"cronExpression,omitempty" example:"0 12 1 * *"`
+	// Interval is the interval between runs (used when no cron expression is set).
+	Interval string `json:"interval,omitempty" example:"24h0m0s"`
+	// EndAt is the time after which no more runs are made.
+	EndAt *time.Time `json:"endAt,omitempty" example:"2025-02-26T11:00:28.069911Z"`
+	// MaxRuns is the maximum number of runs, 0 means unlimited.
+	MaxRuns uint32 `json:"maxRuns" example:"12"`
+	// RunCount is the number of successful runs.
+	RunCount uint32 `json:"runCount" example:"3"`
+	// FailureCount is the number of consecutive failed runs.
+	FailureCount uint32 `json:"failureCount" example:"0"`
+	// NextRunAt is the time of the next run.
+	NextRunAt *time.Time `json:"nextRunAt,omitempty" example:"2024-03-01T12:00:00Z"`
+	// LastRunAt is the time of the last run.
+	LastRunAt *time.Time `json:"lastRunAt,omitempty" example:"2024-02-01T12:00:00Z"`
+	// Status is the status of the scheduled payment.
+	Status string `json:"status" example:"active"`
+	// Runs is the history of the latest runs.
+	Runs []*ScheduledPaymentRun `json:"runs"`
+}
+
+// ScheduledPaymentRun is a model that represents a single run of a scheduled payment.
+type ScheduledPaymentRun struct {
+	// ExecutedAt is the time when the run was executed.
+	ExecutedAt time.Time `json:"executedAt" example:"2024-02-01T12:00:00Z"`
+	// DraftID is the id of the draft transaction created in this run.
+	DraftID string `json:"draftId,omitempty" example:"b356f7fa00cd3f20cce6c21d704cd13e871d28d714a5ebd0532f5a0e0cde63f7"`
+	// Error is the failure reason of the run.
+	Error string `json:"error,omitempty" example:"not enough funds"`
+}
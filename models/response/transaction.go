@@ -1,5 +1,7 @@
 package response
 
+import "time"
+
 // Transaction is a model that represents a transaction.
 type Transaction struct {
 	// Model is a common model that contains common fields for all models.
@@ -34,4 +36,30 @@ type Transaction struct {
 	Status string `json:"status" example:"MINED"`
 	// TransactionDirection is a transaction direction (incoming/outgoing).
 	TransactionDirection string `json:"direction" example:"outgoing"`
+	// P2PDelivery is the delivery state of the transaction to the P2P receivers (only for transactions with P2P receivers).
+	P2PDelivery *P2PDelivery `json:"p2pDelivery,omitempty"`
+	// ContactID is the id of the contact (the counterparty) of the transaction.
+	ContactID string `json:"contactId,omitempty" example:"68af358bde7d8641621c7dd3de1a276c9a62cfa9e2d0740494519f1ba61e2f4a"`
+}
+
+// P2PDelivery is a model that represents the delivery state of a transaction to the paymail providers of the P2P receivers.
+type P2PDelivery struct {
+	// Status is the P2P delivery status (ready = waiting for the next attempt, complete, error = rejected or out of attempts).
+	Status string `json:"status" example:"ready"`
+	// Attempts is the number of the delivery attempts.
+	Attempts uint32 `json:"attempts" example:"2"`
+	// NextAttemptAt is the time of the next delivery attempt.
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" example:"2024-02-26T11:02:28.069911Z"`
+	// Results are the results of the delivery attempts.
+	Results []*P2PDeliveryResult `json:"results"`
+}
+
+// P2PDeliveryResult is a model that represents a result of the delivery attempt.
+type P2PDeliveryResult struct {
+	// Receiver is the notified paymail (empty for the failed attempts).
+	Receiver string `json:"receiver,omitempty" example:"alice@handcash.io"`
+	// ExecutedAt is the time of the attempt.
+	ExecutedAt time.Time `json:"executedAt" example:"2024-02-26T11:00:28.069911Z"`
+	// Message is the status message of the attempt.
+	Message string `json:"message" example:"success: 01d0d0067652f684c6acb3683763f353fce55f6496521c7d99e71e1d27e53f5c"`
 }
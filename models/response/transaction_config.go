@@ -26,10 +26,14 @@ type TransactionConfig struct {
 	IncludeUtxos []*UtxoPointer `json:"includeUtxos"`
 	// Inputs is a slice of transaction inputs.
 	Inputs []*TransactionInput `json:"inputs"`
+	// LockTime is a lock time of transaction (block height or unix timestamp), 0 means no lock time.
+	LockTime uint32 `json:"lockTime,omitempty" example:"0"`
 	// Outputs is a slice of transaction outputs.
 	Outputs []*TransactionOutput `json:"outputs"`
 	// SendAllTo is a pointer to a transaction output object.
 	SendAllTo *TransactionOutput `json:"sendAllTo"`
+	// Sequences is a slice of sequence numbers of inputs selected with fromUtxos or includeUtxos.
+	Sequences []*InputSequence `json:"sequences,omitempty"`
 	// Sync contains sync configuration.
 	Sync *SyncConfig `json:"sync"`
 }
@@ -42,6 +46,14 @@ type TransactionInput struct {
 	Destination Destination `json:"destination"`
 }
 
+// InputSequence is a model that represents a sequence number of a transaction input.
+type InputSequence struct {
+	// UtxoPointer is a pointer to a utxo spent by the input.
+	UtxoPointer `json:",inline"`
+	// Sequence is a sequence number of the input.
+	Sequence uint32 `json:"sequence" example:"4294967294"`
+}
+
 // TransactionOutput is a model that represents a transaction output.
 type TransactionOutput struct {
 	// OpReturn is a pointer to a op return object.
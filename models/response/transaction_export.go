@@ -0,0 +1,13 @@
+package response
+
+// TransactionExport is a model that represents a transaction exported as BEEF, EF or raw hex.
+type TransactionExport struct {
+	// ID is a transaction id.
+	ID string `json:"id" example:"01d0d0067652f684c6acb3683763f353fce55f6496521c7d99e71e1d27e53f5c"`
+	// Format is the format of the exported transaction: beef, ef or raw.
+	Format string `json:"format" example:"beef"`
+	// Atomic is true if the transaction is exported as Atomic BEEF.
+	Atomic bool `json:"atomic" example:"false"`
+	// Hex is the exported transaction in hex.
+	Hex string `json:"hex" example:"0100beef01fe..."`
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/bitcoin-sv/spv-wallet/actions/base"
 	"github.com/bitcoin-sv/spv-wallet/actions/contacts"
 	"github.com/bitcoin-sv/spv-wallet/actions/destinations"
+	"github.com/bitcoin-sv/spv-wallet/actions/multisig"
+	scheduledpayments "github.com/bitcoin-sv/spv-wallet/actions/scheduled_payments"
 	"github.com/bitcoin-sv/spv-wallet/actions/sharedconfig"
 	"github.com/bitcoin-sv/spv-wallet/actions/transactions"
 	"github.com/bitcoin-sv/spv-wallet/actions/users"
@@ -136,6 +138,8 @@ func SetupServerRoutes(appConfig *config.AppConfig, services *config.AppServices
 	usersAPIRoutes := users.NewHandler(appConfig, services)
 	oldSharedConfigRoutes := sharedconfig.OldSharedConfigHandler(appConfig, services)
 	sharedConfigRoutes := sharedconfig.NewHandler(appConfig, services)
+	scheduledPaymentsAPIRoutes := scheduledpayments.NewHandler(appConfig, services)
+	multisigAPIRoutes := multisig.NewHandler(appConfig, services)
 
 	routes := []interface{}{
 		// Admin routes
@@ -163,6 +167,10 @@ func SetupServerRoutes(appConfig *config.AppConfig, services *config.AppServices
 		// Shared Config routes
 		oldSharedConfigRoutes,
 		sharedConfigRoutes,
+		// Scheduled payments routes
+		scheduledPaymentsAPIRoutes,
+		// Multisig routes
+		multisigAPIRoutes,
 	}
 
 	if appConfig.ExperimentalFeatures.PikeContactsEnabled {